@@ -69,6 +69,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/aws/k8s v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker v0.90.1 // indirect
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/kubelet v0.90.1 // indirect
@@ -751,6 +752,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/metad
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent => ../../internal/sharedcomponent
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext => ../../internal/encodingext
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk => ../../internal/splunk
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/alibabacloudlogserviceexporter => ../../exporter/alibabacloudlogserviceexporter
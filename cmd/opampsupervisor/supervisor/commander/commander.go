@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"sync/atomic"
@@ -18,6 +19,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
 )
 
+// AgentLogFile is the file the Agent's standard output and error are written to.
+const AgentLogFile = "agent.log"
+
+// stderrTailLines is the number of lines of the Agent's standard error kept to
+// report why the Agent failed.
+const stderrTailLines = 20
+
 // Commander can start/stop/restart the Agent executable and also watch for a signal
 // for the Agent process to finish.
 type Commander struct {
@@ -27,6 +35,7 @@ type Commander struct {
 	cmd     *exec.Cmd
 	doneCh  chan struct{}
 	running *atomic.Int64
+	stderr  *lineTail
 }
 
 func NewCommander(logger *zap.Logger, cfg *config.Agent, args ...string) (*Commander, error) {
@@ -39,6 +48,7 @@ func NewCommander(logger *zap.Logger, cfg *config.Agent, args ...string) (*Comma
 		cfg:     cfg,
 		args:    args,
 		running: &atomic.Int64{},
+		stderr:  newLineTail(stderrTailLines),
 	}, nil
 }
 
@@ -54,18 +64,18 @@ func (c *Commander) Start(ctx context.Context) error {
 
 	c.logger.Debug("Starting agent", zap.String("agent", c.cfg.Executable))
 
-	logFilePath := "agent.log"
-	logFile, err := os.Create(logFilePath)
+	logFile, err := os.Create(AgentLogFile)
 	if err != nil {
-		return fmt.Errorf("cannot create %s: %w", logFilePath, err)
+		return fmt.Errorf("cannot create %s: %w", AgentLogFile, err)
 	}
 
 	c.cmd = exec.CommandContext(ctx, c.cfg.Executable, c.args...) // #nosec G204
 
 	// Capture standard output and standard error.
 	// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21072
+	c.stderr = newLineTail(stderrTailLines)
 	c.cmd.Stdout = logFile
-	c.cmd.Stderr = logFile
+	c.cmd.Stderr = io.MultiWriter(logFile, c.stderr)
 
 	c.doneCh = make(chan struct{})
 
@@ -125,6 +135,12 @@ func (c *Commander) ExitCode() int {
 	return c.cmd.ProcessState.ExitCode()
 }
 
+// StderrTail returns the last lines written by the Agent process to its
+// standard error.
+func (c *Commander) StderrTail() string {
+	return c.stderr.String()
+}
+
 func (c *Commander) IsRunning() bool {
 	return c.running.Load() != 0
 }
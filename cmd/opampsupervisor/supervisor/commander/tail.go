@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package commander
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// lineTail is an io.Writer keeping the last lines written to it.
+type lineTail struct {
+	mu       sync.Mutex
+	maxLines int
+	lines    []string
+	partial  []byte
+}
+
+func newLineTail(maxLines int) *lineTail {
+	return &lineTail{maxLines: maxLines}
+}
+
+func (t *lineTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.partial = append(t.partial, p...)
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			break
+		}
+		t.addLine(string(t.partial[:i]))
+		t.partial = t.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+func (t *lineTail) addLine(line string) {
+	t.lines = append(t.lines, strings.TrimSuffix(line, "\r"))
+	if len(t.lines) > t.maxLines {
+		t.lines = t.lines[len(t.lines)-t.maxLines:]
+	}
+}
+
+// String returns the last lines written, including an unterminated last line.
+func (t *lineTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lines := t.lines
+	if len(t.partial) > 0 {
+		lines = append(lines[:len(lines):len(lines)], string(t.partial))
+		if len(lines) > t.maxLines {
+			lines = lines[1:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -4,6 +4,8 @@
 package config
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -12,6 +14,8 @@ type Supervisor struct {
 	Server       *OpAMPServer
 	Agent        *Agent
 	Capabilities *Capabilities `mapstructure:"capabilities"`
+	Storage      *Storage      `mapstructure:"storage"`
+	Packages     *Packages     `mapstructure:"packages"`
 }
 
 // Capabilities is the set of capabilities that the Supervisor supports.
@@ -19,8 +23,12 @@ type Capabilities struct {
 	AcceptsRemoteConfig    *bool `mapstructure:"accepts_remote_config"`
 	ReportsEffectiveConfig *bool `mapstructure:"reports_effective_config"`
 	ReportsOwnMetrics      *bool `mapstructure:"reports_own_metrics"`
+	ReportsOwnLogs         *bool `mapstructure:"reports_own_logs"`
+	ReportsOwnTraces       *bool `mapstructure:"reports_own_traces"`
 	ReportsHealth          *bool `mapstructure:"reports_health"`
 	ReportsRemoteConfig    *bool `mapstructure:"reports_remote_config"`
+	AcceptsPackages        *bool `mapstructure:"accepts_packages"`
+	ReportsPackageStatuses *bool `mapstructure:"reports_package_statuses"`
 }
 
 type OpAMPServer struct {
@@ -30,4 +38,30 @@ type OpAMPServer struct {
 
 type Agent struct {
 	Executable string
+	// ConfigApplyTimeout is how long the Agent has to report being healthy
+	// after a new config is applied, before the config is reverted.
+	ConfigApplyTimeout time.Duration `mapstructure:"config_apply_timeout"`
+}
+
+// Storage is where the Supervisor keeps its data.
+type Storage struct {
+	// Directory is a writable directory where the Supervisor stores its data.
+	Directory string `mapstructure:"directory"`
+}
+
+// Packages configures the installation of Collector executable packages
+// offered by the OpAMP server.
+type Packages struct {
+	// PublicKeyFile is the path to a PEM encoded public key, used to verify
+	// the signature of the packages. Packages are not required to be signed
+	// if unspecified.
+	PublicKeyFile string `mapstructure:"public_key_file"`
+	// HealthCheckTimeout is how long the Collector has to report being
+	// healthy after an update, before the update is reverted.
+	HealthCheckTimeout time.Duration `mapstructure:"health_check_timeout"`
+	// DownloadTimeout is the maximum duration of a package download.
+	DownloadTimeout time.Duration `mapstructure:"download_timeout"`
+	// AllowUnverified allows installing packages which are neither signed
+	// nor have a content hash. Such packages are rejected by default.
+	AllowUnverified bool `mapstructure:"allow_unverified"`
 }
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+)
+
+const defaultConfigApplyTimeout = 30 * time.Second
+
+// configRollout is a config applied to the agent, waiting for the agent to be
+// healthy to be considered good.
+type configRollout struct {
+	// Remote config the effective config was composed from, if any.
+	remoteConfig    *protobufs.AgentRemoteConfig
+	effectiveConfig string
+	deadline        time.Time
+}
+
+func (s *Supervisor) configApplyTimeout() time.Duration {
+	if s.config.Agent != nil && s.config.Agent.ConfigApplyTimeout > 0 {
+		return s.config.Agent.ConfigApplyTimeout
+	}
+	return defaultConfigApplyTimeout
+}
+
+// startConfigRollout watches the health of the agent just restarted with a
+// new effective config.
+func (s *Supervisor) startConfigRollout() {
+	s.configRollout = &configRollout{
+		remoteConfig:    s.currentRemoteConfig(),
+		effectiveConfig: s.effectiveConfig.Load().(string),
+		deadline:        time.Now().Add(s.configApplyTimeout()),
+	}
+}
+
+// checkConfigRollout confirms the config being rolled out if the agent is
+// healthy, or reverts it if the agent is still not healthy after the config
+// apply timeout.
+func (s *Supervisor) checkConfigRollout(healthErr error) {
+	r := s.configRollout
+	if r == nil {
+		return
+	}
+
+	if healthErr == nil {
+		s.configRollout = nil
+		s.lastGoodEffectiveConfig = r.effectiveConfig
+		if r.remoteConfig == nil {
+			return
+		}
+
+		s.lastGoodRemoteConfig = r.remoteConfig
+		if err := s.saveLastReceivedRemoteConfig(r.remoteConfig); err != nil {
+			s.logger.Error("Could not save the last received remote config", zap.Error(err))
+		}
+		err := s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+			LastRemoteConfigHash: r.remoteConfig.ConfigHash,
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+		})
+		if err != nil {
+			s.logger.Error("Could not report applied OpAMP remote config status", zap.Error(err))
+		}
+		return
+	}
+
+	if time.Now().Before(r.deadline) {
+		return
+	}
+	s.failConfigRollout(fmt.Errorf("agent is not healthy after applying config: %w", healthErr))
+}
+
+// failConfigRollout reverts the agent to the last known good effective config
+// and reports why the remote config being rolled out failed.
+func (s *Supervisor) failConfigRollout(cause error) {
+	r := s.configRollout
+	if r == nil {
+		return
+	}
+	s.configRollout = nil
+
+	// The output of the agent is reset when it is restarted.
+	stderr := s.commander.StderrTail()
+	s.logger.Error("Reverting to the last good config", zap.Error(cause))
+
+	if err := s.commander.Stop(context.Background()); err != nil {
+		s.logger.Error("Could not stop agent process", zap.Error(err))
+	}
+	s.setRemoteConfig(s.lastGoodRemoteConfig)
+	s.effectiveConfig.Store(s.lastGoodEffectiveConfig)
+	s.writeEffectiveConfigToFile(s.lastGoodEffectiveConfig, s.effectiveConfigFilePath)
+	s.startAgent()
+
+	if err := s.opampClient.UpdateEffectiveConfig(context.Background()); err != nil {
+		s.logger.Error("The OpAMP client failed to update the effective config", zap.Error(err))
+	}
+
+	if r.remoteConfig == nil {
+		return
+	}
+	errMsg := cause.Error()
+	if stderr != "" {
+		errMsg = fmt.Sprintf("%s\nAgent output:\n%s", errMsg, stderr)
+	}
+	err := s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: r.remoteConfig.ConfigHash,
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		ErrorMessage:         errMsg,
+	})
+	if err != nil {
+		s.logger.Error("Could not report failed OpAMP remote config status", zap.Error(err))
+	}
+}
+
+func (s *Supervisor) currentRemoteConfig() *protobufs.AgentRemoteConfig {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+	return s.remoteConfig
+}
+
+func (s *Supervisor) setRemoteConfig(cfg *protobufs.AgentRemoteConfig) {
+	s.remoteConfigMu.Lock()
+	defer s.remoteConfigMu.Unlock()
+	s.remoteConfig = cfg
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+// fakeOpAMPClient records the remote config statuses reported to the server.
+type fakeOpAMPClient struct {
+	client.OpAMPClient
+
+	mu       sync.Mutex
+	statuses []*protobufs.RemoteConfigStatus
+}
+
+func (c *fakeOpAMPClient) SetRemoteConfigStatus(status *protobufs.RemoteConfigStatus) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses = append(c.statuses, status)
+	return nil
+}
+
+func (c *fakeOpAMPClient) UpdateEffectiveConfig(context.Context) error {
+	return nil
+}
+
+func (c *fakeOpAMPClient) SetHealth(*protobufs.ComponentHealth) error {
+	return nil
+}
+
+func newTestRolloutSupervisor(t *testing.T) (*Supervisor, *fakeOpAMPClient) {
+	dir := t.TempDir()
+	opampClient := &fakeOpAMPClient{}
+	s := &Supervisor{
+		logger:                  zap.NewNop(),
+		config:                  config.Supervisor{Storage: &config.Storage{Directory: dir}},
+		opampClient:             opampClient,
+		effectiveConfig:         &atomic.Value{},
+		effectiveConfigFilePath: filepath.Join(dir, "effective.yaml"),
+	}
+	return s, opampClient
+}
+
+func remoteConfig(body string) *protobufs.AgentRemoteConfig {
+	return &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{"": {Body: []byte(body)}},
+		},
+		ConfigHash: []byte(body),
+	}
+}
+
+func TestCheckConfigRolloutApplied(t *testing.T) {
+	s, opampClient := newTestRolloutSupervisor(t)
+	cfg := remoteConfig("new")
+	s.configRollout = &configRollout{
+		remoteConfig:    cfg,
+		effectiveConfig: "new effective",
+		deadline:        time.Now().Add(time.Minute),
+	}
+
+	s.checkConfigRollout(nil)
+
+	assert.Nil(t, s.configRollout)
+	assert.Equal(t, "new effective", s.lastGoodEffectiveConfig)
+	assert.Equal(t, cfg, s.lastGoodRemoteConfig)
+	saved, err := s.loadLastReceivedRemoteConfig()
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(cfg, saved))
+	require.Len(t, opampClient.statuses, 1)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, opampClient.statuses[0].Status)
+	assert.Equal(t, cfg.ConfigHash, opampClient.statuses[0].LastRemoteConfigHash)
+}
+
+func TestCheckConfigRolloutWaitsForDeadline(t *testing.T) {
+	s, opampClient := newTestRolloutSupervisor(t)
+	rollout := &configRollout{
+		remoteConfig:    remoteConfig("new"),
+		effectiveConfig: "new effective",
+		deadline:        time.Now().Add(time.Minute),
+	}
+	s.configRollout = rollout
+
+	s.checkConfigRollout(errors.New("not ready"))
+
+	assert.Equal(t, rollout, s.configRollout)
+	assert.Empty(t, s.lastGoodEffectiveConfig)
+	assert.Empty(t, opampClient.statuses)
+}
+
+func TestCheckConfigRolloutReverted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the agent is a shell command")
+	}
+	// The agent log file is created in the working directory.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(wd)) })
+
+	s, opampClient := newTestRolloutSupervisor(t)
+	s.commander, err = commander.NewCommander(zap.NewNop(), &config.Agent{Executable: "sleep"}, "60")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, s.commander.Stop(context.Background())) })
+
+	good := remoteConfig("good")
+	s.lastGoodRemoteConfig = good
+	s.lastGoodEffectiveConfig = "good effective"
+	bad := remoteConfig("bad")
+	s.setRemoteConfig(bad)
+	s.effectiveConfig.Store("bad effective")
+	s.configRollout = &configRollout{
+		remoteConfig:    bad,
+		effectiveConfig: "bad effective",
+		deadline:        time.Now().Add(-time.Second),
+	}
+
+	s.checkConfigRollout(errors.New("not ready"))
+
+	assert.Nil(t, s.configRollout)
+	assert.Equal(t, good, s.currentRemoteConfig())
+	assert.Equal(t, "good effective", s.effectiveConfig.Load())
+	written, err := os.ReadFile(s.effectiveConfigFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "good effective", string(written))
+	assert.True(t, s.commander.IsRunning(), "the agent is restarted with the last good config")
+
+	require.Len(t, opampClient.statuses, 1)
+	status := opampClient.statuses[0]
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.Status)
+	assert.Equal(t, bad.ConfigHash, status.LastRemoteConfigHash)
+	assert.Equal(t, "agent is not healthy after applying config: not ready", status.ErrorMessage)
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
+)
+
+// ownTracesFeatureGate enables the configuration of the Collector's own traces
+// exporters through service::telemetry::traces::processors.
+const ownTracesFeatureGate = "telemetry.useOtelWithSDKConfigurationForInternalTelemetry"
+
+func (s *Supervisor) setupOwnLogs(_ context.Context, settings *protobufs.TelemetryConnectionSettings) (configChanged bool) {
+	var cfg string
+	if settings.DestinationEndpoint == "" {
+		// No destination. Disable log collection.
+		s.logger.Debug("Disabling own logs pipeline in the config")
+		cfg = ""
+	} else {
+		s.logger.Debug("Enabling own logs pipeline in the config")
+
+		logFile, err := filepath.Abs(commander.AgentLogFile)
+		if err != nil {
+			s.logger.Error("Could not setup own logs", zap.Error(err))
+			return
+		}
+
+		// The Agent's standard output and error are written to the log file,
+		// its own logs being JSON encoded. The logs of the own logs pipeline
+		// are dropped, otherwise every export failure would be logged, read
+		// and exported again.
+		cfg = fmt.Sprintf(
+			`
+receivers:
+  # Collect own logs
+  filelog/own_logs:
+    include: [%s]
+    start_at: beginning
+    operators:
+      - type: json_parser
+        on_error: send
+        severity:
+          parse_from: attributes.level
+      - type: filter
+        expr: 'attributes.name in ["filelog/own_logs", "otlphttp/own_logs"]'
+exporters:
+  otlphttp/own_logs:
+    logs_endpoint: %s
+    headers: %s
+
+service:
+  telemetry:
+    logs:
+      encoding: json
+  pipelines:
+    logs/own_logs:
+      receivers: [filelog/own_logs]
+      exporters: [otlphttp/own_logs]
+`,
+			strconv.Quote(logFile),
+			settings.DestinationEndpoint,
+			headersConfig(settings.Headers),
+		)
+	}
+
+	s.agentConfigOwnLogsSection.Store(cfg)
+
+	// Need to recalculate the Agent config so that the log config is included in it.
+	configChanged, err := s.recalcEffectiveConfig()
+	if err != nil {
+		return
+	}
+
+	return configChanged
+}
+
+func (s *Supervisor) setupOwnTraces(_ context.Context, settings *protobufs.TelemetryConnectionSettings) (configChanged bool) {
+	var cfg string
+	if settings.DestinationEndpoint == "" {
+		// No destination. Disable trace collection.
+		s.logger.Debug("Disabling own traces in the config")
+		cfg = ""
+	} else {
+		s.logger.Debug("Enabling own traces in the config")
+
+		cfg = fmt.Sprintf(
+			`
+service:
+  telemetry:
+    traces:
+      processors:
+        - batch:
+            exporter:
+              otlp:
+                protocol: http/protobuf
+                endpoint: %s
+                headers: %s
+`,
+			settings.DestinationEndpoint,
+			headersConfig(settings.Headers),
+		)
+	}
+
+	s.agentConfigOwnTracesSection.Store(cfg)
+
+	// Need to recalculate the Agent config so that the trace config is included in it.
+	configChanged, err := s.recalcEffectiveConfig()
+	if err != nil {
+		return
+	}
+
+	return configChanged
+}
+
+// headersConfig formats the headers to send along with the Agent's own
+// telemetry as a YAML flow mapping.
+func headersConfig(headers *protobufs.Headers) string {
+	var pairs []string
+	for _, h := range headers.GetHeaders() {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", strconv.Quote(h.GetKey()), strconv.Quote(h.GetValue())))
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
+)
+
+func newTestTelemetrySupervisor() *Supervisor {
+	s := &Supervisor{
+		logger:                       zap.NewNop(),
+		agentConfigOwnMetricsSection: &atomic.Value{},
+		agentConfigOwnLogsSection:    &atomic.Value{},
+		agentConfigOwnTracesSection:  &atomic.Value{},
+		effectiveConfig:              &atomic.Value{},
+		agentHealthCheckEndpoint:     "localhost:13133",
+	}
+	s.effectiveConfig.Store("")
+	return s
+}
+
+func effectiveConfig(t *testing.T, s *Supervisor) map[string]any {
+	var cfg map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(s.effectiveConfig.Load().(string)), &cfg))
+	return cfg
+}
+
+func lookup(t *testing.T, cfg map[string]any, keys ...string) any {
+	var v any = cfg
+	for _, key := range keys {
+		m, ok := v.(map[string]any)
+		require.True(t, ok, "%s is not a map", key)
+		v = m[key]
+	}
+	return v
+}
+
+func TestSetupOwnLogs(t *testing.T) {
+	s := newTestTelemetrySupervisor()
+	settings := &protobufs.TelemetryConnectionSettings{
+		DestinationEndpoint: "https://logs.example.com/v1/logs",
+		Headers: &protobufs.Headers{Headers: []*protobufs.Header{
+			{Key: "Authorization", Value: `Bearer "token"`},
+		}},
+	}
+	require.True(t, s.setupOwnLogs(context.Background(), settings))
+
+	cfg := effectiveConfig(t, s)
+	// The json_parser operator requires JSON encoded logs.
+	assert.Equal(t, "json", lookup(t, cfg, "service", "telemetry", "logs", "encoding"))
+
+	logFile, err := filepath.Abs(commander.AgentLogFile)
+	require.NoError(t, err)
+	assert.Equal(t, []any{logFile}, lookup(t, cfg, "receivers", "filelog/own_logs", "include"))
+	operators := lookup(t, cfg, "receivers", "filelog/own_logs", "operators").([]any)
+	require.Len(t, operators, 2)
+	assert.Equal(t, "json_parser", operators[0].(map[string]any)["type"])
+	// The logs of the own logs pipeline are not exported again.
+	assert.Equal(t, map[string]any{
+		"type": "filter",
+		"expr": `attributes.name in ["filelog/own_logs", "otlphttp/own_logs"]`,
+	}, operators[1])
+
+	assert.Equal(t, "https://logs.example.com/v1/logs", lookup(t, cfg, "exporters", "otlphttp/own_logs", "logs_endpoint"))
+	assert.Equal(t, map[string]any{"Authorization": `Bearer "token"`}, lookup(t, cfg, "exporters", "otlphttp/own_logs", "headers"))
+	assert.Equal(t, map[string]any{
+		"receivers": []any{"filelog/own_logs"},
+		"exporters": []any{"otlphttp/own_logs"},
+	}, lookup(t, cfg, "service", "pipelines", "logs/own_logs"))
+
+	require.True(t, s.setupOwnLogs(context.Background(), &protobufs.TelemetryConnectionSettings{}))
+	cfg = effectiveConfig(t, s)
+	assert.Nil(t, lookup(t, cfg, "receivers"))
+	assert.Nil(t, lookup(t, cfg, "service", "pipelines"))
+}
+
+func TestSetupOwnTraces(t *testing.T) {
+	s := newTestTelemetrySupervisor()
+	settings := &protobufs.TelemetryConnectionSettings{DestinationEndpoint: "https://traces.example.com/v1/traces"}
+	require.True(t, s.setupOwnTraces(context.Background(), settings))
+
+	processors := lookup(t, effectiveConfig(t, s), "service", "telemetry", "traces", "processors").([]any)
+	require.Len(t, processors, 1)
+	assert.Equal(t, map[string]any{
+		"protocol": "http/protobuf",
+		"endpoint": "https://traces.example.com/v1/traces",
+		"headers":  map[string]any{},
+	}, lookup(t, processors[0].(map[string]any), "batch", "exporter", "otlp"))
+
+	require.True(t, s.setupOwnTraces(context.Background(), &protobufs.TelemetryConnectionSettings{}))
+	assert.Nil(t, lookup(t, effectiveConfig(t, s), "service", "telemetry", "traces"))
+}
+
+func TestHeadersConfig(t *testing.T) {
+	assert.Equal(t, "{}", headersConfig(nil))
+	assert.Equal(t, `{"a": "1", "b\"": "x: y"}`, headersConfig(&protobufs.Headers{Headers: []*protobufs.Header{
+		{Key: "a", Value: "1"},
+		{Key: `b"`, Value: "x: y"},
+	}}))
+}
@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/packages"
+)
+
+const (
+	packagesDirName                  = "packages"
+	defaultPackageHealthCheckTimeout = time.Minute
+)
+
+var errAgentNotRunning = errors.New("agent is not running")
+
+// stagedPackage is a downloaded package waiting to be installed.
+type stagedPackage struct {
+	name            string
+	pkg             *protobufs.PackageAvailable
+	path            string
+	allPackagesHash []byte
+}
+
+// pendingPackage is an installed package waiting for the agent to be healthy.
+type pendingPackage struct {
+	*stagedPackage
+	previous packages.Package
+	deadline time.Time
+}
+
+func (s *Supervisor) acceptsPackages() bool {
+	c := s.config.Capabilities
+	return c != nil && c.AcceptsPackages != nil && *c.AcceptsPackages
+}
+
+func (s *Supervisor) reportsPackageStatuses() bool {
+	c := s.config.Capabilities
+	return c != nil && c.ReportsPackageStatuses != nil && *c.ReportsPackageStatuses
+}
+
+// setupPackages prepares the installation of the packages offered by the
+// OpAMP server and reports the version of the installed agent package, if any.
+func (s *Supervisor) setupPackages() error {
+	set := packages.InstallerSettings{
+		Dir:        filepath.Join(s.storageDir(), packagesDirName),
+		Executable: s.config.Agent.Executable,
+	}
+	s.packageHealthCheckTimeout = defaultPackageHealthCheckTimeout
+	if p := s.config.Packages; p != nil {
+		set.PublicKeyFile = p.PublicKeyFile
+		set.AllowUnverified = p.AllowUnverified
+		set.DownloadTimeout = p.DownloadTimeout
+		if p.HealthCheckTimeout > 0 {
+			s.packageHealthCheckTimeout = p.HealthCheckTimeout
+		}
+	}
+
+	installer, err := packages.NewInstaller(s.logger, set)
+	if err != nil {
+		return err
+	}
+	state, err := installer.LoadState()
+	if err != nil {
+		return err
+	}
+
+	s.packageInstaller = installer
+	s.packagesState = state
+	s.lastOfferedPackagesHash = state.AllPackagesHash
+	s.packagesCtx, s.cancelPackages = context.WithCancel(context.Background())
+	s.packageStatuses = &protobufs.PackageStatuses{
+		Packages:                      map[string]*protobufs.PackageStatus{},
+		ServerProvidedAllPackagesHash: state.AllPackagesHash,
+	}
+	for name, pkg := range state.Packages {
+		s.packageStatuses.Packages[name] = &protobufs.PackageStatus{
+			Name:                 name,
+			AgentHasVersion:      pkg.Version,
+			AgentHasHash:         pkg.Hash,
+			ServerOfferedVersion: pkg.Version,
+			ServerOfferedHash:    pkg.Hash,
+			Status:               protobufs.PackageStatusEnum_PackageStatusEnum_Installed,
+		}
+		if pkg.Version != "" {
+			// Only the top-level package of the agent is ever installed.
+			s.agentVersion = pkg.Version
+		}
+	}
+	return nil
+}
+
+// processPackagesAvailable downloads the packages offered by the OpAMP server
+// which are not installed yet and hands them over to be installed.
+func (s *Supervisor) processPackagesAvailable(available *protobufs.PackagesAvailable) {
+	s.packagesSyncMu.Lock()
+	defer s.packagesSyncMu.Unlock()
+
+	if bytes.Equal(s.lastOfferedPackagesHash, available.GetAllPackagesHash()) {
+		s.logger.Debug("Offered packages are unchanged")
+		return
+	}
+	s.lastOfferedPackagesHash = available.GetAllPackagesHash()
+
+	// The hash of the offered packages is only reported once they are all
+	// installed, so that the server offers them again otherwise.
+	allInstalled := true
+	for name, pkg := range available.GetPackages() {
+		installed := s.installedPackage(name)
+
+		switch {
+		case pkg.GetType() != protobufs.PackageType_PackageType_TopLevel:
+			allInstalled = false
+			s.setPackageStatus(name, pkg, installed, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed,
+				"only the top-level package of the agent is supported")
+		case bytes.Equal(installed.Hash, pkg.GetHash()):
+			s.setPackageStatus(name, pkg, installed, protobufs.PackageStatusEnum_PackageStatusEnum_Installed, "")
+		case s.isBadPackage(pkg.GetHash()):
+			allInstalled = false
+			s.setPackageStatus(name, pkg, installed, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed,
+				"package previously failed to install")
+		default:
+			allInstalled = false
+			s.logger.Debug("Downloading package", zap.String("name", name), zap.String("version", pkg.GetVersion()))
+			s.setPackageStatus(name, pkg, installed, protobufs.PackageStatusEnum_PackageStatusEnum_Installing, "")
+
+			staged, err := s.packageInstaller.Download(s.packagesCtx, name, pkg)
+			if err != nil {
+				s.logger.Error("Cannot download package", zap.String("name", name), zap.Error(err))
+				s.setPackageStatus(name, pkg, installed, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, err.Error())
+				// Download again when the server offers the packages again.
+				s.lastOfferedPackagesHash = nil
+				continue
+			}
+
+			select {
+			case s.packageUpdate <- &stagedPackage{
+				name:            name,
+				pkg:             pkg,
+				path:            staged,
+				allPackagesHash: available.GetAllPackagesHash(),
+			}:
+			case <-s.packagesCtx.Done():
+				return
+			}
+		}
+	}
+	if allInstalled {
+		s.setServerProvidedAllPackagesHash(available.GetAllPackagesHash())
+		s.packagesMu.Lock()
+		statuses := s.packageStatuses
+		s.packagesMu.Unlock()
+		s.reportPackageStatuses(statuses)
+	}
+}
+
+// installPackage replaces the agent executable with a staged package and
+// restarts the agent. The update is confirmed once the agent is healthy.
+func (s *Supervisor) installPackage(update *stagedPackage) {
+	s.logger.Debug("Stopping the agent to install a package", zap.String("name", update.name), zap.String("version", update.pkg.GetVersion()))
+	if err := s.commander.Stop(context.Background()); err != nil {
+		s.logger.Error("Could not stop agent process", zap.Error(err))
+	}
+
+	installed := s.installedPackage(update.name)
+	if err := s.packageInstaller.Install(update.path); err != nil {
+		s.logger.Error("Cannot install package", zap.String("name", update.name), zap.Error(err))
+		s.setPackageStatus(update.name, update.pkg, installed, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, err.Error())
+		s.startAgent()
+		return
+	}
+
+	s.pendingPackage = &pendingPackage{
+		stagedPackage: update,
+		previous:      installed,
+		deadline:      time.Now().Add(s.packageHealthCheckTimeout),
+	}
+	s.startAgent()
+}
+
+// checkPendingPackage confirms the installation of the pending package if the
+// agent is healthy, or reverts it if the agent is still not healthy after the
+// package health check timeout.
+func (s *Supervisor) checkPendingPackage(healthErr error) {
+	p := s.pendingPackage
+	if p == nil {
+		return
+	}
+
+	if healthErr == nil {
+		s.pendingPackage = nil
+		installed := packages.Package{Version: p.pkg.GetVersion(), Hash: p.pkg.GetHash()}
+		s.updatePackagesState(func(state *packages.State) {
+			state.Packages[p.name] = installed
+		})
+		s.setServerProvidedAllPackagesHash(p.allPackagesHash)
+		s.setPackageStatus(p.name, p.pkg, installed, protobufs.PackageStatusEnum_PackageStatusEnum_Installed, "")
+		s.logger.Info("Package installed", zap.String("name", p.name), zap.String("version", installed.Version))
+
+		s.agentVersion = installed.Version
+		if err := s.opampClient.SetAgentDescription(s.createAgentDescription()); err != nil {
+			s.logger.Error("Failed to send agent description to OpAMP server", zap.Error(err))
+		}
+		return
+	}
+
+	if time.Now().Before(p.deadline) {
+		return
+	}
+
+	s.pendingPackage = nil
+	s.logger.Error("Agent is not healthy after installing package, reverting it",
+		zap.String("name", p.name), zap.String("version", p.pkg.GetVersion()), zap.Error(healthErr))
+	if err := s.commander.Stop(context.Background()); err != nil {
+		s.logger.Error("Could not stop agent process", zap.Error(err))
+	}
+	if err := s.packageInstaller.Revert(); err != nil {
+		s.logger.Error("Cannot revert package", zap.String("name", p.name), zap.Error(err))
+	}
+	s.updatePackagesState(func(state *packages.State) {
+		state.BadHashes = append(state.BadHashes, p.pkg.GetHash())
+	})
+	s.setPackageStatus(p.name, p.pkg, p.previous, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed,
+		fmt.Sprintf("agent is not healthy after installing package: %v", healthErr))
+	s.startAgent()
+}
+
+func (s *Supervisor) installedPackage(name string) packages.Package {
+	s.packagesMu.Lock()
+	defer s.packagesMu.Unlock()
+	return s.packagesState.Packages[name]
+}
+
+func (s *Supervisor) isBadPackage(hash []byte) bool {
+	s.packagesMu.Lock()
+	defer s.packagesMu.Unlock()
+	return s.packagesState.IsBad(hash)
+}
+
+// updatePackagesState applies update to the packages state and persists it.
+func (s *Supervisor) updatePackagesState(update func(state *packages.State)) {
+	s.packagesMu.Lock()
+	defer s.packagesMu.Unlock()
+
+	if s.packagesState.Packages == nil {
+		s.packagesState.Packages = map[string]packages.Package{}
+	}
+	update(s.packagesState)
+	if err := s.packageInstaller.SaveState(s.packagesState); err != nil {
+		s.logger.Error("Cannot save packages state", zap.Error(err))
+	}
+}
+
+// setServerProvidedAllPackagesHash records that all the packages offered with
+// the given hash are installed.
+func (s *Supervisor) setServerProvidedAllPackagesHash(hash []byte) {
+	s.updatePackagesState(func(state *packages.State) {
+		state.AllPackagesHash = hash
+	})
+
+	s.packagesMu.Lock()
+	defer s.packagesMu.Unlock()
+	s.packageStatuses = &protobufs.PackageStatuses{
+		Packages:                      s.packageStatuses.Packages,
+		ServerProvidedAllPackagesHash: hash,
+	}
+}
+
+// setPackageStatus updates the status of a package and reports the statuses
+// of all packages to the OpAMP server.
+func (s *Supervisor) setPackageStatus(name string, pkg *protobufs.PackageAvailable, installed packages.Package, status protobufs.PackageStatusEnum, errMsg string) {
+	s.packagesMu.Lock()
+	defer s.packagesMu.Unlock()
+
+	statuses := &protobufs.PackageStatuses{
+		Packages:                      make(map[string]*protobufs.PackageStatus, len(s.packageStatuses.Packages)+1),
+		ServerProvidedAllPackagesHash: s.packageStatuses.ServerProvidedAllPackagesHash,
+	}
+	for n, st := range s.packageStatuses.Packages {
+		statuses.Packages[n] = st
+	}
+	statuses.Packages[name] = &protobufs.PackageStatus{
+		Name:                 name,
+		AgentHasVersion:      installed.Version,
+		AgentHasHash:         installed.Hash,
+		ServerOfferedVersion: pkg.GetVersion(),
+		ServerOfferedHash:    pkg.GetHash(),
+		Status:               status,
+		ErrorMessage:         errMsg,
+	}
+	s.packageStatuses = statuses
+
+	s.reportPackageStatuses(statuses)
+}
+
+func (s *Supervisor) reportPackageStatuses(statuses *protobufs.PackageStatuses) {
+	if !s.reportsPackageStatuses() {
+		return
+	}
+	if err := s.opampClient.SetPackageStatuses(statuses); err != nil {
+		s.logger.Error("Could not report package statuses to OpAMP server", zap.Error(err))
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package packages
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+)
+
+const (
+	stateFileName    = "packages.json"
+	stagingDirName   = "staging"
+	previousDirName  = "previous"
+	executableSuffix = ".new"
+
+	defaultDownloadTimeout = 5 * time.Minute
+)
+
+// State is the persisted state of the packages installed by the Supervisor.
+type State struct {
+	// AllPackagesHash is the hash of all the packages offered by the server
+	// which were last installed.
+	AllPackagesHash []byte `json:"all_packages_hash,omitempty"`
+	// Packages holds the installed packages by name.
+	Packages map[string]Package `json:"packages,omitempty"`
+	// BadHashes holds the hashes of the packages which failed to install,
+	// so that they are not installed again even if offered by the server.
+	BadHashes [][]byte `json:"bad_hashes,omitempty"`
+}
+
+// Package is an installed package.
+type Package struct {
+	Version string `json:"version"`
+	Hash    []byte `json:"hash"`
+}
+
+// IsBad returns whether the package with the given hash failed to install.
+func (s *State) IsBad(hash []byte) bool {
+	for _, h := range s.BadHashes {
+		if bytes.Equal(h, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// Installer downloads, verifies and installs the Collector executable
+// packages offered by the OpAMP server. It overwrites the Collector
+// executable, keeping the previous one to revert the update if needed.
+type Installer struct {
+	logger          *zap.Logger
+	dir             string
+	executable      string
+	publicKey       crypto.PublicKey
+	allowUnverified bool
+	client          *http.Client
+}
+
+// InstallerSettings configures an Installer.
+type InstallerSettings struct {
+	// Dir is where the Installer stores its data.
+	Dir string
+	// Executable is the path of the Collector executable to update.
+	Executable string
+	// PublicKeyFile is the path to the public key verifying the signature of
+	// the packages. Packages must be signed if set.
+	PublicKeyFile string
+	// AllowUnverified allows installing packages which are neither signed nor
+	// have a content hash.
+	AllowUnverified bool
+	// DownloadTimeout is the maximum duration of a package download. It
+	// defaults to 5 minutes.
+	DownloadTimeout time.Duration
+}
+
+// NewInstaller creates an Installer of the executable.
+func NewInstaller(logger *zap.Logger, set InstallerSettings) (*Installer, error) {
+	for _, d := range []string{set.Dir, filepath.Join(set.Dir, stagingDirName), filepath.Join(set.Dir, previousDirName)} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			return nil, fmt.Errorf("cannot create packages directory: %w", err)
+		}
+	}
+
+	timeout := set.DownloadTimeout
+	if timeout <= 0 {
+		timeout = defaultDownloadTimeout
+	}
+	i := &Installer{
+		logger:          logger,
+		dir:             set.Dir,
+		executable:      set.Executable,
+		allowUnverified: set.AllowUnverified,
+		client:          &http.Client{Timeout: timeout},
+	}
+	if set.PublicKeyFile != "" {
+		publicKey, err := loadPublicKey(set.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		i.publicKey = publicKey
+	}
+	return i, nil
+}
+
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read public key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse public key: %w", err)
+	}
+	switch publicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// LoadState returns the persisted state, or an empty state if there is none.
+func (i *Installer) LoadState() (*State, error) {
+	state := &State{}
+	data, err := os.ReadFile(filepath.Join(i.dir, stateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("cannot parse packages state: %w", err)
+	}
+	return state, nil
+}
+
+// SaveState persists the state.
+func (i *Installer) SaveState(state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(i.dir, stateFileName), data, 0600)
+}
+
+// Download downloads the file of a package into the staging directory and
+// verifies its hash and signature. It returns the path of the staged file.
+func (i *Installer) Download(ctx context.Context, name string, pkg *protobufs.PackageAvailable) (string, error) {
+	file := pkg.GetFile()
+	if file.GetDownloadUrl() == "" {
+		return "", errors.New("package has no download URL")
+	}
+	if i.publicKey != nil && len(file.GetSignature()) == 0 {
+		return "", errors.New("package is not signed")
+	}
+	if i.publicKey == nil && len(file.GetContentHash()) == 0 && !i.allowUnverified {
+		return "", errors.New("package is neither signed nor hashed")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.GetDownloadUrl(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot download package: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot download package: %s returned %d", file.GetDownloadUrl(), resp.StatusCode)
+	}
+
+	staged := filepath.Join(i.dir, stagingDirName, stagedFileName(name))
+	f, err := os.OpenFile(staged, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0700) // #nosec G302 -- the Collector executable
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), resp.Body)
+	err = errors.Join(err, f.Close())
+	if err == nil {
+		err = i.verify(h, file)
+	}
+	if err != nil {
+		return "", errors.Join(err, os.Remove(staged))
+	}
+
+	i.logger.Debug("Package downloaded", zap.String("name", name), zap.String("version", pkg.GetVersion()))
+	return staged, nil
+}
+
+// verify checks the SHA-256 digest of the package file against its content
+// hash and signature, if any.
+func (i *Installer) verify(h hash.Hash, file *protobufs.DownloadableFile) error {
+	digest := h.Sum(nil)
+	if len(file.GetContentHash()) > 0 && !bytes.Equal(digest, file.GetContentHash()) {
+		return errors.New("package content hash does not match")
+	}
+	if i.publicKey == nil {
+		return nil
+	}
+
+	signature := file.GetSignature()
+	var valid bool
+	switch publicKey := i.publicKey.(type) {
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(publicKey, digest, signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(publicKey, digest, signature)
+	}
+	if !valid {
+		return errors.New("package signature is invalid")
+	}
+	return nil
+}
+
+// Install replaces the Collector executable with a staged package file,
+// saving the current executable to revert the update if needed.
+func (i *Installer) Install(staged string) error {
+	if err := copyFile(i.executable, i.previousExecutable()); err != nil {
+		return fmt.Errorf("cannot save current executable: %w", err)
+	}
+	if err := i.replaceExecutable(staged); err != nil {
+		return err
+	}
+	return os.Remove(staged)
+}
+
+// Revert restores the executable saved before the last update.
+func (i *Installer) Revert() error {
+	return i.replaceExecutable(i.previousExecutable())
+}
+
+// replaceExecutable copies src next to the executable, then renames it over
+// the executable so that it is never partially written.
+func (i *Installer) replaceExecutable(src string) error {
+	tmp := i.executable + executableSuffix
+	if err := copyFile(src, tmp); err != nil {
+		return fmt.Errorf("cannot copy executable: %w", err)
+	}
+	if err := os.Rename(tmp, i.executable); err != nil {
+		return errors.Join(fmt.Errorf("cannot replace executable: %w", err), os.Remove(tmp))
+	}
+	return nil
+}
+
+func (i *Installer) previousExecutable() string {
+	return filepath.Join(i.dir, previousDirName, filepath.Base(i.executable))
+}
+
+// stagedFileName returns the name of the staged file of a package, the
+// top-level package having an empty name.
+func stagedFileName(name string) string {
+	if name == "" {
+		return "agent"
+	}
+	return filepath.Base(name)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	return errors.Join(err, out.Close())
+}
+
+func writeFileAtomically(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
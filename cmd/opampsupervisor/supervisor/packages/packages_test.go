@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package packages
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var packageContent = []byte("#!/bin/sh\necho collector\n")
+
+func newTestInstaller(t *testing.T, set InstallerSettings) *Installer {
+	set.Dir = t.TempDir()
+	set.Executable = filepath.Join(t.TempDir(), "otelcol")
+	i, err := NewInstaller(zap.NewNop(), set)
+	require.NoError(t, err)
+	return i
+}
+
+func writePublicKey(t *testing.T, publicKey any) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	return path
+}
+
+func serve(t *testing.T, content []byte) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func offer(url string, contentHash []byte, signature []byte) *protobufs.PackageAvailable {
+	return &protobufs.PackageAvailable{
+		Type:    protobufs.PackageType_PackageType_TopLevel,
+		Version: "1.0.0",
+		File: &protobufs.DownloadableFile{
+			DownloadUrl: url,
+			ContentHash: contentHash,
+			Signature:   signature,
+		},
+	}
+}
+
+func TestDownload(t *testing.T) {
+	digest := sha256.Sum256(packageContent)
+	url := serve(t, packageContent)
+
+	tests := []struct {
+		name        string
+		set         InstallerSettings
+		pkg         *protobufs.PackageAvailable
+		expectedErr string
+	}{
+		{
+			name: "hashed",
+			pkg:  offer(url, digest[:], nil),
+		},
+		{
+			name:        "hash mismatch",
+			pkg:         offer(url, []byte("wrong"), nil),
+			expectedErr: "package content hash does not match",
+		},
+		{
+			name:        "neither signed nor hashed",
+			pkg:         offer(url, nil, nil),
+			expectedErr: "package is neither signed nor hashed",
+		},
+		{
+			name: "unverified allowed",
+			set:  InstallerSettings{AllowUnverified: true},
+			pkg:  offer(url, nil, nil),
+		},
+		{
+			name:        "no download URL",
+			pkg:         offer("", digest[:], nil),
+			expectedErr: "package has no download URL",
+		},
+		{
+			name:        "invalid URL",
+			pkg:         offer(url+"/missing\x7f", digest[:], nil),
+			expectedErr: "invalid control character in URL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := newTestInstaller(t, tt.set)
+			staged, err := i.Download(context.Background(), "", tt.pkg)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				entries, readErr := os.ReadDir(filepath.Join(i.dir, stagingDirName))
+				require.NoError(t, readErr)
+				assert.Empty(t, entries, "failed downloads must not be staged")
+				return
+			}
+			require.NoError(t, err)
+			content, err := os.ReadFile(staged)
+			require.NoError(t, err)
+			assert.Equal(t, packageContent, content)
+		})
+	}
+}
+
+func TestDownloadSigned(t *testing.T) {
+	digest := sha256.Sum256(packageContent)
+	url := serve(t, packageContent)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	require.NoError(t, err)
+
+	ed25519PublicKey, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ed25519Signature := ed25519.Sign(ed25519Key, digest[:])
+
+	tests := []struct {
+		name      string
+		publicKey any
+		signature []byte
+	}{
+		{name: "rsa", publicKey: &rsaKey.PublicKey, signature: rsaSignature},
+		{name: "ecdsa", publicKey: &ecdsaKey.PublicKey, signature: ecdsaSignature},
+		{name: "ed25519", publicKey: ed25519PublicKey, signature: ed25519Signature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := newTestInstaller(t, InstallerSettings{PublicKeyFile: writePublicKey(t, tt.publicKey)})
+
+			_, err := i.Download(context.Background(), "", offer(url, nil, tt.signature))
+			assert.NoError(t, err)
+
+			_, err = i.Download(context.Background(), "", offer(url, digest[:], nil))
+			assert.EqualError(t, err, "package is not signed")
+
+			invalid := append([]byte{}, tt.signature...)
+			invalid[len(invalid)-1] ^= 0xff
+			_, err = i.Download(context.Background(), "", offer(url, nil, invalid))
+			assert.EqualError(t, err, "package signature is invalid")
+		})
+	}
+}
+
+func TestDownloadCanceled(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(block)
+
+	i := newTestInstaller(t, InstallerSettings{AllowUnverified: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err := i.Download(ctx, "", offer(server.URL, nil, nil))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDownloadTimeout(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(block)
+
+	i := newTestInstaller(t, InstallerSettings{AllowUnverified: true, DownloadTimeout: 10 * time.Millisecond})
+	_, err := i.Download(context.Background(), "", offer(server.URL, nil, nil))
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func TestInstallAndRevert(t *testing.T) {
+	i := newTestInstaller(t, InstallerSettings{AllowUnverified: true})
+	require.NoError(t, os.WriteFile(i.executable, []byte("previous"), 0600))
+
+	staged, err := i.Download(context.Background(), "", offer(serve(t, packageContent), nil, nil))
+	require.NoError(t, err)
+	require.NoError(t, i.Install(staged))
+	content, err := os.ReadFile(i.executable)
+	require.NoError(t, err)
+	assert.Equal(t, packageContent, content)
+	assert.NoFileExists(t, staged)
+
+	require.NoError(t, i.Revert())
+	content, err = os.ReadFile(i.executable)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("previous"), content)
+}
+
+func TestState(t *testing.T) {
+	i := newTestInstaller(t, InstallerSettings{})
+	state, err := i.LoadState()
+	require.NoError(t, err)
+	assert.Equal(t, &State{}, state)
+
+	state = &State{
+		AllPackagesHash: []byte("all"),
+		Packages:        map[string]Package{"": {Version: "1.0.0", Hash: []byte("hash")}},
+		BadHashes:       [][]byte{[]byte("bad")},
+	}
+	require.NoError(t, i.SaveState(state))
+	loaded, err := i.LoadState()
+	require.NoError(t, err)
+	assert.Equal(t, state, loaded)
+	assert.True(t, loaded.IsBad([]byte("bad")))
+	assert.False(t, loaded.IsBad([]byte("hash")))
+}
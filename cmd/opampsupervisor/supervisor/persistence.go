@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	persistentStateFileName      = "persistent_state.yaml"
+	lastRecvRemoteConfigFileName = "last_recv_remote_config.dat"
+)
+
+// createStorageDir creates the storage directory and checks that the Supervisor
+// can write to it, so that a missing storage::directory setting is reported
+// right away rather than when the state is first persisted.
+func (s *Supervisor) createStorageDir() error {
+	dir := s.storageDir()
+	err := os.MkdirAll(dir, 0700)
+	if err == nil {
+		var f *os.File
+		if f, err = os.CreateTemp(dir, ".write-check-*"); err == nil {
+			err = errors.Join(f.Close(), os.Remove(f.Name()))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("storage directory %s is not writable, set storage::directory to a directory the Supervisor can write to: %w", dir, err)
+	}
+	return nil
+}
+
+// persistentState is the state of the Supervisor kept across restarts.
+type persistentState struct {
+	// InstanceID is the instance ID of the agent, reported to the OpAMP Server.
+	InstanceID ulid.ULID `yaml:"instance_id"`
+
+	// Path to the file the state is persisted to.
+	path string
+}
+
+// SetInstanceID updates and persists the instance ID.
+func (p *persistentState) SetInstanceID(id ulid.ULID) error {
+	p.InstanceID = id
+	return p.write()
+}
+
+func (p *persistentState) write() error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.path, data, 0600)
+}
+
+// loadOrCreatePersistentState loads the state persisted in the given file, or
+// creates it with a new instance ID if the file does not exist.
+func loadOrCreatePersistentState(path string, newInstanceID func() (ulid.ULID, error)) (*persistentState, error) {
+	state := &persistentState{path: path}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(data, state); err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", path, err)
+		}
+		return state, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	id, err := newInstanceID()
+	if err != nil {
+		return nil, err
+	}
+	return state, state.SetInstanceID(id)
+}
+
+// loadLastReceivedRemoteConfig returns the last remote config accepted from
+// the OpAMP Server, or nil if there is none.
+func (s *Supervisor) loadLastReceivedRemoteConfig() (*protobufs.AgentRemoteConfig, error) {
+	data, err := os.ReadFile(filepath.Join(s.storageDir(), lastRecvRemoteConfigFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &protobufs.AgentRemoteConfig{}
+	if err = proto.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse last received remote config: %w", err)
+	}
+	return cfg, nil
+}
+
+// saveLastReceivedRemoteConfig persists the remote config accepted from the
+// OpAMP Server so that the agent can be started with it if the Supervisor
+// restarts while the server is unreachable.
+func (s *Supervisor) saveLastReceivedRemoteConfig(cfg *protobufs.AgentRemoteConfig) error {
+	data, err := proto.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.storageDir(), lastRecvRemoteConfigFileName), data, 0600)
+}
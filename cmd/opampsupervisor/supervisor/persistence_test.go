@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package supervisor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
+)
+
+func TestLoadOrCreatePersistentState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), persistentStateFileName)
+	id := ulid.MustParse("01HF8Z5Y4M3Q4B7M4P3X2S1R0T")
+
+	state, err := loadOrCreatePersistentState(path, func() (ulid.ULID, error) { return id, nil })
+	require.NoError(t, err)
+	assert.Equal(t, id, state.InstanceID)
+	assert.FileExists(t, path)
+
+	// The persisted instance ID is kept across restarts.
+	state, err = loadOrCreatePersistentState(path, func() (ulid.ULID, error) {
+		return ulid.ULID{}, errors.New("unexpected new instance ID")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, id, state.InstanceID)
+
+	newID := ulid.MustParse("01HF8Z5Y4M3Q4B7M4P3X2S1R0V")
+	require.NoError(t, state.SetInstanceID(newID))
+	state, err = loadOrCreatePersistentState(path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, newID, state.InstanceID)
+}
+
+func TestLoadOrCreatePersistentStateErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), persistentStateFileName)
+	require.NoError(t, os.WriteFile(path, []byte("instance_id: [invalid"), 0600))
+	_, err := loadOrCreatePersistentState(path, nil)
+	assert.ErrorContains(t, err, "cannot parse")
+
+	_, err = loadOrCreatePersistentState(filepath.Join(t.TempDir(), persistentStateFileName), func() (ulid.ULID, error) {
+		return ulid.ULID{}, errors.New("no entropy")
+	})
+	assert.EqualError(t, err, "no entropy")
+}
+
+func TestLastReceivedRemoteConfig(t *testing.T) {
+	s := &Supervisor{config: config.Supervisor{Storage: &config.Storage{Directory: t.TempDir()}}}
+
+	cfg, err := s.loadLastReceivedRemoteConfig()
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	remoteConfig := &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: []byte("receivers: {}")},
+			},
+		},
+		ConfigHash: []byte("hash"),
+	}
+	require.NoError(t, s.saveLastReceivedRemoteConfig(remoteConfig))
+	cfg, err = s.loadLastReceivedRemoteConfig()
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(remoteConfig, cfg))
+
+	require.NoError(t, os.WriteFile(filepath.Join(s.storageDir(), lastRecvRemoteConfigFileName), []byte("invalid"), 0600))
+	_, err = s.loadLastReceivedRemoteConfig()
+	assert.ErrorContains(t, err, "cannot parse last received remote config")
+}
+
+func TestCreateStorageDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "supervisor")
+	s := &Supervisor{config: config.Supervisor{Storage: &config.Storage{Directory: dir}}}
+	require.NoError(t, s.createStorageDir())
+	assert.DirExists(t, dir)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// A file stands in the way of the directory.
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+	s = &Supervisor{config: config.Supervisor{Storage: &config.Storage{Directory: filepath.Join(file, "supervisor")}}}
+	assert.ErrorContains(t, s.createStorageDir(), "set storage::directory")
+}
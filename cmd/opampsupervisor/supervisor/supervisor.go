@@ -10,8 +10,10 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -29,11 +31,18 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/commander"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/config"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/healthchecker"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/opampsupervisor/supervisor/packages"
 )
 
 // This Supervisor is developed specifically for the OpenTelemetry Collector.
 const agentType = "io.opentelemetry.collector"
 
+// Default directories where the Supervisor stores its data.
+const (
+	defaultStorageDir        = "/var/lib/otelcol/supervisor"
+	defaultWindowsStorageDir = "Otelcol/Supervisor"
+)
+
 // Supervisor implements supervising of OpenTelemetry Collector and uses OpAMPClient
 // to work with an OpAMP Server.
 type Supervisor struct {
@@ -54,6 +63,9 @@ type Supervisor struct {
 	// Agent's instance id.
 	instanceID ulid.ULID
 
+	// State of the Supervisor persisted in the storage directory.
+	persistentState *persistentState
+
 	// The version of the agent.
 	agentVersion string
 
@@ -63,6 +75,10 @@ type Supervisor struct {
 	// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21078
 	agentConfigOwnMetricsSection *atomic.Value
 
+	// Config sections to be added to the Collector's config to send its own logs and traces.
+	agentConfigOwnLogsSection   *atomic.Value
+	agentConfigOwnTracesSection *atomic.Value
+
 	// agentHealthCheckEndpoint is the endpoint the Collector's health check extension
 	// will listen on for health check requests from the Supervisor.
 	agentHealthCheckEndpoint string
@@ -73,8 +89,17 @@ type Supervisor struct {
 	// Location of the effective config file.
 	effectiveConfigFilePath string
 
-	// Last received remote config.
-	remoteConfig *protobufs.AgentRemoteConfig
+	// Last received remote config, guarded by remoteConfigMu.
+	remoteConfig   *protobufs.AgentRemoteConfig
+	remoteConfigMu sync.Mutex
+
+	// Config applied to the agent, waiting for the agent to become healthy.
+	configRollout *configRollout
+
+	// Last configs the agent was healthy with, the remote one being persisted
+	// in the storage directory.
+	lastGoodEffectiveConfig string
+	lastGoodRemoteConfig    *protobufs.AgentRemoteConfig
 
 	// A channel to indicate there is a new config to apply.
 	hasNewConfig chan struct{}
@@ -82,6 +107,32 @@ type Supervisor struct {
 	// The OpAMP client to connect to the OpAMP Server.
 	opampClient client.OpAMPClient
 
+	// Installs the packages offered by the OpAMP Server.
+	packageInstaller *packages.Installer
+
+	// Persisted state of the installed packages and the statuses reported to
+	// the OpAMP Server, both guarded by packagesMu.
+	packagesState   *packages.State
+	packageStatuses *protobufs.PackageStatuses
+	packagesMu      sync.Mutex
+
+	// Prevents processing several offers of packages at the same time, and
+	// guards the hash of the last packages offered by the OpAMP Server.
+	packagesSyncMu          sync.Mutex
+	lastOfferedPackagesHash []byte
+
+	// Cancels the downloads of packages when shutting down.
+	packagesCtx    context.Context
+	cancelPackages context.CancelFunc
+
+	// A channel to indicate there is a downloaded package to install.
+	packageUpdate chan *stagedPackage
+
+	// Installed package waiting for the agent to become healthy, and how long
+	// the agent has to become healthy before the package is reverted.
+	pendingPackage            *pendingPackage
+	packageHealthCheckTimeout time.Duration
+
 	shuttingDown bool
 
 	agentHasStarted               bool
@@ -92,8 +143,11 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 	s := &Supervisor{
 		logger:                       logger,
 		hasNewConfig:                 make(chan struct{}, 1),
+		packageUpdate:                make(chan *stagedPackage, 1),
 		effectiveConfigFilePath:      "effective.yaml",
 		agentConfigOwnMetricsSection: &atomic.Value{},
+		agentConfigOwnLogsSection:    &atomic.Value{},
+		agentConfigOwnTracesSection:  &atomic.Value{},
 		effectiveConfig:              &atomic.Value{},
 	}
 
@@ -105,6 +159,16 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 		s.logger.Error("Couldn't get agent version", zap.Error(err))
 	}
 
+	if err := s.createStorageDir(); err != nil {
+		return nil, err
+	}
+
+	if s.acceptsPackages() {
+		if err := s.setupPackages(); err != nil {
+			return nil, fmt.Errorf("cannot set up packages: %w", err)
+		}
+	}
+
 	port, err := s.findRandomPort()
 
 	if err != nil {
@@ -113,27 +177,34 @@ func NewSupervisor(logger *zap.Logger, configFile string) (*Supervisor, error) {
 
 	s.agentHealthCheckEndpoint = fmt.Sprintf("localhost:%d", port)
 
-	id, err := s.createInstanceID()
+	s.persistentState, err = loadOrCreatePersistentState(filepath.Join(s.storageDir(), persistentStateFileName), s.createInstanceID)
 
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("cannot load persistent state: %w", err)
 	}
 
-	s.instanceID = id
+	s.instanceID = s.persistentState.InstanceID
 
 	logger.Debug("Supervisor starting",
 		zap.String("id", s.instanceID.String()), zap.String("type", agentType), zap.String("version", s.agentVersion))
 
 	s.loadAgentEffectiveConfig()
+	s.lastGoodEffectiveConfig = s.effectiveConfig.Load().(string)
+	s.lastGoodRemoteConfig = s.remoteConfig
 
 	if err = s.startOpAMP(); err != nil {
 		return nil, fmt.Errorf("cannot start OpAMP client: %w", err)
 	}
 
+	args := []string{"--config", s.effectiveConfigFilePath}
+	if c := s.config.Capabilities; c != nil && c.ReportsOwnTraces != nil && *c.ReportsOwnTraces {
+		args = append(args, "--feature-gates", ownTracesFeatureGate)
+	}
+
 	s.commander, err = commander.NewCommander(
 		s.logger,
 		s.config.Agent,
-		"--config", s.effectiveConfigFilePath,
+		args...,
 	)
 	if err != nil {
 		return nil, err
@@ -166,6 +237,17 @@ func (s *Supervisor) loadConfig(configFile string) error {
 	return nil
 }
 
+// storageDir returns the directory where the Supervisor stores its data.
+func (s *Supervisor) storageDir() string {
+	if s.config.Storage != nil && s.config.Storage.Directory != "" {
+		return s.config.Storage.Directory
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("ProgramData"), defaultWindowsStorageDir)
+	}
+	return defaultStorageDir
+}
+
 // TODO: Implement bootstrapping https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21071
 // nolint: unparam
 func (s *Supervisor) getBootstrapInfo() (err error) {
@@ -192,6 +274,14 @@ func (s *Supervisor) Capabilities() protobufs.AgentCapabilities {
 			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics
 		}
 
+		if c.ReportsOwnLogs != nil && *c.ReportsOwnLogs {
+			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnLogs
+		}
+
+		if c.ReportsOwnTraces != nil && *c.ReportsOwnTraces {
+			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnTraces
+		}
+
 		if c.AcceptsRemoteConfig != nil && *c.AcceptsRemoteConfig {
 			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig
 		}
@@ -199,6 +289,14 @@ func (s *Supervisor) Capabilities() protobufs.AgentCapabilities {
 		if c.ReportsRemoteConfig != nil && *c.ReportsRemoteConfig {
 			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig
 		}
+
+		if c.AcceptsPackages != nil && *c.AcceptsPackages {
+			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages
+		}
+
+		if c.ReportsPackageStatuses != nil && *c.ReportsPackageStatuses {
+			supportedCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses
+		}
 	}
 	return supportedCapabilities
 }
@@ -252,6 +350,14 @@ func (s *Supervisor) startOpAMP() error {
 		},
 		Capabilities: s.Capabilities(),
 	}
+	if s.remoteConfig != nil {
+		// Let the server know which config the agent was started with, so
+		// that it sends the remote config again only if it has changed.
+		settings.RemoteConfigStatus = &protobufs.RemoteConfigStatus{
+			LastRemoteConfigHash: s.remoteConfig.ConfigHash,
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+		}
+	}
 	err = s.opampClient.SetAgentDescription(s.createAgentDescription())
 	if err != nil {
 		return err
@@ -262,6 +368,12 @@ func (s *Supervisor) startOpAMP() error {
 		return err
 	}
 
+	if s.packageStatuses != nil && s.reportsPackageStatuses() {
+		if err = s.opampClient.SetPackageStatuses(s.packageStatuses); err != nil {
+			return err
+		}
+	}
+
 	s.logger.Debug("Starting OpAMP client...")
 
 	err = s.opampClient.Start(context.Background(), settings)
@@ -274,7 +386,6 @@ func (s *Supervisor) startOpAMP() error {
 	return nil
 }
 
-// TODO: Persist instance ID. https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21073
 func (s *Supervisor) createInstanceID() (ulid.ULID, error) {
 	entropy := ulid.Monotonic(rand.New(rand.NewSource(0)), 0)
 	id, err := ulid.New(ulid.Timestamp(time.Now()), entropy)
@@ -341,6 +452,24 @@ extensions:
 }
 
 func (s *Supervisor) loadAgentEffectiveConfig() {
+	remoteConfig, err := s.loadLastReceivedRemoteConfig()
+	if err != nil {
+		s.logger.Error("Cannot load the last received remote config", zap.Error(err))
+	}
+
+	if remoteConfig != nil {
+		// Start the agent with the last remote config right away, without
+		// waiting for the server which may be unreachable.
+		s.logger.Debug("Using the last received remote config", zap.String("hash", fmt.Sprintf("%x", remoteConfig.ConfigHash)))
+		s.remoteConfig = remoteConfig
+		s.effectiveConfig.Store("")
+		if _, err = s.composeEffectiveConfig(remoteConfig); err == nil {
+			s.writeEffectiveConfigToFile(s.effectiveConfig.Load().(string), s.effectiveConfigFilePath)
+			return
+		}
+		s.logger.Error("Cannot compose effective config from the last received remote config", zap.Error(err))
+	}
+
 	var effectiveConfigBytes []byte
 
 	effFromFile, err := os.ReadFile(s.effectiveConfigFilePath)
@@ -433,7 +562,7 @@ service:
 
 // composeEffectiveConfig composes the effective config from multiple sources:
 // 1) the remote config from OpAMP Server
-// 2) the own metrics config section
+// 2) the own metrics, logs and traces config sections
 // 3) the local override config that is hard-coded in the Supervisor.
 func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig) (configChanged bool, err error) {
 	var k = koanf.New(".")
@@ -445,7 +574,8 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 
 	// Sort to make sure the order of merging is stable.
 	var names []string
-	for name := range config.Config.ConfigMap {
+	configMap := config.GetConfig().GetConfigMap()
+	for name := range configMap {
 		if name == "" {
 			// skip instance config
 			continue
@@ -460,7 +590,10 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 
 	// Merge received configs.
 	for _, name := range names {
-		item := config.Config.ConfigMap[name]
+		item, ok := configMap[name]
+		if !ok {
+			continue
+		}
 		var k2 = koanf.New(".")
 		err = k2.Load(rawbytes.Provider(item.Body), yaml.Parser())
 		if err != nil {
@@ -480,6 +613,22 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 		}
 	}
 
+	// Merge own logs config.
+	ownLogsCfg, ok := s.agentConfigOwnLogsSection.Load().(string)
+	if ok {
+		if err = k.Load(rawbytes.Provider([]byte(ownLogsCfg)), yaml.Parser()); err != nil {
+			return false, err
+		}
+	}
+
+	// Merge own traces config.
+	ownTracesCfg, ok := s.agentConfigOwnTracesSection.Load().(string)
+	if ok {
+		if err = k.Load(rawbytes.Provider([]byte(ownTracesCfg)), yaml.Parser()); err != nil {
+			return false, err
+		}
+	}
+
 	// Merge local config last since it has the highest precedence.
 	if err = k.Load(rawbytes.Provider([]byte(s.composeExtraLocalConfig())), yaml.Parser()); err != nil {
 		return false, err
@@ -506,7 +655,7 @@ func (s *Supervisor) composeEffectiveConfig(config *protobufs.AgentRemoteConfig)
 // Recalculate the Agent's effective config and if the config changes, signal to the
 // background goroutine that the config needs to be applied to the Agent.
 func (s *Supervisor) recalcEffectiveConfig() (configChanged bool, err error) {
-	configChanged, err = s.composeEffectiveConfig(s.remoteConfig)
+	configChanged, err = s.composeEffectiveConfig(s.currentRemoteConfig())
 	if err != nil {
 		s.logger.Error("Error composing effective config. Ignoring received config", zap.Error(err))
 		return configChanged, err
@@ -549,6 +698,8 @@ func (s *Supervisor) startHealthCheckTicker() {
 
 func (s *Supervisor) healthCheck() {
 	if !s.commander.IsRunning() {
+		s.checkPendingPackage(errAgentNotRunning)
+		s.checkConfigRollout(errAgentNotRunning)
 		return
 	}
 
@@ -557,6 +708,9 @@ func (s *Supervisor) healthCheck() {
 	err := s.healthChecker.Check(ctx)
 	cancel()
 
+	s.checkPendingPackage(err)
+	s.checkConfigRollout(err)
+
 	if errors.Is(err, s.lastHealthCheckErr) {
 		// No difference from last check. Nothing new to report.
 		return
@@ -606,6 +760,7 @@ func (s *Supervisor) runAgentProcess() {
 			restartTimer.Stop()
 			s.stopAgentApplyConfig()
 			s.startAgent()
+			s.startConfigRollout()
 
 		case <-s.commander.Done():
 			if s.shuttingDown {
@@ -623,13 +778,21 @@ func (s *Supervisor) runAgentProcess() {
 				s.logger.Error("Could not report health to OpAMP server", zap.Error(err))
 			}
 
-			// TODO: decide why the agent stopped. If it was due to bad config, report it to server.
-			// https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/21079
+			if s.configRollout != nil {
+				// The agent stopped while a new config was being applied, most
+				// likely because of that config.
+				s.failConfigRollout(fmt.Errorf("agent process exited unexpectedly, exit code=%d", s.commander.ExitCode()))
+				break
+			}
 
 			// Wait 5 seconds before starting again.
 			restartTimer.Stop()
 			restartTimer.Reset(5 * time.Second)
 
+		case update := <-s.packageUpdate:
+			restartTimer.Stop()
+			s.installPackage(update)
+
 		case <-restartTimer.C:
 			s.startAgent()
 
@@ -668,6 +831,9 @@ func (s *Supervisor) writeEffectiveConfigToFile(cfg string, filePath string) {
 func (s *Supervisor) Shutdown() {
 	s.logger.Debug("Supervisor shutting down...")
 	s.shuttingDown = true
+	if s.cancelPackages != nil {
+		s.cancelPackages()
+	}
 	if s.commander != nil {
 		err := s.commander.Stop(context.Background())
 
@@ -698,8 +864,8 @@ func (s *Supervisor) Shutdown() {
 func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 	configChanged := false
 	if msg.RemoteConfig != nil {
-		s.remoteConfig = msg.RemoteConfig
-		s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", s.remoteConfig.ConfigHash)))
+		s.setRemoteConfig(msg.RemoteConfig)
+		s.logger.Debug("Received remote config from server", zap.String("hash", fmt.Sprintf("%x", msg.RemoteConfig.ConfigHash)))
 
 		var err error
 		configChanged, err = s.recalcEffectiveConfig()
@@ -712,7 +878,20 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 			if err != nil {
 				s.logger.Error("Could not report failed OpAMP remote config status", zap.Error(err))
 			}
+		} else if configChanged {
+			// The config is applied once the agent is healthy with it, see checkConfigRollout.
+			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
+				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING,
+			})
+			if err != nil {
+				s.logger.Error("Could not report applying OpAMP remote config status", zap.Error(err))
+			}
 		} else {
+			if err = s.saveLastReceivedRemoteConfig(msg.RemoteConfig); err != nil {
+				s.logger.Error("Could not save the last received remote config", zap.Error(err))
+			}
+
 			err = s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
 				LastRemoteConfigHash: msg.RemoteConfig.ConfigHash,
 				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
@@ -727,6 +906,14 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		configChanged = s.setupOwnMetrics(ctx, msg.OwnMetricsConnSettings) || configChanged
 	}
 
+	if msg.OwnLogsConnSettings != nil {
+		configChanged = s.setupOwnLogs(ctx, msg.OwnLogsConnSettings) || configChanged
+	}
+
+	if msg.OwnTracesConnSettings != nil {
+		configChanged = s.setupOwnTraces(ctx, msg.OwnTracesConnSettings) || configChanged
+	}
+
 	if msg.AgentIdentification != nil {
 		newInstanceID, err := ulid.Parse(msg.AgentIdentification.NewInstanceUid)
 		if err != nil {
@@ -737,6 +924,9 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 			zap.String("old_id", s.instanceID.String()),
 			zap.String("new_id", newInstanceID.String()))
 		s.instanceID = newInstanceID
+		if err = s.persistentState.SetInstanceID(newInstanceID); err != nil {
+			s.logger.Error("Failed to persist new instance ID", zap.Error(err))
+		}
 		err = s.opampClient.SetAgentDescription(s.createAgentDescription())
 		if err != nil {
 			s.logger.Error("Failed to send agent description to OpAMP server")
@@ -745,6 +935,14 @@ func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 		configChanged = true
 	}
 
+	if msg.PackagesAvailable != nil {
+		if s.packageInstaller != nil {
+			go s.processPackagesAvailable(msg.PackagesAvailable)
+		} else {
+			s.logger.Debug("Ignoring offered packages since the AcceptsPackages capability is disabled")
+		}
+	}
+
 	if configChanged {
 		err := s.opampClient.UpdateEffectiveConfig(ctx)
 		if err != nil {
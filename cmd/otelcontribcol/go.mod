@@ -531,6 +531,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/collectd v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/datadog v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker v0.90.1 // indirect
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka v0.90.1 // indirect
@@ -639,7 +640,7 @@ require (
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20181117223130-1be2e3e5546d // indirect
-	github.com/yuin/gopher-lua v0.0.0-20220504180219-658193537a64 // indirect
+	github.com/yuin/gopher-lua v1.1.0 // indirect
 	github.com/yusufpapurcu/wmi v1.2.3 // indirect
 	github.com/zeebo/xxh3 v1.0.2 // indirect
 	go.etcd.io/bbolt v1.3.8 // indirect
@@ -1046,6 +1047,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokie
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent => ../../internal/sharedcomponent
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext => ../../internal/encodingext
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/expvarreceiver => ../../receiver/expvarreceiver
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/receiver/apachereceiver => ../../receiver/apachereceiver
@@ -6,6 +6,7 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 import (
 	"errors"
 
+	"go.opentelemetry.io/collector/component"
 	"go.uber.org/multierr"
 )
 
@@ -36,6 +37,12 @@ type Config struct {
 	MarshalerName MarshalerType    `mapstructure:"marshaler"`
 
 	FileFormat string `mapstructure:"file_format"`
+
+	// Encoding is the component ID of an encoding extension used to marshal
+	// telemetry data. It takes precedence over MarshalerName when set.
+	Encoding *component.ID `mapstructure:"encoding"`
+	// EncodingFileExtension is the file extension of objects written with Encoding.
+	EncodingFileExtension string `mapstructure:"encoding_file_extension"`
 }
 
 func (c *Config) Validate() error {
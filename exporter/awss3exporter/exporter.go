@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -31,20 +32,37 @@ func newS3Exporter(config *Config,
 
 	logger := params.Logger
 
-	m, err := newMarshaler(config.MarshalerName, logger)
-	if err != nil {
-		return nil, errors.New("unknown marshaler")
-	}
-
 	s3Exporter := &s3Exporter{
 		config:     config,
 		dataWriter: &s3Writer{},
 		logger:     logger,
-		marshaler:  m,
 	}
+
+	// An encoding extension can only be resolved once the host is available.
+	if config.Encoding != nil {
+		return s3Exporter, nil
+	}
+
+	m, err := newMarshaler(config.MarshalerName, logger)
+	if err != nil {
+		return nil, errors.New("unknown marshaler")
+	}
+	s3Exporter.marshaler = m
 	return s3Exporter, nil
 }
 
+func (e *s3Exporter) start(_ context.Context, host component.Host) error {
+	if e.config.Encoding == nil {
+		return nil
+	}
+	m, err := newMarshalerFromEncoding(e.config.Encoding, e.config.EncodingFileExtension, host, e.logger)
+	if err != nil {
+		return err
+	}
+	e.marshaler = m
+	return nil
+}
+
 func (e *s3Exporter) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
@@ -46,7 +46,8 @@ func createLogsExporter(ctx context.Context,
 
 	return exporterhelper.NewLogsExporter(ctx, params,
 		config,
-		s3Exporter.ConsumeLogs)
+		s3Exporter.ConsumeLogs,
+		exporterhelper.WithStart(s3Exporter.start))
 }
 
 func createMetricsExporter(ctx context.Context,
@@ -64,7 +65,8 @@ func createMetricsExporter(ctx context.Context,
 
 	return exporterhelper.NewMetricsExporter(ctx, params,
 		config,
-		s3Exporter.ConsumeMetrics)
+		s3Exporter.ConsumeMetrics,
+		exporterhelper.WithStart(s3Exporter.start))
 }
 
 func createTracesExporter(ctx context.Context,
@@ -83,5 +85,6 @@ func createTracesExporter(ctx context.Context,
 	return exporterhelper.NewTracesExporter(ctx,
 		params,
 		config,
-		s3Exporter.ConsumeTraces)
+		s3Exporter.ConsumeTraces,
+		exporterhelper.WithStart(s3Exporter.start))
 }
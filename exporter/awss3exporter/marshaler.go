@@ -5,11 +5,15 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"errors"
+	"fmt"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext"
 )
 
 type marshaler interface {
@@ -40,3 +44,20 @@ func newMarshaler(mType MarshalerType, logger *zap.Logger) (marshaler, error) {
 	}
 	return marshaler, nil
 }
+
+func newMarshalerFromEncoding(encoding *component.ID, fileFormat string, host component.Host, logger *zap.Logger) (marshaler, error) {
+	ext, err := encodingext.GetExtension(host, *encoding)
+	if err != nil {
+		return nil, err
+	}
+	marshaler := &s3Marshaler{logger: logger, fileFormat: fileFormat}
+	// An encoding extension may only support some signals, the others are
+	// rejected by s3Marshaler when they are exported.
+	marshaler.logsMarshaler, _ = ext.(plog.Marshaler)
+	marshaler.tracesMarshaler, _ = ext.(ptrace.Marshaler)
+	marshaler.metricsMarshaler, _ = ext.(pmetric.Marshaler)
+	if marshaler.logsMarshaler == nil && marshaler.tracesMarshaler == nil && marshaler.metricsMarshaler == nil {
+		return nil, fmt.Errorf("extension %q is not a marshaler", encoding)
+	}
+	return marshaler, nil
+}
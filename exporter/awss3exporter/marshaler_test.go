@@ -8,6 +8,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
@@ -30,3 +34,50 @@ func TestMarshaler(t *testing.T) {
 		require.Nil(t, m)
 	}
 }
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type testLogsEncodingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (testLogsEncodingExtension) MarshalLogs(plog.Logs) ([]byte, error) {
+	return []byte("encoded"), nil
+}
+
+func TestMarshalerFromEncoding(t *testing.T) {
+	id := component.NewID("test_encoding")
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			id: &testLogsEncodingExtension{},
+		},
+	}
+	{
+		m, err := newMarshalerFromEncoding(&id, "txt", host, zap.NewNop())
+		assert.NoError(t, err)
+		require.NotNil(t, m)
+		assert.Equal(t, m.format(), "txt")
+
+		buf, err := m.MarshalLogs(plog.NewLogs())
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("encoded"), buf)
+
+		_, err = m.MarshalTraces(ptrace.NewTraces())
+		assert.ErrorIs(t, err, errTracesNotSupported)
+	}
+	{
+		unknown := component.NewID("unknown")
+		m, err := newMarshalerFromEncoding(&unknown, "txt", host, zap.NewNop())
+		assert.EqualError(t, err, `unknown encoding extension "unknown"`)
+		require.Nil(t, m)
+	}
+}
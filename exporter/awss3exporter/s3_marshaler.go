@@ -4,12 +4,20 @@
 package awss3exporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
 
 import (
+	"errors"
+
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
+var (
+	errTracesNotSupported  = errors.New("traces are not supported by the configured marshaler")
+	errLogsNotSupported    = errors.New("logs are not supported by the configured marshaler")
+	errMetricsNotSupported = errors.New("metrics are not supported by the configured marshaler")
+)
+
 type s3Marshaler struct {
 	logsMarshaler    plog.Marshaler
 	tracesMarshaler  ptrace.Marshaler
@@ -19,14 +27,23 @@ type s3Marshaler struct {
 }
 
 func (marshaler *s3Marshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	if marshaler.tracesMarshaler == nil {
+		return nil, errTracesNotSupported
+	}
 	return marshaler.tracesMarshaler.MarshalTraces(td)
 }
 
 func (marshaler *s3Marshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	if marshaler.logsMarshaler == nil {
+		return nil, errLogsNotSupported
+	}
 	return marshaler.logsMarshaler.MarshalLogs(ld)
 }
 
 func (marshaler *s3Marshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	if marshaler.metricsMarshaler == nil {
+		return nil, errMetricsNotSupported
+	}
 	return marshaler.metricsMarshaler.MarshalMetrics(md)
 }
 
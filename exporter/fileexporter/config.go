@@ -20,8 +20,15 @@ const (
 type Config struct {
 
 	// Path of the file to write to. Path is relative to current directory.
+	// When GroupBy is enabled, it contains resource attribute placeholders
+	// such as {service.name}, and the telemetry of every resource is written
+	// to the file resolved from its attributes.
 	Path string `mapstructure:"path"`
 
+	// GroupBy configures how telemetry is split between the files resolved
+	// from the placeholders of Path.
+	GroupBy *GroupBy `mapstructure:"group_by"`
+
 	// Rotation defines an option about rotation of telemetry files
 	Rotation *Rotation `mapstructure:"rotation"`
 
@@ -31,6 +38,11 @@ type Config struct {
 	// - proto:  OTLP binary protobuf bytes.
 	FormatType string `mapstructure:"format"`
 
+	// Encoding is the component ID of an encoding extension used to marshal
+	// telemetry data instead of the OTLP marshaler selected by FormatType.
+	// FormatType still decides how each marshaled message is framed in the file.
+	Encoding *component.ID `mapstructure:"encoding"`
+
 	// Compression Codec used to export telemetry data
 	// Supported compression algorithms:`zstd`
 	Compression string `mapstructure:"compression"`
@@ -61,6 +73,32 @@ type Rotation struct {
 	// backup files is the computer's local time.  The default is to use UTC
 	// time.
 	LocalTime bool `mapstructure:"localtime"`
+
+	// Interval is the maximum duration a file is written to before it gets
+	// rotated. The default is to only rotate files based on their size.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Compression is the algorithm used to compress rotated files.
+	// Supported compression algorithms: `gzip`, `zstd`.
+	// The default is not to compress rotated files.
+	Compression string `mapstructure:"compression"`
+}
+
+// GroupBy defines how telemetry is split between the files resolved from
+// resource attributes.
+type GroupBy struct {
+	// Enabled treats Path as a template whose placeholders are replaced by
+	// resource attributes. Paths are written as is by default.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxOpenFiles is the maximum number of files kept open at the same time.
+	// The least recently written file is closed when another one needs to be
+	// opened. It defaults to 100 files.
+	MaxOpenFiles int `mapstructure:"max_open_files"`
+
+	// DefaultValue replaces the placeholders of resource attributes that are
+	// missing or empty. It defaults to "unknown".
+	DefaultValue string `mapstructure:"default_value"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -79,6 +117,26 @@ func (cfg *Config) Validate() error {
 	if cfg.FlushInterval < 0 {
 		return errors.New("flush_interval must be larger than zero")
 	}
+	if cfg.Rotation != nil {
+		if cfg.Rotation.Interval < 0 {
+			return errors.New("rotation interval must be larger than zero")
+		}
+		if cfg.Rotation.Compression != "" && cfg.Rotation.Compression != compressionGZIP && cfg.Rotation.Compression != compressionZSTD {
+			return errors.New("rotation compression is not supported")
+		}
+	}
+	if cfg.GroupBy != nil && cfg.GroupBy.Enabled {
+		template, err := parsePathTemplate(cfg.Path)
+		if err != nil {
+			return err
+		}
+		if len(template.keys) == 0 {
+			return errors.New("path must contain at least one placeholder when group_by is enabled")
+		}
+		if cfg.GroupBy.MaxOpenFiles < 0 {
+			return errors.New("group_by max_open_files cannot be negative")
+		}
+	}
 	return nil
 }
 
@@ -22,6 +22,8 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	encodingID := component.NewIDWithName("otlp_encoding", "proto")
+
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -79,6 +81,41 @@ func TestLoadConfig(t *testing.T) {
 				FlushInterval: time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "rotation_with_interval"),
+			expected: &Config{
+				Path: "./foo",
+				Rotation: &Rotation{
+					MaxBackups:  defaultMaxBackups,
+					Interval:    time.Hour,
+					Compression: compressionGZIP,
+				},
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "group_by"),
+			expected: &Config{
+				Path: "./data/{service.name}/{host.name}.json",
+				GroupBy: &GroupBy{
+					Enabled:      true,
+					MaxOpenFiles: 10,
+					DefaultValue: "none",
+				},
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "encoding"),
+			expected: &Config{
+				Path:          "./encoded",
+				FormatType:    formatTypeProto,
+				Encoding:      &encodingID,
+				FlushInterval: time.Second,
+			},
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "compression_error"),
 			errorMessage: "compression is not supported",
@@ -111,6 +148,18 @@ func TestLoadConfig(t *testing.T) {
 				FormatType:    formatTypeJSON,
 			},
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "rotation_compression_error"),
+			errorMessage: "rotation compression is not supported",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "path_template_error"),
+			errorMessage: `unclosed '{' in path "./data/{service.name/traces.json"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "path_template_without_placeholders"),
+			errorMessage: "path must contain at least one placeholder when group_by is enabled",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "flush_interval_negative_value"),
 			errorMessage: "flush_interval must be larger than zero",
@@ -7,11 +7,15 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/metadata"
@@ -22,12 +26,22 @@ const (
 	// the number of old log files to retain
 	defaultMaxBackups = 100
 
+	// the maximum size in megabytes of a file before it is rotated
+	defaultMaxMegabytes = 100
+
+	// the number of files kept open when grouping telemetry by resource
+	defaultMaxOpenFiles = 100
+
+	// the value of the path placeholders of missing resource attributes
+	defaultGroupByValue = "unknown"
+
 	// the format of encoded telemetry data
 	formatTypeJSON  = "json"
 	formatTypeProto = "proto"
 
 	// the type of compression codec
 	compressionZSTD = "zstd"
+	compressionGZIP = "gzip"
 )
 
 // NewFactory creates a factory for OTLP exporter.
@@ -52,19 +66,15 @@ func createTracesExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateFileExporter(cfg)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
-	})
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
 		cfg,
-		fe.Unwrap().(*fileExporter).consumeTraces,
+		fe.Unwrap().(fileExporterComponent).consumeTraces,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -76,19 +86,15 @@ func createMetricsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateFileExporter(cfg)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
-	})
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
 		cfg,
-		fe.Unwrap().(*fileExporter).consumeMetrics,
+		fe.Unwrap().(fileExporterComponent).consumeMetrics,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -100,25 +106,50 @@ func createLogsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateFileExporter(cfg)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
-	})
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
 		cfg,
-		fe.Unwrap().(*fileExporter).consumeLogs,
+		fe.Unwrap().(fileExporterComponent).consumeLogs,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
 	)
 }
 
+// fileExporterComponent is implemented by the exporters writing telemetry to a
+// single file or to a file per group of resources.
+type fileExporterComponent interface {
+	component.Component
+	consumeTraces(context.Context, ptrace.Traces) error
+	consumeMetrics(context.Context, pmetric.Metrics) error
+	consumeLogs(context.Context, plog.Logs) error
+}
+
+func getOrCreateFileExporter(cfg component.Config) (*sharedcomponent.SharedComponent, error) {
+	conf := cfg.(*Config)
+	if conf.GroupBy != nil && conf.GroupBy.Enabled {
+		template, err := parsePathTemplate(conf.Path)
+		if err != nil {
+			return nil, err
+		}
+		return exporters.GetOrAdd(cfg, func() component.Component {
+			return newGroupingFileExporter(conf, template)
+		}), nil
+	}
+	writer, err := buildFileWriter(conf)
+	if err != nil {
+		return nil, err
+	}
+	return exporters.GetOrAdd(cfg, func() component.Component {
+		return newFileExporter(conf, writer)
+	}), nil
+}
+
 func newFileExporter(conf *Config, writer io.WriteCloser) *fileExporter {
 	return &fileExporter{
 		path:             conf.Path,
@@ -127,6 +158,7 @@ func newFileExporter(conf *Config, writer io.WriteCloser) *fileExporter {
 		tracesMarshaler:  tracesMarshalers[conf.FormatType],
 		metricsMarshaler: metricsMarshalers[conf.FormatType],
 		logsMarshaler:    logsMarshalers[conf.FormatType],
+		encoding:         conf.Encoding,
 		exporter:         buildExportFunc(conf),
 		compression:      conf.Compression,
 		compressor:       buildCompressor(conf.Compression),
@@ -142,13 +174,39 @@ func buildFileWriter(cfg *Config) (io.WriteCloser, error) {
 		}
 		return newBufferedWriteCloser(f), nil
 	}
-	return &lumberjack.Logger{
+	return buildRotationWriter(cfg), nil
+}
+
+// buildRotationWriter only uses rotatingWriter for the rotation settings that
+// lumberjack does not support. Both are buffered so that flush_interval applies.
+func buildRotationWriter(cfg *Config) io.WriteCloser {
+	if cfg.Rotation.Interval > 0 || cfg.Rotation.Compression != "" {
+		return newRotatingWriter(cfg.Path, cfg.Rotation)
+	}
+	return newBufferedWriteCloser(&lumberjack.Logger{
 		Filename:   cfg.Path,
 		MaxSize:    cfg.Rotation.MaxMegabytes,
 		MaxAge:     cfg.Rotation.MaxDays,
 		MaxBackups: cfg.Rotation.MaxBackups,
 		LocalTime:  cfg.Rotation.LocalTime,
-	}, nil
+	})
+}
+
+// buildGroupFileWriter creates the writer of a file resolved from resource
+// attributes. Unlike buildFileWriter, it appends to existing files since they
+// are closed and reopened when more than max_open_files are written to.
+func buildGroupFileWriter(cfg *Config) (io.WriteCloser, error) {
+	if cfg.Rotation != nil {
+		return buildRotationWriter(cfg), nil
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0750); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return newBufferedWriteCloser(f), nil
 }
 
 // This is the map of already created File exporters for particular configurations.
@@ -6,6 +6,7 @@ package fileexporter
 import (
 	"context"
 	"io"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -129,7 +130,9 @@ func TestBuildFileWriter(t *testing.T) {
 				},
 			},
 			validate: func(t *testing.T, closer io.WriteCloser) {
-				writer, ok := closer.(*lumberjack.Logger)
+				buffered, ok := closer.(*bufferedWriteCloser)
+				require.True(t, ok)
+				writer, ok := buffered.wrapped.(*lumberjack.Logger)
 				assert.Equal(t, true, ok)
 				assert.Equal(t, defaultMaxBackups, writer.MaxBackups)
 			},
@@ -148,7 +151,9 @@ func TestBuildFileWriter(t *testing.T) {
 				},
 			},
 			validate: func(t *testing.T, closer io.WriteCloser) {
-				writer, ok := closer.(*lumberjack.Logger)
+				buffered, ok := closer.(*bufferedWriteCloser)
+				require.True(t, ok)
+				writer, ok := buffered.wrapped.(*lumberjack.Logger)
 				assert.Equal(t, true, ok)
 				assert.Equal(t, 3, writer.MaxBackups)
 				assert.Equal(t, 30, writer.MaxSize)
@@ -168,3 +173,26 @@ func TestBuildFileWriter(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOrCreateFileExporterGroupBy(t *testing.T) {
+	dir := t.TempDir()
+	literal := &Config{
+		FormatType: formatTypeJSON,
+		Path:       filepath.Join(dir, "{literal}.json"),
+	}
+	fe, err := getOrCreateFileExporter(literal)
+	require.NoError(t, err)
+	assert.IsType(t, &fileExporter{}, fe.Unwrap())
+	assert.FileExists(t, literal.Path)
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	grouped := &Config{
+		FormatType: formatTypeJSON,
+		Path:       filepath.Join(dir, "{service.name}.json"),
+		GroupBy:    &GroupBy{Enabled: true},
+	}
+	fe, err = getOrCreateFileExporter(grouped)
+	require.NoError(t, err)
+	assert.IsType(t, &groupingFileExporter{}, fe.Unwrap())
+	assert.NoError(t, fe.Shutdown(context.Background()))
+}
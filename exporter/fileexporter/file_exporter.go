@@ -6,14 +6,18 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
 	"io"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext"
 )
 
 // Marshaler configuration used for marhsaling Protobuf
@@ -42,6 +46,7 @@ type fileExporter struct {
 	tracesMarshaler  ptrace.Marshaler
 	metricsMarshaler pmetric.Marshaler
 	logsMarshaler    plog.Marshaler
+	encoding         *component.ID
 
 	compression string
 	compressor  compressFunc
@@ -55,6 +60,9 @@ type fileExporter struct {
 }
 
 func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	if e.tracesMarshaler == nil {
+		return consumererror.NewPermanent(fmt.Errorf("encoding extension %q does not marshal traces", e.encoding))
+	}
 	buf, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
@@ -64,6 +72,9 @@ func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error
 }
 
 func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if e.metricsMarshaler == nil {
+		return consumererror.NewPermanent(fmt.Errorf("encoding extension %q does not marshal metrics", e.encoding))
+	}
 	buf, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
@@ -73,6 +84,9 @@ func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) err
 }
 
 func (e *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	if e.logsMarshaler == nil {
+		return consumererror.NewPermanent(fmt.Errorf("encoding extension %q does not marshal logs", e.encoding))
+	}
 	buf, err := e.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
@@ -135,14 +149,36 @@ func (e *fileExporter) startFlusher() {
 	}()
 }
 
-// Start starts the flush timer if set.
-func (e *fileExporter) Start(context.Context, component.Host) error {
+// Start resolves the encoding extension if configured and starts the flush timer if set.
+func (e *fileExporter) Start(_ context.Context, host component.Host) error {
+	if e.encoding != nil {
+		if err := e.loadEncodingExtension(host); err != nil {
+			return err
+		}
+	}
 	if e.flushInterval > 0 {
 		e.startFlusher()
 	}
 	return nil
 }
 
+// loadEncodingExtension replaces the OTLP marshalers with the ones implemented by
+// the configured encoding extension. Signals the extension cannot marshal are
+// rejected when consumed, since the exporter is shared between pipelines.
+func (e *fileExporter) loadEncodingExtension(host component.Host) error {
+	ext, err := encodingext.GetExtension(host, *e.encoding)
+	if err != nil {
+		return err
+	}
+	e.tracesMarshaler, _ = ext.(ptrace.Marshaler)
+	e.metricsMarshaler, _ = ext.(pmetric.Marshaler)
+	e.logsMarshaler, _ = ext.(plog.Marshaler)
+	if e.tracesMarshaler == nil && e.metricsMarshaler == nil && e.logsMarshaler == nil {
+		return fmt.Errorf("extension %q is not a marshaler", e.encoding)
+	}
+	return nil
+}
+
 // Shutdown stops the exporter and is invoked during shutdown.
 // It stops the flush ticker if set.
 func (e *fileExporter) Shutdown(context.Context) error {
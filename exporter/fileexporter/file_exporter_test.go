@@ -18,6 +18,7 @@ import (
 	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -662,3 +663,58 @@ func TestFlushing(t *testing.T) {
 	assert.EqualValues(t, b, bbuf.Bytes())
 	assert.NoError(t, fe.Shutdown(ctx))
 }
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type testLogsEncodingExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (testLogsEncodingExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	v, _ := ld.ResourceLogs().At(0).Resource().Attributes().Get("resource-attr")
+	return []byte(v.Str()), nil
+}
+
+func TestEncodingExtension(t *testing.T) {
+	encodingID := component.NewID("test_encoding")
+	cfg := &Config{
+		FormatType: formatTypeJSON,
+		Encoding:   &encodingID,
+	}
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			encodingID: &testLogsEncodingExtension{},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	fe := newFileExporter(cfg, &NopWriteCloser{buf})
+	require.NoError(t, fe.Start(context.Background(), host))
+
+	assert.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	assert.Equal(t, "resource-attr-val-1\n", buf.String())
+
+	err := fe.consumeTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	assert.ErrorContains(t, err, `encoding extension "test_encoding" does not marshal traces`)
+	assert.NoError(t, fe.Shutdown(context.Background()))
+}
+
+func TestEncodingExtensionNotFound(t *testing.T) {
+	encodingID := component.NewID("test_encoding")
+	cfg := &Config{
+		FormatType: formatTypeJSON,
+		Encoding:   &encodingID,
+	}
+
+	fe := newFileExporter(cfg, &NopWriteCloser{&bytes.Buffer{}})
+	assert.EqualError(t, fe.Start(context.Background(), componenttest.NewNopHost()), `unknown encoding extension "test_encoding"`)
+}
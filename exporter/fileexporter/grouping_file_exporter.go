@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// groupingFileExporter writes the telemetry of every resource to the file
+// whose path is resolved from the path template and the resource attributes.
+// At most maxOpenFiles files are kept open: the least recently used one is
+// closed when another one needs to be opened.
+type groupingFileExporter struct {
+	conf         *Config
+	template     *pathTemplate
+	maxOpenFiles int
+	defaultValue string
+
+	host  component.Host
+	mutex sync.Mutex
+	// files holds the exporters of the open files, most recently used first.
+	files  *list.List
+	byPath map[string]*list.Element
+}
+
+func newGroupingFileExporter(conf *Config, template *pathTemplate) *groupingFileExporter {
+	e := &groupingFileExporter{
+		conf:         conf,
+		template:     template,
+		maxOpenFiles: defaultMaxOpenFiles,
+		defaultValue: defaultGroupByValue,
+		files:        list.New(),
+		byPath:       make(map[string]*list.Element),
+	}
+	if conf.GroupBy != nil {
+		if conf.GroupBy.MaxOpenFiles > 0 {
+			e.maxOpenFiles = conf.GroupBy.MaxOpenFiles
+		}
+		if conf.GroupBy.DefaultValue != "" {
+			e.defaultValue = conf.GroupBy.DefaultValue
+		}
+	}
+	return e
+}
+
+func (e *groupingFileExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	groups := make(map[string]ptrace.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		path := e.template.resolve(rs.Resource().Attributes(), e.defaultValue)
+		group, ok := groups[path]
+		if !ok {
+			group = ptrace.NewTraces()
+			groups[path] = group
+		}
+		rs.CopyTo(group.ResourceSpans().AppendEmpty())
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	var errs error
+	for path, group := range groups {
+		fe, err := e.getFileExporter(ctx, path)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		errs = errors.Join(errs, fe.consumeTraces(ctx, group))
+	}
+	return errs
+}
+
+func (e *groupingFileExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	groups := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		path := e.template.resolve(rm.Resource().Attributes(), e.defaultValue)
+		group, ok := groups[path]
+		if !ok {
+			group = pmetric.NewMetrics()
+			groups[path] = group
+		}
+		rm.CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	var errs error
+	for path, group := range groups {
+		fe, err := e.getFileExporter(ctx, path)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		errs = errors.Join(errs, fe.consumeMetrics(ctx, group))
+	}
+	return errs
+}
+
+func (e *groupingFileExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	groups := make(map[string]plog.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		path := e.template.resolve(rl.Resource().Attributes(), e.defaultValue)
+		group, ok := groups[path]
+		if !ok {
+			group = plog.NewLogs()
+			groups[path] = group
+		}
+		rl.CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	var errs error
+	for path, group := range groups {
+		fe, err := e.getFileExporter(ctx, path)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		errs = errors.Join(errs, fe.consumeLogs(ctx, group))
+	}
+	return errs
+}
+
+// getFileExporter returns the exporter writing to path, opening the file if
+// needed. It must be called with the mutex held.
+func (e *groupingFileExporter) getFileExporter(ctx context.Context, path string) (*fileExporter, error) {
+	if elem, ok := e.byPath[path]; ok {
+		e.files.MoveToFront(elem)
+		return elem.Value.(*fileExporter), nil
+	}
+
+	if e.files.Len() >= e.maxOpenFiles {
+		oldest := e.files.Back()
+		e.files.Remove(oldest)
+		fe := oldest.Value.(*fileExporter)
+		delete(e.byPath, fe.path)
+		if err := fe.Shutdown(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	conf := *e.conf
+	conf.Path = path
+	writer, err := buildGroupFileWriter(&conf)
+	if err != nil {
+		return nil, err
+	}
+	fe := newFileExporter(&conf, writer)
+	if err = fe.Start(ctx, e.host); err != nil {
+		return nil, errors.Join(err, writer.Close())
+	}
+	e.byPath[path] = e.files.PushFront(fe)
+	return fe, nil
+}
+
+// Start checks the encoding extension if configured. Files are opened when
+// telemetry is first written to them.
+func (e *groupingFileExporter) Start(_ context.Context, host component.Host) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.host = host
+	if e.conf.Encoding != nil {
+		// Fail on startup rather than when the first file is opened.
+		return newFileExporter(e.conf, nil).loadEncodingExtension(host)
+	}
+	return nil
+}
+
+// Shutdown closes all the open files.
+func (e *groupingFileExporter) Shutdown(ctx context.Context) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	var errs error
+	for elem := e.files.Front(); elem != nil; elem = elem.Next() {
+		errs = errors.Join(errs, elem.Value.(*fileExporter).Shutdown(ctx))
+	}
+	e.files.Init()
+	e.byPath = make(map[string]*list.Element)
+	return errs
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func generateLogs(services ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, service := range services {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if service != "" {
+			rl.Resource().Attributes().PutStr("service.name", service)
+		}
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log from " + service)
+	}
+	return ld
+}
+
+func readLogsFile(t *testing.T, path string) []plog.Logs {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var logs []plog.Logs
+	unmarshaler := &plog.JSONUnmarshaler{}
+	for _, line := range bytes.Split(bytes.TrimSuffix(content, []byte("\n")), []byte("\n")) {
+		ld, err := unmarshaler.UnmarshalLogs(line)
+		require.NoError(t, err)
+		logs = append(logs, ld)
+	}
+	return logs
+}
+
+func TestGroupingFileExporter(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:          filepath.Join(dir, "{service.name}", "logs.json"),
+		FormatType:    formatTypeJSON,
+		FlushInterval: time.Second,
+	}
+	template, err := parsePathTemplate(conf.Path)
+	require.NoError(t, err)
+	fe := newGroupingFileExporter(conf, template)
+
+	ctx := context.Background()
+	require.NoError(t, fe.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, fe.consumeLogs(ctx, generateLogs("checkout", "cart", "checkout", "")))
+	require.NoError(t, fe.consumeLogs(ctx, generateLogs("cart")))
+	require.NoError(t, fe.Shutdown(ctx))
+
+	checkout := readLogsFile(t, filepath.Join(dir, "checkout", "logs.json"))
+	require.Len(t, checkout, 1)
+	assert.Equal(t, 2, checkout[0].ResourceLogs().Len())
+
+	cart := readLogsFile(t, filepath.Join(dir, "cart", "logs.json"))
+	require.Len(t, cart, 2)
+	assert.Equal(t, generateLogs("cart"), cart[1])
+
+	unknown := readLogsFile(t, filepath.Join(dir, defaultGroupByValue, "logs.json"))
+	require.Len(t, unknown, 1)
+	assert.Equal(t, generateLogs(""), unknown[0])
+}
+
+func TestGroupingFileExporterMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:       filepath.Join(dir, "{service.name}.json"),
+		FormatType: formatTypeJSON,
+		GroupBy: &GroupBy{
+			MaxOpenFiles: 1,
+		},
+	}
+	template, err := parsePathTemplate(conf.Path)
+	require.NoError(t, err)
+	fe := newGroupingFileExporter(conf, template)
+
+	ctx := context.Background()
+	require.NoError(t, fe.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, fe.consumeLogs(ctx, generateLogs("checkout")))
+	require.NoError(t, fe.consumeLogs(ctx, generateLogs("cart")))
+	assert.Equal(t, 1, fe.files.Len())
+	// Reopening an evicted file appends to it.
+	require.NoError(t, fe.consumeLogs(ctx, generateLogs("checkout")))
+	require.NoError(t, fe.Shutdown(ctx))
+
+	assert.Len(t, readLogsFile(t, filepath.Join(dir, "checkout.json")), 2)
+	assert.Len(t, readLogsFile(t, filepath.Join(dir, "cart.json")), 1)
+}
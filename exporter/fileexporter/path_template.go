@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// pathTemplate is a path containing resource attribute placeholders such as
+// "/data/{service.name}/traces.json".
+type pathTemplate struct {
+	// literals has one more element than keys: the path is made of
+	// literals[0] + value(keys[0]) + literals[1] + ... + literals[len(keys)].
+	literals []string
+	keys     []string
+}
+
+func parsePathTemplate(path string) (*pathTemplate, error) {
+	t := &pathTemplate{}
+	rest := path
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			if strings.IndexByte(rest, '}') >= 0 {
+				return nil, fmt.Errorf("unexpected '}' in path %q", path)
+			}
+			t.literals = append(t.literals, rest)
+			return t, nil
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed '{' in path %q", path)
+		}
+		end += start
+		key := rest[start+1 : end]
+		if key == "" {
+			return nil, fmt.Errorf("empty placeholder in path %q", path)
+		}
+		if strings.IndexByte(rest[:start], '}') >= 0 {
+			return nil, fmt.Errorf("unexpected '}' in path %q", path)
+		}
+		if strings.IndexByte(key, '{') >= 0 {
+			return nil, fmt.Errorf("nested placeholders in path %q", path)
+		}
+		t.literals = append(t.literals, rest[:start])
+		t.keys = append(t.keys, key)
+		rest = rest[end+1:]
+	}
+}
+
+// resolve returns the path of the telemetry of a resource with the given
+// attributes. Placeholders of missing or empty attributes are replaced with
+// defaultValue.
+func (t *pathTemplate) resolve(attrs pcommon.Map, defaultValue string) string {
+	var sb strings.Builder
+	for i, key := range t.keys {
+		sb.WriteString(t.literals[i])
+		value := defaultValue
+		if v, ok := attrs.Get(key); ok && v.AsString() != "" {
+			value = v.AsString()
+		}
+		sb.WriteString(sanitizePathSegment(value))
+	}
+	sb.WriteString(t.literals[len(t.keys)])
+	return sb.String()
+}
+
+// sanitizePathSegment prevents attribute values from escaping the directory
+// they are written to.
+func sanitizePathSegment(value string) string {
+	value = strings.NewReplacer("/", "_", `\`, "_").Replace(value)
+	if value == "." || value == ".." {
+		return "_"
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		attrs    map[string]any
+		expected string
+	}{
+		{
+			name:     "no placeholder",
+			path:     "/data/traces.json",
+			expected: "/data/traces.json",
+		},
+		{
+			name:     "placeholders",
+			path:     "/data/{service.name}/{host.name}.json",
+			attrs:    map[string]any{"service.name": "checkout", "host.name": "host-1"},
+			expected: "/data/checkout/host-1.json",
+		},
+		{
+			name:     "non string attribute",
+			path:     "/data/{shard}.json",
+			attrs:    map[string]any{"shard": 3},
+			expected: "/data/3.json",
+		},
+		{
+			name:     "missing attribute",
+			path:     "/data/{service.name}/traces.json",
+			expected: "/data/unknown/traces.json",
+		},
+		{
+			name:     "empty attribute",
+			path:     "/data/{service.name}/traces.json",
+			attrs:    map[string]any{"service.name": ""},
+			expected: "/data/unknown/traces.json",
+		},
+		{
+			name:     "path separators",
+			path:     "/data/{service.name}/traces.json",
+			attrs:    map[string]any{"service.name": "../../etc"},
+			expected: "/data/.._.._etc/traces.json",
+		},
+		{
+			name:     "parent directory",
+			path:     "/data/{service.name}/traces.json",
+			attrs:    map[string]any{"service.name": ".."},
+			expected: "/data/_/traces.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := parsePathTemplate(tt.path)
+			require.NoError(t, err)
+			attrs := pcommon.NewMap()
+			require.NoError(t, attrs.FromRaw(tt.attrs))
+			assert.Equal(t, tt.expected, template.resolve(attrs, defaultGroupByValue))
+		})
+	}
+}
+
+func TestParsePathTemplateErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		err  string
+	}{
+		{
+			path: "/data/{service.name/traces.json",
+			err:  `unclosed '{' in path "/data/{service.name/traces.json"`,
+		},
+		{
+			path: "/data/service.name}/traces.json",
+			err:  `unexpected '}' in path "/data/service.name}/traces.json"`,
+		},
+		{
+			path: "/data/{}/traces.json",
+			err:  `empty placeholder in path "/data/{}/traces.json"`,
+		},
+		{
+			path: "/data/{service.{name}}/traces.json",
+			err:  `nested placeholders in path "/data/{service.{name}}/traces.json"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := parsePathTemplate(tt.path)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
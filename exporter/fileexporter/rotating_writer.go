@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	megabyte = 1024 * 1024
+
+	// backupTimeFormat is the timestamp inserted in the name of rotated files.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// compressedExtensions maps the rotation compression algorithms to the
+// extension appended to the name of the compressed files.
+var compressedExtensions = map[string]string{
+	compressionGZIP: ".gz",
+	compressionZSTD: ".zst",
+}
+
+// rotatingWriter writes to the file at path and rotates it once it grows
+// larger than maxSize or, if interval is set, once it is older than interval.
+// Rotated files are renamed with a timestamp, optionally compressed, and
+// removed according to maxBackups and maxAge. It is only used when interval
+// or compression is set, lumberjack handles the plain size based rotation.
+type rotatingWriter struct {
+	path        string
+	maxSize     int64
+	maxAge      time.Duration
+	maxBackups  int
+	localTime   bool
+	interval    time.Duration
+	compression string
+
+	file     io.WriteCloser
+	size     int64
+	openedAt time.Time
+
+	// compressing tracks the rotated files compressed in the background, so
+	// that writes are not blocked while compressing large files. Each
+	// compression waits for the previous one to be done, so that backups are
+	// compressed and removed in the order they were rotated.
+	compressing  sync.WaitGroup
+	lastCompress chan struct{}
+	millMu       sync.Mutex
+	millErr      error
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+var _ io.WriteCloser = (*rotatingWriter)(nil)
+
+func newRotatingWriter(path string, rotation *Rotation) *rotatingWriter {
+	maxMegabytes := rotation.MaxMegabytes
+	if maxMegabytes == 0 {
+		maxMegabytes = defaultMaxMegabytes
+	}
+	return &rotatingWriter{
+		path:        path,
+		maxSize:     int64(maxMegabytes) * megabyte,
+		maxAge:      time.Duration(rotation.MaxDays) * 24 * time.Hour,
+		maxBackups:  rotation.MaxBackups,
+		localTime:   rotation.LocalTime,
+		interval:    rotation.Interval,
+		compression: rotation.Compression,
+		now:         time.Now,
+	}
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > w.maxSize {
+		return 0, fmt.Errorf("write length %d exceeds maximum file size %d", len(p), w.maxSize)
+	}
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the file and waits for the pending compressions to complete,
+// returning the errors they ran into.
+func (w *rotatingWriter) Close() error {
+	err := w.closeFile()
+	w.compressing.Wait()
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+	err = errors.Join(err, w.millErr)
+	w.millErr = nil
+	return err
+}
+
+func (w *rotatingWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *rotatingWriter) flush() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.file.(*bufferedWriteCloser).flush()
+}
+
+func (w *rotatingWriter) shouldRotate(n int) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.size+int64(n) > w.maxSize {
+		return true
+	}
+	return w.interval > 0 && w.now().Sub(w.openedAt) >= w.interval
+}
+
+// open appends to the file at path, creating it and its directory if needed.
+func (w *rotatingWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Join(err, f.Close())
+	}
+	w.file = newBufferedWriteCloser(f)
+	w.size = info.Size()
+	w.openedAt = w.now()
+	return nil
+}
+
+func (w *rotatingWriter) rotate() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+	backup := w.backupName(w.now())
+	if err := os.Rename(w.path, backup); err != nil {
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+	if w.compression == "" {
+		return w.removeBackups()
+	}
+	previous, done := w.lastCompress, make(chan struct{})
+	w.lastCompress = done
+	w.compressing.Add(1)
+	go func() {
+		defer w.compressing.Done()
+		defer close(done)
+		if previous != nil {
+			<-previous
+		}
+		err := compressFile(backup, w.compression)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			// The backup was removed by a previous compression as it
+			// was beyond maxBackups.
+			err = nil
+		case err != nil:
+			err = fmt.Errorf("failed to compress %q: %w", backup, err)
+		}
+		err = errors.Join(err, w.removeBackups())
+		w.millMu.Lock()
+		w.millErr = errors.Join(w.millErr, err)
+		w.millMu.Unlock()
+	}()
+	return nil
+}
+
+// backupName inserts t in the file name immediately before its extension.
+func (w *rotatingWriter) backupName(t time.Time) string {
+	if !w.localTime {
+		t = t.UTC()
+	}
+	prefix, ext := w.nameParts()
+	return prefix + t.Format(backupTimeFormat) + ext
+}
+
+// nameParts returns the prefix and extension shared by all the backups of path.
+func (w *rotatingWriter) nameParts() (string, string) {
+	ext := filepath.Ext(w.path)
+	return strings.TrimSuffix(w.path, ext) + "-", ext
+}
+
+// removeBackups removes the rotated files beyond maxBackups or older than maxAge.
+func (w *rotatingWriter) removeBackups() error {
+	if w.maxBackups == 0 && w.maxAge == 0 {
+		return nil
+	}
+	prefix, ext := w.nameParts()
+	matches, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		path string
+		time time.Time
+	}
+	var backups []backup
+	for _, match := range matches {
+		ts := strings.TrimPrefix(match, prefix)
+		for _, compressedExt := range compressedExtensions {
+			ts = strings.TrimSuffix(ts, compressedExt)
+		}
+		t, err := time.Parse(backupTimeFormat, strings.TrimSuffix(ts, ext))
+		if err != nil {
+			// Not a backup of this file.
+			continue
+		}
+		backups = append(backups, backup{path: match, time: t})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.After(backups[j].time)
+	})
+
+	var errs error
+	cutoff := w.now().Add(-w.maxAge)
+	for i, b := range backups {
+		if (w.maxBackups > 0 && i >= w.maxBackups) || (w.maxAge > 0 && b.time.Before(cutoff)) {
+			errs = errors.Join(errs, os.Remove(b.path))
+		}
+	}
+	return errs
+}
+
+// compressFile replaces the file at path with a compressed copy.
+func compressFile(path string, compression string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressedExtensions[compression], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, dst.Close())
+		if err != nil {
+			_ = os.Remove(dst.Name())
+		}
+	}()
+
+	var zw io.WriteCloser
+	switch compression {
+	case compressionGZIP:
+		zw = gzip.NewWriter(dst)
+	case compressionZSTD:
+		if zw, err = zstd.NewWriter(dst); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported compression %q", compression)
+	}
+	if _, err = io.Copy(zw, src); err != nil {
+		return errors.Join(err, zw.Close())
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	if err = src.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock returns a time advanced manually by the tests.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func newTestRotatingWriter(t *testing.T, rotation *Rotation) (*rotatingWriter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)}
+	w := newRotatingWriter(filepath.Join(t.TempDir(), "data.json"), rotation)
+	w.now = clock.now
+	return w, clock
+}
+
+func backups(t *testing.T, w *rotatingWriter) []string {
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(w.path), "data-*"))
+	require.NoError(t, err)
+	return matches
+}
+
+func TestRotatingWriterRotatesBySize(t *testing.T) {
+	w, clock := newTestRotatingWriter(t, &Rotation{MaxMegabytes: 1})
+	buf := make([]byte, megabyte/2+1)
+
+	_, err := w.Write(buf)
+	require.NoError(t, err)
+	clock.t = clock.t.Add(time.Second)
+	_, err = w.Write(buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{filepath.Join(filepath.Dir(w.path), "data-2023-12-01T10-00-01.000.json")}, backups(t, w))
+	info, err := os.Stat(w.path)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(buf), info.Size())
+}
+
+func TestRotatingWriterRotatesByInterval(t *testing.T) {
+	w, clock := newTestRotatingWriter(t, &Rotation{Interval: time.Hour})
+
+	_, err := w.Write([]byte(msg))
+	require.NoError(t, err)
+	clock.t = clock.t.Add(30 * time.Minute)
+	_, err = w.Write([]byte(msg))
+	require.NoError(t, err)
+	assert.Empty(t, backups(t, w))
+
+	clock.t = clock.t.Add(30 * time.Minute)
+	_, err = w.Write([]byte(msg))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	require.Len(t, backups(t, w), 1)
+	content, err := os.ReadFile(backups(t, w)[0])
+	require.NoError(t, err)
+	assert.Equal(t, msg+msg, string(content))
+	content, err = os.ReadFile(w.path)
+	require.NoError(t, err)
+	assert.Equal(t, msg, string(content))
+}
+
+func TestRotatingWriterCompression(t *testing.T) {
+	tests := []struct {
+		compression string
+		reader      func(io.Reader) (io.Reader, error)
+	}{
+		{
+			compression: compressionGZIP,
+			reader: func(r io.Reader) (io.Reader, error) {
+				return gzip.NewReader(r)
+			},
+		},
+		{
+			compression: compressionZSTD,
+			reader: func(r io.Reader) (io.Reader, error) {
+				return zstd.NewReader(r)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.compression, func(t *testing.T) {
+			w, clock := newTestRotatingWriter(t, &Rotation{Interval: time.Minute, Compression: tt.compression})
+
+			_, err := w.Write([]byte(msg))
+			require.NoError(t, err)
+			clock.t = clock.t.Add(time.Minute)
+			_, err = w.Write([]byte(msg))
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			backup := filepath.Join(filepath.Dir(w.path), "data-2023-12-01T10-01-00.000.json"+compressedExtensions[tt.compression])
+			assert.Equal(t, []string{backup}, backups(t, w))
+			f, err := os.Open(backup)
+			require.NoError(t, err)
+			defer f.Close()
+			r, err := tt.reader(f)
+			require.NoError(t, err)
+			content, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, msg, string(content))
+		})
+	}
+}
+
+func TestRotatingWriterRemovesBackups(t *testing.T) {
+	w, clock := newTestRotatingWriter(t, &Rotation{Interval: time.Minute, MaxBackups: 2, Compression: compressionGZIP})
+
+	for i := 0; i < 5; i++ {
+		_, err := w.Write([]byte(msg))
+		require.NoError(t, err)
+		clock.t = clock.t.Add(time.Minute)
+	}
+	require.NoError(t, w.Close())
+
+	dir := filepath.Dir(w.path)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "data-2023-12-01T10-03-00.000.json.gz"),
+		filepath.Join(dir, "data-2023-12-01T10-04-00.000.json.gz"),
+	}, backups(t, w))
+}
+
+func TestRotatingWriterRemovesOldBackups(t *testing.T) {
+	w, clock := newTestRotatingWriter(t, &Rotation{Interval: 24 * time.Hour, MaxDays: 2})
+
+	for i := 0; i < 4; i++ {
+		_, err := w.Write([]byte(msg))
+		require.NoError(t, err)
+		clock.t = clock.t.Add(24 * time.Hour)
+	}
+	require.NoError(t, w.Close())
+
+	dir := filepath.Dir(w.path)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "data-2023-12-02T10-00-00.000.json"),
+		filepath.Join(dir, "data-2023-12-03T10-00-00.000.json"),
+		filepath.Join(dir, "data-2023-12-04T10-00-00.000.json"),
+	}, backups(t, w))
+}
+
+func TestRotatingWriterMessageTooLarge(t *testing.T) {
+	w, _ := newTestRotatingWriter(t, &Rotation{MaxMegabytes: 1})
+	_, err := w.Write(make([]byte, megabyte+1))
+	assert.Error(t, err)
+	assert.NoError(t, w.Close())
+}
@@ -38,6 +38,10 @@ type Config struct {
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
+	// EncodingExtension is the component ID of an encoding extension used to marshal
+	// messages. It takes precedence over Encoding when set.
+	EncodingExtension *component.ID `mapstructure:"encoding_extension"`
+
 	// Metadata is the namespace for metadata management properties used by the
 	// Client, and shared by the Producer/Consumer.
 	Metadata Metadata `mapstructure:"metadata"`
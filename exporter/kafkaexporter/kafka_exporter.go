@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -16,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
 )
 
@@ -27,6 +29,8 @@ type kafkaTracesProducer struct {
 	topic     string
 	marshaler TracesMarshaler
 	logger    *zap.Logger
+
+	encodingExtension *component.ID
 }
 
 type kafkaErrors struct {
@@ -60,12 +64,26 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
+func (e *kafkaTracesProducer) start(_ context.Context, host component.Host) error {
+	if e.encodingExtension == nil {
+		return nil
+	}
+	marshaler, err := encodingext.LoadExtension[ptrace.Marshaler](host, *e.encodingExtension)
+	if err != nil {
+		return err
+	}
+	e.marshaler = newPdataTracesMarshaler(marshaler, e.encodingExtension.String())
+	return nil
+}
+
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
 	producer  sarama.SyncProducer
 	topic     string
 	marshaler MetricsMarshaler
 	logger    *zap.Logger
+
+	encodingExtension *component.ID
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.Metrics) error {
@@ -90,12 +108,26 @@ func (e *kafkaMetricsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
+func (e *kafkaMetricsProducer) start(_ context.Context, host component.Host) error {
+	if e.encodingExtension == nil {
+		return nil
+	}
+	marshaler, err := encodingext.LoadExtension[pmetric.Marshaler](host, *e.encodingExtension)
+	if err != nil {
+		return err
+	}
+	e.marshaler = newPdataMetricsMarshaler(marshaler, e.encodingExtension.String())
+	return nil
+}
+
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
 	producer  sarama.SyncProducer
 	topic     string
 	marshaler LogsMarshaler
 	logger    *zap.Logger
+
+	encodingExtension *component.ID
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) error {
@@ -120,6 +152,18 @@ func (e *kafkaLogsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
 
+func (e *kafkaLogsProducer) start(_ context.Context, host component.Host) error {
+	if e.encodingExtension == nil {
+		return nil
+	}
+	marshaler, err := encodingext.LoadExtension[plog.Marshaler](host, *e.encodingExtension)
+	if err != nil {
+		return err
+	}
+	e.marshaler = newPdataLogsMarshaler(marshaler, e.encodingExtension.String())
+	return nil
+}
+
 func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	c := sarama.NewConfig()
 	// These setting are required by the sarama.SyncProducer implementation.
@@ -164,8 +208,9 @@ func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 }
 
 func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers map[string]MetricsMarshaler) (*kafkaMetricsProducer, error) {
+	// The encoding extension can only be resolved once the host is available in start.
 	marshaler := marshalers[config.Encoding]
-	if marshaler == nil {
+	if marshaler == nil && config.EncodingExtension == nil {
 		return nil, errUnrecognizedEncoding
 	}
 	producer, err := newSaramaProducer(config)
@@ -178,14 +223,17 @@ func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers m
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+
+		encodingExtension: config.EncodingExtension,
 	}, nil
 
 }
 
 // newTracesExporter creates Kafka exporter.
 func newTracesExporter(config Config, set exporter.CreateSettings, marshalers map[string]TracesMarshaler) (*kafkaTracesProducer, error) {
+	// The encoding extension can only be resolved once the host is available in start.
 	marshaler := marshalers[config.Encoding]
-	if marshaler == nil {
+	if marshaler == nil && config.EncodingExtension == nil {
 		return nil, errUnrecognizedEncoding
 	}
 	producer, err := newSaramaProducer(config)
@@ -197,12 +245,15 @@ func newTracesExporter(config Config, set exporter.CreateSettings, marshalers ma
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+
+		encodingExtension: config.EncodingExtension,
 	}, nil
 }
 
 func newLogsExporter(config Config, set exporter.CreateSettings, marshalers map[string]LogsMarshaler) (*kafkaLogsProducer, error) {
+	// The encoding extension can only be resolved once the host is available in start.
 	marshaler := marshalers[config.Encoding]
-	if marshaler == nil {
+	if marshaler == nil && config.EncodingExtension == nil {
 		return nil, errUnrecognizedEncoding
 	}
 	producer, err := newSaramaProducer(config)
@@ -215,6 +266,8 @@ func newLogsExporter(config Config, set exporter.CreateSettings, marshalers map[
 		topic:     config.Topic,
 		marshaler: marshaler,
 		logger:    set.Logger,
+
+		encodingExtension: config.EncodingExtension,
 	}, nil
 
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/IBM/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -79,6 +81,45 @@ func TestNewLogsExporter_err_traces_encoding(t *testing.T) {
 	assert.Nil(t, mexp)
 }
 
+func TestNewExporter_encoding_extension(t *testing.T) {
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("test_encoding"): &testEncodingExtension{},
+		},
+	}
+
+	id := component.NewID("test_encoding")
+	tp := kafkaTracesProducer{encodingExtension: &id}
+	require.NoError(t, tp.start(context.Background(), host))
+	assert.Equal(t, "test_encoding", tp.marshaler.Encoding())
+
+	mp := kafkaMetricsProducer{encodingExtension: &id}
+	require.NoError(t, mp.start(context.Background(), host))
+	assert.Equal(t, "test_encoding", mp.marshaler.Encoding())
+
+	lp := kafkaLogsProducer{encodingExtension: &id}
+	require.NoError(t, lp.start(context.Background(), host))
+	assert.Equal(t, "test_encoding", lp.marshaler.Encoding())
+}
+
+func TestNewExporter_encoding_extension_wrong_type(t *testing.T) {
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("nop"): &nopExtension{},
+		},
+	}
+
+	id := component.NewID("nop")
+	tp := kafkaTracesProducer{encodingExtension: &id}
+	assert.EqualError(t, tp.start(context.Background(), host), `extension "nop" is not a ptrace.Marshaler`)
+
+	missing := component.NewID("missing")
+	tp = kafkaTracesProducer{encodingExtension: &missing}
+	assert.EqualError(t, tp.start(context.Background(), host), `unknown encoding extension "missing"`)
+}
+
 func TestNewExporter_err_auth_type(t *testing.T) {
 	c := Config{
 		ProtocolVersion: "2.0.0",
@@ -235,6 +276,25 @@ func TestLogsDataPusher(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestLogsDataPusher_messages_marshaler(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndSucceed()
+
+	p := kafkaLogsProducer{
+		producer:  producer,
+		marshaler: newPdataLogsMarshaler(&testMessagesEncodingExtension{}, "test_encoding"),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	ld := testdata.GenerateLogsOneLogRecord()
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+	err := p.logsDataPusher(context.Background(), ld)
+	require.NoError(t, err)
+}
+
 func TestLogsDataPusher_err(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
@@ -303,3 +363,42 @@ func (e logsErrorMarshaler) Marshal(_ plog.Logs, _ string) ([]*sarama.ProducerMe
 func (e logsErrorMarshaler) Encoding() string {
 	panic("implement me")
 }
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type testEncodingExtension struct {
+	nopExtension
+}
+
+func (testEncodingExtension) MarshalTraces(ptrace.Traces) ([]byte, error) {
+	return nil, nil
+}
+
+func (testEncodingExtension) MarshalMetrics(pmetric.Metrics) ([]byte, error) {
+	return nil, nil
+}
+
+func (testEncodingExtension) MarshalLogs(plog.Logs) ([]byte, error) {
+	return nil, nil
+}
+
+// testMessagesEncodingExtension marshals each log record into a message of its own.
+type testMessagesEncodingExtension struct {
+	testEncodingExtension
+}
+
+func (testMessagesEncodingExtension) MarshalLogsMessages(ld plog.Logs) ([][]byte, error) {
+	return make([][]byte, ld.LogRecordCount()), nil
+}
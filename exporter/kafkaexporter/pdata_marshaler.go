@@ -8,6 +8,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext"
 )
 
 type pdataLogsMarshaler struct {
@@ -16,6 +18,21 @@ type pdataLogsMarshaler struct {
 }
 
 func (p pdataLogsMarshaler) Marshal(ld plog.Logs, topic string) ([]*sarama.ProducerMessage, error) {
+	if messagesMarshaler, ok := p.marshaler.(encodingext.LogsMessagesMarshaler); ok {
+		// The encoding cannot hold every log record in a single message.
+		values, err := messagesMarshaler.MarshalLogsMessages(ld)
+		if err != nil {
+			return nil, err
+		}
+		messages := make([]*sarama.ProducerMessage, 0, len(values))
+		for _, value := range values {
+			messages = append(messages, &sarama.ProducerMessage{
+				Topic: topic,
+				Value: sarama.ByteEncoder(value),
+			})
+		}
+		return messages, nil
+	}
 	bts, err := p.marshaler.MarshalLogs(ld)
 	if err != nil {
 		return nil, err
@@ -51,8 +51,19 @@ type Config struct {
 
 	// SendMetadata controls whether prometheus metadata will be generated and sent
 	SendMetadata bool `mapstructure:"send_metadata"`
+
+	// ProtobufMessage is the protobuf message sent to the remote write endpoint:
+	// "prometheus.WriteRequest" for remote write 1.0 or "io.prometheus.write.v2.Request"
+	// for remote write 2.0. Remote write 2.0 always sends metadata and created
+	// timestamps, and falls back to 1.0 if the endpoint does not support it.
+	ProtobufMessage string `mapstructure:"protobuf_message"`
 }
 
+const (
+	protobufMessageV1 = "prometheus.WriteRequest"
+	protobufMessageV2 = "io.prometheus.write.v2.Request"
+)
+
 type CreatedMetric struct {
 	// Enabled if true the _created metrics could be exported
 	Enabled bool `mapstructure:"enabled"`
@@ -113,6 +124,12 @@ func (cfg *Config) Validate() error {
 		// Defaults to ~2.81MB
 		cfg.MaxBatchSizeBytes = 3000000
 	}
+	if cfg.ProtobufMessage == "" {
+		cfg.ProtobufMessage = protobufMessageV1
+	}
+	if cfg.ProtobufMessage != protobufMessageV1 && cfg.ProtobufMessage != protobufMessageV2 {
+		return fmt.Errorf("protobuf_message must be either %q or %q", protobufMessageV1, protobufMessageV2)
+	}
 
 	return nil
 }
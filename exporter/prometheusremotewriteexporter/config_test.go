@@ -77,9 +77,14 @@ func TestLoadConfig(t *testing.T) {
 				TargetInfo: &TargetInfo{
 					Enabled: true,
 				},
-				CreatedMetric: &CreatedMetric{Enabled: true},
+				CreatedMetric:   &CreatedMetric{Enabled: true},
+				ProtobufMessage: protobufMessageV2,
 			},
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_protobuf_message"),
+			errorMessage: `protobuf_message must be either "prometheus.WriteRequest" or "io.prometheus.write.v2.Request"`,
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "negative_queue_size"),
 			errorMessage: "remote write queue size can't be negative",
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/gogo/protobuf/proto"
@@ -26,7 +27,9 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/writev2"
 	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
 )
@@ -45,6 +48,17 @@ type prwExporter struct {
 	retrySettings     exporterhelper.RetrySettings
 	wal               *prweWAL
 	exporterSettings  prometheusremotewrite.Settings
+
+	// remoteWriteV2 is set while remote write 2.0 requests are sent. It is
+	// cleared for good if the endpoint rejects their content type.
+	remoteWriteV2 atomic.Bool
+	// missingWrittenHeaders is set once the endpoint accepted a remote write
+	// 2.0 request without reporting what was written.
+	missingWrittenHeaders atomic.Bool
+	// remoteWriteV2Configured is set if remote write 2.0 was configured.
+	remoteWriteV2Configured bool
+	// createdMetric is set if _created series are sent with remote write 1.0.
+	createdMetric bool
 }
 
 // newPRWExporter initializes a new prwExporter instance and sets fields accordingly.
@@ -79,6 +93,14 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 			AddMetricSuffixes:   cfg.AddMetricSuffixes,
 			SendMetadata:        cfg.SendMetadata,
 		},
+		createdMetric: cfg.CreatedMetric.Enabled,
+	}
+	if cfg.ProtobufMessage == protobufMessageV2 {
+		prwe.remoteWriteV2.Store(true)
+		prwe.remoteWriteV2Configured = true
+		// Start timestamps are sent as _created series, then folded into the
+		// created timestamp of the remote write 2.0 series.
+		prwe.exporterSettings.ExportCreatedMetric = true
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
@@ -138,11 +160,11 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 		}
 
 		var m []*prompb.MetricMetadata
-		if prwe.exporterSettings.SendMetadata {
+		if prwe.exporterSettings.SendMetadata && !prwe.remoteWriteV2.Load() {
 			m = prometheusremotewrite.OtelMetricsToMetadata(md, prwe.exporterSettings.AddMetricSuffixes)
 		}
 		// Call export even if a conversion error, since there may be points that were successfully converted.
-		return multierr.Combine(err, prwe.handleExport(ctx, tsMap, m))
+		return multierr.Combine(err, prwe.handleExport(ctx, tsMap, m, md))
 	}
 }
 
@@ -158,17 +180,33 @@ func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	return sanitizedLabels, nil
 }
 
-func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*prompb.TimeSeries, m []*prompb.MetricMetadata) error {
+func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*prompb.TimeSeries, m []*prompb.MetricMetadata, md pmetric.Metrics) error {
 	// There are no metrics to export, so return.
 	if len(tsMap) == 0 {
 		return nil
 	}
 
 	// Calls the helper function to convert and batch the TsMap to the desired format
-	requests, err := batchTimeSeries(tsMap, prwe.maxBatchSizeBytes, m)
+	var requests []*prompb.WriteRequest
+	var err error
+	if prwe.remoteWriteV2Configured {
+		// The created timestamps are only known to the requests holding the
+		// _created series, so those are batched along with their families.
+		requests, err = batchTimeSeriesGroups(groupCreatedSeries(tsMap), len(tsMap), prwe.maxBatchSizeBytes, m)
+	} else {
+		requests, err = batchTimeSeries(tsMap, prwe.maxBatchSizeBytes, m)
+	}
 	if err != nil {
 		return err
 	}
+	if prwe.remoteWriteV2.Load() {
+		// Remote write 2.0 attaches metadata to every series, so every
+		// request carries the metadata of its metric families.
+		metadata := otelMetricsToMetadata(md, prwe.exporterSettings.Namespace, prwe.exporterSettings.AddMetricSuffixes)
+		for _, request := range requests {
+			request.Metadata = metadata
+		}
+	}
 	if !prwe.walEnabled() {
 		// Perform a direct export otherwise.
 		return prwe.export(ctx, requests)
@@ -226,11 +264,42 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 }
 
 func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
+	if prwe.remoteWriteV2.Load() {
+		err := prwe.send(ctx, convertToV2(writeReq).Marshal(), writev2.ContentType, writev2.Version)
+		if !errors.Is(err, errRemoteWriteV2Unsupported) {
+			return err
+		}
+		if prwe.remoteWriteV2.CompareAndSwap(true, false) {
+			prwe.settings.Logger.Warn("Falling back to remote write 1.0", zap.Error(err))
+		}
+	}
+	if prwe.remoteWriteV2Configured {
+		// The request may have been prepared for remote write 2.0.
+		writeReq = prwe.toV1Request(writeReq)
+	}
+
 	// Uses proto.Marshal to convert the WriteRequest into bytes array
 	data, errMarshal := proto.Marshal(writeReq)
 	if errMarshal != nil {
 		return consumererror.NewPermanent(errMarshal)
 	}
+	return prwe.send(ctx, data, "application/x-protobuf", "0.1.0")
+}
+
+// toV1Request removes from a request prepared for remote write 2.0 what was
+// not configured to be sent with remote write 1.0.
+func (prwe *prwExporter) toV1Request(writeReq *prompb.WriteRequest) *prompb.WriteRequest {
+	if !prwe.createdMetric {
+		writeReq = withoutCreatedSeries(writeReq)
+	}
+	if !prwe.exporterSettings.SendMetadata && writeReq.Metadata != nil {
+		writeReq = &prompb.WriteRequest{Timeseries: writeReq.Timeseries}
+	}
+	return writeReq
+}
+
+// send posts the Snappy-compressed data to the remote write endpoint.
+func (prwe *prwExporter) send(ctx context.Context, data []byte, contentType string, version string) error {
 	buf := make([]byte, len(data), cap(data))
 	compressedData := snappy.Encode(buf, data)
 
@@ -245,8 +314,8 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Add necessary headers specified by:
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
-		req.Header.Set("Content-Type", "application/x-protobuf")
-		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Prometheus-Remote-Write-Version", version)
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
 
 		resp, err := prwe.client.Do(req)
@@ -259,7 +328,19 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// 5xx errors are recoverable and the exporter should retry
 		// Reference for different behavior according to status code:
 		// https://github.com/prometheus/prometheus/pull/2552/files#diff-ae8db9d16d8057358e49d694522e7186
+		if version == writev2.Version && resp.StatusCode == http.StatusUnsupportedMediaType {
+			// Endpoints only supporting remote write 1.0 reject the content type.
+			return backoff.Permanent(errRemoteWriteV2Unsupported)
+		}
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if version == writev2.Version && resp.Header.Get(writev2.SamplesWrittenHeader) == "" &&
+				prwe.missingWrittenHeaders.CompareAndSwap(false, true) {
+				// The request may have been accepted by an endpoint ignoring
+				// the content type. Sending it again with remote write 1.0
+				// could duplicate its samples, so only warn about it.
+				prwe.settings.Logger.Warn("Remote write 2.0 request accepted without the " + writev2.SamplesWrittenHeader +
+					" header, the endpoint may not support remote write 2.0")
+			}
 			return nil
 		}
 
@@ -12,29 +12,44 @@ import (
 
 // batchTimeSeries splits series into multiple batch write requests.
 func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int, m []*prompb.MetricMetadata) ([]*prompb.WriteRequest, error) {
-	if len(tsMap) == 0 {
+	groups := make([][]*prompb.TimeSeries, 0, len(tsMap))
+	for _, v := range tsMap {
+		groups = append(groups, []*prompb.TimeSeries{v})
+	}
+	return batchTimeSeriesGroups(groups, len(tsMap), maxBatchByteSize, m)
+}
+
+// batchTimeSeriesGroups splits series into multiple batch write requests,
+// keeping the series of a group in the same request.
+func batchTimeSeriesGroups(groups [][]*prompb.TimeSeries, count int, maxBatchByteSize int, m []*prompb.MetricMetadata) ([]*prompb.WriteRequest, error) {
+	if count == 0 {
 		return nil, errors.New("invalid tsMap: cannot be empty map")
 	}
 
-	requests := make([]*prompb.WriteRequest, 0, len(tsMap)+len(m))
-	tsArray := make([]prompb.TimeSeries, 0, len(tsMap))
+	requests := make([]*prompb.WriteRequest, 0, count+len(m))
+	tsArray := make([]prompb.TimeSeries, 0, count)
 	sizeOfCurrentBatch := 0
 
 	i := 0
-	for _, v := range tsMap {
-		sizeOfSeries := v.Size()
+	for _, group := range groups {
+		sizeOfGroup := 0
+		for _, v := range group {
+			sizeOfGroup += v.Size()
+		}
 
-		if sizeOfCurrentBatch+sizeOfSeries >= maxBatchByteSize {
+		if sizeOfCurrentBatch+sizeOfGroup >= maxBatchByteSize {
 			wrapped := convertTimeseriesToRequest(tsArray)
 			requests = append(requests, wrapped)
 
-			tsArray = make([]prompb.TimeSeries, 0, len(tsMap)-i)
+			tsArray = make([]prompb.TimeSeries, 0, count-i)
 			sizeOfCurrentBatch = 0
 		}
 
-		tsArray = append(tsArray, *v)
-		sizeOfCurrentBatch += sizeOfSeries
-		i++
+		for _, v := range group {
+			tsArray = append(tsArray, *v)
+		}
+		sizeOfCurrentBatch += sizeOfGroup
+		i += len(group)
 	}
 
 	if len(tsArray) != 0 {
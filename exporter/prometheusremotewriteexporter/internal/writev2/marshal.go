@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/writev2"
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Marshal returns the protobuf encoding of the request.
+func (r *Request) Marshal() []byte {
+	var b []byte
+	for _, s := range r.Symbols {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendString(b, s)
+	}
+	for i := range r.Timeseries {
+		b = appendMessage(b, 5, r.Timeseries[i].marshal())
+	}
+	return b
+}
+
+func (ts *TimeSeries) marshal() []byte {
+	var b []byte
+	b = appendPackedUint32(b, 1, ts.LabelsRefs)
+	for _, s := range ts.Samples {
+		b = appendMessage(b, 2, s.marshal())
+	}
+	for i := range ts.Histograms {
+		b = appendMessage(b, 3, ts.Histograms[i].marshal())
+	}
+	for i := range ts.Exemplars {
+		b = appendMessage(b, 4, ts.Exemplars[i].marshal())
+	}
+	if ts.Metadata != (Metadata{}) {
+		b = appendMessage(b, 5, ts.Metadata.marshal())
+	}
+	b = appendVarint(b, 6, uint64(ts.CreatedTimestamp))
+	return b
+}
+
+func (s Sample) marshal() []byte {
+	var b []byte
+	b = appendDouble(b, 1, s.Value)
+	b = appendVarint(b, 2, uint64(s.Timestamp))
+	return b
+}
+
+func (e *Exemplar) marshal() []byte {
+	var b []byte
+	b = appendPackedUint32(b, 1, e.LabelsRefs)
+	b = appendDouble(b, 2, e.Value)
+	b = appendVarint(b, 3, uint64(e.Timestamp))
+	return b
+}
+
+func (m Metadata) marshal() []byte {
+	var b []byte
+	b = appendVarint(b, 1, uint64(m.Type))
+	b = appendVarint(b, 3, uint64(m.HelpRef))
+	b = appendVarint(b, 4, uint64(m.UnitRef))
+	return b
+}
+
+func (h *Histogram) marshal() []byte {
+	var b []byte
+	// The count and zero count are oneofs: the selected field is always
+	// encoded, even when zero.
+	if h.Float {
+		b = protowire.AppendTag(b, 2, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(h.CountFloat))
+	} else {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, h.CountInt)
+	}
+	b = appendDouble(b, 3, h.Sum)
+	b = appendVarint(b, 4, protowire.EncodeZigZag(int64(h.Schema)))
+	b = appendDouble(b, 5, h.ZeroThreshold)
+	if h.Float {
+		b = protowire.AppendTag(b, 7, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(h.ZeroCountFloat))
+	} else {
+		b = protowire.AppendTag(b, 6, protowire.VarintType)
+		b = protowire.AppendVarint(b, h.ZeroCountInt)
+	}
+	for _, s := range h.NegativeSpans {
+		b = appendMessage(b, 8, s.marshal())
+	}
+	b = appendPackedSint64(b, 9, h.NegativeDeltas)
+	b = appendPackedDouble(b, 10, h.NegativeCounts)
+	for _, s := range h.PositiveSpans {
+		b = appendMessage(b, 11, s.marshal())
+	}
+	b = appendPackedSint64(b, 12, h.PositiveDeltas)
+	b = appendPackedDouble(b, 13, h.PositiveCounts)
+	b = appendVarint(b, 14, uint64(h.ResetHint))
+	b = appendVarint(b, 15, uint64(h.Timestamp))
+	return b
+}
+
+func (s BucketSpan) marshal() []byte {
+	var b []byte
+	b = appendVarint(b, 1, protowire.EncodeZigZag(int64(s.Offset)))
+	b = appendVarint(b, 2, uint64(s.Length))
+	return b
+}
+
+// The helpers below omit fields holding their zero value, as proto3 does.
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	bits := math.Float64bits(v)
+	if bits == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, bits)
+}
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendPackedUint32(b []byte, num protowire.Number, values []uint32) []byte {
+	if len(values) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range values {
+		packed = protowire.AppendVarint(packed, uint64(v))
+	}
+	return appendMessage(b, num, packed)
+}
+
+func appendPackedSint64(b []byte, num protowire.Number, values []int64) []byte {
+	if len(values) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range values {
+		packed = protowire.AppendVarint(packed, protowire.EncodeZigZag(v))
+	}
+	return appendMessage(b, num, packed)
+}
+
+func appendPackedDouble(b []byte, num protowire.Number, values []float64) []byte {
+	if len(values) == 0 {
+		return b
+	}
+	packed := make([]byte, 0, 8*len(values))
+	for _, v := range values {
+		packed = protowire.AppendFixed64(packed, math.Float64bits(v))
+	}
+	return appendMessage(b, num, packed)
+}
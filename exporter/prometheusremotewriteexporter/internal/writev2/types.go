@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package writev2 implements the messages of the Prometheus remote write 2.0
+// protocol, defined by the io.prometheus.write.v2 protobuf package.
+// See https://prometheus.io/docs/specs/remote_write_spec_2_0/
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/writev2"
+
+const (
+	// ContentType is the content type of remote write 2.0 requests.
+	ContentType = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+	// Version is the value of the X-Prometheus-Remote-Write-Version header.
+	Version = "2.0.0"
+
+	// Headers returned by receivers to report what was written.
+	SamplesWrittenHeader    = "X-Prometheus-Remote-Write-Samples-Written"
+	HistogramsWrittenHeader = "X-Prometheus-Remote-Write-Histograms-Written"
+	ExemplarsWrittenHeader  = "X-Prometheus-Remote-Write-Exemplars-Written"
+)
+
+// Request is a remote write 2.0 request. All the strings it refers to, such
+// as label names and values, are interned in Symbols and referred to by their
+// index. The first symbol is always the empty string.
+type Request struct {
+	Symbols    []string
+	Timeseries []TimeSeries
+}
+
+// TimeSeries is a series of samples or histograms sharing the same labels.
+type TimeSeries struct {
+	// LabelsRefs holds the symbol references of the label names and values,
+	// alternating between names and values.
+	LabelsRefs []uint32
+	Samples    []Sample
+	Histograms []Histogram
+	Exemplars  []Exemplar
+	Metadata   Metadata
+	// CreatedTimestamp is the time in milliseconds the counter, histogram or
+	// summary of the series was started, or 0 if unknown.
+	CreatedTimestamp int64
+}
+
+// Sample is a float value at a given time in milliseconds.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Exemplar is a sample of the series with its own labels, such as a trace ID.
+type Exemplar struct {
+	LabelsRefs []uint32
+	Value      float64
+	Timestamp  int64
+}
+
+// MetricType is the type of the metric a series belongs to.
+type MetricType int32
+
+const (
+	MetricTypeUnspecified    MetricType = 0
+	MetricTypeCounter        MetricType = 1
+	MetricTypeGauge          MetricType = 2
+	MetricTypeHistogram      MetricType = 3
+	MetricTypeGaugeHistogram MetricType = 4
+	MetricTypeSummary        MetricType = 5
+	MetricTypeInfo           MetricType = 6
+	MetricTypeStateset       MetricType = 7
+)
+
+// Metadata describes the metric a series belongs to.
+type Metadata struct {
+	Type    MetricType
+	HelpRef uint32
+	UnitRef uint32
+}
+
+// ResetHint tells whether a histogram is known to have been reset.
+type ResetHint int32
+
+const (
+	ResetHintUnknown ResetHint = 0
+	ResetHintYes     ResetHint = 1
+	ResetHintNo      ResetHint = 2
+	ResetHintGauge   ResetHint = 3
+)
+
+// Histogram is a native histogram. Integer histograms use CountInt,
+// ZeroCountInt and the Deltas fields, float histograms, flagged by Float, use
+// CountFloat, ZeroCountFloat and the Counts fields.
+type Histogram struct {
+	Float          bool
+	CountInt       uint64
+	CountFloat     float64
+	Sum            float64
+	Schema         int32
+	ZeroThreshold  float64
+	ZeroCountInt   uint64
+	ZeroCountFloat float64
+
+	NegativeSpans  []BucketSpan
+	NegativeDeltas []int64
+	NegativeCounts []float64
+	PositiveSpans  []BucketSpan
+	PositiveDeltas []int64
+	PositiveCounts []float64
+
+	ResetHint ResetHint
+	Timestamp int64
+}
+
+// BucketSpan is a run of consecutive buckets of a native histogram.
+type BucketSpan struct {
+	Offset int32
+	Length uint32
+}
+
+// SymbolTable interns the strings of a request.
+type SymbolTable struct {
+	symbols []string
+	refs    map[string]uint32
+}
+
+// NewSymbolTable returns a symbol table holding the empty string.
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{
+		symbols: []string{""},
+		refs:    map[string]uint32{"": 0},
+	}
+}
+
+// Symbolize returns the reference of s, adding it to the table if needed.
+func (t *SymbolTable) Symbolize(s string) uint32 {
+	if ref, ok := t.refs[s]; ok {
+		return ref
+	}
+	ref := uint32(len(t.symbols))
+	t.symbols = append(t.symbols, s)
+	t.refs[s] = ref
+	return ref
+}
+
+// Symbols returns the interned strings, indexed by their reference.
+func (t *SymbolTable) Symbols() []string {
+	return t.symbols
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymbolTable(t *testing.T) {
+	st := NewSymbolTable()
+	assert.Equal(t, uint32(0), st.Symbolize(""))
+	assert.Equal(t, uint32(1), st.Symbolize("__name__"))
+	assert.Equal(t, uint32(2), st.Symbolize("up"))
+	assert.Equal(t, uint32(1), st.Symbolize("__name__"))
+	assert.Equal(t, []string{"", "__name__", "up"}, st.Symbols())
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/writev2"
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// errRemoteWriteV2Unsupported is returned when the endpoint does not accept
+// remote write 2.0 requests.
+var errRemoteWriteV2Unsupported = errors.New("remote write endpoint does not support remote write 2.0")
+
+const createdSuffix = "_created"
+
+// familySuffixes are the suffixes of the series of histograms and summaries.
+var familySuffixes = []string{"_bucket", "_sum", "_count"}
+
+// otelMetricsToMetadata returns the metadata of the metrics in md, named after
+// their series. Unlike prometheusremotewrite.OtelMetricsToMetadata, it honors
+// the namespace and sets the unit.
+func otelMetricsToMetadata(md pmetric.Metrics, namespace string, addMetricSuffixes bool) []prompb.MetricMetadata {
+	var metadata []prompb.MetricMetadata
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				metadata = append(metadata, prompb.MetricMetadata{
+					Type:             otelMetricTypeToPromMetricType(metric),
+					MetricFamilyName: prometheustranslator.BuildCompliantName(metric, namespace, addMetricSuffixes),
+					Help:             metric.Description(),
+					Unit:             metric.Unit(),
+				})
+			}
+		}
+	}
+	return metadata
+}
+
+func otelMetricTypeToPromMetricType(metric pmetric.Metric) prompb.MetricMetadata_MetricType {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return prompb.MetricMetadata_GAUGE
+	case pmetric.MetricTypeSum:
+		if metric.Sum().IsMonotonic() {
+			return prompb.MetricMetadata_COUNTER
+		}
+		return prompb.MetricMetadata_GAUGE
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeExponentialHistogram:
+		return prompb.MetricMetadata_HISTOGRAM
+	case pmetric.MetricTypeSummary:
+		return prompb.MetricMetadata_SUMMARY
+	}
+	return prompb.MetricMetadata_UNKNOWN
+}
+
+// groupCreatedSeries groups the series of tsMap so that the _created series
+// generated by the translator end up in the same request as the series of
+// their family, whatever the batching of the series.
+func groupCreatedSeries(tsMap map[string]*prompb.TimeSeries) [][]*prompb.TimeSeries {
+	created := make(map[string]int)
+	groups := make([][]*prompb.TimeSeries, 0, len(tsMap))
+	for _, ts := range tsMap {
+		if family, ok := createdSeriesFamily(ts); ok {
+			created[seriesKey(family, ts.Labels)] = len(groups)
+			groups = append(groups, []*prompb.TimeSeries{ts})
+		}
+	}
+	for _, ts := range tsMap {
+		if _, ok := createdSeriesFamily(ts); ok {
+			continue
+		}
+		grouped := false
+		for _, family := range familyNames(metricName(ts.Labels)) {
+			if i, ok := created[seriesKey(family, ts.Labels)]; ok {
+				groups[i] = append(groups[i], ts)
+				grouped = true
+				break
+			}
+		}
+		if !grouped {
+			groups = append(groups, []*prompb.TimeSeries{ts})
+		}
+	}
+	return groups
+}
+
+// convertToV2 converts a remote write 1.0 request into a 2.0 one. The _created
+// series generated by the translator are folded into the created timestamp of
+// their counters, histograms and summaries, and the metadata of the request is
+// attached to the series of the same metric family. The _created series are
+// expected in the same request as their family, see groupCreatedSeries.
+func convertToV2(req *prompb.WriteRequest) *writev2.Request {
+	metadata := make(map[string]prompb.MetricMetadata, len(req.Metadata))
+	for _, m := range req.Metadata {
+		metadata[m.MetricFamilyName] = m
+	}
+
+	created := make(map[string]int64)
+	series := make([]*prompb.TimeSeries, 0, len(req.Timeseries))
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		if family, ok := createdSeriesFamily(ts); ok {
+			created[seriesKey(family, ts.Labels)] = int64(ts.Samples[0].Value)
+			continue
+		}
+		series = append(series, ts)
+	}
+
+	symbols := writev2.NewSymbolTable()
+	out := &writev2.Request{Timeseries: make([]writev2.TimeSeries, 0, len(series))}
+	for _, ts := range series {
+		v2 := writev2.TimeSeries{
+			LabelsRefs: symbolizeLabels(symbols, ts.Labels),
+			Samples:    make([]writev2.Sample, 0, len(ts.Samples)),
+		}
+		for _, s := range ts.Samples {
+			v2.Samples = append(v2.Samples, writev2.Sample{Value: s.Value, Timestamp: s.Timestamp})
+		}
+		for _, h := range ts.Histograms {
+			v2.Histograms = append(v2.Histograms, convertHistogramToV2(h))
+		}
+		for _, e := range ts.Exemplars {
+			v2.Exemplars = append(v2.Exemplars, writev2.Exemplar{
+				LabelsRefs: symbolizeLabels(symbols, e.Labels),
+				Value:      e.Value,
+				Timestamp:  e.Timestamp,
+			})
+		}
+		for _, family := range familyNames(metricName(ts.Labels)) {
+			if m, ok := metadata[family]; ok && v2.Metadata == (writev2.Metadata{}) {
+				v2.Metadata = writev2.Metadata{
+					// Both versions of the protocol number metric types the same way.
+					Type:    writev2.MetricType(m.Type),
+					HelpRef: symbols.Symbolize(m.Help),
+					UnitRef: symbols.Symbolize(m.Unit),
+				}
+			}
+			if ct, ok := created[seriesKey(family, ts.Labels)]; ok && v2.CreatedTimestamp == 0 {
+				v2.CreatedTimestamp = ct
+			}
+		}
+		out.Timeseries = append(out.Timeseries, v2)
+	}
+	out.Symbols = symbols.Symbols()
+	return out
+}
+
+// withoutCreatedSeries returns a copy of req without the _created series
+// generated by the translator for remote write 2.0.
+func withoutCreatedSeries(req *prompb.WriteRequest) *prompb.WriteRequest {
+	filtered := &prompb.WriteRequest{
+		Timeseries: make([]prompb.TimeSeries, 0, len(req.Timeseries)),
+		Metadata:   req.Metadata,
+	}
+	for i := range req.Timeseries {
+		if _, ok := createdSeriesFamily(&req.Timeseries[i]); !ok {
+			filtered.Timeseries = append(filtered.Timeseries, req.Timeseries[i])
+		}
+	}
+	return filtered
+}
+
+// createdSeriesFamily returns the metric family of a _created series generated
+// by the translator. Those have a single sample without timestamp, holding the
+// start time of the family in milliseconds.
+func createdSeriesFamily(ts *prompb.TimeSeries) (string, bool) {
+	if len(ts.Samples) != 1 || ts.Samples[0].Timestamp != 0 || len(ts.Histograms) != 0 {
+		return "", false
+	}
+	return strings.CutSuffix(metricName(ts.Labels), createdSuffix)
+}
+
+// familyNames returns the metric families a series may belong to, given its name.
+func familyNames(name string) []string {
+	names := []string{name}
+	for _, suffix := range familySuffixes {
+		if family, ok := strings.CutSuffix(name, suffix); ok {
+			names = append(names, family)
+		}
+	}
+	return names
+}
+
+func metricName(labels []prompb.Label) string {
+	for _, l := range labels {
+		if l.Name == model.MetricNameLabel {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// seriesKey identifies the series of a metric family sharing the same labels,
+// ignoring the labels distinguishing buckets and quantiles.
+func seriesKey(family string, labels []prompb.Label) string {
+	var sb strings.Builder
+	sb.WriteString(family)
+	for _, l := range labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.BucketLabel, model.QuantileLabel:
+			continue
+		}
+		sb.WriteByte(0xff)
+		sb.WriteString(l.Name)
+		sb.WriteByte(0xff)
+		sb.WriteString(l.Value)
+	}
+	return sb.String()
+}
+
+// symbolizeLabels returns the references of the labels, sorted by name as
+// required by the protocol.
+func symbolizeLabels(symbols *writev2.SymbolTable, labels []prompb.Label) []uint32 {
+	sorted := make([]prompb.Label, len(labels))
+	copy(sorted, labels)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	refs := make([]uint32, 0, 2*len(sorted))
+	for _, l := range sorted {
+		refs = append(refs, symbols.Symbolize(l.Name), symbols.Symbolize(l.Value))
+	}
+	return refs
+}
+
+func convertHistogramToV2(h prompb.Histogram) writev2.Histogram {
+	v2 := writev2.Histogram{
+		Sum:            h.Sum,
+		Schema:         h.Schema,
+		ZeroThreshold:  h.ZeroThreshold,
+		NegativeSpans:  convertSpansToV2(h.NegativeSpans),
+		NegativeDeltas: h.NegativeDeltas,
+		NegativeCounts: h.NegativeCounts,
+		PositiveSpans:  convertSpansToV2(h.PositiveSpans),
+		PositiveDeltas: h.PositiveDeltas,
+		PositiveCounts: h.PositiveCounts,
+		ResetHint:      writev2.ResetHint(h.ResetHint),
+		Timestamp:      h.Timestamp,
+	}
+	if _, ok := h.Count.(*prompb.Histogram_CountFloat); ok {
+		v2.Float = true
+		v2.CountFloat = h.GetCountFloat()
+		v2.ZeroCountFloat = h.GetZeroCountFloat()
+	} else {
+		v2.CountInt = h.GetCountInt()
+		v2.ZeroCountInt = h.GetZeroCountInt()
+	}
+	return v2
+}
+
+func convertSpansToV2(spans []prompb.BucketSpan) []writev2.BucketSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	v2 := make([]writev2.BucketSpan, 0, len(spans))
+	for _, s := range spans {
+		v2 = append(v2, writev2.BucketSpan{Offset: s.Offset, Length: s.Length})
+	}
+	return v2
+}
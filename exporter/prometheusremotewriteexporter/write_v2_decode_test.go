@@ -0,0 +1,321 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/writev2"
+)
+
+// unmarshalV2Request decodes the protobuf encoding of a remote write 2.0
+// request. It is only needed by the tests, the exporter only encodes requests.
+func unmarshalV2Request(b []byte) (*writev2.Request, error) {
+	r := &writev2.Request{}
+	return r, unmarshalMessage(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		switch {
+		case num == 4 && typ == protowire.BytesType:
+			r.Symbols = append(r.Symbols, string(v))
+		case num == 5 && typ == protowire.BytesType:
+			var ts writev2.TimeSeries
+			if err := unmarshalV2TimeSeries(v, &ts); err != nil {
+				return err
+			}
+			r.Timeseries = append(r.Timeseries, ts)
+		}
+		return nil
+	})
+}
+
+func unmarshalV2TimeSeries(b []byte, ts *writev2.TimeSeries) error {
+	return unmarshalMessage(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			return unmarshalUint32s(typ, v, n, &ts.LabelsRefs)
+		case 2:
+			var s writev2.Sample
+			if err := unmarshalV2Sample(v, &s); err != nil {
+				return err
+			}
+			ts.Samples = append(ts.Samples, s)
+		case 3:
+			var h writev2.Histogram
+			if err := unmarshalV2Histogram(v, &h); err != nil {
+				return err
+			}
+			ts.Histograms = append(ts.Histograms, h)
+		case 4:
+			var e writev2.Exemplar
+			if err := unmarshalV2Exemplar(v, &e); err != nil {
+				return err
+			}
+			ts.Exemplars = append(ts.Exemplars, e)
+		case 5:
+			return unmarshalV2Metadata(v, &ts.Metadata)
+		case 6:
+			ts.CreatedTimestamp = int64(n)
+		}
+		return nil
+	})
+}
+
+func unmarshalV2Sample(b []byte, s *writev2.Sample) error {
+	return unmarshalMessage(b, func(num protowire.Number, _ protowire.Type, _ []byte, n uint64) error {
+		switch num {
+		case 1:
+			s.Value = math.Float64frombits(n)
+		case 2:
+			s.Timestamp = int64(n)
+		}
+		return nil
+	})
+}
+
+func unmarshalV2Exemplar(b []byte, e *writev2.Exemplar) error {
+	return unmarshalMessage(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			return unmarshalUint32s(typ, v, n, &e.LabelsRefs)
+		case 2:
+			e.Value = math.Float64frombits(n)
+		case 3:
+			e.Timestamp = int64(n)
+		}
+		return nil
+	})
+}
+
+func unmarshalV2Metadata(b []byte, m *writev2.Metadata) error {
+	return unmarshalMessage(b, func(num protowire.Number, _ protowire.Type, _ []byte, n uint64) error {
+		switch num {
+		case 1:
+			m.Type = writev2.MetricType(n)
+		case 3:
+			m.HelpRef = uint32(n)
+		case 4:
+			m.UnitRef = uint32(n)
+		}
+		return nil
+	})
+}
+
+func unmarshalV2Histogram(b []byte, h *writev2.Histogram) error {
+	return unmarshalMessage(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			h.CountInt = n
+		case 2:
+			h.Float = true
+			h.CountFloat = math.Float64frombits(n)
+		case 3:
+			h.Sum = math.Float64frombits(n)
+		case 4:
+			h.Schema = int32(protowire.DecodeZigZag(n))
+		case 5:
+			h.ZeroThreshold = math.Float64frombits(n)
+		case 6:
+			h.ZeroCountInt = n
+		case 7:
+			h.Float = true
+			h.ZeroCountFloat = math.Float64frombits(n)
+		case 8:
+			var s writev2.BucketSpan
+			if err := unmarshalV2BucketSpan(v, &s); err != nil {
+				return err
+			}
+			h.NegativeSpans = append(h.NegativeSpans, s)
+		case 9:
+			return unmarshalSint64s(typ, v, n, &h.NegativeDeltas)
+		case 10:
+			return unmarshalDoubles(typ, v, n, &h.NegativeCounts)
+		case 11:
+			var s writev2.BucketSpan
+			if err := unmarshalV2BucketSpan(v, &s); err != nil {
+				return err
+			}
+			h.PositiveSpans = append(h.PositiveSpans, s)
+		case 12:
+			return unmarshalSint64s(typ, v, n, &h.PositiveDeltas)
+		case 13:
+			return unmarshalDoubles(typ, v, n, &h.PositiveCounts)
+		case 14:
+			h.ResetHint = writev2.ResetHint(n)
+		case 15:
+			h.Timestamp = int64(n)
+		}
+		return nil
+	})
+}
+
+func unmarshalV2BucketSpan(b []byte, s *writev2.BucketSpan) error {
+	return unmarshalMessage(b, func(num protowire.Number, _ protowire.Type, _ []byte, n uint64) error {
+		switch num {
+		case 1:
+			s.Offset = int32(protowire.DecodeZigZag(n))
+		case 2:
+			s.Length = uint32(n)
+		}
+		return nil
+	})
+}
+
+// unmarshalMessage calls field for every field of the message encoded in b.
+// Length delimited values are passed in v, other values in n.
+func unmarshalMessage(b []byte, field func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error) error {
+	for len(b) > 0 {
+		num, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		b = b[l:]
+
+		var v []byte
+		var n uint64
+		switch typ {
+		case protowire.VarintType:
+			n, l = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			n, l = protowire.ConsumeFixed64(b)
+		case protowire.Fixed32Type:
+			var n32 uint32
+			n32, l = protowire.ConsumeFixed32(b)
+			n = uint64(n32)
+		case protowire.BytesType:
+			v, l = protowire.ConsumeBytes(b)
+		default:
+			l = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if l < 0 {
+			return fmt.Errorf("field %d: %w", num, protowire.ParseError(l))
+		}
+		b = b[l:]
+		if err := field(num, typ, v, n); err != nil {
+			return fmt.Errorf("field %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
+// The helpers below decode repeated fields, either packed or not.
+
+func unmarshalUint32s(typ protowire.Type, v []byte, n uint64, dst *[]uint32) error {
+	if typ != protowire.BytesType {
+		*dst = append(*dst, uint32(n))
+		return nil
+	}
+	for len(v) > 0 {
+		x, l := protowire.ConsumeVarint(v)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		*dst = append(*dst, uint32(x))
+		v = v[l:]
+	}
+	return nil
+}
+
+func unmarshalSint64s(typ protowire.Type, v []byte, n uint64, dst *[]int64) error {
+	if typ != protowire.BytesType {
+		*dst = append(*dst, protowire.DecodeZigZag(n))
+		return nil
+	}
+	for len(v) > 0 {
+		x, l := protowire.ConsumeVarint(v)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		*dst = append(*dst, protowire.DecodeZigZag(x))
+		v = v[l:]
+	}
+	return nil
+}
+
+func unmarshalDoubles(typ protowire.Type, v []byte, n uint64, dst *[]float64) error {
+	if typ != protowire.BytesType {
+		*dst = append(*dst, math.Float64frombits(n))
+		return nil
+	}
+	for len(v) > 0 {
+		x, l := protowire.ConsumeFixed64(v)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		*dst = append(*dst, math.Float64frombits(x))
+		v = v[l:]
+	}
+	return nil
+}
+
+func TestRequestRoundTrip(t *testing.T) {
+	req := writev2.Request{
+		Symbols: []string{"", "__name__", "http_requests_total", "job", "api", "trace_id", "abc", "Number of requests", "requests"},
+		Timeseries: []writev2.TimeSeries{
+			{
+				LabelsRefs: []uint32{1, 2, 3, 4},
+				Samples: []writev2.Sample{
+					{Value: 10, Timestamp: 1000},
+					{Value: 12.5, Timestamp: 2000},
+				},
+				Exemplars: []writev2.Exemplar{
+					{LabelsRefs: []uint32{5, 6}, Value: 1, Timestamp: 1500},
+				},
+				Metadata: writev2.Metadata{
+					Type:    writev2.MetricTypeCounter,
+					HelpRef: 7,
+					UnitRef: 8,
+				},
+				CreatedTimestamp: 500,
+			},
+			{
+				LabelsRefs: []uint32{1, 2},
+				Histograms: []writev2.Histogram{
+					{
+						CountInt:       5,
+						Sum:            -3.5,
+						Schema:         -2,
+						ZeroThreshold:  1e-128,
+						ZeroCountInt:   0,
+						NegativeSpans:  []writev2.BucketSpan{{Offset: -1, Length: 2}},
+						NegativeDeltas: []int64{1, -1},
+						PositiveSpans:  []writev2.BucketSpan{{Offset: 0, Length: 1}, {Offset: 3, Length: 1}},
+						PositiveDeltas: []int64{2, 0},
+						ResetHint:      writev2.ResetHintNo,
+						Timestamp:      3000,
+					},
+					{
+						Float:          true,
+						CountFloat:     2.5,
+						ZeroCountFloat: 0.5,
+						PositiveSpans:  []writev2.BucketSpan{{Offset: 1, Length: 2}},
+						PositiveCounts: []float64{1, 1},
+						ResetHint:      writev2.ResetHintGauge,
+						Timestamp:      4000,
+					},
+				},
+				Samples: []writev2.Sample{
+					{Value: math.Float64frombits(0x7ff0000000000002), Timestamp: 5000},
+				},
+			},
+		},
+	}
+
+	got, err := unmarshalV2Request(req.Marshal())
+	require.NoError(t, err)
+	require.Len(t, got.Timeseries, 2)
+	// NaN values cannot be compared with assert.Equal.
+	assert.Equal(t, uint64(0x7ff0000000000002), math.Float64bits(got.Timeseries[1].Samples[0].Value))
+	got.Timeseries[1].Samples = req.Timeseries[1].Samples
+	assert.Equal(t, &req, got)
+}
+
+func TestRequestUnmarshalError(t *testing.T) {
+	_, err := unmarshalV2Request([]byte{0x2a, 0x05, 0x0a})
+	assert.Error(t, err)
+}
@@ -0,0 +1,328 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/writev2"
+)
+
+// labelsOf resolves the label references of a remote write 2.0 series.
+func labelsOf(symbols []string, refs []uint32) map[string]string {
+	labels := make(map[string]string, len(refs)/2)
+	for i := 0; i+1 < len(refs); i += 2 {
+		labels[symbols[refs[i]]] = symbols[refs[i+1]]
+	}
+	return labels
+}
+
+func TestConvertToV2(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "job", Value: "api"}, {Name: "__name__", Value: "requests_total"}},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 2000}},
+				Exemplars: []prompb.Exemplar{
+					{Labels: []prompb.Label{{Name: "trace_id", Value: "abc"}}, Value: 1, Timestamp: 1500},
+				},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "job", Value: "api"}, {Name: "__name__", Value: "requests_total_created"}},
+				Samples: []prompb.Sample{{Value: 1000}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "job", Value: "api"}, {Name: "le", Value: "+Inf"}, {Name: "__name__", Value: "latency_bucket"}},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 2000}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "job", Value: "api"}, {Name: "__name__", Value: "latency_created"}},
+				Samples: []prompb.Sample{{Value: 500}},
+			},
+			{
+				Labels: []prompb.Label{{Name: "__name__", Value: "sizes"}},
+				Histograms: []prompb.Histogram{{
+					Count:          &prompb.Histogram_CountInt{CountInt: 3},
+					Sum:            12,
+					Schema:         1,
+					ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+					PositiveSpans:  []prompb.BucketSpan{{Offset: 2, Length: 2}},
+					PositiveDeltas: []int64{1, 0},
+					Timestamp:      2000,
+				}},
+			},
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests_total", Help: "Requests", Unit: "1"},
+			{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency", Help: "Latency", Unit: "s"},
+		},
+	}
+
+	got := convertToV2(req)
+	require.Len(t, got.Timeseries, 3)
+	assert.Equal(t, "", got.Symbols[0])
+
+	requests := got.Timeseries[0]
+	assert.Equal(t, map[string]string{"__name__": "requests_total", "job": "api"}, labelsOf(got.Symbols, requests.LabelsRefs))
+	assert.Equal(t, uint32(1), requests.LabelsRefs[0], "labels must be sorted by name")
+	assert.Equal(t, []writev2.Sample{{Value: 10, Timestamp: 2000}}, requests.Samples)
+	assert.Equal(t, int64(1000), requests.CreatedTimestamp)
+	assert.Equal(t, writev2.MetricTypeCounter, requests.Metadata.Type)
+	assert.Equal(t, "Requests", got.Symbols[requests.Metadata.HelpRef])
+	assert.Equal(t, "1", got.Symbols[requests.Metadata.UnitRef])
+	require.Len(t, requests.Exemplars, 1)
+	assert.Equal(t, map[string]string{"trace_id": "abc"}, labelsOf(got.Symbols, requests.Exemplars[0].LabelsRefs))
+
+	latency := got.Timeseries[1]
+	assert.Equal(t, int64(500), latency.CreatedTimestamp)
+	assert.Equal(t, writev2.MetricTypeHistogram, latency.Metadata.Type)
+	assert.Equal(t, "s", got.Symbols[latency.Metadata.UnitRef])
+
+	sizes := got.Timeseries[2]
+	assert.Equal(t, writev2.Metadata{}, sizes.Metadata)
+	assert.Equal(t, []writev2.Histogram{{
+		CountInt:       3,
+		Sum:            12,
+		Schema:         1,
+		ZeroCountInt:   1,
+		PositiveSpans:  []writev2.BucketSpan{{Offset: 2, Length: 2}},
+		PositiveDeltas: []int64{1, 0},
+		Timestamp:      2000,
+	}}, sizes.Histograms)
+}
+
+func TestWithoutCreatedSeries(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "requests_total"}},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 2000}},
+			},
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "requests_total_created"}},
+				Samples: []prompb.Sample{{Value: 1000}},
+			},
+			{
+				// Not generated by the translator since it has a timestamp.
+				Labels:  []prompb.Label{{Name: "__name__", Value: "jobs_created"}},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 2000}},
+			},
+		},
+	}
+	got := withoutCreatedSeries(req)
+	require.Len(t, got.Timeseries, 2)
+	assert.Equal(t, "requests_total", metricName(got.Timeseries[0].Labels))
+	assert.Equal(t, "jobs_created", metricName(got.Timeseries[1].Labels))
+}
+
+// remoteWriteReceiver records the requests of the exporter. It only accepts
+// remote write 2.0 requests if v2 is set.
+type remoteWriteReceiver struct {
+	t  *testing.T
+	v2 bool
+
+	mu         sync.Mutex
+	v1Requests []*prompb.WriteRequest
+	v2Requests []*writev2.Request
+}
+
+func (rw *remoteWriteReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(rw.t, err)
+	data, err := snappy.Decode(nil, body)
+	require.NoError(rw.t, err)
+
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if r.Header.Get("Content-Type") == writev2.ContentType {
+		if !rw.v2 {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		assert.Equal(rw.t, writev2.Version, r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		req, err := unmarshalV2Request(data)
+		require.NoError(rw.t, err)
+		rw.v2Requests = append(rw.v2Requests, req)
+		samples := 0
+		for _, ts := range req.Timeseries {
+			samples += len(ts.Samples)
+		}
+		w.Header().Set(writev2.SamplesWrittenHeader, strconv.Itoa(samples))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	req := &prompb.WriteRequest{}
+	require.NoError(rw.t, proto.Unmarshal(data, req))
+	rw.v1Requests = append(rw.v1Requests, req)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newTestV2Exporter(t *testing.T, endpoint string) *prwExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings = confighttp.HTTPClientSettings{Endpoint: endpoint}
+	cfg.ProtobufMessage = protobufMessageV2
+	cfg.RemoteWriteQueue.NumConsumers = 1
+	cfg.RetrySettings.Enabled = false
+	require.NoError(t, cfg.Validate())
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, prwe.Shutdown(context.Background()))
+	})
+	return prwe
+}
+
+func generateV2TestMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("http.requests")
+	metric.SetDescription("Number of HTTP requests")
+	metric.SetUnit("1")
+	sum := metric.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("method", "GET")
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(1000)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(2000)))
+	dp.SetIntValue(10)
+	return md
+}
+
+func TestPushMetricsRemoteWriteV2(t *testing.T) {
+	receiver := &remoteWriteReceiver{t: t, v2: true}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	prwe := newTestV2Exporter(t, server.URL)
+	require.NoError(t, prwe.PushMetrics(context.Background(), generateV2TestMetrics()))
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	assert.Empty(t, receiver.v1Requests)
+	require.Len(t, receiver.v2Requests, 1)
+	req := receiver.v2Requests[0]
+	require.Len(t, req.Timeseries, 1)
+	ts := req.Timeseries[0]
+	assert.Equal(t, map[string]string{"__name__": "http_requests_total", "method": "GET"}, labelsOf(req.Symbols, ts.LabelsRefs))
+	assert.Equal(t, []writev2.Sample{{Value: 10, Timestamp: 2000}}, ts.Samples)
+	assert.Equal(t, int64(1000), ts.CreatedTimestamp)
+	assert.Equal(t, writev2.MetricTypeCounter, ts.Metadata.Type)
+	assert.Equal(t, "Number of HTTP requests", req.Symbols[ts.Metadata.HelpRef])
+	assert.Equal(t, "1", req.Symbols[ts.Metadata.UnitRef])
+}
+
+func TestPushMetricsRemoteWriteV2Batches(t *testing.T) {
+	receiver := &remoteWriteReceiver{t: t, v2: true}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	md := generateV2TestMetrics()
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	dps := metrics.At(0).Sum().DataPoints()
+	for i := 0; i < 20; i++ {
+		dp := dps.AppendEmpty()
+		dps.At(0).CopyTo(dp)
+		dp.Attributes().PutStr("method", strconv.Itoa(i))
+	}
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("http.latency")
+	histogram.SetUnit("s")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for i := 0; i < 5; i++ {
+		dp := histogram.Histogram().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("method", strconv.Itoa(i))
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(1000)))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(2000)))
+		dp.ExplicitBounds().FromRaw([]float64{0.1, 1, 10})
+		dp.BucketCounts().FromRaw([]uint64{1, 2, 3, 4})
+		dp.SetCount(10)
+		dp.SetSum(20)
+	}
+
+	prwe := newTestV2Exporter(t, server.URL)
+	prwe.maxBatchSizeBytes = 300
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	require.Greater(t, len(receiver.v2Requests), 1)
+	count := 0
+	for _, req := range receiver.v2Requests {
+		for _, ts := range req.Timeseries {
+			// Every series keeps the created timestamp of its family, whatever its request.
+			assert.Equal(t, int64(1000), ts.CreatedTimestamp, labelsOf(req.Symbols, ts.LabelsRefs))
+			count++
+		}
+	}
+	// 21 counters, and 5 histograms of 4 buckets, a sum and a count.
+	assert.Equal(t, 21+5*6, count)
+}
+
+func TestPushMetricsRemoteWriteV2Fallback(t *testing.T) {
+	receiver := &remoteWriteReceiver{t: t}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	prwe := newTestV2Exporter(t, server.URL)
+	require.NoError(t, prwe.PushMetrics(context.Background(), generateV2TestMetrics()))
+	assert.False(t, prwe.remoteWriteV2.Load())
+	require.NoError(t, prwe.PushMetrics(context.Background(), generateV2TestMetrics()))
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	assert.Empty(t, receiver.v2Requests)
+	require.Len(t, receiver.v1Requests, 2)
+	for _, req := range receiver.v1Requests {
+		// Neither _created series nor metadata were configured for remote write 1.0.
+		require.Len(t, req.Timeseries, 1)
+		assert.Equal(t, "http_requests_total", metricName(req.Timeseries[0].Labels))
+		assert.Empty(t, req.Metadata)
+	}
+}
+
+func TestPushMetricsRemoteWriteV2MissingHeaders(t *testing.T) {
+	var contentTypes []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		// Accepts any request without reporting what was written, like
+		// receivers ignoring the content type.
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prwe := newTestV2Exporter(t, server.URL)
+	require.NoError(t, prwe.PushMetrics(context.Background(), generateV2TestMetrics()))
+	require.NoError(t, prwe.PushMetrics(context.Background(), generateV2TestMetrics()))
+	assert.True(t, prwe.remoteWriteV2.Load())
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The requests are neither sent again nor downgraded to remote write 1.0.
+	assert.Equal(t, []string{writev2.ContentType, writev2.ContentType}, contentTypes)
+}
@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avrologencodingextension"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logCodec converts log records from and to Avro records of a single schema.
+type logCodec struct {
+	codec   *goavro.Codec
+	fields  []avroField
+	mapping map[string]string
+}
+
+func newLogCodec(schema string, mapping map[string]string) (*logCodec, error) {
+	fields, err := parseRecordFields(schema)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create codec: %w", err)
+	}
+	return &logCodec{
+		codec:   codec,
+		fields:  fields,
+		mapping: mapping,
+	}, nil
+}
+
+// appendLogs appends one Avro record for every log record in ld to buf.
+func (c *logCodec) appendLogs(buf []byte, ld plog.Logs) ([]byte, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				var err error
+				if buf, err = c.appendLogRecord(buf, rl.Resource(), lrs.At(k)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return buf, nil
+}
+
+// appendLogRecord encodes a single log record into buf.
+func (c *logCodec) appendLogRecord(buf []byte, resource pcommon.Resource, lr plog.LogRecord) ([]byte, error) {
+	record, err := c.toNative(resource, lr)
+	if err != nil {
+		return nil, err
+	}
+	return c.codec.BinaryFromNative(buf, record)
+}
+
+// readLogs decodes all the Avro records in buf, each into its own resource.
+func (c *logCodec) readLogs(buf []byte) (plog.Logs, error) {
+	ld := plog.NewLogs()
+	for len(buf) > 0 {
+		native, rest, err := c.codec.NativeFromBinary(buf)
+		if err != nil {
+			return ld, err
+		}
+		record, ok := native.(map[string]any)
+		if !ok {
+			return ld, fmt.Errorf("expected a record, got %T", native)
+		}
+		rl := ld.ResourceLogs().AppendEmpty()
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		if err = c.fromNative(record, rl.Resource(), lr); err != nil {
+			return ld, err
+		}
+		buf = rest
+	}
+	return ld, nil
+}
+
+func (c *logCodec) toNative(resource pcommon.Resource, lr plog.LogRecord) (map[string]any, error) {
+	record := make(map[string]any, len(c.fields))
+	for _, f := range c.fields {
+		value, err := toAvro(f.typ, getTarget(targetFor(f.name, c.mapping), resource, lr))
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.name, err)
+		}
+		record[f.name] = value
+	}
+	return record, nil
+}
+
+func (c *logCodec) fromNative(record map[string]any, resource pcommon.Resource, lr plog.LogRecord) error {
+	for _, f := range c.fields {
+		value := record[f.name]
+		if f.typ.union {
+			value = unwrapUnion(value)
+		}
+		if err := setTarget(targetFor(f.name, c.mapping), f.typ, value, resource, lr); err != nil {
+			return fmt.Errorf("field %q: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// unwrapUnion returns the value of a union decoded by goavro as a single entry map.
+func unwrapUnion(value any) any {
+	m, ok := value.(map[string]any)
+	if !ok || len(m) != 1 {
+		return value
+	}
+	for _, v := range m {
+		return v
+	}
+	return nil
+}
+
+// toAvro converts a raw log record value into the goavro native value of typ.
+func toAvro(typ avroType, value any) (any, error) {
+	if value == nil {
+		if typ.nullable {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("value is required by type %q", typ.name)
+	}
+	native, err := convert(typ, value)
+	if err != nil {
+		return nil, err
+	}
+	if typ.union {
+		return goavro.Union(typ.name, native), nil
+	}
+	return native, nil
+}
+
+func convert(typ avroType, value any) (any, error) {
+	if ts, ok := value.(pcommon.Timestamp); ok {
+		return convertTimestamp(typ, ts)
+	}
+	switch typ.kind {
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return hex.EncodeToString(v), nil
+		}
+		return fmt.Sprint(value), nil
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case "int", "long":
+		n, err := toInt64(value)
+		if s, ok := value.(string); ok {
+			n, err = strconv.ParseInt(s, 10, 64)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if typ.kind == "int" {
+			return int32(n), nil
+		}
+		return n, nil
+	case "float", "double":
+		var f float64
+		switch v := value.(type) {
+		case float64:
+			f = v
+		case int64:
+			f = float64(v)
+		case string:
+			var err error
+			if f, err = strconv.ParseFloat(v, 64); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("expected a number, got %T", value)
+		}
+		if typ.kind == "float" {
+			return float32(f), nil
+		}
+		return f, nil
+	case "bytes", "fixed":
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return []byte(v), nil
+		}
+	case "map":
+		m, ok := value.(map[string]any)
+		if !ok {
+			break
+		}
+		out := make(map[string]any, len(m))
+		for k, v := range m {
+			converted, err := toAvro(*typ.values, v)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", k, err)
+			}
+			out[k] = converted
+		}
+		return out, nil
+	case "array":
+		s, ok := value.([]any)
+		if !ok {
+			break
+		}
+		out := make([]any, len(s))
+		for i, v := range s {
+			converted, err := toAvro(*typ.values, v)
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", i, err)
+			}
+			out[i] = converted
+		}
+		return out, nil
+	default:
+		// Records, enums and named type references are passed through as is.
+		return value, nil
+	}
+	return nil, fmt.Errorf("cannot convert %T to %q", value, typ.kind)
+}
+
+func convertTimestamp(typ avroType, ts pcommon.Timestamp) (any, error) {
+	switch typ.kind {
+	case "long":
+		switch typ.logicalType {
+		case "timestamp-millis", "timestamp-micros":
+			return ts.AsTime(), nil
+		case "local-timestamp-millis":
+			return ts.AsTime().UnixMilli(), nil
+		case "local-timestamp-micros":
+			return ts.AsTime().UnixMicro(), nil
+		}
+		return int64(ts), nil
+	case "string":
+		return ts.AsTime().Format(time.RFC3339Nano), nil
+	case "double":
+		return float64(ts) / float64(time.Second), nil
+	}
+	return nil, fmt.Errorf("cannot convert a timestamp to %q", typ.kind)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avrologencodingextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	wireFormatRaw       = "raw"
+	wireFormatConfluent = "confluent"
+)
+
+var _ component.ConfigValidator = (*Config)(nil)
+
+type Config struct {
+	// Schema is the Avro schema, in JSON, of the record written for each log record.
+	Schema string `mapstructure:"schema"`
+
+	// SchemaFile is the path of a file containing the Avro schema.
+	// Only one of Schema and SchemaFile can be set.
+	SchemaFile string `mapstructure:"schema_file"`
+
+	// Mapping maps Avro record field names to log record fields. Fields that are
+	// not mapped and are not named after a log record field are stored as attributes.
+	Mapping map[string]string `mapstructure:"mapping"`
+
+	// WireFormat is the framing of the encoded messages, either raw or confluent.
+	WireFormat string `mapstructure:"wire_format"`
+
+	// SchemaRegistry configures the schema IDs used by the confluent wire format.
+	SchemaRegistry SchemaRegistryConfig `mapstructure:"schema_registry"`
+}
+
+// SchemaRegistryConfig uses a local file in place of a schema registry server.
+type SchemaRegistryConfig struct {
+	// File is the path of a JSON object mapping schema IDs to Avro schemas.
+	// It is used to find the writer schema of messages in the confluent wire format.
+	File string `mapstructure:"file"`
+
+	// SchemaID is the ID written in front of every marshaled message. When
+	// neither Schema nor SchemaFile is set, the schema is looked up in File.
+	SchemaID uint32 `mapstructure:"schema_id"`
+}
+
+func (c *Config) Validate() error {
+	if c.Schema != "" && c.SchemaFile != "" {
+		return errors.New("only one of schema and schema_file can be set")
+	}
+	switch c.WireFormat {
+	case wireFormatRaw:
+		if c.Schema == "" && c.SchemaFile == "" {
+			return errors.New("schema or schema_file must be set")
+		}
+	case wireFormatConfluent:
+		if c.Schema == "" && c.SchemaFile == "" && c.SchemaRegistry.File == "" {
+			return errors.New("schema, schema_file or schema_registry::file must be set")
+		}
+	default:
+		return fmt.Errorf("unsupported wire_format: %q", c.WireFormat)
+	}
+	for field, target := range c.Mapping {
+		if !isValidTarget(target) {
+			return fmt.Errorf("unsupported mapping of field %q to %q", field, target)
+		}
+	}
+	return nil
+}
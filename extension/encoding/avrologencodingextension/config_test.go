@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "default config without schema",
+			cfg:  createDefaultConfig().(*Config),
+			err:  "schema or schema_file must be set",
+		},
+		{
+			name: "schema",
+			cfg:  &Config{WireFormat: wireFormatRaw, Schema: `{"type":"record","name":"r","fields":[]}`},
+		},
+		{
+			name: "schema and schema file",
+			cfg:  &Config{WireFormat: wireFormatRaw, Schema: "{}", SchemaFile: "schema.avsc"},
+			err:  "only one of schema and schema_file can be set",
+		},
+		{
+			name: "confluent with registry only",
+			cfg: &Config{
+				WireFormat:     wireFormatConfluent,
+				SchemaRegistry: SchemaRegistryConfig{File: "registry.json", SchemaID: 1},
+			},
+		},
+		{
+			name: "confluent without schema",
+			cfg:  &Config{WireFormat: wireFormatConfluent},
+			err:  "schema, schema_file or schema_registry::file must be set",
+		},
+		{
+			name: "unknown wire format",
+			cfg:  &Config{WireFormat: "magic", Schema: "{}"},
+			err:  `unsupported wire_format: "magic"`,
+		},
+		{
+			name: "invalid mapping",
+			cfg: &Config{
+				WireFormat: wireFormatRaw,
+				SchemaFile: "schema.avsc",
+				Mapping:    map[string]string{"message": "attributes."},
+			},
+			err: `unsupported mapping of field "message" to "attributes."`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avrologencodingextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension   = (*avroLogExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension = (*avroLogExtension)(nil)
+
+	errMultipleRecords = errors.New("the confluent wire format holds a single log record per message")
+)
+
+type avroLogExtension struct {
+	config *Config
+	// codec is used to marshal logs and to unmarshal messages without a schema ID.
+	codec *logCodec
+	// registry holds the codecs of the schemas listed in the schema registry file.
+	registry map[uint32]*logCodec
+}
+
+func (e *avroLogExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	if e.codec == nil {
+		return nil, fmt.Errorf("no schema found for schema ID %d", e.config.SchemaRegistry.SchemaID)
+	}
+	var buf []byte
+	if e.config.WireFormat == wireFormatConfluent {
+		// Confluent deserializers read exactly one datum after the header, any other
+		// record of the message would be silently lost.
+		if ld.LogRecordCount() > 1 {
+			return nil, errMultipleRecords
+		}
+		buf = appendConfluentHeader(buf, e.config.SchemaRegistry.SchemaID)
+	}
+	return e.codec.appendLogs(buf, ld)
+}
+
+// MarshalLogsMessages marshals each log record into a message of its own with the
+// confluent wire format, and all of them into a single message otherwise. Exporters
+// sending messages, such as the Kafka exporter, use it in place of MarshalLogs.
+func (e *avroLogExtension) MarshalLogsMessages(ld plog.Logs) ([][]byte, error) {
+	if e.config.WireFormat != wireFormatConfluent {
+		buf, err := e.MarshalLogs(ld)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{buf}, nil
+	}
+	if e.codec == nil {
+		return nil, fmt.Errorf("no schema found for schema ID %d", e.config.SchemaRegistry.SchemaID)
+	}
+	messages := make([][]byte, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				buf := appendConfluentHeader(nil, e.config.SchemaRegistry.SchemaID)
+				buf, err := e.codec.appendLogRecord(buf, rl.Resource(), lrs.At(k))
+				if err != nil {
+					return nil, err
+				}
+				messages = append(messages, buf)
+			}
+		}
+	}
+	return messages, nil
+}
+
+func (e *avroLogExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	if e.config.WireFormat != wireFormatConfluent {
+		return e.codec.readLogs(buf)
+	}
+	schemaID, payload, err := parseConfluentHeader(buf)
+	if err != nil {
+		return plog.NewLogs(), err
+	}
+	codec, ok := e.registry[schemaID]
+	if !ok && schemaID == e.config.SchemaRegistry.SchemaID && e.codec != nil {
+		codec, ok = e.codec, true
+	}
+	if !ok {
+		return plog.NewLogs(), fmt.Errorf("unknown schema ID %d", schemaID)
+	}
+	return codec.readLogs(payload)
+}
+
+func (e *avroLogExtension) Start(_ context.Context, _ component.Host) error {
+	if e.config.SchemaRegistry.File != "" {
+		registry, err := loadRegistry(e.config.SchemaRegistry.File, e.config.Mapping)
+		if err != nil {
+			return err
+		}
+		e.registry = registry
+	}
+
+	schema := e.config.Schema
+	if e.config.SchemaFile != "" {
+		content, err := os.ReadFile(e.config.SchemaFile)
+		if err != nil {
+			return err
+		}
+		schema = string(content)
+	}
+	if schema == "" {
+		// Validate ensures a registry file is set in that case.
+		e.codec = e.registry[e.config.SchemaRegistry.SchemaID]
+		return nil
+	}
+
+	codec, err := newLogCodec(schema, e.config.Mapping)
+	if err != nil {
+		return err
+	}
+	e.codec = codec
+	return nil
+}
+
+func (e *avroLogExtension) Shutdown(_ context.Context) error {
+	return nil
+}
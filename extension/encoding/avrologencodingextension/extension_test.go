@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var testMapping = map[string]string{
+	"ts":      "timestamp",
+	"level":   "severity_text",
+	"message": "body",
+	"host":    "resource_attributes.host.name",
+	"tags":    "attributes",
+}
+
+func startExtension(t *testing.T, cfg *Config) *avroLogExtension {
+	require.NoError(t, cfg.Validate())
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	e := ext.(*avroLogExtension)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, e.Shutdown(context.Background()))
+	})
+	return e
+}
+
+func generateLogs(count int) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "host-1")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < count; i++ {
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(1700000000123)))
+		lr.SetSeverityText("INFO")
+		lr.Body().SetStr("hello")
+		lr.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		lr.Attributes().PutStr("env", "prod")
+		lr.Attributes().PutStr("user", "alice")
+	}
+	return ld
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	e := startExtension(t, &Config{
+		WireFormat: wireFormatRaw,
+		SchemaFile: filepath.Join("testdata", "schema.avsc"),
+		Mapping:    testMapping,
+	})
+
+	buf, err := e.MarshalLogs(generateLogs(1))
+	require.NoError(t, err)
+
+	ld, err := e.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"host.name": "host-1"}, rl.Resource().Attributes().AsRaw())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, time.UnixMilli(1700000000123).UTC(), lr.Timestamp().AsTime())
+	assert.Equal(t, "INFO", lr.SeverityText())
+	assert.Equal(t, "hello", lr.Body().Str())
+	assert.Equal(t, pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), lr.TraceID())
+	assert.Equal(t, map[string]any{"env": "prod", "user": "alice"}, lr.Attributes().AsRaw())
+}
+
+func TestMarshalUnmarshalMultipleRecords(t *testing.T) {
+	e := startExtension(t, &Config{
+		WireFormat: wireFormatRaw,
+		SchemaFile: filepath.Join("testdata", "schema.avsc"),
+		Mapping:    testMapping,
+	})
+
+	buf, err := e.MarshalLogs(generateLogs(3))
+	require.NoError(t, err)
+
+	ld, err := e.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, ld.LogRecordCount())
+	assert.Equal(t, 3, ld.ResourceLogs().Len())
+}
+
+func TestConfluentWireFormatMultipleRecords(t *testing.T) {
+	e := startExtension(t, &Config{
+		WireFormat:     wireFormatConfluent,
+		SchemaFile:     filepath.Join("testdata", "schema.avsc"),
+		Mapping:        testMapping,
+		SchemaRegistry: SchemaRegistryConfig{SchemaID: 7},
+	})
+
+	_, err := e.MarshalLogs(generateLogs(2))
+	assert.ErrorIs(t, err, errMultipleRecords)
+
+	messages, err := e.MarshalLogsMessages(generateLogs(2))
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	for _, message := range messages {
+		assert.Equal(t, []byte{0, 0, 0, 0, 7}, message[:5])
+		ld, err := e.UnmarshalLogs(message)
+		require.NoError(t, err)
+		assert.Equal(t, 1, ld.LogRecordCount())
+	}
+}
+
+func TestMarshalLogsMessagesRaw(t *testing.T) {
+	e := startExtension(t, &Config{
+		WireFormat: wireFormatRaw,
+		SchemaFile: filepath.Join("testdata", "schema.avsc"),
+		Mapping:    testMapping,
+	})
+
+	messages, err := e.MarshalLogsMessages(generateLogs(3))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	ld, err := e.UnmarshalLogs(messages[0])
+	require.NoError(t, err)
+	assert.Equal(t, 3, ld.LogRecordCount())
+}
+
+func TestMarshalMissingRequiredField(t *testing.T) {
+	e := startExtension(t, &Config{
+		WireFormat: wireFormatRaw,
+		SchemaFile: filepath.Join("testdata", "schema.avsc"),
+		Mapping:    testMapping,
+	})
+
+	ld := generateLogs(1)
+	ld.ResourceLogs().At(0).Resource().Attributes().Clear()
+	_, err := e.MarshalLogs(ld)
+	assert.ErrorContains(t, err, `field "host": value is required by type "string"`)
+}
+
+func TestConfluentWireFormat(t *testing.T) {
+	e := startExtension(t, &Config{
+		WireFormat:     wireFormatConfluent,
+		SchemaFile:     filepath.Join("testdata", "schema.avsc"),
+		Mapping:        testMapping,
+		SchemaRegistry: SchemaRegistryConfig{SchemaID: 7},
+	})
+
+	buf, err := e.MarshalLogs(generateLogs(1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 7}, buf[:confluentHeaderSize])
+
+	ld, err := e.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.LogRecordCount())
+
+	buf[4] = 8
+	_, err = e.UnmarshalLogs(buf)
+	assert.EqualError(t, err, "unknown schema ID 8")
+
+	_, err = e.UnmarshalLogs([]byte{1, 2})
+	assert.ErrorIs(t, err, errNotConfluentFramed)
+}
+
+func TestConfluentWireFormatRegistry(t *testing.T) {
+	e := startExtension(t, &Config{
+		WireFormat: wireFormatConfluent,
+		SchemaRegistry: SchemaRegistryConfig{
+			File:     filepath.Join("testdata", "registry.json"),
+			SchemaID: 2,
+		},
+	})
+
+	buf, err := e.MarshalLogs(generateLogs(1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 2}, buf[:confluentHeaderSize])
+
+	ld, err := e.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "hello", lr.Body().Str())
+	assert.Equal(t, "INFO", lr.SeverityText())
+
+	// Messages written with another schema of the registry can be read as well.
+	buf, err = e.registry[1].appendLogs(appendConfluentHeader(nil, 1), generateLogs(1))
+	require.NoError(t, err)
+	ld, err = e.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	lr = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "hello", lr.Body().Str())
+	assert.Equal(t, "", lr.SeverityText())
+}
+
+func TestStartInvalidSchema(t *testing.T) {
+	e := &avroLogExtension{config: &Config{
+		WireFormat: wireFormatRaw,
+		Schema:     `{"type": "string"}`,
+	}}
+	assert.EqualError(t, e.Start(context.Background(), componenttest.NewNopHost()), `schema must be a record, got "string"`)
+}
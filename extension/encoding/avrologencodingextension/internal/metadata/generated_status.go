@@ -0,0 +1,12 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	Type               = "avro_log_encoding"
+	ExtensionStability = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avrologencodingextension"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Log record fields an Avro field can be mapped to.
+const (
+	targetBody               = "body"
+	targetTimestamp          = "timestamp"
+	targetObservedTimestamp  = "observed_timestamp"
+	targetSeverityNumber     = "severity_number"
+	targetSeverityText       = "severity_text"
+	targetTraceID            = "trace_id"
+	targetSpanID             = "span_id"
+	targetFlags              = "flags"
+	targetAttributes         = "attributes"
+	targetResourceAttributes = "resource_attributes"
+)
+
+var topLevelTargets = map[string]bool{
+	targetBody:               true,
+	targetTimestamp:          true,
+	targetObservedTimestamp:  true,
+	targetSeverityNumber:     true,
+	targetSeverityText:       true,
+	targetTraceID:            true,
+	targetSpanID:             true,
+	targetFlags:              true,
+	targetAttributes:         true,
+	targetResourceAttributes: true,
+}
+
+func isValidTarget(target string) bool {
+	if topLevelTargets[target] {
+		return true
+	}
+	if key, ok := attributeKey(target, targetAttributes); ok {
+		return key != ""
+	}
+	if key, ok := attributeKey(target, targetResourceAttributes); ok {
+		return key != ""
+	}
+	return false
+}
+
+// attributeKey returns the attribute key of targets such as "attributes.host".
+func attributeKey(target, prefix string) (string, bool) {
+	return strings.CutPrefix(target, prefix+".")
+}
+
+// targetFor returns the log record field an Avro field is mapped to. Fields
+// without an explicit mapping are matched by name, or else stored as attributes.
+func targetFor(field string, mapping map[string]string) string {
+	if target, ok := mapping[field]; ok {
+		return target
+	}
+	if topLevelTargets[field] {
+		return field
+	}
+	return targetAttributes + "." + field
+}
+
+// getTarget returns the raw value of target in the log record, or nil if it is not set.
+func getTarget(target string, resource pcommon.Resource, lr plog.LogRecord) any {
+	switch target {
+	case targetBody:
+		if lr.Body().Type() == pcommon.ValueTypeEmpty {
+			return nil
+		}
+		return lr.Body().AsRaw()
+	case targetTimestamp:
+		return lr.Timestamp()
+	case targetObservedTimestamp:
+		return lr.ObservedTimestamp()
+	case targetSeverityNumber:
+		return int64(lr.SeverityNumber())
+	case targetSeverityText:
+		return lr.SeverityText()
+	case targetTraceID:
+		if lr.TraceID().IsEmpty() {
+			return nil
+		}
+		id := lr.TraceID()
+		return id[:]
+	case targetSpanID:
+		if lr.SpanID().IsEmpty() {
+			return nil
+		}
+		id := lr.SpanID()
+		return id[:]
+	case targetFlags:
+		return int64(lr.Flags())
+	case targetAttributes:
+		return lr.Attributes().AsRaw()
+	case targetResourceAttributes:
+		return resource.Attributes().AsRaw()
+	}
+	if key, ok := attributeKey(target, targetAttributes); ok {
+		return getAttribute(lr.Attributes(), key)
+	}
+	if key, ok := attributeKey(target, targetResourceAttributes); ok {
+		return getAttribute(resource.Attributes(), key)
+	}
+	return nil
+}
+
+func getAttribute(attrs pcommon.Map, key string) any {
+	v, ok := attrs.Get(key)
+	if !ok {
+		return nil
+	}
+	return v.AsRaw()
+}
+
+// setTarget stores the decoded value of an Avro field of type typ in target.
+func setTarget(target string, typ avroType, value any, resource pcommon.Resource, lr plog.LogRecord) error {
+	if value == nil {
+		return nil
+	}
+	switch target {
+	case targetBody:
+		return lr.Body().FromRaw(toRaw(value))
+	case targetTimestamp:
+		ts, err := toTimestamp(typ, value)
+		lr.SetTimestamp(ts)
+		return err
+	case targetObservedTimestamp:
+		ts, err := toTimestamp(typ, value)
+		lr.SetObservedTimestamp(ts)
+		return err
+	case targetSeverityNumber:
+		n, err := toInt64(value)
+		lr.SetSeverityNumber(plog.SeverityNumber(n))
+		return err
+	case targetSeverityText:
+		lr.SetSeverityText(fmt.Sprint(toRaw(value)))
+		return nil
+	case targetTraceID:
+		var id pcommon.TraceID
+		if err := toID(value, id[:]); err != nil {
+			return err
+		}
+		lr.SetTraceID(id)
+		return nil
+	case targetSpanID:
+		var id pcommon.SpanID
+		if err := toID(value, id[:]); err != nil {
+			return err
+		}
+		lr.SetSpanID(id)
+		return nil
+	case targetFlags:
+		n, err := toInt64(value)
+		lr.SetFlags(plog.LogRecordFlags(n))
+		return err
+	case targetAttributes:
+		return putAll(lr.Attributes(), value)
+	case targetResourceAttributes:
+		return putAll(resource.Attributes(), value)
+	}
+	if key, ok := attributeKey(target, targetAttributes); ok {
+		return lr.Attributes().PutEmpty(key).FromRaw(toRaw(value))
+	}
+	if key, ok := attributeKey(target, targetResourceAttributes); ok {
+		return resource.Attributes().PutEmpty(key).FromRaw(toRaw(value))
+	}
+	return fmt.Errorf("unsupported target %q", target)
+}
+
+func putAll(attrs pcommon.Map, value any) error {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("expected a map, got %T", value)
+	}
+	for k, v := range m {
+		if err := attrs.PutEmpty(k).FromRaw(toRaw(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toRaw converts a goavro native value into one accepted by pcommon.Value.FromRaw.
+func toRaw(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = toRaw(e)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, e := range v {
+			s[i] = toRaw(e)
+		}
+		return s
+	}
+	return value
+}
+
+func toTimestamp(typ avroType, value any) (pcommon.Timestamp, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return pcommon.NewTimestampFromTime(v), nil
+	case float64:
+		// Fractional seconds since the epoch, as written for double fields.
+		return pcommon.Timestamp(v * float64(time.Second)), nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return 0, err
+		}
+		return pcommon.NewTimestampFromTime(t), nil
+	}
+	n, err := toInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	switch typ.logicalType {
+	case "timestamp-millis", "local-timestamp-millis":
+		return pcommon.NewTimestampFromTime(time.UnixMilli(n)), nil
+	case "timestamp-micros", "local-timestamp-micros":
+		return pcommon.NewTimestampFromTime(time.UnixMicro(n)), nil
+	}
+	return pcommon.Timestamp(n), nil
+}
+
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	}
+	return 0, fmt.Errorf("expected a number, got %T", value)
+}
+
+func toID(value any, dst []byte) error {
+	var src []byte
+	switch v := value.(type) {
+	case []byte:
+		src = v
+	case string:
+		var err error
+		if src, err = hex.DecodeString(v); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("expected bytes or a hex string, got %T", value)
+	}
+	if len(src) != len(dst) {
+		return fmt.Errorf("expected %d bytes, got %d", len(dst), len(src))
+	}
+	copy(dst, src)
+	return nil
+}
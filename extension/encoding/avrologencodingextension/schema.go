@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avrologencodingextension"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// avroType is the subset of an Avro type needed to convert values from and to
+// the native representation used by goavro.
+type avroType struct {
+	// kind is the primitive or complex type, such as "string", "map" or "fixed".
+	kind string
+	// name is how the type is referred to in unions: the type itself for
+	// primitive and unnamed types, or the full name of named types.
+	name string
+	// logicalType is the Avro logical type annotation, if any.
+	logicalType string
+	// values is the type of map values or array items.
+	values *avroType
+	// union is set when the type is the non-null branch of a union.
+	union bool
+	// nullable is set when the union also accepts null.
+	nullable bool
+}
+
+// avroField is a field of the top-level record schema.
+type avroField struct {
+	name string
+	typ  avroType
+}
+
+// parseRecordFields returns the fields of the record described by schema.
+func parseRecordFields(schema string) ([]avroField, error) {
+	var record struct {
+		Type   string `json:"type"`
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string          `json:"name"`
+			Type json.RawMessage `json:"type"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(schema), &record); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	if record.Type != "record" {
+		return nil, fmt.Errorf("schema must be a record, got %q", record.Type)
+	}
+
+	fields := make([]avroField, 0, len(record.Fields))
+	for _, f := range record.Fields {
+		typ, err := parseType(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.Name, err)
+		}
+		fields = append(fields, avroField{name: f.Name, typ: typ})
+	}
+	return fields, nil
+}
+
+func parseType(raw json.RawMessage) (avroType, error) {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return avroType{kind: name, name: name}, nil
+	}
+
+	var union []json.RawMessage
+	if err := json.Unmarshal(raw, &union); err == nil {
+		return parseUnion(union)
+	}
+
+	var complexType struct {
+		Type        string          `json:"type"`
+		Name        string          `json:"name"`
+		Namespace   string          `json:"namespace"`
+		LogicalType string          `json:"logicalType"`
+		Values      json.RawMessage `json:"values"`
+		Items       json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &complexType); err != nil {
+		return avroType{}, fmt.Errorf("invalid type: %w", err)
+	}
+	typ := avroType{kind: complexType.Type, name: complexType.Type, logicalType: complexType.LogicalType}
+	switch complexType.Type {
+	case "map", "array":
+		elem := complexType.Values
+		if complexType.Type == "array" {
+			elem = complexType.Items
+		}
+		values, err := parseType(elem)
+		if err != nil {
+			return avroType{}, err
+		}
+		typ.values = &values
+	case "record", "enum", "fixed":
+		// Named types are referred to by their full name in unions.
+		typ.name = complexType.Name
+		if complexType.Namespace != "" {
+			typ.name = complexType.Namespace + "." + complexType.Name
+		}
+	}
+	return typ, nil
+}
+
+func parseUnion(union []json.RawMessage) (avroType, error) {
+	var branches []avroType
+	nullable := false
+	for _, raw := range union {
+		typ, err := parseType(raw)
+		if err != nil {
+			return avroType{}, err
+		}
+		if typ.kind == "null" {
+			nullable = true
+			continue
+		}
+		branches = append(branches, typ)
+	}
+	if len(branches) != 1 {
+		return avroType{}, errors.New("only unions of null and one other type are supported")
+	}
+	typ := branches[0]
+	typ.union = true
+	typ.nullable = nullable
+	return typ, nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package avrologencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/avrologencodingextension"
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// The confluent wire format prefixes every message with a zero magic byte
+// followed by the big endian schema ID.
+// See https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+const (
+	confluentMagicByte  = 0
+	confluentHeaderSize = 5
+)
+
+var errNotConfluentFramed = errors.New("message is not in the confluent wire format")
+
+func appendConfluentHeader(buf []byte, schemaID uint32) []byte {
+	buf = append(buf, confluentMagicByte)
+	return binary.BigEndian.AppendUint32(buf, schemaID)
+}
+
+func parseConfluentHeader(buf []byte) (uint32, []byte, error) {
+	if len(buf) < confluentHeaderSize || buf[0] != confluentMagicByte {
+		return 0, nil, errNotConfluentFramed
+	}
+	return binary.BigEndian.Uint32(buf[1:confluentHeaderSize]), buf[confluentHeaderSize:], nil
+}
+
+// loadRegistry reads a JSON object mapping schema IDs to Avro schemas and
+// builds a codec for each of them.
+func loadRegistry(path string, mapping map[string]string) (map[uint32]*logCodec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schemas map[string]json.RawMessage
+	if err = json.Unmarshal(content, &schemas); err != nil {
+		return nil, fmt.Errorf("failed to parse schema registry file: %w", err)
+	}
+
+	registry := make(map[uint32]*logCodec, len(schemas))
+	for key, schema := range schemas {
+		id, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema ID %q: %w", key, err)
+		}
+		// Schemas can be inlined as JSON or, as returned by registry servers, as a string.
+		var text string
+		if json.Unmarshal(schema, &text) != nil {
+			text = string(schema)
+		}
+		codec, err := newLogCodec(text, mapping)
+		if err != nil {
+			return nil, fmt.Errorf("schema ID %d: %w", id, err)
+		}
+		registry[uint32(id)] = codec
+	}
+	return registry, nil
+}
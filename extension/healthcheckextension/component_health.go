@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/status"
+)
+
+// grpcHealthUpdateInterval is the interval the gRPC health statuses are
+// updated at, recoverable errors becoming unhealthy over time.
+const grpcHealthUpdateInterval = time.Second
+
+func (hc *healthCheckExtension) ComponentStatusChanged(source *component.InstanceID, event *component.StatusEvent) {
+	hc.aggregator.RecordStatus(source, event)
+	if hc.grpcHealth != nil {
+		hc.updateGRPCHealth()
+	}
+}
+
+func (hc *healthCheckExtension) statusRules() status.Rules {
+	cfg := hc.config.ComponentHealth
+	rules := status.Rules{
+		IncludePermanentErrors:   cfg.IncludePermanentErrors,
+		IncludeRecoverableErrors: cfg.IncludeRecoverableErrors,
+		RecoveryDuration:         cfg.RecoveryDuration,
+		IgnorePipelines:          make(map[string]struct{}, len(cfg.IgnorePipelines)),
+	}
+	for _, p := range cfg.IgnorePipelines {
+		rules.IgnorePipelines[p] = struct{}{}
+	}
+	return rules
+}
+
+// componentHealthHandler serves the health of the collector and of its
+// pipelines, or of a single pipeline given with the "pipeline" parameter.
+func (hc *healthCheckExtension) componentHealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st := hc.aggregator.Collector(hc.statusRules(), time.Now())
+
+		var body any = st
+		healthy := st.Healthy
+		if name := r.URL.Query().Get("pipeline"); name != "" {
+			pst, ok := st.Pipelines[name]
+			if !ok {
+				http.Error(w, fmt.Sprintf("unknown pipeline %q", name), http.StatusNotFound)
+				return
+			}
+			body, healthy = pst, pst.Healthy
+		}
+
+		data, err := json.Marshal(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write(data)
+	})
+}
+
+// updateGRPCHealth reports the health of the collector as the "" service, and
+// the health of each pipeline as the service named after the pipeline.
+func (hc *healthCheckExtension) updateGRPCHealth() {
+	st := hc.aggregator.Collector(hc.statusRules(), time.Now())
+	hc.grpcHealth.SetServingStatus("", servingStatus(st.Healthy))
+	for name, pst := range st.Pipelines {
+		hc.grpcHealth.SetServingStatus(name, servingStatus(pst.Healthy))
+	}
+}
+
+func servingStatus(healthy bool) healthpb.HealthCheckResponse_ServingStatus {
+	if healthy {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func (hc *healthCheckExtension) startGRPCHealth(host component.Host) error {
+	cfg := hc.config.ComponentHealth.GRPC
+	netAddr := cfg.NetAddr
+	if netAddr.Transport == "" {
+		netAddr.Transport = "tcp"
+	}
+	ln, err := netAddr.Listen()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", netAddr.Endpoint, err)
+	}
+	hc.grpcServer, err = cfg.ToServer(host, hc.settings)
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	hc.updateGRPCHealth()
+	healthpb.RegisterHealthServer(hc.grpcServer, hc.grpcHealth)
+
+	hc.grpcStopCh = make(chan struct{})
+	hc.grpcWG.Add(2)
+	go func() {
+		defer hc.grpcWG.Done()
+
+		ticker := time.NewTicker(grpcHealthUpdateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				hc.updateGRPCHealth()
+			case <-hc.grpcStopCh:
+				return
+			}
+		}
+	}()
+	go func() {
+		defer hc.grpcWG.Done()
+
+		if errGRPC := hc.grpcServer.Serve(ln); errGRPC != nil {
+			host.ReportFatalError(errGRPC)
+		}
+	}()
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
@@ -37,6 +38,10 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// ComponentHealth contains the settings of the health aggregated from the
+	// status reported by the components of the collector.
+	ComponentHealth componentHealthSettings `mapstructure:"component_health"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -44,6 +49,9 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errInvalidComponentHealthPath              = errors.New("bad config: component_health::path must start with / and differ from path")
+	errInvalidRecoveryDuration                 = errors.New("bad config: component_health::recovery_duration must not be negative")
+	errNoGRPCEndpointProvided                  = errors.New("bad config: component_health::grpc::endpoint must be specified")
 )
 
 // Validate checks if the extension configuration is valid
@@ -61,6 +69,17 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	if cfg.ComponentHealth.Enabled {
+		if !strings.HasPrefix(cfg.ComponentHealth.Path, "/") || cfg.ComponentHealth.Path == cfg.Path {
+			return errInvalidComponentHealthPath
+		}
+		if cfg.ComponentHealth.RecoveryDuration < 0 {
+			return errInvalidRecoveryDuration
+		}
+		if cfg.ComponentHealth.GRPC != nil && cfg.ComponentHealth.GRPC.NetAddr.Endpoint == "" {
+			return errNoGRPCEndpointProvided
+		}
+	}
 	return nil
 }
 
@@ -72,3 +91,25 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type componentHealthSettings struct {
+	// Enabled indicates whether to report the health of the components.
+	Enabled bool `mapstructure:"enabled"`
+	// Path is the path the health of the collector, its pipelines and their
+	// components is served on as JSON. The default path is "/status".
+	Path string `mapstructure:"path"`
+	// IncludePermanentErrors makes components reporting a permanent error unhealthy.
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+	// IncludeRecoverableErrors makes components reporting a recoverable error
+	// unhealthy once the error has lasted for RecoveryDuration.
+	IncludeRecoverableErrors bool `mapstructure:"include_recoverable_errors"`
+	// RecoveryDuration is the time components are given to recover from a
+	// recoverable error before being considered unhealthy.
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+	// IgnorePipelines lists the pipelines not taken into account in the health
+	// of the collector, such as non-critical pipelines.
+	IgnorePipelines []string `mapstructure:"ignore_pipelines"`
+	// GRPC configures the server implementing the gRPC health checking
+	// protocol. It is not started if not set.
+	GRPC *configgrpc.GRPCServerSettings `mapstructure:"grpc"`
+}
@@ -6,11 +6,14 @@ package healthcheckextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
@@ -43,10 +46,35 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth:        defaultComponentHealthSettings(),
 				Path:                   "/",
 				ResponseBody:           nil,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "componenthealth"),
+			expected: &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentHealth: componentHealthSettings{
+					Enabled:                  true,
+					Path:                     "/health/status",
+					IncludePermanentErrors:   true,
+					IncludeRecoverableErrors: false,
+					RecoveryDuration:         time.Minute,
+					IgnorePipelines:          []string{"metrics/debug"},
+					GRPC: &configgrpc.GRPCServerSettings{
+						NetAddr: confignet.NetAddr{
+							Endpoint: "localhost:14",
+						},
+					},
+				},
+				Path:         "/",
+				ResponseBody: nil,
+			},
+		},
 		{
 			id:          component.NewIDWithName(metadata.Type, "missingendpoint"),
 			expectedErr: errNoEndpointProvided,
@@ -59,6 +87,10 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidcomponenthealthpath"),
+			expectedErr: errInvalidComponentHealthPath,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
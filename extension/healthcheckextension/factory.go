@@ -5,6 +5,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -35,6 +36,7 @@ func createDefaultConfig() component.Config {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
 		Path:                   "/",
 	}
 }
@@ -53,3 +55,14 @@ func defaultCheckCollectorPipelineSettings() checkCollectorPipelineSettings {
 		ExporterFailureThreshold: 5,
 	}
 }
+
+// defaultComponentHealthSettings returns the default settings for ComponentHealth.
+func defaultComponentHealthSettings() componentHealthSettings {
+	return componentHealthSettings{
+		Enabled:                  false,
+		Path:                     "/status",
+		IncludePermanentErrors:   true,
+		IncludeRecoverableErrors: true,
+		RecoveryDuration:         5 * time.Minute,
+	}
+}
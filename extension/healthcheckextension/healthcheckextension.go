@@ -8,14 +8,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/healthcheck"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/status"
 )
 
 type healthCheckExtension struct {
@@ -26,9 +30,16 @@ type healthCheckExtension struct {
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
 	settings component.TelemetrySettings
+
+	aggregator *status.Aggregator
+	grpcServer *grpc.Server
+	grpcHealth *health.Server
+	grpcStopCh chan struct{}
+	grpcWG     sync.WaitGroup
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ extension.StatusWatcher = (*healthCheckExtension)(nil)
 
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
@@ -45,9 +56,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 
 	if !hc.config.CheckCollectorPipeline.Enabled {
 		// Mount HC handler
-		mux := http.NewServeMux()
-		mux.Handle(hc.config.Path, hc.baseHandler())
-		hc.server.Handler = mux
+		hc.server.Handler = hc.newMux(hc.baseHandler())
 		hc.stopCh = make(chan struct{})
 		go func() {
 			defer close(hc.stopCh)
@@ -70,9 +79,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		// ticker used by collector pipeline health check for rotation
 		ticker := time.NewTicker(time.Second)
 
-		mux := http.NewServeMux()
-		mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
-		hc.server.Handler = mux
+		hc.server.Handler = hc.newMux(hc.checkCollectorPipelineHandler())
 		hc.stopCh = make(chan struct{})
 		go func() {
 			defer close(hc.stopCh)
@@ -96,9 +103,23 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		}()
 	}
 
+	if hc.grpcHealth != nil {
+		return hc.startGRPCHealth(host)
+	}
 	return nil
 }
 
+// newMux mounts the given health check handler, and the component health
+// handler if enabled.
+func (hc *healthCheckExtension) newMux(handler http.Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle(hc.config.Path, handler)
+	if hc.config.ComponentHealth.Enabled {
+		mux.Handle(hc.config.ComponentHealth.Path, hc.componentHealthHandler())
+	}
+	return mux
+}
+
 // base handler function
 func (hc *healthCheckExtension) baseHandler() http.Handler {
 	if hc.config.ResponseBody != nil {
@@ -137,6 +158,12 @@ func (hc *healthCheckExtension) check() bool {
 }
 
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
+	if hc.grpcServer != nil {
+		close(hc.grpcStopCh)
+		hc.grpcHealth.Shutdown()
+		hc.grpcServer.Stop()
+		hc.grpcWG.Wait()
+	}
 	if hc.server == nil {
 		return nil
 	}
@@ -163,6 +190,11 @@ func newServer(config Config, settings component.TelemetrySettings) *healthCheck
 		logger:   settings.Logger,
 		state:    healthcheck.New(),
 		settings: settings,
+
+		aggregator: status.NewAggregator(),
+	}
+	if config.ComponentHealth.Enabled && config.ComponentHealth.GRPC != nil {
+		hc.grpcHealth = health.NewServer()
 	}
 
 	hc.state.SetLogger(settings.Logger)
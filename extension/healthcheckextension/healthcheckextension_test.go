@@ -5,6 +5,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"net/http"
@@ -17,7 +18,12 @@ import (
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 )
@@ -417,6 +423,80 @@ func TestHealthCheckShutdownWithoutStart(t *testing.T) {
 	require.NoError(t, hcExt.Shutdown(context.Background()))
 }
 
+func TestHealthCheckExtensionComponentHealth(t *testing.T) {
+	grpcEndpoint := testutil.GetAvailableLocalAddress(t)
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentHealth:        defaultComponentHealthSettings(),
+		Path:                   "/",
+	}
+	config.ComponentHealth.Enabled = true
+	config.ComponentHealth.IgnorePipelines = []string{"metrics/debug"}
+	config.ComponentHealth.GRPC = &configgrpc.GRPCServerSettings{
+		NetAddr: confignet.NetAddr{Endpoint: grpcEndpoint},
+	}
+
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+	require.NoError(t, hcExt.Start(context.Background(), newAssertNoErrorHost(t)))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(config.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	conn, err := grpc.Dial(grpcEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	healthClient := healthpb.NewHealthClient(conn)
+
+	traces := &component.InstanceID{
+		ID:          component.NewID("otlp"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{component.NewID("traces"): {}},
+	}
+	debug := &component.InstanceID{
+		ID:          component.NewID("debug"),
+		Kind:        component.KindExporter,
+		PipelineIDs: map[component.ID]struct{}{component.NewIDWithName("metrics", "debug"): {}},
+	}
+
+	assertHealth := func(query string, expectedStatusCode int, expectedBody string) {
+		resp, err := http.Get("http://" + config.Endpoint + config.ComponentHealth.Path + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, expectedStatusCode, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), expectedBody)
+	}
+	assertServing := func(service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+		resp, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp.Status)
+	}
+
+	assertHealth("", http.StatusServiceUnavailable, `"status":"none"`)
+	assertServing("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	hcExt.ComponentStatusChanged(traces, component.NewStatusEvent(component.StatusOK))
+	hcExt.ComponentStatusChanged(debug, component.NewPermanentErrorEvent(errors.New("invalid endpoint")))
+
+	assertHealth("", http.StatusOK, `"exporter:otlp":{"status":"ok","healthy":true`)
+	assertHealth("?pipeline=traces", http.StatusOK, `"status":"ok"`)
+	assertHealth("?pipeline=metrics/debug", http.StatusServiceUnavailable, `"error":"invalid endpoint"`)
+	assertHealth("?pipeline=logs", http.StatusNotFound, "unknown pipeline")
+	assertServing("", healthpb.HealthCheckResponse_SERVING)
+	assertServing("traces", healthpb.HealthCheckResponse_SERVING)
+	assertServing("metrics/debug", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// The base path is unaffected by the component health.
+	resp, err := http.Get("http://" + config.Endpoint + config.Path)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
 func viewData() *view.Data {
 	currentTime := time.Now()
 	vd := &view.Data{
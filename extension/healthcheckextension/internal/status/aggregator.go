@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package status aggregates the status reported by the components of the
+// collector into the health of their pipelines and of the collector.
+package status // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension/internal/status"
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// extensionsKey is the key the statuses of the extensions, which are not part
+// of any pipeline, are aggregated under.
+const extensionsKey = "extensions"
+
+// Rules are the rules deciding whether a component status is healthy.
+type Rules struct {
+	// IncludePermanentErrors makes the components reporting a permanent error unhealthy.
+	IncludePermanentErrors bool
+	// IncludeRecoverableErrors makes the components reporting a recoverable
+	// error unhealthy once the error has lasted for RecoveryDuration.
+	IncludeRecoverableErrors bool
+	RecoveryDuration         time.Duration
+	// IgnorePipelines are the pipelines not taken into account in the health
+	// of the collector.
+	IgnorePipelines map[string]struct{}
+}
+
+// healthy returns whether a component reporting the given event is healthy.
+func (r Rules) healthy(ev *component.StatusEvent, now time.Time) bool {
+	switch ev.Status() {
+	case component.StatusOK:
+		return true
+	case component.StatusPermanentError:
+		return !r.IncludePermanentErrors
+	case component.StatusRecoverableError:
+		return !r.IncludeRecoverableErrors || now.Sub(ev.Timestamp()) < r.RecoveryDuration
+	default:
+		// Components starting, stopping or failed are not able to process data.
+		return false
+	}
+}
+
+// ComponentStatus is the health of a component, or the aggregated health of
+// a group of components.
+type ComponentStatus struct {
+	Status     string    `json:"status"`
+	Healthy    bool      `json:"healthy"`
+	Error      string    `json:"error,omitempty"`
+	StatusTime time.Time `json:"status_time"`
+}
+
+// PipelineStatus is the health of a pipeline, aggregated from the status of
+// its components.
+type PipelineStatus struct {
+	ComponentStatus
+	Components map[string]*ComponentStatus `json:"components"`
+}
+
+// CollectorStatus is the health of the collector, aggregated from the status
+// of its pipelines and extensions.
+type CollectorStatus struct {
+	ComponentStatus
+	Pipelines  map[string]*PipelineStatus  `json:"pipelines"`
+	Extensions map[string]*ComponentStatus `json:"extensions,omitempty"`
+}
+
+// Aggregator keeps the last status reported by each component.
+type Aggregator struct {
+	mu sync.RWMutex
+	// Last status events by component, grouped by pipeline.
+	pipelines  map[string]map[string]*component.StatusEvent
+	extensions map[string]*component.StatusEvent
+}
+
+// NewAggregator creates an Aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		pipelines:  map[string]map[string]*component.StatusEvent{},
+		extensions: map[string]*component.StatusEvent{},
+	}
+}
+
+// RecordStatus records the status reported by a component.
+func (a *Aggregator) RecordStatus(source *component.InstanceID, event *component.StatusEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := componentKey(source)
+	if source.Kind == component.KindExtension {
+		a.extensions[key] = event
+		return
+	}
+	for pipelineID := range source.PipelineIDs {
+		pipeline, ok := a.pipelines[pipelineID.String()]
+		if !ok {
+			pipeline = map[string]*component.StatusEvent{}
+			a.pipelines[pipelineID.String()] = pipeline
+		}
+		pipeline[key] = event
+	}
+}
+
+// Collector returns the health of the collector and of its pipelines
+// according to the given rules.
+func (a *Aggregator) Collector(rules Rules, now time.Time) *CollectorStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	st := &CollectorStatus{
+		Pipelines:  make(map[string]*PipelineStatus, len(a.pipelines)),
+		Extensions: make(map[string]*ComponentStatus, len(a.extensions)),
+	}
+	groups := map[string]*ComponentStatus{}
+	for name, events := range a.pipelines {
+		pst := &PipelineStatus{Components: make(map[string]*ComponentStatus, len(events))}
+		for key, ev := range events {
+			pst.Components[key] = newComponentStatus(ev, rules, now)
+		}
+		pst.ComponentStatus = aggregate(pst.Components)
+		st.Pipelines[name] = pst
+		if _, ignored := rules.IgnorePipelines[name]; !ignored {
+			groups[name] = &pst.ComponentStatus
+		}
+	}
+	for key, ev := range a.extensions {
+		st.Extensions[key] = newComponentStatus(ev, rules, now)
+	}
+	if len(st.Extensions) > 0 {
+		extensions := aggregate(st.Extensions)
+		groups[extensionsKey] = &extensions
+	}
+	st.ComponentStatus = aggregate(groups)
+	return st
+}
+
+func newComponentStatus(ev *component.StatusEvent, rules Rules, now time.Time) *ComponentStatus {
+	st := &ComponentStatus{
+		Status:     statusName(ev.Status()),
+		Healthy:    rules.healthy(ev, now),
+		StatusTime: ev.Timestamp(),
+	}
+	if ev.Err() != nil {
+		st.Error = ev.Err().Error()
+	}
+	return st
+}
+
+// aggregate returns the most severe status of the given ones, being healthy
+// only if all of them are healthy. An empty group is not healthy, no
+// component having reported being able to process data yet.
+func aggregate(statuses map[string]*ComponentStatus) ComponentStatus {
+	keys := make([]string, 0, len(statuses))
+	for key := range statuses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	agg := ComponentStatus{Status: statusName(component.StatusNone), Healthy: len(keys) > 0}
+	for _, key := range keys {
+		st := statuses[key]
+		agg.Healthy = agg.Healthy && st.Healthy
+		if st.StatusTime.After(agg.StatusTime) {
+			agg.StatusTime = st.StatusTime
+		}
+		if severity(st.Status) > severity(agg.Status) {
+			agg.Status = st.Status
+			agg.Error = st.Error
+		}
+	}
+	return agg
+}
+
+// statusSeverity orders the statuses from the least to the most severe.
+var statusSeverity = []component.Status{
+	component.StatusNone,
+	component.StatusOK,
+	component.StatusStarting,
+	component.StatusStopped,
+	component.StatusStopping,
+	component.StatusRecoverableError,
+	component.StatusPermanentError,
+	component.StatusFatalError,
+}
+
+func severity(name string) int {
+	for i, s := range statusSeverity {
+		if statusName(s) == name {
+			return i
+		}
+	}
+	return 0
+}
+
+func statusName(s component.Status) string {
+	switch s {
+	case component.StatusStarting:
+		return "starting"
+	case component.StatusOK:
+		return "ok"
+	case component.StatusRecoverableError:
+		return "recoverable_error"
+	case component.StatusPermanentError:
+		return "permanent_error"
+	case component.StatusFatalError:
+		return "fatal_error"
+	case component.StatusStopping:
+		return "stopping"
+	case component.StatusStopped:
+		return "stopped"
+	default:
+		return "none"
+	}
+}
+
+func componentKey(source *component.InstanceID) string {
+	var kind string
+	switch source.Kind {
+	case component.KindReceiver:
+		kind = "receiver"
+	case component.KindProcessor:
+		kind = "processor"
+	case component.KindExporter:
+		kind = "exporter"
+	case component.KindExtension:
+		kind = "extension"
+	case component.KindConnector:
+		kind = "connector"
+	}
+	return kind + ":" + source.ID.String()
+}
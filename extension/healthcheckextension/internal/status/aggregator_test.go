@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	tracesID  = component.NewID("traces")
+	metricsID = component.NewIDWithName("metrics", "debug")
+)
+
+func newInstanceID(kind component.Kind, id string, pipelines ...component.ID) *component.InstanceID {
+	instanceID := &component.InstanceID{
+		ID:          component.NewID(component.Type(id)),
+		Kind:        kind,
+		PipelineIDs: map[component.ID]struct{}{},
+	}
+	for _, p := range pipelines {
+		instanceID.PipelineIDs[p] = struct{}{}
+	}
+	return instanceID
+}
+
+func defaultRules() Rules {
+	return Rules{
+		IncludePermanentErrors:   true,
+		IncludeRecoverableErrors: true,
+		RecoveryDuration:         time.Minute,
+	}
+}
+
+func TestAggregatorEmpty(t *testing.T) {
+	st := NewAggregator().Collector(defaultRules(), time.Now())
+	assert.False(t, st.Healthy)
+	assert.Equal(t, "none", st.Status)
+	assert.Empty(t, st.Pipelines)
+}
+
+func TestAggregatorPipelines(t *testing.T) {
+	agg := NewAggregator()
+	otlp := newInstanceID(component.KindReceiver, "otlp", tracesID, metricsID)
+	exporter := newInstanceID(component.KindExporter, "otlp", tracesID)
+	debug := newInstanceID(component.KindExporter, "debug", metricsID)
+	ext := newInstanceID(component.KindExtension, "health_check")
+
+	for _, id := range []*component.InstanceID{otlp, exporter, debug, ext} {
+		agg.RecordStatus(id, component.NewStatusEvent(component.StatusStarting))
+	}
+	st := agg.Collector(defaultRules(), time.Now())
+	assert.False(t, st.Healthy)
+	assert.Equal(t, "starting", st.Status)
+
+	for _, id := range []*component.InstanceID{otlp, exporter, debug, ext} {
+		agg.RecordStatus(id, component.NewStatusEvent(component.StatusOK))
+	}
+	st = agg.Collector(defaultRules(), time.Now())
+	assert.True(t, st.Healthy)
+	assert.Equal(t, "ok", st.Status)
+	require.Len(t, st.Pipelines, 2)
+	assert.Len(t, st.Pipelines["traces"].Components, 2)
+	assert.Contains(t, st.Pipelines["metrics/debug"].Components, "exporter:debug")
+	assert.Contains(t, st.Extensions, "extension:health_check")
+
+	agg.RecordStatus(debug, component.NewPermanentErrorEvent(errors.New("invalid endpoint")))
+	st = agg.Collector(defaultRules(), time.Now())
+	assert.False(t, st.Healthy)
+	assert.Equal(t, "permanent_error", st.Status)
+	assert.Equal(t, "invalid endpoint", st.Error)
+	assert.True(t, st.Pipelines["traces"].Healthy)
+	assert.False(t, st.Pipelines["metrics/debug"].Healthy)
+
+	rules := defaultRules()
+	rules.IgnorePipelines = map[string]struct{}{"metrics/debug": {}}
+	st = agg.Collector(rules, time.Now())
+	assert.True(t, st.Healthy)
+	assert.Equal(t, "ok", st.Status)
+	assert.False(t, st.Pipelines["metrics/debug"].Healthy)
+
+	rules = defaultRules()
+	rules.IncludePermanentErrors = false
+	st = agg.Collector(rules, time.Now())
+	assert.True(t, st.Healthy)
+	assert.Equal(t, "permanent_error", st.Status)
+}
+
+func TestAggregatorRecoverableErrors(t *testing.T) {
+	agg := NewAggregator()
+	exporter := newInstanceID(component.KindExporter, "otlp", tracesID)
+	agg.RecordStatus(exporter, component.NewRecoverableErrorEvent(errors.New("connection refused")))
+
+	now := time.Now()
+	st := agg.Collector(defaultRules(), now)
+	assert.True(t, st.Healthy)
+	assert.Equal(t, "recoverable_error", st.Status)
+
+	st = agg.Collector(defaultRules(), now.Add(2*time.Minute))
+	assert.False(t, st.Healthy)
+
+	rules := defaultRules()
+	rules.IncludeRecoverableErrors = false
+	st = agg.Collector(rules, now.Add(2*time.Minute))
+	assert.True(t, st.Healthy)
+
+	agg.RecordStatus(exporter, component.NewStatusEvent(component.StatusOK))
+	st = agg.Collector(defaultRules(), now.Add(2*time.Minute))
+	assert.True(t, st.Healthy)
+	assert.Equal(t, "ok", st.Status)
+	assert.Empty(t, st.Error)
+}
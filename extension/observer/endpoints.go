@@ -31,6 +31,8 @@ const (
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
 	ContainerType EndpointType = "container"
+	// InventoryType is an endpoint defined in an inventory.
+	InventoryType EndpointType = "inventory"
 )
 
 var (
@@ -40,6 +42,7 @@ var (
 	_ EndpointDetails = (*K8sNode)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
+	_ EndpointDetails = (*Inventory)(nil)
 )
 
 // EndpointDetails provides additional context about an endpoint such as a Pod or Port.
@@ -299,3 +302,32 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// Inventory is an endpoint defined in an inventory, such as the file read by
+// the file observer, rather than discovered from a runtime.
+type Inventory struct {
+	// Name of the endpoint.
+	Name string
+	// Host is the hostname/ip address of the Endpoint.
+	Host string
+	// Port number of the endpoint, if any.
+	Port uint16
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+	// Labels is a map of user-specified metadata on the endpoint.
+	Labels map[string]string
+}
+
+func (i *Inventory) Env() EndpointEnv {
+	return map[string]any{
+		"name":      i.Name,
+		"host":      i.Host,
+		"port":      i.Port,
+		"transport": i.Transport,
+		"labels":    i.Labels,
+	}
+}
+
+func (i *Inventory) Type() EndpointType {
+	return InventoryType
+}
@@ -230,6 +230,34 @@ func TestEndpointEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Inventory",
+			endpoint: Endpoint{
+				ID:     EndpointID("inventory_endpoint_id"),
+				Target: "db.example.com:5432",
+				Details: &Inventory{
+					Name:      "orders-db",
+					Host:      "db.example.com",
+					Port:      5432,
+					Transport: ProtocolTCP,
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":      "inventory",
+				"id":        "inventory_endpoint_id",
+				"endpoint":  "db.example.com:5432",
+				"name":      "orders-db",
+				"host":      "db.example.com",
+				"port":      uint16(5432),
+				"transport": ProtocolTCP,
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"errors"
+	"time"
+)
+
+const defaultRefreshInterval = 10 * time.Second
+
+// Config defines configuration for file observer.
+type Config struct {
+	// Path is the path of the YAML or JSON file defining the endpoints.
+	Path string `mapstructure:"path"`
+
+	// RefreshInterval determines how often the file is read for changes in endpoints.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be specified")
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package fileobserver provides an observer reporting the endpoints defined
+// in a file, such as an inventory synced from a CMDB or a mounted Kubernetes
+// ConfigMap.
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+var _ extension.Extension = (*fileObserver)(nil)
+var _ observer.EndpointsLister = (*fileObserver)(nil)
+var _ observer.Observable = (*fileObserver)(nil)
+
+type fileObserver struct {
+	*observer.EndpointsWatcher
+	config   *Config
+	idPrefix string
+	logger   *zap.Logger
+
+	mu sync.Mutex
+	// endpoints last read from the file, kept when the file is invalid so
+	// that a partially written file does not remove the endpoints.
+	endpoints []observer.Endpoint
+}
+
+func newObserver(params extension.CreateSettings, config *Config) *fileObserver {
+	o := &fileObserver{
+		config:   config,
+		idPrefix: params.ID.String(),
+		logger:   params.Logger,
+	}
+	o.EndpointsWatcher = observer.NewEndpointsWatcher(o, config.RefreshInterval, params.Logger)
+	return o
+}
+
+func (o *fileObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (o *fileObserver) Shutdown(context.Context) error {
+	o.StopListAndWatch()
+	return nil
+}
+
+// ListEndpoints is invoked by an observer.EndpointsWatcher helper to report the endpoints
+// defined in the file. It's required to implement observer.EndpointsLister
+func (o *fileObserver) ListEndpoints() []observer.Endpoint {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	content, err := os.ReadFile(filepath.Clean(o.config.Path))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// The file not being there, for instance before its first sync, means there are no endpoints.
+		o.logger.Debug("endpoints file does not exist", zap.String("path", o.config.Path))
+		o.endpoints = nil
+	case err != nil:
+		o.logger.Warn("failed to read endpoints file", zap.String("path", o.config.Path), zap.Error(err))
+	default:
+		endpoints, parseErr := parseInventory(content, o.idPrefix)
+		if parseErr != nil {
+			o.logger.Warn("invalid endpoints file, keeping the previous endpoints", zap.String("path", o.config.Path), zap.Error(parseErr))
+			break
+		}
+		o.endpoints = endpoints
+	}
+	return o.endpoints
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver/internal/metadata"
+)
+
+type mockNotifier struct {
+	mu      sync.Mutex
+	added   []observer.Endpoint
+	removed []observer.Endpoint
+	changed []observer.Endpoint
+}
+
+func (m *mockNotifier) ID() observer.NotifyID {
+	return "mockNotifier"
+}
+
+func (m *mockNotifier) OnAdd(added []observer.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.added = append(m.added, added...)
+}
+
+func (m *mockNotifier) OnRemove(removed []observer.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.removed = append(m.removed, removed...)
+}
+
+func (m *mockNotifier) OnChange(changed []observer.Endpoint) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.changed = append(m.changed, changed...)
+}
+
+func (m *mockNotifier) counts() (int, int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.added), len(m.removed), len(m.changed)
+}
+
+func newTestObserver(t *testing.T, path string) *fileObserver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = path
+	cfg.RefreshInterval = 10 * time.Millisecond
+	settings := extensiontest.NewNopCreateSettings()
+	settings.ID = component.NewID(metadata.Type)
+	o := newObserver(settings, cfg)
+	require.NoError(t, o.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, o.Shutdown(context.Background()))
+	})
+	return o
+}
+
+func writeEndpoints(t *testing.T, path string, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestListEndpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	o := newTestObserver(t, path)
+
+	// A missing file defines no endpoints
+	assert.Empty(t, o.ListEndpoints())
+
+	writeEndpoints(t, path, "endpoints: [{id: db, host: db.example.com, port: 5432}]")
+	endpoints := o.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, observer.EndpointID("file_observer/db"), endpoints[0].ID)
+
+	// An invalid file keeps the previous endpoints
+	writeEndpoints(t, path, "endpoints: [{id: db, host: db.exa")
+	assert.Equal(t, endpoints, o.ListEndpoints())
+
+	require.NoError(t, os.Remove(path))
+	assert.Empty(t, o.ListEndpoints())
+}
+
+func TestListAndWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	writeEndpoints(t, path, "endpoints: [{id: db, host: db.example.com, port: 5432}, {id: web, host: web.example.com}]")
+	o := newTestObserver(t, path)
+
+	notifier := &mockNotifier{}
+	o.ListAndWatch(notifier)
+	defer o.Unsubscribe(notifier)
+
+	added, _, _ := notifier.counts()
+	assert.Equal(t, 2, added)
+
+	writeEndpoints(t, path, "endpoints: [{id: db, host: db.example.com, port: 5432, labels: {env: prod}}]")
+	require.Eventually(t, func() bool {
+		_, removed, changed := notifier.counts()
+		return removed == 1 && changed == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	assert.Equal(t, observer.EndpointID("file_observer/web"), notifier.removed[0].ID)
+	assert.Equal(t, map[string]string{"env": "prod"}, notifier.changed[0].Details.(*observer.Inventory).Labels)
+}
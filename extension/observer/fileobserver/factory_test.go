@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestValidConfig(t *testing.T) {
+	err := componenttest.CheckConfigStruct(createDefaultConfig())
+	require.NoError(t, err)
+}
+
+func TestCreateExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = "endpoints.yaml"
+	fileObserver, err := createExtension(
+		context.Background(),
+		extensiontest.NewNopCreateSettings(),
+		cfg,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, fileObserver)
+}
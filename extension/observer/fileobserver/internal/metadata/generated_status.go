@@ -0,0 +1,12 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	Type               = "file_observer"
+	ExtensionStability = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/fileobserver"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// inventory is the content of the file defining the endpoints.
+type inventory struct {
+	Endpoints []inventoryEndpoint `yaml:"endpoints"`
+}
+
+// inventoryEndpoint is an endpoint defined in the file.
+type inventoryEndpoint struct {
+	// ID uniquely identifies the endpoint in the file.
+	ID string `yaml:"id"`
+	// Name of the endpoint, defaulting to its ID.
+	Name string `yaml:"name"`
+	// Host is the hostname or IP address of the endpoint.
+	Host string `yaml:"host"`
+	// Port is the port number of the endpoint, if any.
+	Port uint16 `yaml:"port"`
+	// Transport is the transport protocol of the endpoint, TCP or UDP.
+	Transport string `yaml:"transport"`
+	// Labels are arbitrary metadata, usable in receiver creator rules.
+	Labels map[string]string `yaml:"labels"`
+}
+
+// parseInventory parses the YAML or JSON content of the file into endpoints,
+// whose IDs are prefixed with the given observer ID.
+func parseInventory(content []byte, idPrefix string) ([]observer.Endpoint, error) {
+	var inv inventory
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&inv); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	endpoints := make([]observer.Endpoint, 0, len(inv.Endpoints))
+	seen := make(map[string]struct{}, len(inv.Endpoints))
+	for i, e := range inv.Endpoints {
+		if e.ID == "" {
+			return nil, fmt.Errorf("endpoint %d: id must be specified", i)
+		}
+		if _, ok := seen[e.ID]; ok {
+			return nil, fmt.Errorf("endpoint %q: id is duplicated", e.ID)
+		}
+		seen[e.ID] = struct{}{}
+		if e.Host == "" {
+			return nil, fmt.Errorf("endpoint %q: host must be specified", e.ID)
+		}
+		transport, err := parseTransport(e.Transport)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %q: %w", e.ID, err)
+		}
+
+		name := e.Name
+		if name == "" {
+			name = e.ID
+		}
+		target := e.Host
+		if e.Port != 0 {
+			target = net.JoinHostPort(e.Host, strconv.Itoa(int(e.Port)))
+		}
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:     observer.EndpointID(fmt.Sprintf("%s/%s", idPrefix, e.ID)),
+			Target: target,
+			Details: &observer.Inventory{
+				Name:      name,
+				Host:      e.Host,
+				Port:      e.Port,
+				Transport: transport,
+				Labels:    e.Labels,
+			},
+		})
+	}
+	return endpoints, nil
+}
+
+func parseTransport(transport string) (observer.Transport, error) {
+	switch strings.ToUpper(transport) {
+	case "", string(observer.ProtocolTCP):
+		return observer.ProtocolTCP, nil
+	case string(observer.ProtocolUDP):
+		return observer.ProtocolUDP, nil
+	default:
+		return "", fmt.Errorf("unsupported transport %q", transport)
+	}
+}
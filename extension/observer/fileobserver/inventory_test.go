@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileobserver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestParseInventory(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "endpoints.yaml"))
+	require.NoError(t, err)
+
+	endpoints, err := parseInventory(content, "file_observer")
+	require.NoError(t, err)
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     "file_observer/orders-db",
+			Target: "10.0.0.12:5432",
+			Details: &observer.Inventory{
+				Name:      "orders-db",
+				Host:      "10.0.0.12",
+				Port:      5432,
+				Transport: observer.ProtocolTCP,
+				Labels:    map[string]string{"service": "postgres", "team": "payments"},
+			},
+		},
+		{
+			ID:     "file_observer/syslog-relay",
+			Target: "relay.example.com:514",
+			Details: &observer.Inventory{
+				Name:      "Syslog relay",
+				Host:      "relay.example.com",
+				Port:      514,
+				Transport: observer.ProtocolUDP,
+			},
+		},
+		{
+			ID:     "file_observer/ipv6-host",
+			Target: "2001:db8::1",
+			Details: &observer.Inventory{
+				Name:      "ipv6-host",
+				Host:      "2001:db8::1",
+				Transport: observer.ProtocolTCP,
+			},
+		},
+	}, endpoints)
+}
+
+func TestParseInventoryJSON(t *testing.T) {
+	endpoints, err := parseInventory([]byte(`{"endpoints": [{"id": "web", "host": "web.example.com", "port": 8080}]}`), "file_observer")
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "web.example.com:8080", endpoints[0].Target)
+}
+
+func TestParseInventoryEmpty(t *testing.T) {
+	endpoints, err := parseInventory(nil, "file_observer")
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+}
+
+func TestParseInventoryErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "missing id",
+			content:     "endpoints: [{host: a}]",
+			expectedErr: "endpoint 0: id must be specified",
+		},
+		{
+			name:        "duplicated id",
+			content:     "endpoints: [{id: a, host: a}, {id: a, host: b}]",
+			expectedErr: `endpoint "a": id is duplicated`,
+		},
+		{
+			name:        "missing host",
+			content:     "endpoints: [{id: a}]",
+			expectedErr: `endpoint "a": host must be specified`,
+		},
+		{
+			name:        "invalid transport",
+			content:     "endpoints: [{id: a, host: a, transport: sctp}]",
+			expectedErr: `endpoint "a": unsupported transport "sctp"`,
+		},
+		{
+			name:        "unknown field",
+			content:     "endpoints: [{id: a, hostname: a}]",
+			expectedErr: "field hostname not found",
+		},
+		{
+			name:        "invalid port",
+			content:     "endpoints: [{id: a, host: a, port: 70000}]",
+			expectedErr: "cannot unmarshal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseInventory([]byte(tt.content), "file_observer")
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
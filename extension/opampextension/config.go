@@ -23,11 +23,18 @@ type Config struct {
 
 	// Capabilities contains options to enable a particular OpAMP capability
 	Capabilities Capabilities `mapstructure:"capabilities"`
+
+	// RemoteConfigDirectory is the directory where the remote config accepted
+	// from the OpAMP server is persisted, to be loaded by the opamp confmap
+	// provider. Required if the AcceptsRemoteConfig capability is enabled.
+	RemoteConfigDirectory string `mapstructure:"remote_config_directory"`
 }
 
 type Capabilities struct {
 	// ReportsEffectiveConfig enables the OpAMP ReportsEffectiveConfig Capability. (default: true)
 	ReportsEffectiveConfig bool `mapstructure:"reports_effective_config"`
+	// AcceptsRemoteConfig enables the OpAMP AcceptsRemoteConfig and ReportsRemoteConfig Capabilities. (default: false)
+	AcceptsRemoteConfig bool `mapstructure:"accepts_remote_config"`
 }
 
 func (caps Capabilities) toAgentCapabilities() protobufs.AgentCapabilities {
@@ -38,6 +45,11 @@ func (caps Capabilities) toAgentCapabilities() protobufs.AgentCapabilities {
 		agentCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig
 	}
 
+	if caps.AcceptsRemoteConfig {
+		agentCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig
+		agentCapabilities |= protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig
+	}
+
 	return agentCapabilities
 }
 
@@ -66,5 +78,9 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.Capabilities.AcceptsRemoteConfig && cfg.RemoteConfigDirectory == "" {
+		return errors.New("opamp remote_config_directory must be provided to accept remote config")
+	}
+
 	return nil
 }
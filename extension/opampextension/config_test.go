@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -57,4 +58,26 @@ func TestConfigValidate(t *testing.T) {
 	assert.Equal(t, "opamp instance_uid is invalid", err.Error())
 	cfg.InstanceUID = "01BX5ZZKBKACTAV9WEVGEMMVRZ"
 	require.NoError(t, cfg.Validate())
+	cfg.Capabilities.AcceptsRemoteConfig = true
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, "opamp remote_config_directory must be provided to accept remote config", err.Error())
+	cfg.RemoteConfigDirectory = "/var/lib/otelcol/opamp"
+	require.NoError(t, cfg.Validate())
+}
+
+func TestCapabilities(t *testing.T) {
+	caps := Capabilities{ReportsEffectiveConfig: true}
+	assert.Equal(t,
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus|
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig,
+		caps.toAgentCapabilities())
+
+	caps.AcceptsRemoteConfig = true
+	assert.Equal(t,
+		protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus|
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig|
+			protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig|
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig,
+		caps.toAgentCapabilities())
 }
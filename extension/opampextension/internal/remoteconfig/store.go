@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remoteconfig persists the remote config accepted from the OpAMP
+// server by the opamp extension, to be loaded by the opamp confmap provider.
+package remoteconfig // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension/internal/remoteconfig"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// ConfigFileName is the name of the file holding the remote config.
+	ConfigFileName   = "remote_config.yaml"
+	lastGoodFileName = "last_good_remote_config.yaml"
+	statusFileName   = "remote_config_status.json"
+)
+
+// State is the state of the rollout of a remote config.
+type State string
+
+const (
+	// StateApplying is the state of a remote config written to be loaded by
+	// the Collector.
+	StateApplying State = "applying"
+	// StateApplied is the state of a remote config the Collector started with.
+	StateApplied State = "applied"
+	// StateFailed is the state of a remote config the Collector did not start
+	// with, the last good remote config being restored.
+	StateFailed State = "failed"
+)
+
+// Status is the status of the last remote config received from the OpAMP server.
+type Status struct {
+	Hash         []byte `json:"hash"`
+	State        State  `json:"state"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// Store persists the remote config in a directory.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a Store persisting the remote config in dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the directory the remote config is persisted in.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Config returns the persisted remote config, or nil if there is none.
+func (s *Store) Config() ([]byte, error) {
+	return readFile(filepath.Join(s.dir, ConfigFileName))
+}
+
+// Status returns the status of the persisted remote config, or nil if there
+// is none.
+func (s *Store) Status() (*Status, error) {
+	data, err := readFile(filepath.Join(s.dir, statusFileName))
+	if err != nil || data == nil {
+		return nil, err
+	}
+	status := &Status{}
+	if err = json.Unmarshal(data, status); err != nil {
+		return nil, fmt.Errorf("cannot parse remote config status: %w", err)
+	}
+	return status, nil
+}
+
+// SetStatus persists the status of the remote config.
+func (s *Store) SetStatus(status *Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(filepath.Join(s.dir, statusFileName), data)
+}
+
+// Apply persists a new remote config with the applying state, keeping the
+// previous one if it was applied to restore it if needed. It returns false if
+// the config is the same as the persisted one, in which case it is applied
+// right away.
+func (s *Store) Apply(config []byte, hash []byte) (bool, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return false, err
+	}
+
+	current, err := s.Config()
+	if err != nil {
+		return false, err
+	}
+	status, err := s.Status()
+	if err != nil {
+		return false, err
+	}
+
+	if current != nil && bytes.Equal(current, config) {
+		return false, s.SetStatus(&Status{Hash: hash, State: StateApplied})
+	}
+
+	if current != nil && (status == nil || status.State == StateApplied) {
+		if err = writeFileAtomically(filepath.Join(s.dir, lastGoodFileName), current); err != nil {
+			return false, err
+		}
+	}
+	// The status is written first so that the new config is never loaded
+	// without being known as being applied.
+	if err = s.SetStatus(&Status{Hash: hash, State: StateApplying}); err != nil {
+		return false, err
+	}
+	return true, writeFileAtomically(filepath.Join(s.dir, ConfigFileName), config)
+}
+
+// Revert restores the last good remote config, if any, and marks the remote
+// config being applied as failed.
+func (s *Store) Revert(status *Status, reason string) error {
+	lastGood, err := readFile(filepath.Join(s.dir, lastGoodFileName))
+	if err != nil {
+		return err
+	}
+	if lastGood != nil {
+		err = writeFileAtomically(filepath.Join(s.dir, ConfigFileName), lastGood)
+	} else {
+		err = os.Remove(filepath.Join(s.dir, ConfigFileName))
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return s.SetStatus(&Status{Hash: status.Hash, State: StateFailed, ErrorMessage: reason})
+}
+
+func readFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func writeFileAtomically(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package remoteconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreEmpty(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "opamp"))
+
+	config, err := store.Config()
+	require.NoError(t, err)
+	assert.Nil(t, config)
+
+	status, err := store.Status()
+	require.NoError(t, err)
+	assert.Nil(t, status)
+}
+
+func TestStoreApply(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "opamp"))
+
+	changed, err := store.Apply([]byte("receivers: {}\n"), []byte{1})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assertConfig(t, store, "receivers: {}\n")
+	assertStatus(t, store, &Status{Hash: []byte{1}, State: StateApplying})
+
+	// The same config is applied right away.
+	changed, err = store.Apply([]byte("receivers: {}\n"), []byte{2})
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assertConfig(t, store, "receivers: {}\n")
+	assertStatus(t, store, &Status{Hash: []byte{2}, State: StateApplied})
+
+	changed, err = store.Apply([]byte("exporters: {}\n"), []byte{3})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assertConfig(t, store, "exporters: {}\n")
+	assertStatus(t, store, &Status{Hash: []byte{3}, State: StateApplying})
+
+	lastGood, err := os.ReadFile(filepath.Join(store.Dir(), lastGoodFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "receivers: {}\n", string(lastGood))
+}
+
+func TestStoreRevert(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "opamp"))
+
+	_, err := store.Apply([]byte("receivers: {}\n"), []byte{1})
+	require.NoError(t, err)
+	require.NoError(t, store.SetStatus(&Status{Hash: []byte{1}, State: StateApplied}))
+	_, err = store.Apply([]byte("exporters: {}\n"), []byte{2})
+	require.NoError(t, err)
+
+	status, err := store.Status()
+	require.NoError(t, err)
+	require.NoError(t, store.Revert(status, "failed"))
+	assertConfig(t, store, "receivers: {}\n")
+	assertStatus(t, store, &Status{Hash: []byte{2}, State: StateFailed, ErrorMessage: "failed"})
+
+	// A config which was not applied is not kept to be restored.
+	_, err = store.Apply([]byte("processors: {}\n"), []byte{3})
+	require.NoError(t, err)
+	require.NoError(t, store.SetStatus(&Status{Hash: []byte{3}, State: StateFailed}))
+	_, err = store.Apply([]byte("extensions: {}\n"), []byte{4})
+	require.NoError(t, err)
+	status, err = store.Status()
+	require.NoError(t, err)
+	require.NoError(t, store.Revert(status, "failed"))
+	assertConfig(t, store, "receivers: {}\n")
+}
+
+func TestStoreRevertWithoutLastGoodConfig(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "opamp"))
+
+	_, err := store.Apply([]byte("receivers: {}\n"), []byte{1})
+	require.NoError(t, err)
+	status, err := store.Status()
+	require.NoError(t, err)
+	require.NoError(t, store.Revert(status, "failed"))
+
+	config, err := store.Config()
+	require.NoError(t, err)
+	assert.Nil(t, config)
+	assertStatus(t, store, &Status{Hash: []byte{1}, State: StateFailed, ErrorMessage: "failed"})
+}
+
+func assertConfig(t *testing.T, store *Store, expected string) {
+	config, err := store.Config()
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(config))
+}
+
+func assertStatus(t *testing.T, store *Store, expected *Status) {
+	status, err := store.Status()
+	require.NoError(t, err)
+	assert.Equal(t, expected, status)
+}
@@ -5,9 +5,11 @@ package opampextension // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"runtime"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -21,6 +23,8 @@ import (
 	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension/internal/remoteconfig"
 )
 
 type opampAgent struct {
@@ -39,6 +43,10 @@ type opampAgent struct {
 
 	agentDescription *protobufs.AgentDescription
 
+	// remoteConfigStore persists the accepted remote config, nil if the
+	// AcceptsRemoteConfig capability is disabled.
+	remoteConfigStore *remoteconfig.Store
+
 	opampClient client.OpAMPClient
 }
 
@@ -79,6 +87,14 @@ func (o *opampAgent) Start(_ context.Context, _ component.Host) error {
 		Capabilities: o.capabilities.toAgentCapabilities(),
 	}
 
+	if o.remoteConfigStore != nil {
+		status, err := o.loadRemoteConfigStatus()
+		if err != nil {
+			return err
+		}
+		settings.RemoteConfigStatus = status
+	}
+
 	if err := o.createAgentDescription(); err != nil {
 		return err
 	}
@@ -165,6 +181,10 @@ func newOpampAgent(cfg *Config, logger *zap.Logger, build component.BuildInfo, r
 		capabilities: cfg.Capabilities,
 	}
 
+	if cfg.Capabilities.AcceptsRemoteConfig {
+		agent.remoteConfigStore = remoteconfig.NewStore(cfg.RemoteConfigDirectory)
+	}
+
 	return agent, nil
 }
 
@@ -234,15 +254,105 @@ func (o *opampAgent) composeEffectiveConfig() *protobufs.EffectiveConfig {
 }
 
 func (o *opampAgent) onMessage(_ context.Context, msg *types.MessageData) {
-	if msg.AgentIdentification == nil {
-		return
+	if msg.AgentIdentification != nil {
+		instanceID, err := ulid.Parse(msg.AgentIdentification.NewInstanceUid)
+		if err != nil {
+			o.logger.Error("Failed to parse a new agent identity", zap.Error(err))
+		} else {
+			o.updateAgentIdentity(instanceID)
+		}
 	}
 
-	instanceID, err := ulid.Parse(msg.AgentIdentification.NewInstanceUid)
-	if err != nil {
-		o.logger.Error("Failed to parse a new agent identity", zap.Error(err))
+	if msg.RemoteConfig != nil {
+		o.applyRemoteConfig(msg.RemoteConfig)
+	}
+}
+
+// applyRemoteConfig persists the remote config for the opamp confmap provider
+// to load it, which makes the Collector reload its config.
+func (o *opampAgent) applyRemoteConfig(remoteConfig *protobufs.AgentRemoteConfig) {
+	if o.remoteConfigStore == nil {
+		o.logger.Debug("Ignoring remote config since the AcceptsRemoteConfig capability is disabled")
 		return
 	}
 
-	o.updateAgentIdentity(instanceID)
+	status := &protobufs.RemoteConfigStatus{LastRemoteConfigHash: remoteConfig.ConfigHash}
+	var changed bool
+	conf, err := composeRemoteConfig(remoteConfig)
+	if err == nil {
+		changed, err = o.remoteConfigStore.Apply(conf, remoteConfig.ConfigHash)
+	}
+	switch {
+	case err != nil:
+		o.logger.Error("Failed to apply the remote config", zap.Error(err))
+		status.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+		status.ErrorMessage = err.Error()
+	case changed:
+		// The status is updated once the Collector is reloaded with the config.
+		o.logger.Info("Remote config received, reloading the Collector")
+		status.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING
+	default:
+		status.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}
+
+	if err = o.opampClient.SetRemoteConfigStatus(status); err != nil {
+		o.logger.Error("Failed to report the remote config status", zap.Error(err))
+	}
+}
+
+// loadRemoteConfigStatus returns the status of the persisted remote config.
+// A remote config being applied is applied once the extension is started,
+// the Collector having started with it.
+func (o *opampAgent) loadRemoteConfigStatus() (*protobufs.RemoteConfigStatus, error) {
+	status, err := o.remoteConfigStore.Status()
+	if err != nil || status == nil {
+		return nil, err
+	}
+
+	if status.State == remoteconfig.StateApplying {
+		status.State = remoteconfig.StateApplied
+		if err = o.remoteConfigStore.SetStatus(status); err != nil {
+			return nil, err
+		}
+	}
+
+	remoteConfigStatus := &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: status.Hash,
+		ErrorMessage:         status.ErrorMessage,
+	}
+	switch status.State {
+	case remoteconfig.StateApplied:
+		remoteConfigStatus.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	case remoteconfig.StateFailed:
+		remoteConfigStatus.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+	}
+	return remoteConfigStatus, nil
+}
+
+// composeRemoteConfig merges the config files of a remote config, the instance
+// config having an empty name being merged last.
+func composeRemoteConfig(remoteConfig *protobufs.AgentRemoteConfig) ([]byte, error) {
+	configMap := remoteConfig.GetConfig().GetConfigMap()
+	names := make([]string, 0, len(configMap))
+	for name := range configMap {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := configMap[""]; ok {
+		names = append(names, "")
+	}
+
+	conf := confmap.New()
+	for _, name := range names {
+		var raw map[string]any
+		if err := yaml.Unmarshal(configMap[name].GetBody(), &raw); err != nil {
+			return nil, fmt.Errorf("cannot parse config named %q: %w", name, err)
+		}
+		if err := conf.Merge(confmap.NewFromStringMap(raw)); err != nil {
+			return nil, fmt.Errorf("cannot merge config named %q: %w", name, err)
+		}
+	}
+	return yaml.Marshal(conf.ToStringMap())
 }
@@ -10,12 +10,18 @@ import (
 	"testing"
 
 	"github.com/oklog/ulid/v2"
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension/internal/remoteconfig"
 )
 
 func TestNewOpampAgent(t *testing.T) {
@@ -114,3 +120,129 @@ func TestStart(t *testing.T) {
 
 	assert.NoError(t, o.Start(context.TODO(), componenttest.NewNopHost()))
 }
+
+type mockOpAMPClient struct {
+	client.OpAMPClient
+	remoteConfigStatuses []*protobufs.RemoteConfigStatus
+}
+
+func (m *mockOpAMPClient) SetRemoteConfigStatus(status *protobufs.RemoteConfigStatus) error {
+	m.remoteConfigStatuses = append(m.remoteConfigStatuses, status)
+	return nil
+}
+
+func newRemoteConfig(hash byte, files map[string]string) *protobufs.AgentRemoteConfig {
+	configMap := map[string]*protobufs.AgentConfigFile{}
+	for name, body := range files {
+		configMap[name] = &protobufs.AgentConfigFile{Body: []byte(body)}
+	}
+	return &protobufs.AgentRemoteConfig{
+		Config:     &protobufs.AgentConfigMap{ConfigMap: configMap},
+		ConfigHash: []byte{hash},
+	}
+}
+
+func TestComposeRemoteConfig(t *testing.T) {
+	conf, err := composeRemoteConfig(newRemoteConfig(1, map[string]string{
+		"":  "exporters:\n  debug:\n    verbosity: basic\n",
+		"b": "receivers:\n  otlp: {}\nexporters:\n  debug:\n    verbosity: detailed\n",
+		"a": "receivers:\n  otlp:\n    protocols:\n      grpc:\n        endpoint: localhost:4317\n",
+	}))
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+exporters:
+  debug:
+    verbosity: basic
+`, string(conf))
+
+	_, err = composeRemoteConfig(newRemoteConfig(2, map[string]string{"": "receivers: ["}))
+	assert.Error(t, err)
+}
+
+func TestApplyRemoteConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Capabilities.AcceptsRemoteConfig = true
+	cfg.RemoteConfigDirectory = filepath.Join(t.TempDir(), "opamp")
+	set := extensiontest.NewNopCreateSettings()
+	o, err := newOpampAgent(cfg, set.Logger, set.BuildInfo, set.Resource)
+	require.NoError(t, err)
+	mock := &mockOpAMPClient{}
+	o.opampClient = mock
+
+	o.onMessage(context.Background(), &types.MessageData{
+		RemoteConfig: newRemoteConfig(1, map[string]string{"": "receivers:\n  otlp:\n    protocols:\n      grpc:\n        endpoint: localhost:4317\n"}),
+	})
+	o.onMessage(context.Background(), &types.MessageData{
+		RemoteConfig: newRemoteConfig(2, map[string]string{"": "receivers:\n  otlp:\n    protocols:\n      grpc:\n        endpoint: localhost:4317\n"}),
+	})
+	o.onMessage(context.Background(), &types.MessageData{
+		RemoteConfig: newRemoteConfig(3, map[string]string{"": "receivers: ["}),
+	})
+	require.Len(t, mock.remoteConfigStatuses, 3)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, mock.remoteConfigStatuses[0].Status)
+	assert.Equal(t, []byte{1}, mock.remoteConfigStatuses[0].LastRemoteConfigHash)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, mock.remoteConfigStatuses[1].Status)
+	assert.Equal(t, []byte{2}, mock.remoteConfigStatuses[1].LastRemoteConfigHash)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, mock.remoteConfigStatuses[2].Status)
+	assert.NotEmpty(t, mock.remoteConfigStatuses[2].ErrorMessage)
+
+	config, err := o.remoteConfigStore.Config()
+	require.NoError(t, err)
+	assert.YAMLEq(t, "receivers:\n  otlp:\n    protocols:\n      grpc:\n        endpoint: localhost:4317\n", string(config))
+}
+
+func TestApplyRemoteConfigDisabled(t *testing.T) {
+	cfg := createDefaultConfig()
+	set := extensiontest.NewNopCreateSettings()
+	o, err := newOpampAgent(cfg.(*Config), set.Logger, set.BuildInfo, set.Resource)
+	require.NoError(t, err)
+	mock := &mockOpAMPClient{}
+	o.opampClient = mock
+
+	o.onMessage(context.Background(), &types.MessageData{
+		RemoteConfig: newRemoteConfig(1, map[string]string{"": "receivers:\n  otlp:\n    protocols:\n      grpc:\n        endpoint: localhost:4317\n"}),
+	})
+	assert.Empty(t, mock.remoteConfigStatuses)
+}
+
+func TestLoadRemoteConfigStatus(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Capabilities.AcceptsRemoteConfig = true
+	cfg.RemoteConfigDirectory = filepath.Join(t.TempDir(), "opamp")
+	set := extensiontest.NewNopCreateSettings()
+	o, err := newOpampAgent(cfg, set.Logger, set.BuildInfo, set.Resource)
+	require.NoError(t, err)
+
+	status, err := o.loadRemoteConfigStatus()
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	// The Collector started with the config being applied.
+	_, err = o.remoteConfigStore.Apply([]byte("receivers: {}\n"), []byte{1})
+	require.NoError(t, err)
+	status, err = o.loadRemoteConfigStatus()
+	require.NoError(t, err)
+	assert.Equal(t, &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: []byte{1},
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+	}, status)
+	stored, err := o.remoteConfigStore.Status()
+	require.NoError(t, err)
+	assert.Equal(t, remoteconfig.StateApplied, stored.State)
+
+	require.NoError(t, o.remoteConfigStore.SetStatus(&remoteconfig.Status{
+		Hash: []byte{2}, State: remoteconfig.StateFailed, ErrorMessage: "failed",
+	}))
+	status, err = o.loadRemoteConfigStatus()
+	require.NoError(t, err)
+	assert.Equal(t, &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: []byte{2},
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		ErrorMessage:         "failed",
+	}, status)
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package opampprovider provides a confmap.Provider loading the remote config
+// accepted from the OpAMP server by the opamp extension.
+package opampprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension/opampprovider"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/confmap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension/internal/remoteconfig"
+)
+
+const schemeName = "opamp"
+
+// revertReason is reported when the Collector did not start with a remote config.
+const revertReason = "the Collector did not start with the remote config"
+
+type provider struct {
+	mu sync.Mutex
+	// Whether the config was retrieved by the running Collector.
+	retrieved bool
+	watchers  map[*fsnotify.Watcher]struct{}
+}
+
+// New returns a new confmap.Provider that reads the remote config accepted
+// by the opamp extension from the directory it is persisted in, and watches
+// it to reload the Collector when a new remote config is accepted.
+//
+// This Provider supports "opamp" scheme, and can be called with a "uri" that
+// follows:
+//
+//	opamp-uri : opamp:<remote_config_directory of the opamp extension>
+//
+// The remote config is empty until one is accepted from the OpAMP server. If
+// the Collector stops before starting with a remote config, the last remote
+// config it started with is restored when it is restarted.
+//
+// Examples:
+// `opamp:/var/lib/otelcol/opamp` - (unix)
+// `opamp:C:\ProgramData\otelcol\opamp` - (windows)
+func New() confmap.Provider {
+	return &provider{watchers: map[*fsnotify.Watcher]struct{}{}}
+}
+
+func (p *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	store := remoteconfig.NewStore(filepath.Clean(uri[len(schemeName)+1:]))
+
+	if err := p.checkPreviousRollout(store); err != nil {
+		return nil, err
+	}
+
+	data, err := store.Config()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the remote config: %w", err)
+	}
+	var rawConf map[string]any
+	if err = yaml.Unmarshal(data, &rawConf); err != nil {
+		return nil, fmt.Errorf("unable to parse the remote config: %w", err)
+	}
+	if rawConf == nil {
+		rawConf = map[string]any{}
+	}
+
+	if watcher == nil {
+		return confmap.NewRetrieved(rawConf)
+	}
+	closeFunc, err := p.watch(store.Dir(), watcher)
+	if err != nil {
+		return nil, err
+	}
+	return confmap.NewRetrieved(rawConf, confmap.WithRetrievedClose(closeFunc))
+}
+
+// checkPreviousRollout reverts the remote config being applied when the
+// Collector was stopped, since the Collector did not start with it.
+func (p *provider) checkPreviousRollout(store *remoteconfig.Store) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.retrieved {
+		return nil
+	}
+	p.retrieved = true
+
+	status, err := store.Status()
+	if err != nil {
+		return fmt.Errorf("unable to read the remote config status: %w", err)
+	}
+	if status == nil || status.State != remoteconfig.StateApplying {
+		return nil
+	}
+	if err = store.Revert(status, revertReason); err != nil {
+		return fmt.Errorf("unable to revert the remote config: %w", err)
+	}
+	return nil
+}
+
+// watch calls watcher once the remote config file in dir changes.
+func (p *provider) watch(dir string, watcher confmap.WatcherFunc) (confmap.CloseFunc, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err = w.Add(dir); err != nil {
+		_ = w.Close()
+		return nil, fmt.Errorf("unable to watch %s: %w", dir, err)
+	}
+	p.mu.Lock()
+	p.watchers[w] = struct{}{}
+	p.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != remoteconfig.ConfigFileName || event.Op == fsnotify.Chmod {
+					continue
+				}
+				// The Collector retrieves the config again once notified,
+				// watching it with a new watcher.
+				watcher(&confmap.ChangeEvent{})
+				return
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				watcher(&confmap.ChangeEvent{Error: err})
+				return
+			}
+		}
+	}()
+
+	return func(context.Context) error {
+		return p.closeWatcher(w)
+	}, nil
+}
+
+func (p *provider) closeWatcher(w *fsnotify.Watcher) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.watchers[w]; !ok {
+		return nil
+	}
+	delete(p.watchers, w)
+	return w.Close()
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (p *provider) Shutdown(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for w := range p.watchers {
+		errs = append(errs, w.Close())
+	}
+	p.watchers = map[*fsnotify.Watcher]struct{}{}
+	return errors.Join(errs...)
+}
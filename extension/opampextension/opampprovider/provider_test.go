@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampprovider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/opampextension/internal/remoteconfig"
+)
+
+func TestUnsupportedScheme(t *testing.T) {
+	p := New()
+	_, err := p.Retrieve(context.Background(), "file:/tmp/opamp", nil)
+	assert.Error(t, err)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieveEmpty(t *testing.T) {
+	p := New()
+	ret, err := p.Retrieve(context.Background(), schemeName+":"+filepath.Join(t.TempDir(), "opamp"), nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{}, raw)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieve(t *testing.T) {
+	dir := t.TempDir()
+	store := remoteconfig.NewStore(dir)
+	_, err := store.Apply([]byte("receivers:\n  otlp: {}\n"), []byte{1})
+	require.NoError(t, err)
+	require.NoError(t, store.SetStatus(&remoteconfig.Status{Hash: []byte{1}, State: remoteconfig.StateApplied}))
+
+	p := New()
+	ret, err := p.Retrieve(context.Background(), schemeName+":"+dir, nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"receivers": map[string]any{"otlp": map[string]any{}}}, raw)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieveRevertsRemoteConfigBeingApplied(t *testing.T) {
+	dir := t.TempDir()
+	store := remoteconfig.NewStore(dir)
+	_, err := store.Apply([]byte("receivers:\n  otlp: {}\n"), []byte{1})
+	require.NoError(t, err)
+
+	p := New()
+	ret, err := p.Retrieve(context.Background(), schemeName+":"+dir, nil)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{}, raw)
+
+	status, err := store.Status()
+	require.NoError(t, err)
+	assert.Equal(t, &remoteconfig.Status{Hash: []byte{1}, State: remoteconfig.StateFailed, ErrorMessage: revertReason}, status)
+
+	// A remote config applied while the Collector is running is retrieved.
+	_, err = store.Apply([]byte("exporters:\n  otlp: {}\n"), []byte{2})
+	require.NoError(t, err)
+	ret, err = p.Retrieve(context.Background(), schemeName+":"+dir, nil)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"exporters": map[string]any{"otlp": map[string]any{}}}, raw)
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestWatch(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "opamp")
+	p := New()
+
+	events := make(chan *confmap.ChangeEvent, 1)
+	ret, err := p.Retrieve(context.Background(), schemeName+":"+dir, func(event *confmap.ChangeEvent) {
+		events <- event
+	})
+	require.NoError(t, err)
+
+	store := remoteconfig.NewStore(dir)
+	require.NoError(t, store.SetStatus(&remoteconfig.Status{Hash: []byte{1}, State: remoteconfig.StateApplied}))
+	select {
+	case <-events:
+		t.Fatal("the status is not part of the config")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = store.Apply([]byte("receivers:\n  otlp: {}\n"), []byte{2})
+	require.NoError(t, err)
+	select {
+	case event := <-events:
+		assert.NoError(t, event.Error)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change event received")
+	}
+
+	assert.NoError(t, ret.Close(context.Background()))
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
@@ -35,6 +35,10 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	// cipher encrypts the stored values, if encryption is enabled
+	cipher *valueCipher
+	// limit enforces the size limit, if set
+	limit *sizeLimit
 }
 
 func bboltOptions(timeout time.Duration, fSync bool) *bbolt.Options {
@@ -46,7 +50,7 @@ func bboltOptions(timeout time.Duration, fSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, fSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, fSync bool, cipher *valueCipher, limit *sizeLimit) (*fileStorageClient, error) {
 	options := bboltOptions(timeout, fSync)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -54,15 +58,26 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 	}
 
 	initBucket := func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(defaultBucket)
-		return err
+		if _, err := tx.CreateBucketIfNotExists(defaultBucket); err != nil {
+			return err
+		}
+		if limit != nil && limit.trackOrder() {
+			return initOrderBuckets(tx)
+		}
+		return nil
 	}
 	if err := db.Update(initBucket); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, cipher: cipher, limit: limit}
+	if limit != nil {
+		if err := client.updateSize(); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -92,7 +107,12 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 }
 
 // Batch executes the specified operations in order. Get operation results are updated in place
-func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	trackOrder := c.limit != nil && c.limit.trackOrder()
+
+	var evicted int
+	// sizeDelta is the net change of the size of the stored data, applied once the transaction is committed
+	var sizeDelta int64
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
@@ -100,22 +120,58 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 		}
 
 		var err error
+		var order, orderKeys *bbolt.Bucket
+		if trackOrder {
+			order, orderKeys = tx.Bucket(orderBucket), tx.Bucket(orderKeysBucket)
+		}
+
+		// setKeys are the keys set by the batch, which must not be evicted to make room for it
+		var setKeys map[string]bool
+		var addsEntries bool
 		for _, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case c.cipher != nil:
+					// decryption returns a new slice, valid outside the transaction
+					op.Value, err = c.cipher.decrypt(op.Key, value)
+				default:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.cipher != nil {
+					if value, err = c.cipher.encrypt(op.Key, op.Value); err != nil {
+						return err
+					}
+				}
+				if c.limit != nil {
+					existing := bucket.Get([]byte(op.Key))
+					addsEntries = addsEntries || existing == nil
+					sizeDelta += entrySize([]byte(op.Key), value) - entrySize([]byte(op.Key), existing)
+					if setKeys == nil {
+						setKeys = map[string]bool{}
+					}
+					setKeys[op.Key] = true
+				}
+				err = bucket.Put([]byte(op.Key), value)
+				if err == nil && trackOrder {
+					err = trackSet(order, orderKeys, []byte(op.Key))
+				}
 			case storage.Delete:
+				if c.limit != nil {
+					sizeDelta -= entrySize([]byte(op.Key), bucket.Get([]byte(op.Key)))
+				}
 				err = bucket.Delete([]byte(op.Key))
+				if err == nil && trackOrder {
+					err = trackDelete(order, orderKeys, []byte(op.Key))
+				}
 			default:
 				return errors.New("wrong operation type")
 			}
@@ -125,12 +181,67 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			}
 		}
 
+		// only the batches growing the stored data are limited
+		if c.limit == nil || sizeDelta <= 0 {
+			return nil
+		}
+		overflow := c.limit.tracker.overflow(sizeDelta)
+		switch {
+		case overflow <= 0:
+			return nil
+		case c.limit.persistentQueue && !addsEntries:
+			// the persistent queues drop the item being dequeued when updating their index fails,
+			// so the batches only updating existing entries are never rejected
+			return nil
+		case !trackOrder:
+			return errStorageFull
+		}
+		var freed int64
+		if evicted, freed, err = evictOldest(tx, overflow, setKeys); err != nil {
+			return err
+		}
+		sizeDelta -= freed
 		return nil
 	}
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	err := c.db.Update(batch)
+	if c.limit == nil {
+		return err
+	}
+
+	if errors.Is(err, errStorageFull) {
+		c.limit.tracker.rejectedWrites.Add(ctx, 1, c.limit.attributes)
+	}
+	if err != nil {
+		return err
+	}
+	if evicted > 0 {
+		c.limit.tracker.evictedEntries.Add(ctx, int64(evicted), c.limit.attributes)
+		c.logger.Debug("dropped oldest entries to stay within the size limit", zap.Int("count", evicted))
+	}
+	c.limit.tracker.addSize(c.limit.client, sizeDelta)
+	return nil
+}
+
+// updateSize records the size of the data stored by the client, from all its entries
+func (c *fileStorageClient) updateSize() error {
+	var size int64
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return errors.New("storage not initialized")
+		}
+		var err error
+		size, err = storedSize(bucket)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	c.limit.tracker.setSize(c.limit.client, size)
+	return nil
 }
 
 // Close will close the database
@@ -181,6 +292,12 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 		return nil
 	}
 
+	if c.cipher != nil {
+		if err = c.reencrypt(maxTransactionSize); err != nil {
+			return fmt.Errorf("failed to re-encrypt values before compaction: %w", err)
+		}
+	}
+
 	c.logger.Debug("starting compaction",
 		zap.String(directoryKey, c.db.Path()),
 		zap.String(tempDirectoryKey, file.Name()))
@@ -234,6 +351,70 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 		zap.String(directoryKey, dbPath),
 		zap.Duration(elapsedKey, time.Since(compactionStart)))
 
+	if c.limit != nil {
+		if err = c.updateSize(); err != nil {
+			c.logger.Error("failed to get stored data size", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// reencrypt encrypts the values that are not encrypted with the current key with it,
+// in transactions of at most maxTransactionSize values
+func (c *fileStorageClient) reencrypt(maxTransactionSize int64) error {
+	var keys [][]byte
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return errors.New("storage not initialized")
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			if v != nil && c.cipher.needsReencryption(v) {
+				keys = append(keys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+	})
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+
+	if maxTransactionSize <= 0 {
+		maxTransactionSize = int64(len(keys))
+	}
+	for start := 0; start < len(keys); start += int(maxTransactionSize) {
+		end := start + int(maxTransactionSize)
+		if end > len(keys) {
+			end = len(keys)
+		}
+		err = c.db.Update(func(tx *bbolt.Tx) error {
+			bucket := tx.Bucket(defaultBucket)
+			for _, k := range keys[start:end] {
+				v := bucket.Get(k)
+				if v == nil || !c.cipher.needsReencryption(v) {
+					continue
+				}
+				plain, err := c.cipher.decrypt(string(k), v)
+				if err != nil {
+					return err
+				}
+				encrypted, err := c.cipher.encrypt(string(k), plain)
+				if err != nil {
+					return err
+				}
+				if err = bucket.Put(k, encrypted); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	c.logger.Debug("re-encrypted values with the current key", zap.Int("count", len(keys)))
 	return nil
 }
 
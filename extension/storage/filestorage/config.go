@@ -20,6 +20,40 @@ type Config struct {
 
 	// FSync specifies that fsync should be called after each database write
 	FSync bool `mapstructure:"fsync,omitempty"`
+
+	// Encryption specifies that the stored values are encrypted
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+
+	// SizeLimit specifies the maximum size of the data stored by all the clients
+	SizeLimit *SizeLimitConfig `mapstructure:"size_limit,omitempty"`
+}
+
+// SizeLimitConfig defines configuration for the optional limit of the stored data size.
+type SizeLimitConfig struct {
+	// MaxSizeMiB specifies the maximum size of the data stored in the directory, across all the clients
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+	// Policy specifies what happens to the writes exceeding the limit: `reject` fails them,
+	// `drop_oldest` deletes the least recently set entries of the client writing to make room,
+	// except for persistent queues whose writes are rejected. Defaults to `reject`
+	Policy string `mapstructure:"policy,omitempty"`
+}
+
+// EncryptionConfig defines configuration for optional encryption of the stored values.
+type EncryptionConfig struct {
+	// Keys are the AES keys the values are encrypted with. Values are encrypted with the
+	// first key, and decrypted with any of them, allowing keys to be rotated
+	Keys []EncryptionKeyConfig `mapstructure:"keys"`
+	// MigrateUnencrypted allows reading the values stored before encryption was enabled,
+	// which are encrypted on compaction. Such values are rejected otherwise
+	MigrateUnencrypted bool `mapstructure:"migrate_unencrypted,omitempty"`
+}
+
+// EncryptionKeyConfig defines where the base64 encoded material of an encryption key is loaded from.
+type EncryptionKeyConfig struct {
+	// File is the path of the file holding the key
+	File string `mapstructure:"file,omitempty"`
+	// Env is the name of the environment variable holding the key
+	Env string `mapstructure:"env,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -79,5 +113,30 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Encryption != nil {
+		if len(cfg.Encryption.Keys) == 0 {
+			return errors.New("at least one encryption key must be set when encryption is set")
+		}
+		for i, key := range cfg.Encryption.Keys {
+			if (key.File == "") == (key.Env == "") {
+				return fmt.Errorf("encryption key %d must set exactly one of file and env", i)
+			}
+		}
+	}
+
+	if cfg.SizeLimit != nil {
+		if cfg.SizeLimit.MaxSizeMiB <= 0 {
+			return errors.New("max size must be positive when size limit is set")
+		}
+		switch cfg.SizeLimit.Policy {
+		case "", policyReject, policyDropOldest:
+		default:
+			return fmt.Errorf("size limit policy must be one of %q or %q", policyReject, policyDropOldest)
+		}
+		if cfg.Compaction.OnRebound && cfg.Compaction.ReboundNeededThresholdMiB > cfg.SizeLimit.MaxSizeMiB {
+			return errors.New("compaction rebound needed threshold cannot be greater than the max size of the size limit")
+		}
+	}
+
 	return nil
 }
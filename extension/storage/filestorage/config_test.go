@@ -48,6 +48,17 @@ func TestLoadConfig(t *testing.T) {
 				},
 				Timeout: 2 * time.Second,
 				FSync:   true,
+				Encryption: &EncryptionConfig{
+					Keys: []EncryptionKeyConfig{
+						{File: "/etc/otelcol/file_storage.key"},
+						{Env: "FILE_STORAGE_PREVIOUS_KEY"},
+					},
+					MigrateUnencrypted: true,
+				},
+				SizeLimit: &SizeLimitConfig{
+					MaxSizeMiB: 1024,
+					Policy:     "drop_oldest",
+				},
 			},
 		},
 	}
@@ -94,3 +105,36 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestInvalidEncryptionConfig(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	cfg.Encryption = &EncryptionConfig{}
+	require.EqualError(t, component.ValidateConfig(cfg), "at least one encryption key must be set when encryption is set")
+
+	cfg.Encryption.Keys = []EncryptionKeyConfig{{File: "/key", Env: "KEY"}}
+	require.EqualError(t, component.ValidateConfig(cfg), "encryption key 0 must set exactly one of file and env")
+
+	cfg.Encryption.Keys = []EncryptionKeyConfig{{Env: "KEY"}, {}}
+	require.EqualError(t, component.ValidateConfig(cfg), "encryption key 1 must set exactly one of file and env")
+}
+
+func TestInvalidSizeLimitConfig(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	cfg.SizeLimit = &SizeLimitConfig{}
+	require.EqualError(t, component.ValidateConfig(cfg), "max size must be positive when size limit is set")
+
+	cfg.SizeLimit = &SizeLimitConfig{MaxSizeMiB: 64, Policy: "drop_newest"}
+	require.EqualError(t, component.ValidateConfig(cfg), `size limit policy must be one of "reject" or "drop_oldest"`)
+
+	cfg.SizeLimit = &SizeLimitConfig{MaxSizeMiB: 64}
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.Compaction.OnRebound = true
+	require.EqualError(t, component.ValidateConfig(cfg), "compaction rebound needed threshold cannot be greater than the max size of the size limit")
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const keyIDSize = 8
+
+// encryptedValueMagic prefixes the encrypted values, distinguishing them from
+// the values stored before encryption was enabled.
+var encryptedValueMagic = []byte("\x00otelenc1")
+
+var (
+	errUnknownEncryptionKey = errors.New("value is encrypted with an unknown key")
+	errUnencryptedValue     = errors.New("value is not encrypted")
+)
+
+// encryptionKey is an AES-GCM key, identified by the start of its SHA-256 hash.
+type encryptionKey struct {
+	id   []byte
+	aead cipher.AEAD
+}
+
+// valueCipher encrypts the stored values with the current key, and decrypts
+// them with any of the configured keys.
+type valueCipher struct {
+	current *encryptionKey
+	keys    []*encryptionKey
+	// migrateUnencrypted allows reading the values stored before encryption was enabled
+	migrateUnencrypted bool
+}
+
+func newValueCipher(cfg *EncryptionConfig) (*valueCipher, error) {
+	c := &valueCipher{migrateUnencrypted: cfg.MigrateUnencrypted}
+	for i, keyCfg := range cfg.Keys {
+		material, err := loadKeyMaterial(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load encryption key %d: %w", i, err)
+		}
+		block, err := aes.NewCipher(material)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %d: %w", i, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(material)
+		c.keys = append(c.keys, &encryptionKey{id: hash[:keyIDSize], aead: aead})
+	}
+	c.current = c.keys[0]
+	return c, nil
+}
+
+// loadKeyMaterial reads the base64 encoded key from its file or environment variable.
+func loadKeyMaterial(cfg EncryptionKeyConfig) ([]byte, error) {
+	var encoded string
+	if cfg.File != "" {
+		data, err := os.ReadFile(filepath.Clean(cfg.File))
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(data)
+	} else {
+		var ok bool
+		if encoded, ok = os.LookupEnv(cfg.Env); !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", cfg.Env)
+		}
+	}
+	material, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded: %w", err)
+	}
+	return material, nil
+}
+
+// encrypt seals the value with the current key, authenticating the storage
+// key it is stored under so that values cannot be swapped.
+func (c *valueCipher) encrypt(key string, value []byte) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	nonceSize := c.current.aead.NonceSize()
+	headerSize := len(encryptedValueMagic) + keyIDSize + nonceSize
+	out := make([]byte, headerSize, headerSize+len(value)+c.current.aead.Overhead())
+	copy(out, encryptedValueMagic)
+	copy(out[len(encryptedValueMagic):], c.current.id)
+	nonce := out[headerSize-nonceSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.current.aead.Seal(out, nonce, value, []byte(key)), nil
+}
+
+// decrypt opens a value encrypted with any of the configured keys. Values
+// stored before encryption was enabled are returned as is when migrating them,
+// and rejected otherwise.
+func (c *valueCipher) decrypt(key string, value []byte) ([]byte, error) {
+	if !bytes.HasPrefix(value, encryptedValueMagic) {
+		if !c.migrateUnencrypted {
+			return nil, errUnencryptedValue
+		}
+		return value, nil
+	}
+	rest := value[len(encryptedValueMagic):]
+	if len(rest) < keyIDSize {
+		return nil, errors.New("encrypted value is truncated")
+	}
+	k := c.key(rest[:keyIDSize])
+	if k == nil {
+		return nil, errUnknownEncryptionKey
+	}
+	rest = rest[keyIDSize:]
+	if len(rest) < k.aead.NonceSize() {
+		return nil, errors.New("encrypted value is truncated")
+	}
+	plain, err := k.aead.Open(nil, rest[:k.aead.NonceSize()], rest[k.aead.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plain, nil
+}
+
+// isCurrent returns whether the value is encrypted with the current key.
+func (c *valueCipher) isCurrent(value []byte) bool {
+	rest, ok := bytes.CutPrefix(value, encryptedValueMagic)
+	return ok && bytes.HasPrefix(rest, c.current.id)
+}
+
+// needsReencryption returns whether the value is to be encrypted with the current key
+// on compaction. Unencrypted values are only encrypted when migrating them.
+func (c *valueCipher) needsReencryption(value []byte) bool {
+	if !bytes.HasPrefix(value, encryptedValueMagic) {
+		return c.migrateUnencrypted
+	}
+	return !c.isCurrent(value)
+}
+
+func (c *valueCipher) key(id []byte) *encryptionKey {
+	for _, k := range c.keys {
+		if bytes.Equal(k.id, id) {
+			return k
+		}
+	}
+	return nil
+}
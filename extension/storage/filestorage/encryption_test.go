@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+func newTestKeyFile(t *testing.T) EncryptionKeyConfig {
+	material := make([]byte, 32)
+	_, err := rand.Read(material)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(file, []byte(base64.StdEncoding.EncodeToString(material)+"\n"), 0600))
+	return EncryptionKeyConfig{File: file}
+}
+
+func newTestCipher(t *testing.T, keys ...EncryptionKeyConfig) *valueCipher {
+	c, err := newValueCipher(&EncryptionConfig{Keys: keys})
+	require.NoError(t, err)
+	return c
+}
+
+func TestValueCipher(t *testing.T) {
+	c := newTestCipher(t, newTestKeyFile(t))
+
+	encrypted, err := c.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(encrypted, []byte("value")))
+	assert.True(t, c.isCurrent(encrypted))
+
+	value, err := c.decrypt("key", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// The value is bound to its key
+	_, err = c.decrypt("other", encrypted)
+	assert.Error(t, err)
+
+	encrypted[len(encrypted)-1] ^= 0xff
+	_, err = c.decrypt("key", encrypted)
+	assert.Error(t, err)
+
+	// Values stored before encryption was enabled are rejected
+	_, err = c.decrypt("key", []byte("plain"))
+	assert.ErrorIs(t, err, errUnencryptedValue)
+	assert.False(t, c.isCurrent([]byte("plain")))
+	assert.False(t, c.needsReencryption([]byte("plain")))
+}
+
+func TestValueCipherMigrateUnencrypted(t *testing.T) {
+	c, err := newValueCipher(&EncryptionConfig{Keys: []EncryptionKeyConfig{newTestKeyFile(t)}, MigrateUnencrypted: true})
+	require.NoError(t, err)
+
+	// Values stored before encryption was enabled are returned as is
+	value, err := c.decrypt("key", []byte("plain"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain"), value)
+	assert.True(t, c.needsReencryption([]byte("plain")))
+
+	encrypted, err := c.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+	assert.False(t, c.needsReencryption(encrypted))
+}
+
+func TestValueCipherRotation(t *testing.T) {
+	oldKey, newKey := newTestKeyFile(t), newTestKeyFile(t)
+	oldCipher := newTestCipher(t, oldKey)
+	encrypted, err := oldCipher.encrypt("key", []byte("value"))
+	require.NoError(t, err)
+
+	rotated := newTestCipher(t, newKey, oldKey)
+	assert.False(t, rotated.isCurrent(encrypted))
+	value, err := rotated.decrypt("key", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	_, err = newTestCipher(t, newKey).decrypt("key", encrypted)
+	assert.ErrorIs(t, err, errUnknownEncryptionKey)
+}
+
+func TestValueCipherKeyFromEnv(t *testing.T) {
+	t.Setenv("FILESTORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(make([]byte, 16)))
+	_, err := newValueCipher(&EncryptionConfig{Keys: []EncryptionKeyConfig{{Env: "FILESTORAGE_TEST_KEY"}}})
+	require.NoError(t, err)
+
+	_, err = newValueCipher(&EncryptionConfig{Keys: []EncryptionKeyConfig{{Env: "FILESTORAGE_TEST_MISSING_KEY"}}})
+	assert.ErrorContains(t, err, "is not set")
+
+	t.Setenv("FILESTORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(make([]byte, 10)))
+	_, err = newValueCipher(&EncryptionConfig{Keys: []EncryptionKeyConfig{{Env: "FILESTORAGE_TEST_KEY"}}})
+	assert.ErrorContains(t, err, "invalid encryption key 0")
+}
+
+func TestClientEncryptionCompaction(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	oldKey, newKey := newTestKeyFile(t), newTestKeyFile(t)
+
+	// Store a value before encryption is enabled, and one with the old key
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "plain", []byte("plain value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, newTestCipher(t, oldKey), nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "old", []byte("old value")))
+	require.NoError(t, client.Close(ctx))
+
+	rotated, err := newValueCipher(&EncryptionConfig{Keys: []EncryptionKeyConfig{newKey, oldKey}, MigrateUnencrypted: true})
+	require.NoError(t, err)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, rotated, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+	require.NoError(t, client.Set(ctx, "new", []byte("new value")))
+
+	require.NoError(t, client.Compact(tempDir, time.Second, 1))
+
+	expected := map[string]string{"plain": "plain value", "old": "old value", "new": "new value"}
+	for key, value := range expected {
+		stored, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(value), stored)
+	}
+
+	// All the values are encrypted with the new key on disk
+	err = client.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			assert.True(t, rotated.isCurrent(v), "value of %s is not encrypted with the current key", k)
+			assert.False(t, bytes.Contains(v, []byte("value")))
+			return nil
+		})
+	})
+	require.NoError(t, err)
+}
+
+func TestClientEncryptionRejectsUnencrypted(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "plain", []byte("plain value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, newTestCipher(t, newTestKeyFile(t)), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+	_, err = client.Get(ctx, "plain")
+	assert.ErrorIs(t, err, errUnencryptedValue)
+}
@@ -25,22 +25,37 @@ var replaceUnsafeCharactersFeatureGate = featuregate.GlobalRegistry().MustRegist
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg         *Config
+	logger      *zap.Logger
+	settings    component.TelemetrySettings
+	cipher      *valueCipher
+	sizeTracker *sizeTracker
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
-func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newLocalFileStorage(settings component.TelemetrySettings, config *Config) (extension.Extension, error) {
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:      config,
+		logger:   settings.Logger,
+		settings: settings,
 	}, nil
 }
 
-// Start does nothing
+// Start loads the encryption keys, if encryption is enabled, and sets up the size limit, if any
 func (lfs *localFileStorage) Start(context.Context, component.Host) error {
+	var err error
+	if lfs.cfg.Encryption != nil {
+		if lfs.cipher, err = newValueCipher(lfs.cfg.Encryption); err != nil {
+			return err
+		}
+	}
+	if lfs.cfg.SizeLimit != nil {
+		if lfs.sizeTracker, err = newSizeTracker(lfs.settings, lfs.cfg.SizeLimit); err != nil {
+			return fmt.Errorf("failed to create size limit metrics: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -48,6 +63,9 @@ func (lfs *localFileStorage) Start(context.Context, component.Host) error {
 func (lfs *localFileStorage) Shutdown(context.Context) error {
 	// TODO clean up data files that did not have a client
 	// and are older than a threshold (possibly configurable)
+	if lfs.sizeTracker != nil {
+		return lfs.sizeTracker.shutdown()
+	}
 	return nil
 }
 
@@ -64,7 +82,18 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 		rawName = sanitize(rawName)
 	}
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.cfg.FSync)
+	var limit *sizeLimit
+	if lfs.sizeTracker != nil {
+		policy := lfs.cfg.SizeLimit.Policy
+		persistentQueue := isPersistentQueue(kind, name)
+		if policy == policyDropOldest && persistentQueue {
+			lfs.logger.Warn("the drop_oldest size limit policy would corrupt persistent queues, rejecting their writes exceeding the limit instead",
+				zap.String("client", rawName))
+			policy = policyReject
+		}
+		limit = newSizeLimit(lfs.sizeTracker, rawName, policy, persistentQueue)
+	}
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.cfg.FSync, lfs.cipher, limit)
 
 	if err != nil {
 		return nil, err
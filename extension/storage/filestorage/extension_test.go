@@ -10,13 +10,20 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestExtensionIntegrity(t *testing.T) {
@@ -281,6 +288,196 @@ func TestGetClientErrorsOnDeletedDirectory(t *testing.T) {
 	require.Nil(t, client)
 }
 
+func TestEncryptedExtension(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{Keys: []EncryptionKeyConfig{newTestKeyFile(t)}}
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("secret value")))
+	require.NoError(t, client.Close(ctx))
+
+	data, err := os.ReadFile(filepath.Join(cfg.Directory, "receiver_nop_my_component"))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret value")
+
+	client, err = se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret value"), value)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestEncryptedExtensionStartFailsWithInvalidKey(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{Keys: []EncryptionKeyConfig{{File: filepath.Join(t.TempDir(), "missing")}}}
+
+	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.Error(t, extension.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestSizeLimitedExtension(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.SizeLimit = &SizeLimitConfig{MaxSizeMiB: 1}
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, se.Shutdown(ctx))
+	}()
+
+	client, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close(ctx))
+	}()
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.ErrorIs(t, client.Set(ctx, "large", make([]byte, 2*oneMiB)), errStorageFull)
+}
+
+func TestSizeLimitedExtensionPersistentQueue(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.SizeLimit = &SizeLimitConfig{MaxSizeMiB: 1, Policy: policyDropOldest}
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, se.Shutdown(ctx))
+	}()
+
+	// The entries of persistent queues are never dropped
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("otlp"), string(component.DataTypeTraces))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close(ctx))
+	}()
+	require.NoError(t, client.Set(ctx, "0", make([]byte, oneMiB/2)))
+	require.ErrorIs(t, client.Set(ctx, "1", make([]byte, oneMiB/2)), errStorageFull)
+	value, err := client.Get(ctx, "0")
+	require.NoError(t, err)
+	assert.Len(t, value, oneMiB/2)
+
+	// Other clients drop their oldest entries
+	other, err := se.GetClient(ctx, component.KindReceiver, newTestEntity("filelog"), "")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, other.Close(ctx))
+	}()
+	require.NoError(t, other.Set(ctx, "0", make([]byte, oneMiB/4)))
+	require.NoError(t, other.Set(ctx, "1", make([]byte, oneMiB/4)))
+	value, err = other.Get(ctx, "0")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestSizeLimitedExtensionPersistentQueueDequeue(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.SizeLimit = &SizeLimitConfig{MaxSizeMiB: 1}
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	storageID := component.NewID(f.Type())
+	host := storagetest.NewStorageHost().WithExtension(storageID, extension)
+	require.NoError(t, extension.Start(ctx, host))
+	defer func() {
+		require.NoError(t, extension.Shutdown(ctx))
+	}()
+
+	const numConsumers = 8
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var pushed, exported int
+	push := func(_ context.Context, ld plog.Logs) error {
+		mu.Lock()
+		pushed++
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		exported += ld.LogRecordCount()
+		return nil
+	}
+	queue := exporterhelper.NewDefaultQueueSettings()
+	queue.NumConsumers = numConsumers
+	queue.QueueSize = 100000
+	queue.StorageID = &storageID
+	exporter, err := exporterhelper.NewLogsExporter(ctx, exportertest.NewNopCreateSettings(), &struct{}{}, push, exporterhelper.WithQueue(queue))
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(ctx, host))
+
+	newLogs := func(size int) plog.Logs {
+		ld := plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetEmptyBytes().FromRaw(make([]byte, size))
+		return ld
+	}
+
+	// The consumers of the queue are blocked on small logs, which free almost no room once exported,
+	// while the list of the items being dispatched stored by the queue is the largest
+	for i := 0; i < numConsumers; i++ {
+		require.NoError(t, exporter.ConsumeLogs(ctx, newLogs(1)))
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return pushed == numConsumers
+	}, 10*time.Second, 10*time.Millisecond)
+	accepted := numConsumers
+
+	// Fill the storage up to the limit, with large logs first and then small ones to leave no room
+	for _, size := range []int{64 * 1024, 1} {
+		for {
+			if err = exporter.ConsumeLogs(ctx, newLogs(size)); err != nil {
+				break
+			}
+			accepted++
+		}
+		require.ErrorIs(t, err, errStorageFull)
+	}
+
+	// All the logs accepted by the queue are dequeued and exported
+	close(release)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return exported == accepted
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, exporter.Shutdown(ctx))
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, accepted, exported)
+}
+
 func newTestExtension(t *testing.T) storage.Extension {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
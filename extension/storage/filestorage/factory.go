@@ -56,5 +56,5 @@ func createExtension(
 	params extension.CreateSettings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newLocalFileStorage(params.Logger, cfg.(*Config))
+	return newLocalFileStorage(params.TelemetrySettings, cfg.(*Config))
 }
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+)
+
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+	// policyReject rejects the writes exceeding the size limit
+	policyReject = "reject"
+	// policyDropOldest drops the least recently set entries of the client
+	// writing, to make room for the writes exceeding the size limit
+	policyDropOldest = "drop_oldest"
+
+	clientKey = "client"
+)
+
+var (
+	// orderBucket maps the sequence numbers of the entries, in the order they
+	// were set, to their keys
+	orderBucket = []byte(`order`)
+	// orderKeysBucket maps the keys of the entries to their sequence number
+	orderKeysBucket = []byte(`order_keys`)
+
+	errStorageFull = errors.New("storage size limit reached")
+)
+
+// sizeTracker tracks the size of the data stored by all the clients of the extension
+type sizeTracker struct {
+	maxSize int64
+
+	mu    sync.Mutex
+	sizes map[string]int64
+
+	evictedEntries metric.Int64Counter
+	rejectedWrites metric.Int64Counter
+	registration   metric.Registration
+}
+
+func newSizeTracker(settings component.TelemetrySettings, cfg *SizeLimitConfig) (*sizeTracker, error) {
+	t := &sizeTracker{
+		maxSize: cfg.MaxSizeMiB * oneMiB,
+		sizes:   map[string]int64{},
+	}
+
+	meter := settings.MeterProvider.Meter(scopeName)
+	size, err := meter.Int64ObservableGauge(
+		metadata.Type+"_size",
+		metric.WithDescription("Size of the data stored by the client."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.evictedEntries, err = meter.Int64Counter(
+		metadata.Type+"_evicted_entries",
+		metric.WithDescription("Number of entries of the client dropped to stay within the size limit."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.rejectedWrites, err = meter.Int64Counter(
+		metadata.Type+"_rejected_writes",
+		metric.WithDescription("Number of writes of the client rejected because of the size limit."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.registration, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for client, s := range t.sizes {
+			o.ObserveInt64(size, s, metric.WithAttributes(attribute.String(clientKey, client)))
+		}
+		return nil
+	}, size)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *sizeTracker) shutdown() error {
+	if t.registration == nil {
+		return nil
+	}
+	return t.registration.Unregister()
+}
+
+// setSize records the size of the data stored by a client
+func (t *sizeTracker) setSize(client string, size int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sizes[client] = size
+}
+
+// addSize adds the given number of bytes, possibly negative, to the size of the data stored by a client
+func (t *sizeTracker) addSize(client string, delta int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sizes[client] += delta
+}
+
+// overflow returns by how much the given number of bytes written exceeds the size limit
+func (t *sizeTracker) overflow(written int64) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	total := written
+	for _, s := range t.sizes {
+		total += s
+	}
+	return total - t.maxSize
+}
+
+// sizeLimit enforces the size limit for a client
+type sizeLimit struct {
+	tracker *sizeTracker
+	client  string
+	policy  string
+	// persistentQueue is set for the clients of the persistent queues of exporters
+	persistentQueue bool
+	attributes      metric.MeasurementOption
+}
+
+func newSizeLimit(tracker *sizeTracker, client string, policy string, persistentQueue bool) *sizeLimit {
+	return &sizeLimit{
+		tracker:         tracker,
+		client:          client,
+		policy:          policy,
+		persistentQueue: persistentQueue,
+		attributes:      metric.WithAttributes(attribute.String(clientKey, client)),
+	}
+}
+
+func (l *sizeLimit) trackOrder() bool {
+	return l.policy == policyDropOldest
+}
+
+// isPersistentQueue returns whether the client is the persistent queue of an exporter,
+// which keeps its own index of the stored items. Deleting items behind its back
+// would corrupt the queue, so the drop_oldest policy does not apply to it.
+func isPersistentQueue(kind component.Kind, name string) bool {
+	if kind != component.KindExporter {
+		return false
+	}
+	switch component.DataType(name) {
+	case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+		return true
+	default:
+		return false
+	}
+}
+
+// entrySize returns the number of bytes an entry takes, or 0 if it does not exist
+func entrySize(key, value []byte) int64 {
+	if value == nil {
+		return 0
+	}
+	return int64(len(key) + len(value))
+}
+
+// storedSize returns the number of bytes the entries of the bucket take
+func storedSize(bucket *bbolt.Bucket) (int64, error) {
+	var size int64
+	err := bucket.ForEach(func(k, v []byte) error {
+		size += entrySize(k, v)
+		return nil
+	})
+	return size, err
+}
+
+// initOrderBuckets creates the buckets tracking the order the entries are set
+// in, adding the entries stored without being tracked
+func initOrderBuckets(tx *bbolt.Tx) error {
+	order, err := tx.CreateBucketIfNotExists(orderBucket)
+	if err != nil {
+		return err
+	}
+	orderKeys, err := tx.CreateBucketIfNotExists(orderKeysBucket)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(defaultBucket).ForEach(func(k, _ []byte) error {
+		if orderKeys.Get(k) != nil {
+			return nil
+		}
+		return trackSet(order, orderKeys, k)
+	})
+}
+
+// trackSet records the key as the most recently set
+func trackSet(order, orderKeys *bbolt.Bucket, key []byte) error {
+	if err := trackDelete(order, orderKeys, key); err != nil {
+		return err
+	}
+	seq, err := order.NextSequence()
+	if err != nil {
+		return err
+	}
+	seqKey := make([]byte, 8)
+	binary.BigEndian.PutUint64(seqKey, seq)
+	if err = order.Put(seqKey, key); err != nil {
+		return err
+	}
+	return orderKeys.Put(key, seqKey)
+}
+
+// trackDelete stops tracking the key
+func trackDelete(order, orderKeys *bbolt.Bucket, key []byte) error {
+	seqKey := orderKeys.Get(key)
+	if seqKey == nil {
+		return nil
+	}
+	if err := order.Delete(seqKey); err != nil {
+		return err
+	}
+	return orderKeys.Delete(key)
+}
+
+// evictOldest deletes the least recently set entries, other than the kept ones,
+// until at least size bytes are freed, returning the number of entries deleted
+// and of bytes freed. It fails if not enough bytes can be freed.
+func evictOldest(tx *bbolt.Tx, size int64, keep map[string]bool) (int, int64, error) {
+	bucket := tx.Bucket(defaultBucket)
+	order := tx.Bucket(orderBucket)
+	orderKeys := tx.Bucket(orderKeysBucket)
+
+	var seqKeys, keys [][]byte
+	var freed int64
+	cursor := order.Cursor()
+	for seqKey, key := cursor.First(); seqKey != nil && freed < size; seqKey, key = cursor.Next() {
+		if keep[string(key)] {
+			continue
+		}
+		// the cursor's keys and values are invalidated by the deletions
+		seqKeys = append(seqKeys, append([]byte(nil), seqKey...))
+		keys = append(keys, append([]byte(nil), key...))
+		freed += entrySize(key, bucket.Get(key))
+	}
+	if freed < size {
+		return 0, 0, errStorageFull
+	}
+
+	for i, key := range keys {
+		if err := order.Delete(seqKeys[i]); err != nil {
+			return 0, 0, err
+		}
+		if err := orderKeys.Delete(key); err != nil {
+			return 0, 0, err
+		}
+		if err := bucket.Delete(key); err != nil {
+			return 0, 0, err
+		}
+	}
+	return len(keys), freed, nil
+}
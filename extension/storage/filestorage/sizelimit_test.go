@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+const testValueSize = 256 * 1024
+
+func newTestSizeTracker(t *testing.T) *sizeTracker {
+	tracker, err := newSizeTracker(componenttest.NewNopTelemetrySettings(), &SizeLimitConfig{MaxSizeMiB: 1})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, tracker.shutdown())
+	})
+	return tracker
+}
+
+func newTestLimitedClient(t *testing.T, dir string, name string, tracker *sizeTracker, policy string) *fileStorageClient {
+	client, err := newClient(zap.NewNop(), filepath.Join(dir, name), time.Second, &CompactionConfig{}, false, nil, newSizeLimit(tracker, name, policy, false))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.Background()))
+	})
+	return client
+}
+
+func TestSizeTrackerOverflow(t *testing.T) {
+	tracker := newTestSizeTracker(t)
+	assert.Equal(t, int64(-oneMiB+10), tracker.overflow(10))
+
+	tracker.setSize("a", oneMiB/2)
+	tracker.setSize("b", oneMiB/4)
+	assert.Equal(t, int64(0), tracker.overflow(oneMiB/4))
+
+	tracker.setSize("b", oneMiB/2)
+	assert.Equal(t, int64(10), tracker.overflow(10))
+}
+
+func TestClientSizeLimitReject(t *testing.T) {
+	ctx := context.Background()
+	tracker := newTestSizeTracker(t)
+	client := newTestLimitedClient(t, t.TempDir(), "my_db", tracker, policyReject)
+
+	var err error
+	var stored int
+	for ; stored < 10; stored++ {
+		if err = client.Set(ctx, fmt.Sprintf("key%d", stored), make([]byte, testValueSize)); err != nil {
+			break
+		}
+	}
+	require.ErrorIs(t, err, errStorageFull)
+	require.Greater(t, stored, 0)
+	assert.LessOrEqual(t, tracker.overflow(0), int64(0))
+
+	// Entries stored before the limit was reached are kept
+	for i := 0; i < stored; i++ {
+		value, err := client.Get(ctx, fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		assert.Len(t, value, testValueSize)
+	}
+
+	// Deleting entries makes room again
+	require.NoError(t, client.Delete(ctx, "key0"))
+	require.NoError(t, client.Set(ctx, "key0", make([]byte, testValueSize)))
+}
+
+func TestClientSizeLimitDropOldest(t *testing.T) {
+	ctx := context.Background()
+	tracker := newTestSizeTracker(t)
+	client := newTestLimitedClient(t, t.TempDir(), "my_db", tracker, policyDropOldest)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), make([]byte, testValueSize)))
+	}
+	assert.LessOrEqual(t, tracker.overflow(0), int64(0))
+
+	// Setting an entry again makes it the most recently set
+	require.NoError(t, client.Set(ctx, "key0", []byte("value")))
+	value, err := client.Get(ctx, "key0")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	value, err = client.Get(ctx, "key9")
+	require.NoError(t, err)
+	assert.Len(t, value, testValueSize)
+
+	var evicted []string
+	for i := 1; i < 10; i++ {
+		key := fmt.Sprintf("key%d", i)
+		if value, err = client.Get(ctx, key); err == nil && value == nil {
+			evicted = append(evicted, key)
+		}
+	}
+	require.NotEmpty(t, evicted)
+	assert.Equal(t, "key1", evicted[0])
+
+	// Writes larger than the limit cannot make room
+	require.ErrorIs(t, client.Set(ctx, "huge", make([]byte, 2*oneMiB)), errStorageFull)
+	value, err = client.Get(ctx, "key9")
+	require.NoError(t, err)
+	assert.Len(t, value, testValueSize)
+}
+
+func TestClientSizeLimitSharedByClients(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	tracker := newTestSizeTracker(t)
+	first := newTestLimitedClient(t, dir, "first", tracker, policyDropOldest)
+	second := newTestLimitedClient(t, dir, "second", tracker, policyDropOldest)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, first.Set(ctx, fmt.Sprintf("key%d", i), make([]byte, testValueSize)))
+	}
+
+	// The second client can only drop its own entries
+	require.ErrorIs(t, second.Set(ctx, "key", make([]byte, testValueSize)), errStorageFull)
+	value, err := first.Get(ctx, "key0")
+	require.NoError(t, err)
+	assert.Len(t, value, testValueSize)
+}
+
+func TestClientSizeLimitTracksExistingEntries(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	client, err := newClient(zap.NewNop(), filepath.Join(dir, "my_db"), time.Second, &CompactionConfig{}, false, nil, nil)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), make([]byte, testValueSize)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	tracker := newTestSizeTracker(t)
+	client = newTestLimitedClient(t, dir, "my_db", tracker, policyDropOldest)
+	for i := 3; i < 6; i++ {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), make([]byte, testValueSize)))
+	}
+
+	value, err := client.Get(ctx, "key0")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	value, err = client.Get(ctx, "key5")
+	require.NoError(t, err)
+	assert.Len(t, value, testValueSize)
+}
+
+func TestClientSizeTracking(t *testing.T) {
+	ctx := context.Background()
+	tracker := newTestSizeTracker(t)
+	client := newTestLimitedClient(t, t.TempDir(), "my_db", tracker, policyReject)
+	size := func() int64 {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return tracker.sizes["my_db"]
+	}
+	assert.Equal(t, int64(0), size())
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	assert.Equal(t, int64(len("key")+len("value")), size())
+
+	// Overwriting an entry only accounts for the difference
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("key", []byte("longer value")),
+		storage.SetOperation("other", []byte("value")),
+		storage.DeleteOperation("missing"),
+	))
+	assert.Equal(t, int64(len("key")+len("longer value")+len("other")+len("value")), size())
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	assert.Equal(t, int64(len("other")+len("value")), size())
+
+	// Failed batches are not accounted for
+	require.Error(t, client.Batch(ctx, storage.SetOperation("key", []byte("value")), storage.SetOperation("", []byte("value"))))
+	assert.Equal(t, int64(len("other")+len("value")), size())
+
+	// The incremental size matches the stored entries
+	require.NoError(t, client.updateSize())
+	assert.Equal(t, int64(len("other")+len("value")), size())
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+type redisClient struct {
+	client     *redis.Client
+	prefix     string
+	expiration time.Duration
+}
+
+func newClient(client *redis.Client, prefix string, expiration time.Duration) *redisClient {
+	return &redisClient{
+		client:     client,
+		prefix:     prefix,
+		expiration: expiration,
+	}
+}
+
+// Get will retrieve data from storage that corresponds to the specified key
+func (c *redisClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Set will store data. The data can be retrieved using the same key
+func (c *redisClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.client.Set(ctx, c.prefix+key, value, c.expiration).Err()
+}
+
+// Delete will delete data associated with the specified key
+func (c *redisClient) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, c.prefix+key).Err()
+}
+
+// Batch executes the specified operations in order, in a transaction. Get
+// operation results are updated in place
+func (c *redisClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	cmds := make([]redis.Cmder, len(ops))
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, op := range ops {
+			switch op.Type {
+			case storage.Get:
+				cmds[i] = pipe.Get(ctx, c.prefix+op.Key)
+			case storage.Set:
+				cmds[i] = pipe.Set(ctx, c.prefix+op.Key, op.Value, c.expiration)
+			case storage.Delete:
+				cmds[i] = pipe.Del(ctx, c.prefix+op.Key)
+			default:
+				return errors.New("wrong operation type")
+			}
+		}
+		return nil
+	})
+	// A missing key fails the transaction with redis.Nil, the other
+	// operations being applied. Their own errors are checked below
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	for i, cmd := range cmds {
+		if get, ok := cmd.(*redis.StringCmd); ok {
+			value, err := get.Bytes()
+			switch {
+			case errors.Is(err, redis.Nil):
+				ops[i].Value = nil
+			case err != nil:
+				return err
+			default:
+				ops[i].Value = value
+			}
+			continue
+		}
+		if err := cmd.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close does nothing, the connection to Redis being shared by the clients
+// of all the components and closed on shutdown of the extension
+func (c *redisClient) Close(context.Context) error {
+	return nil
+}
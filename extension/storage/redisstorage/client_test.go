@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func newTestClient(t *testing.T, expiration time.Duration) (*redisClient, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { require.NoError(t, rc.Close()) })
+	return newClient(rc, "test_", expiration), mr
+}
+
+func TestClientOperations(t *testing.T) {
+	ctx := context.Background()
+	client, mr := newTestClient(t, 0)
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	stored, err := mr.Get("test_key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", stored)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	assert.False(t, mr.Exists("test_key"))
+
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestClientExpiration(t *testing.T) {
+	ctx := context.Background()
+	client, mr := newTestClient(t, time.Minute)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	assert.Equal(t, time.Minute, mr.TTL("test_key"))
+
+	mr.FastForward(2 * time.Minute)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t, 0)
+
+	require.NoError(t, client.Set(ctx, "existing", []byte("old")))
+
+	ops := []storage.Operation{
+		storage.GetOperation("existing"),
+		storage.SetOperation("existing", []byte("new")),
+		storage.GetOperation("existing"),
+		storage.GetOperation("missing"),
+		storage.SetOperation("other", []byte("other")),
+		storage.DeleteOperation("other"),
+		storage.GetOperation("other"),
+	}
+	require.NoError(t, client.Batch(ctx, ops...))
+
+	assert.Equal(t, []byte("old"), ops[0].Value)
+	assert.Equal(t, []byte("new"), ops[2].Value)
+	assert.Nil(t, ops[3].Value)
+	assert.Nil(t, ops[6].Value)
+
+	value, err := client.Get(ctx, "existing")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), value)
+}
+
+func TestClientBatchErrors(t *testing.T) {
+	ctx := context.Background()
+	client, mr := newTestClient(t, 0)
+
+	_, err := mr.Lpush("test_list", "value")
+	require.NoError(t, err)
+
+	// The error of a command is returned even if the transaction failed
+	// because of a missing key
+	err = client.Batch(ctx,
+		storage.GetOperation("missing"),
+		storage.SetOperation("key", []byte("value")),
+		storage.GetOperation("list"),
+	)
+	assert.ErrorContains(t, err, "WRONGTYPE")
+
+	mr.SetError("server error")
+	err = client.Batch(ctx,
+		storage.GetOperation("missing"),
+		storage.SetOperation("key", []byte("value")),
+	)
+	assert.ErrorContains(t, err, "server error")
+}
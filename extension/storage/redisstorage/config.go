@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines configuration for the redis storage extension.
+type Config struct {
+	// Endpoint is the address of the Redis server, in the host:port format.
+	Endpoint string `mapstructure:"endpoint"`
+	// Username and Password authenticate the connection to the Redis server.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	// DB is the Redis database to select.
+	DB int `mapstructure:"db"`
+	// Prefix is prepended to the keys of all the components, allowing several
+	// collectors to share a Redis database.
+	Prefix string `mapstructure:"prefix"`
+	// Expiration is the time to live of the stored keys. Keys never expire if 0.
+	Expiration time.Duration `mapstructure:"expiration"`
+	// TLS configures the connection to the Redis server. The connection is not
+	// encrypted by default.
+	TLS configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("missing endpoint")
+	}
+	if cfg.DB < 0 {
+		return errors.New("db must not be negative")
+	}
+	if cfg.Expiration < 0 {
+		return errors.New("expiration must not be negative")
+	}
+	return nil
+}
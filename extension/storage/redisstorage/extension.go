@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+type redisStorage struct {
+	cfg    *Config
+	logger *zap.Logger
+	client *redis.Client
+}
+
+// Ensure this storage extension implements the appropriate interface
+var _ storage.Extension = (*redisStorage)(nil)
+
+func newRedisStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+	return &redisStorage{
+		cfg:    config,
+		logger: logger,
+	}, nil
+}
+
+// Start connects to the Redis server
+func (rs *redisStorage) Start(ctx context.Context, _ component.Host) error {
+	tlsConfig, err := rs.cfg.TLS.LoadTLSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load TLS config: %w", err)
+	}
+	c := redis.NewClient(&redis.Options{
+		Addr:      rs.cfg.Endpoint,
+		Username:  rs.cfg.Username,
+		Password:  string(rs.cfg.Password),
+		DB:        rs.cfg.DB,
+		TLSConfig: tlsConfig,
+	})
+	if err := c.Ping(ctx).Err(); err != nil {
+		_ = c.Close()
+		return fmt.Errorf("failed to connect to redis at %s: %w", rs.cfg.Endpoint, err)
+	}
+	rs.client = c
+	return nil
+}
+
+// Shutdown closes the connection to the Redis server
+func (rs *redisStorage) Shutdown(context.Context) error {
+	if rs.client == nil {
+		return nil
+	}
+	return rs.client.Close()
+}
+
+// GetClient returns a storage client for an individual component, prefixing
+// its keys so that they do not collide with the keys of other components
+func (rs *redisStorage) GetClient(_ context.Context, kind component.Kind, ent component.ID, name string) (storage.Client, error) {
+	var prefix string
+	if name == "" {
+		prefix = fmt.Sprintf("%s_%s_%s", kindString(kind), ent.Type(), ent.Name())
+	} else {
+		prefix = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
+	}
+	prefix = strings.ReplaceAll(prefix, " ", "")
+	return newClient(rs.client, rs.cfg.Prefix+prefix+"_", rs.cfg.Expiration), nil
+}
+
+func kindString(k component.Kind) string {
+	switch k {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	case component.KindConnector:
+		return "connector"
+	default:
+		return "other" // not expected
+	}
+}
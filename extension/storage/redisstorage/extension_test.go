@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func newTestExtension(t *testing.T, endpoint string) storage.Extension {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Prefix = "otelcol_"
+
+	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+	return se
+}
+
+func TestExtensionComponentPrefixes(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	se := newTestExtension(t, mr.Addr())
+	require.NoError(t, se.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, se.Shutdown(ctx))
+	}()
+
+	receiver, err := se.GetClient(ctx, component.KindReceiver, component.NewIDWithName("filelog", "app"), "")
+	require.NoError(t, err)
+	exporter, err := se.GetClient(ctx, component.KindExporter, component.NewID("otlp"), "traces")
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Set(ctx, "key", []byte("receiver")))
+	require.NoError(t, exporter.Set(ctx, "key", []byte("exporter")))
+
+	value, err := receiver.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("receiver"), value)
+	value, err = exporter.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("exporter"), value)
+
+	assert.ElementsMatch(t, []string{"otelcol_receiver_filelog_app_key", "otelcol_exporter_otlp__traces_key"}, mr.Keys())
+}
+
+func TestExtensionStartUnreachable(t *testing.T) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+
+	se := newTestExtension(t, addr)
+	require.Error(t, se.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, se.Shutdown(context.Background()))
+}
+
+func TestExtensionStartInvalidTLS(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = miniredis.RunT(t).Addr()
+	cfg.TLS.Insecure = false
+	cfg.TLS.CAFile = "testdata/missing.pem"
+
+	extension, err := f.CreateExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	assert.ErrorContains(t, extension.Start(context.Background(), componenttest.NewNopHost()), "failed to load TLS config")
+	require.NoError(t, extension.Shutdown(context.Background()))
+}
@@ -513,6 +513,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/datadog v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker v0.90.1 // indirect
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig v0.90.1 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka v0.90.1 // indirect
@@ -754,6 +755,8 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/metad
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent => ./internal/sharedcomponent
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext => ./internal/encodingext
+
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk => ./internal/splunk
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/exporter/alibabacloudlogserviceexporter => ./exporter/alibabacloudlogserviceexporter
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encodingext resolves the encoding extensions referenced by the configuration
+// of receivers and exporters.
+package encodingext // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// LogsMessagesMarshaler is implemented by the logs encoding extensions whose format
+// cannot hold every log record in a single message. Exporters sending messages
+// use it in place of plog.Marshaler when available.
+type LogsMessagesMarshaler interface {
+	// MarshalLogsMessages marshals the logs into as many messages as the format requires.
+	MarshalLogsMessages(ld plog.Logs) ([][]byte, error)
+}
+
+// GetExtension returns the extension with the given ID.
+func GetExtension(host component.Host, id component.ID) (component.Component, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding extension %q", id)
+	}
+	return ext, nil
+}
+
+// LoadExtension returns the extension with the given ID, which must implement T.
+func LoadExtension[T any](host component.Host, id component.ID) (T, error) {
+	var zero T
+	ext, err := GetExtension(host, id)
+	if err != nil {
+		return zero, err
+	}
+	t, ok := ext.(T)
+	if !ok {
+		return zero, fmt.Errorf("extension %q is not a %s", id, reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return t, nil
+}
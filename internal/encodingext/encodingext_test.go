@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package encodingext
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopExtension struct{}
+
+func (nopExtension) Start(context.Context, component.Host) error { return nil }
+
+func (nopExtension) Shutdown(context.Context) error { return nil }
+
+type logsExtension struct {
+	nopExtension
+}
+
+func (logsExtension) MarshalLogs(plog.Logs) ([]byte, error) { return nil, nil }
+
+func TestLoadExtension(t *testing.T) {
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("nop"):  nopExtension{},
+			component.NewID("logs"): logsExtension{},
+		},
+	}
+
+	marshaler, err := LoadExtension[plog.Marshaler](host, component.NewID("logs"))
+	require.NoError(t, err)
+	assert.Equal(t, logsExtension{}, marshaler)
+
+	_, err = LoadExtension[plog.Marshaler](host, component.NewID("nop"))
+	assert.EqualError(t, err, `extension "nop" is not a plog.Marshaler`)
+
+	_, err = LoadExtension[plog.Marshaler](host, component.NewIDWithName("logs", "missing"))
+	assert.EqualError(t, err, `unknown encoding extension "logs/missing"`)
+}
@@ -12,6 +12,11 @@ import (
 )
 
 func GetStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	return GetNamedStorageClient(ctx, host, storageID, componentID, "")
+}
+
+// GetNamedStorageClient returns the storage client of the given name, for components needing several clients.
+func GetNamedStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, name string) (storage.Client, error) {
 	if storageID == nil {
 		return storage.NewNopClient(), nil
 	}
@@ -26,7 +31,7 @@ func GetStorageClient(ctx context.Context, host component.Host, storageID *compo
 		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
 	}
 
-	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, name)
 
 }
 
@@ -40,6 +40,15 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name: "auto",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Protocol = Auto
+					cfg.EnablePayloadParsing = true
+					return cfg
+				}(),
+			},
 			{
 				Name: "location",
 				Expect: func() *Config {
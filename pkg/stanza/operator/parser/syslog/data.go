@@ -250,6 +250,130 @@ func CreateCases(basicConfig func() *Config) ([]Case, error) {
 			true,
 			false,
 		},
+		{
+			"AutoRFC3164",
+			func() *Config {
+				cfg := basicConfig()
+				cfg.Protocol = Auto
+				cfg.Location = location["detroit"].String()
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: fmt.Sprintf("<34>%s 1.2.3.4 apache_server[123]: test message", ts.Format("Jan _2 15:04:05")),
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), 0, location["detroit"]),
+				Severity:     entry.Error2,
+				SeverityText: "crit",
+				Attributes: map[string]any{
+					"appname":  "apache_server",
+					"facility": 4,
+					"hostname": "1.2.3.4",
+					"message":  "test message",
+					"priority": 34,
+					"proc_id":  "123",
+				},
+				Body: fmt.Sprintf("<34>%s 1.2.3.4 apache_server[123]: test message", ts.Format("Jan _2 15:04:05")),
+			},
+			true,
+			true,
+		},
+		{
+			"AutoRFC5424",
+			func() *Config {
+				cfg := basicConfig()
+				cfg.Protocol = Auto
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: `<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 - Found the user for retrieving user's profile`,
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(2015, 8, 5, 21, 58, 59, 693000000, time.UTC),
+				Severity:     entry.Info,
+				SeverityText: "info",
+				Attributes: map[string]any{
+					"appname":  "SecureAuth0",
+					"facility": 10,
+					"hostname": "192.168.2.132",
+					"message":  "Found the user for retrieving user's profile",
+					"msg_id":   "ID52020",
+					"priority": 86,
+					"proc_id":  "23108",
+					"version":  1,
+				},
+				Body: `<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 - Found the user for retrieving user's profile`,
+			},
+			true,
+			true,
+		},
+		{
+			"AutoCiscoSequenceNumber",
+			func() *Config {
+				cfg := basicConfig()
+				cfg.Protocol = Auto
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: fmt.Sprintf("<189>1234: router1: *%s: %%SYS-5-CONFIG_I: Configured from console by vty0", ts.Format("Jan _2 15:04:05.000")),
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond()/1e6*1e6, time.UTC),
+				Severity:     entry.Info2,
+				SeverityText: "notice",
+				Attributes: map[string]any{
+					"facility":        23,
+					"hostname":        "router1",
+					"message":         "%SYS-5-CONFIG_I: Configured from console by vty0",
+					"msg_id":          "SYS-5-CONFIG_I",
+					"priority":        189,
+					"sequence_number": 1234,
+				},
+				Body: fmt.Sprintf("<189>1234: router1: *%s: %%SYS-5-CONFIG_I: Configured from console by vty0", ts.Format("Jan _2 15:04:05.000")),
+			},
+			true,
+			true,
+		},
+		{
+			"AutoCEFPayload",
+			func() *Config {
+				cfg := basicConfig()
+				cfg.Protocol = Auto
+				cfg.EnablePayloadParsing = true
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: `<13>2019-01-18T11:07:53Z host CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 msg=Detected a threat. No action needed.`,
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(2019, 1, 18, 11, 7, 53, 0, time.UTC),
+				Severity:     entry.Info2,
+				SeverityText: "notice",
+				Attributes: map[string]any{
+					"facility": 1,
+					"hostname": "host",
+					"message":  "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 msg=Detected a threat. No action needed.",
+					"priority": 13,
+					"cef": map[string]any{
+						"version":               "0",
+						"device_vendor":         "Security",
+						"device_product":        "threatmanager",
+						"device_version":        "1.0",
+						"device_event_class_id": "100",
+						"name":                  "worm successfully stopped",
+						"severity":              "10",
+						"extension": map[string]any{
+							"src": "10.0.0.1",
+							"dst": "2.1.2.2",
+							"msg": "Detected a threat. No action needed.",
+						},
+					},
+				},
+				Body: `<13>2019-01-18T11:07:53Z host CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 msg=Detected a threat. No action needed.`,
+			},
+			true,
+			true,
+		},
 	}
 
 	return cases, nil
@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/syslog"
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+)
+
+var (
+	errMissingPriority = errors.New("expecting a priority value within angle brackets")
+
+	// rfc5424Pattern matches the version following the priority of an RFC 5424 message.
+	rfc5424Pattern = regexp.MustCompile(`^[1-9][0-9]{0,2} `)
+
+	// ciscoPattern matches Cisco IOS messages which start with a sequence number, followed by an
+	// optional origin hostname and an optional timestamp. A leading '*' or '.' on the timestamp
+	// signals that the clock of the device is not synchronized.
+	ciscoPattern = regexp.MustCompile(`(?s)^([0-9]+): (?:([^\s:%][^\s:]*): )?(?:[*.]?([A-Z][a-z]{2} +[0-9]{1,2}(?: [0-9]{4})? [0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)?)(?: ([A-Z]{3,5}))?: )?(.*)$`)
+
+	// ciscoMnemonicPattern matches the %FACILITY-SEVERITY-MNEMONIC prefix of Cisco messages.
+	ciscoMnemonicPattern = regexp.MustCompile(`^%([A-Za-z0-9_]+-[0-7]-[A-Za-z0-9_]+):`)
+
+	// junosEventPattern matches the event ID which starts the message of Junos devices, such as
+	// SNMP_TRAP_LINK_DOWN or RT_FLOW_SESSION_CREATE.
+	junosEventPattern = regexp.MustCompile(`^([A-Z][A-Z0-9]*(?:_[A-Z0-9]+)+): `)
+
+	// tagPattern matches an RFC 3164 tag with an optional process id.
+	tagPattern = regexp.MustCompile(`^([^\s\[\]:]+)(?:\[([^\]]+)\])?:$`)
+
+	ciscoTimestampLayouts = []string{"Jan _2 15:04:05", "Jan _2 2006 15:04:05"}
+
+	// timeNow is the time assigned to messages which have no timestamp.
+	timeNow = time.Now
+)
+
+// parseAuto will detect the format of a syslog message and parse it accordingly.
+func (s *Parser) parseAuto(input []byte) (map[string]any, error) {
+	priority, rest, err := splitPriority(string(input))
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed map[string]any
+	switch {
+	case rfc5424Pattern.MatchString(rest):
+		message, err := rfc5424.NewMachine().Parse(input)
+		if err != nil {
+			return nil, err
+		}
+		if parsed, err = s.parseRFC5424(message.(*rfc5424.SyslogMessage)); err != nil {
+			return nil, err
+		}
+	case ciscoPattern.MatchString(rest):
+		parsed = s.parseCisco(priority, rest)
+	case isFortinet(rest):
+		parsed = s.parseFortinet(priority, rest)
+	default:
+		message, err := rfc3164.NewMachine(rfc3164.WithLocaleTimezone(s.location), rfc3164.WithRFC3339()).Parse(input)
+		if err != nil {
+			// A message without a valid header is kept as a whole, as described in RFC 3164 section 4.3.3.
+			parsed = newPriorityMap(priority)
+			parsed["message"] = rest
+			break
+		}
+		if parsed, err = s.parseRFC3164(message.(*rfc3164.SyslogMessage)); err != nil {
+			return nil, err
+		}
+		fixRFC3164Header(parsed)
+		setJunosEventID(parsed)
+	}
+
+	setCiscoMnemonic(parsed)
+	if _, ok := parsed["timestamp"]; !ok {
+		parsed["timestamp"] = timeNow()
+	}
+	return parsed, nil
+}
+
+// splitPriority returns the priority of a message and the remaining input.
+func splitPriority(input string) (int, string, error) {
+	end := strings.IndexByte(input, '>')
+	if !strings.HasPrefix(input, "<") || end < 2 || end > 4 {
+		return 0, "", errMissingPriority
+	}
+	priority, err := strconv.Atoi(input[1:end])
+	if err != nil || priority < 0 || priority > 191 {
+		return 0, "", errMissingPriority
+	}
+	return priority, input[end+1:], nil
+}
+
+func newPriorityMap(priority int) map[string]any {
+	return map[string]any{
+		"priority": priority,
+		"facility": priority / 8,
+		"severity": priority % 8,
+	}
+}
+
+// fixRFC3164Header corrects the fields of RFC 3164 messages sent without a hostname, in which case
+// the tag is read as the hostname, and of Cisco messages with a sequence number in place of the tag.
+func fixRFC3164Header(parsed map[string]any) {
+	if hostname, ok := parsed["hostname"].(string); ok {
+		if match := tagPattern.FindStringSubmatch(hostname); match != nil {
+			delete(parsed, "hostname")
+			parsed["appname"] = match[1]
+			if match[2] != "" {
+				parsed["proc_id"] = match[2]
+			}
+		}
+	}
+
+	if appname, ok := parsed["appname"].(string); ok {
+		if seq, err := strconv.Atoi(appname); err == nil {
+			delete(parsed, "appname")
+			parsed["sequence_number"] = seq
+		}
+	}
+}
+
+// parseCisco will parse the message of a Cisco IOS device which has sequence numbers enabled.
+func (s *Parser) parseCisco(priority int, rest string) map[string]any {
+	match := ciscoPattern.FindStringSubmatch(rest)
+	parsed := newPriorityMap(priority)
+	if seq, err := strconv.Atoi(match[1]); err == nil {
+		parsed["sequence_number"] = seq
+	}
+	if match[2] != "" {
+		parsed["hostname"] = match[2]
+	}
+	if match[3] != "" {
+		location := s.location
+		if match[4] == "UTC" || match[4] == "GMT" {
+			location = time.UTC
+		}
+		for _, layout := range ciscoTimestampLayouts {
+			if ts, err := time.ParseInLocation(layout, match[3], location); err == nil {
+				parsed["timestamp"] = ts
+				break
+			}
+		}
+	}
+	parsed["message"] = match[5]
+	return parsed
+}
+
+// setCiscoMnemonic sets the msg_id from the %FACILITY-SEVERITY-MNEMONIC prefix of Cisco messages.
+func setCiscoMnemonic(parsed map[string]any) {
+	if _, ok := parsed["msg_id"]; ok {
+		return
+	}
+	message, ok := parsed["message"].(string)
+	if !ok {
+		return
+	}
+	if match := ciscoMnemonicPattern.FindStringSubmatch(message); match != nil {
+		parsed["msg_id"] = match[1]
+	}
+}
+
+// setJunosEventID sets the msg_id from the event ID of Junos messages sent in the default BSD syslog
+// format. Junos messages in the structured-data format are RFC 5424 messages with the event ID as msg_id.
+func setJunosEventID(parsed map[string]any) {
+	message, ok := parsed["message"].(string)
+	if !ok {
+		return
+	}
+	if match := junosEventPattern.FindStringSubmatch(message); match != nil {
+		parsed["msg_id"] = match[1]
+	}
+}
+
+// isFortinet reports whether a message is a FortiGate log, which has no syslog header
+// and starts directly with key=value pairs.
+func isFortinet(rest string) bool {
+	return strings.HasPrefix(rest, "date=") && strings.Contains(rest, " time=")
+}
+
+// parseFortinet will parse the key=value pairs of a FortiGate log into the fortinet field.
+func (s *Parser) parseFortinet(priority int, rest string) map[string]any {
+	fields := parseKeyValues(rest)
+	parsed := newPriorityMap(priority)
+	parsed["message"] = rest
+	parsed["fortinet"] = fields
+
+	if devname, ok := fields["devname"].(string); ok {
+		parsed["hostname"] = devname
+	}
+
+	date, _ := fields["date"].(string)
+	clock, _ := fields["time"].(string)
+	if tz, ok := fields["tz"].(string); ok {
+		if ts, err := time.Parse("2006-01-02 15:04:05 -0700", date+" "+clock+" "+tz); err == nil {
+			parsed["timestamp"] = ts
+			return parsed
+		}
+	}
+	if ts, err := time.ParseInLocation("2006-01-02 15:04:05", date+" "+clock, s.location); err == nil {
+		parsed["timestamp"] = ts
+	}
+	return parsed
+}
+
+// parseKeyValues parses space separated key=value pairs, values may be enclosed in double quotes.
+func parseKeyValues(input string) map[string]any {
+	fields := map[string]any{}
+	for input != "" {
+		input = strings.TrimLeft(input, " ")
+		eq := strings.IndexByte(input, '=')
+		if eq <= 0 {
+			break
+		}
+		key := input[:eq]
+		input = input[eq+1:]
+
+		var value string
+		if strings.HasPrefix(input, `"`) {
+			end := strings.IndexByte(input[1:], '"')
+			if end < 0 {
+				value, input = input[1:], ""
+			} else {
+				value, input = input[1:end+1], input[end+2:]
+			}
+		} else {
+			end := strings.IndexByte(input, ' ')
+			if end < 0 {
+				value, input = input, ""
+			} else {
+				value, input = input[:end], input[end:]
+			}
+		}
+		fields[key] = value
+	}
+	return fields
+}
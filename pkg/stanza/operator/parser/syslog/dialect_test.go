@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuto(t *testing.T) {
+	now := time.Date(2023, 11, 14, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	detroit, err := time.LoadLocation("America/Detroit")
+	require.NoError(t, err)
+	parser := &Parser{location: detroit}
+
+	cases := []struct {
+		name   string
+		input  string
+		expect map[string]any
+	}{
+		{
+			name:  "rfc3164 without hostname",
+			input: "<38>Nov 14 09:12:31 su[1234]: pam_unix(su:session): session opened",
+			expect: map[string]any{
+				"priority":  38,
+				"facility":  4,
+				"severity":  6,
+				"timestamp": time.Date(0, 11, 14, 9, 12, 31, 0, detroit),
+				"appname":   "su",
+				"proc_id":   "1234",
+				"message":   "pam_unix(su:session): session opened",
+			},
+		},
+		{
+			name:  "rfc3164 with rfc3339 timestamp",
+			input: "<38>2023-11-14T09:12:31Z host1 sshd[99]: Accepted publickey",
+			expect: map[string]any{
+				"priority":  38,
+				"facility":  4,
+				"severity":  6,
+				"timestamp": time.Date(2023, 11, 14, 9, 12, 31, 0, time.UTC),
+				"hostname":  "host1",
+				"appname":   "sshd",
+				"proc_id":   "99",
+				"message":   "Accepted publickey",
+			},
+		},
+		{
+			name:  "rfc3164 with numeric tag",
+			input: "<189>Nov 14 09:12:31 10.0.0.1 52: %LINK-3-UPDOWN: Interface Gi0/1, changed state to up",
+			expect: map[string]any{
+				"priority":        189,
+				"facility":        23,
+				"severity":        5,
+				"timestamp":       time.Date(0, 11, 14, 9, 12, 31, 0, detroit),
+				"hostname":        "10.0.0.1",
+				"sequence_number": 52,
+				"msg_id":          "LINK-3-UPDOWN",
+				"message":         "%LINK-3-UPDOWN: Interface Gi0/1, changed state to up",
+			},
+		},
+		{
+			name:  "junos",
+			input: "<28>Nov 14 09:12:31 mx960-re0 mib2d[1758]: SNMP_TRAP_LINK_DOWN: ifIndex 526, ifAdminStatus up(1), ifOperStatus down(2), ifName ge-0/0/1",
+			expect: map[string]any{
+				"priority":  28,
+				"facility":  3,
+				"severity":  4,
+				"timestamp": time.Date(0, 11, 14, 9, 12, 31, 0, detroit),
+				"hostname":  "mx960-re0",
+				"appname":   "mib2d",
+				"proc_id":   "1758",
+				"msg_id":    "SNMP_TRAP_LINK_DOWN",
+				"message":   "SNMP_TRAP_LINK_DOWN: ifIndex 526, ifAdminStatus up(1), ifOperStatus down(2), ifName ge-0/0/1",
+			},
+		},
+		{
+			name:  "junos srx session log",
+			input: "<14>Nov 14 09:12:31 srx-fw1 RT_FLOW: RT_FLOW_SESSION_CREATE: session created 10.1.1.10/52341->8.8.8.8/53 0x0 junos-dns-udp 10.1.1.10/52341->8.8.8.8/53 0x0 N/A N/A N/A N/A 17 trust-to-untrust trust untrust 4587 N/A(N/A) ge-0/0/1.0 UNKNOWN UNKNOWN UNKNOWN N/A N/A -1 N/A N/A N/A Off root",
+			expect: map[string]any{
+				"priority":  14,
+				"facility":  1,
+				"severity":  6,
+				"timestamp": time.Date(0, 11, 14, 9, 12, 31, 0, detroit),
+				"hostname":  "srx-fw1",
+				"appname":   "RT_FLOW",
+				"msg_id":    "RT_FLOW_SESSION_CREATE",
+				"message":   "RT_FLOW_SESSION_CREATE: session created 10.1.1.10/52341->8.8.8.8/53 0x0 junos-dns-udp 10.1.1.10/52341->8.8.8.8/53 0x0 N/A N/A N/A N/A 17 trust-to-untrust trust untrust 4587 N/A(N/A) ge-0/0/1.0 UNKNOWN UNKNOWN UNKNOWN N/A N/A -1 N/A N/A N/A Off root",
+			},
+		},
+		{
+			name:  "junos structured-data",
+			input: `<189>1 2023-11-14T09:12:31.112-05:00 mx960-re0 mgd 7023 UI_COMMIT_COMPLETED [junos@2636.1.1.1.2.25 username="admin"] commit complete`,
+			expect: map[string]any{
+				"priority":        189,
+				"facility":        23,
+				"severity":        5,
+				"version":         uint16(1),
+				"timestamp":       time.Date(2023, 11, 14, 14, 12, 31, 112000000, time.UTC),
+				"hostname":        "mx960-re0",
+				"appname":         "mgd",
+				"proc_id":         "7023",
+				"msg_id":          "UI_COMMIT_COMPLETED",
+				"structured_data": map[string]any{"junos@2636.1.1.1.2.25": map[string]any{"username": "admin"}},
+				"message":         "commit complete",
+			},
+		},
+		{
+			name:  "rfc5424",
+			input: "<165>1 2023-11-14T09:12:31.003Z mymachine evntslog - ID47 - An application event",
+			expect: map[string]any{
+				"priority":  165,
+				"facility":  20,
+				"severity":  5,
+				"version":   uint16(1),
+				"timestamp": time.Date(2023, 11, 14, 9, 12, 31, 3000000, time.UTC),
+				"hostname":  "mymachine",
+				"appname":   "evntslog",
+				"msg_id":    "ID47",
+				"message":   "An application event",
+			},
+		},
+		{
+			name:  "cisco without hostname and timestamp",
+			input: "<187>000123: %SYS-3-CPUHOG: Task ran for 2004 msec",
+			expect: map[string]any{
+				"priority":        187,
+				"facility":        23,
+				"severity":        3,
+				"timestamp":       now,
+				"sequence_number": 123,
+				"msg_id":          "SYS-3-CPUHOG",
+				"message":         "%SYS-3-CPUHOG: Task ran for 2004 msec",
+			},
+		},
+		{
+			name:  "cisco with year and local time zone",
+			input: "<189>17: .Nov 14 2023 09:12:31 EST: %SYS-5-RESTART: System restarted",
+			expect: map[string]any{
+				"priority":        189,
+				"facility":        23,
+				"severity":        5,
+				"timestamp":       time.Date(2023, 11, 14, 9, 12, 31, 0, detroit),
+				"sequence_number": 17,
+				"msg_id":          "SYS-5-RESTART",
+				"message":         "%SYS-5-RESTART: System restarted",
+			},
+		},
+		{
+			name:  "fortinet with time zone",
+			input: `<189>date=2023-11-14 time=09:12:31 devname="FGT-1" devid="FG100E" type="traffic" tz="+0100" msg="allowed"`,
+			expect: map[string]any{
+				"priority":  189,
+				"facility":  23,
+				"severity":  5,
+				"timestamp": time.Date(2023, 11, 14, 8, 12, 31, 0, time.UTC),
+				"hostname":  "FGT-1",
+				"message":   `date=2023-11-14 time=09:12:31 devname="FGT-1" devid="FG100E" type="traffic" tz="+0100" msg="allowed"`,
+				"fortinet": map[string]any{
+					"date":    "2023-11-14",
+					"time":    "09:12:31",
+					"devname": "FGT-1",
+					"devid":   "FG100E",
+					"type":    "traffic",
+					"tz":      "+0100",
+					"msg":     "allowed",
+				},
+			},
+		},
+		{
+			name:  "fortinet without time zone",
+			input: `<189>date=2023-11-14 time=09:12:31 devid=FG100E`,
+			expect: map[string]any{
+				"priority":  189,
+				"facility":  23,
+				"severity":  5,
+				"timestamp": time.Date(2023, 11, 14, 9, 12, 31, 0, detroit),
+				"message":   `date=2023-11-14 time=09:12:31 devid=FG100E`,
+				"fortinet": map[string]any{
+					"date":  "2023-11-14",
+					"time":  "09:12:31",
+					"devid": "FG100E",
+				},
+			},
+		},
+		{
+			name:  "without header",
+			input: "<13>this message has no header",
+			expect: map[string]any{
+				"priority":  13,
+				"facility":  1,
+				"severity":  5,
+				"timestamp": now,
+				"message":   "this message has no header",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parser.parseAuto([]byte(tc.input))
+			require.NoError(t, err)
+			for key, value := range tc.expect {
+				if expected, ok := value.(time.Time); ok {
+					require.True(t, expected.Equal(parsed[key].(time.Time)), "expected %s, got %s", expected, parsed[key])
+					continue
+				}
+				require.EqualValues(t, value, parsed[key], key)
+			}
+			require.Len(t, parsed, len(tc.expect))
+		})
+	}
+}
+
+func TestParseAutoMissingPriority(t *testing.T) {
+	parser := &Parser{location: time.UTC}
+	for _, input := range []string{"", "no priority", "<>1 message", "<192>message", "<abc>message", "<1234>message"} {
+		_, err := parser.parseAuto([]byte(input))
+		require.ErrorIs(t, err, errMissingPriority, input)
+	}
+}
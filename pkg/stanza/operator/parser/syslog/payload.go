@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/syslog"
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	cefPrefix  = "CEF:"
+	leefPrefix = "LEEF:"
+)
+
+var (
+	cefHeaderFields  = []string{"version", "device_vendor", "device_product", "device_version", "device_event_class_id", "name", "severity"}
+	leefHeaderFields = []string{"version", "vendor", "product", "product_version", "event_id"}
+
+	cefValueReplacer = strings.NewReplacer(`\\`, `\`, `\=`, `=`, `\n`, "\n", `\r`, "\r")
+)
+
+// parsePayload will parse a CEF or LEEF message into the cef or leef field.
+// Messages which are not valid CEF or LEEF are left untouched.
+func parsePayload(parsed map[string]any) {
+	message, ok := parsed["message"].(string)
+	if !ok {
+		return
+	}
+	message = strings.TrimLeft(message, " ")
+
+	switch {
+	case strings.HasPrefix(message, cefPrefix):
+		if cef, ok := parseCEF(message[len(cefPrefix):]); ok {
+			parsed["cef"] = cef
+			removePayloadAppname(parsed, cefPrefix)
+		}
+	case strings.HasPrefix(message, leefPrefix):
+		if leef, ok := parseLEEF(message[len(leefPrefix):]); ok {
+			parsed["leef"] = leef
+			removePayloadAppname(parsed, leefPrefix)
+		}
+	}
+}
+
+// removePayloadAppname removes the appname read from the CEF or LEEF prefix
+// of messages sent without a tag.
+func removePayloadAppname(parsed map[string]any, prefix string) {
+	if appname, ok := parsed["appname"].(string); ok && appname+":" == prefix {
+		delete(parsed, "appname")
+	}
+}
+
+// parseCEF parses a message of the form
+// CEF:Version|Device Vendor|Device Product|Device Version|Device Event Class ID|Name|Severity|Extension
+func parseCEF(payload string) (map[string]any, bool) {
+	parts := splitEscaped(payload, '|', len(cefHeaderFields)+1)
+	if len(parts) < len(cefHeaderFields) {
+		return nil, false
+	}
+
+	cef := make(map[string]any, len(cefHeaderFields)+1)
+	for i, field := range cefHeaderFields {
+		cef[field] = parts[i]
+	}
+	if len(parts) > len(cefHeaderFields) {
+		if extension := parseCEFExtension(parts[len(cefHeaderFields)]); len(extension) > 0 {
+			cef["extension"] = extension
+		}
+	}
+	return cef, true
+}
+
+// parseCEFExtension parses the space separated key=value pairs of a CEF extension.
+// Values may contain spaces, a new pair starts at the first unescaped '=' following a key.
+func parseCEFExtension(extension string) map[string]any {
+	type pair struct {
+		keyStart, eq int
+	}
+	var pairs []pair
+	for i := 0; i < len(extension); i++ {
+		switch extension[i] {
+		case '\\':
+			i++
+		case '=':
+			keyStart := strings.LastIndexByte(extension[:i], ' ') + 1
+			if isCEFKey(extension[keyStart:i]) && (len(pairs) == 0 || keyStart > pairs[len(pairs)-1].eq) {
+				pairs = append(pairs, pair{keyStart: keyStart, eq: i})
+			}
+		}
+	}
+
+	result := make(map[string]any, len(pairs))
+	for i, p := range pairs {
+		end := len(extension)
+		if i+1 < len(pairs) {
+			end = pairs[i+1].keyStart
+		}
+		value := strings.TrimRight(extension[p.eq+1:end], " ")
+		result[extension[p.keyStart:p.eq]] = cefValueReplacer.Replace(value)
+	}
+	return result
+}
+
+func isCEFKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '[' || c == ']' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLEEF parses a message of the form
+// LEEF:Version|Vendor|Product|Version|EventID|Attributes for LEEF 1.0, and
+// LEEF:Version|Vendor|Product|Version|EventID|Delimiter|Attributes for LEEF 2.0.
+func parseLEEF(payload string) (map[string]any, bool) {
+	headerLen := len(leefHeaderFields)
+	if strings.HasPrefix(payload, "2") {
+		headerLen++
+	}
+	parts := splitEscaped(payload, '|', headerLen+1)
+	if len(parts) < headerLen {
+		return nil, false
+	}
+
+	leef := make(map[string]any, len(leefHeaderFields)+1)
+	for i, field := range leefHeaderFields {
+		leef[field] = parts[i]
+	}
+
+	delimiter := "\t"
+	if headerLen > len(leefHeaderFields) {
+		var ok bool
+		if delimiter, ok = parseLEEFDelimiter(parts[len(leefHeaderFields)]); !ok {
+			return nil, false
+		}
+	}
+
+	if len(parts) > headerLen {
+		attributes := map[string]any{}
+		for _, attribute := range strings.Split(parts[headerLen], delimiter) {
+			if key, value, found := strings.Cut(attribute, "="); found && key != "" {
+				attributes[key] = value
+			}
+		}
+		if len(attributes) > 0 {
+			leef["attributes"] = attributes
+		}
+	}
+	return leef, true
+}
+
+// parseLEEFDelimiter parses the LEEF 2.0 delimiter, which is a single character
+// or its hexadecimal value such as x09 or 0x09. Tab is used when it is empty.
+func parseLEEFDelimiter(delimiter string) (string, bool) {
+	switch {
+	case delimiter == "":
+		return "\t", true
+	case len(delimiter) == 1:
+		return delimiter, true
+	}
+
+	hex, found := strings.CutPrefix(strings.ToLower(delimiter), "0x")
+	if !found {
+		if hex, found = strings.CutPrefix(strings.ToLower(delimiter), "x"); !found {
+			return "", false
+		}
+	}
+	value, err := strconv.ParseUint(hex, 16, 8)
+	if err != nil {
+		return "", false
+	}
+	return string(rune(value)), true
+}
+
+// splitEscaped splits s on each unescaped sep into at most n parts. Backslash escapes
+// are removed from all parts but the last one, which is returned as is.
+func splitEscaped(s string, sep byte, n int) []string {
+	var parts []string
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		if len(parts) == n-1 {
+			return append(parts, s[i:])
+		}
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && (s[i+1] == sep || s[i+1] == '\\'):
+			i++
+			current.WriteByte(s[i])
+		case s[i] == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(s[i])
+		}
+	}
+	return append(parts, current.String())
+}
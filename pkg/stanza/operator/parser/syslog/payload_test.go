@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePayload(t *testing.T) {
+	cases := []struct {
+		name   string
+		parsed map[string]any
+		expect map[string]any
+	}{
+		{
+			name: "cef with escaped values",
+			parsed: map[string]any{
+				"appname": "CEF",
+				"message": `CEF:0|Trend|Deep Security\|Agent|1.0|4000030|cmd.exe \\ started|6|act=blocked cs1=a\=b c\nd filePath=C:\\Windows\\cmd.exe suser=John Doe`,
+			},
+			expect: map[string]any{
+				"message": `CEF:0|Trend|Deep Security\|Agent|1.0|4000030|cmd.exe \\ started|6|act=blocked cs1=a\=b c\nd filePath=C:\\Windows\\cmd.exe suser=John Doe`,
+				"cef": map[string]any{
+					"version":               "0",
+					"device_vendor":         "Trend",
+					"device_product":        "Deep Security|Agent",
+					"device_version":        "1.0",
+					"device_event_class_id": "4000030",
+					"name":                  `cmd.exe \ started`,
+					"severity":              "6",
+					"extension": map[string]any{
+						"act":      "blocked",
+						"cs1":      "a=b c\nd",
+						"filePath": `C:\Windows\cmd.exe`,
+						"suser":    "John Doe",
+					},
+				},
+			},
+		},
+		{
+			name: "cef without extension keeps the appname from the tag",
+			parsed: map[string]any{
+				"appname": "ids",
+				"message": " CEF:1|Vendor|Product|2.0|100|Name|Low|",
+			},
+			expect: map[string]any{
+				"appname": "ids",
+				"message": " CEF:1|Vendor|Product|2.0|100|Name|Low|",
+				"cef": map[string]any{
+					"version":               "1",
+					"device_vendor":         "Vendor",
+					"device_product":        "Product",
+					"device_version":        "2.0",
+					"device_event_class_id": "100",
+					"name":                  "Name",
+					"severity":              "Low",
+				},
+			},
+		},
+		{
+			name: "leef 1.0",
+			parsed: map[string]any{
+				"message": "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=10.50.1.1\tdst=2.10.20.20\tsev=5",
+			},
+			expect: map[string]any{
+				"message": "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=10.50.1.1\tdst=2.10.20.20\tsev=5",
+				"leef": map[string]any{
+					"version":         "1.0",
+					"vendor":          "Microsoft",
+					"product":         "MSExchange",
+					"product_version": "4.0 SP1",
+					"event_id":        "15345",
+					"attributes": map[string]any{
+						"src": "10.50.1.1",
+						"dst": "2.10.20.20",
+						"sev": "5",
+					},
+				},
+			},
+		},
+		{
+			name: "leef 2.0 with character delimiter",
+			parsed: map[string]any{
+				"appname": "LEEF",
+				"message": "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5",
+			},
+			expect: map[string]any{
+				"message": "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5",
+				"leef": map[string]any{
+					"version":         "2.0",
+					"vendor":          "Lancope",
+					"product":         "StealthWatch",
+					"product_version": "1.0",
+					"event_id":        "41",
+					"attributes": map[string]any{
+						"src": "10.0.1.8",
+						"dst": "10.0.0.5",
+						"sev": "5",
+					},
+				},
+			},
+		},
+		{
+			name: "leef 2.0 with hexadecimal delimiter",
+			parsed: map[string]any{
+				"message": "LEEF:2.0|Vendor|Product|1.0|41|0x7c|src=10.0.1.8|dst=10.0.0.5",
+			},
+			expect: map[string]any{
+				"message": "LEEF:2.0|Vendor|Product|1.0|41|0x7c|src=10.0.1.8|dst=10.0.0.5",
+				"leef": map[string]any{
+					"version":         "2.0",
+					"vendor":          "Vendor",
+					"product":         "Product",
+					"product_version": "1.0",
+					"event_id":        "41",
+					"attributes": map[string]any{
+						"src": "10.0.1.8",
+						"dst": "10.0.0.5",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid cef",
+			parsed: map[string]any{
+				"appname": "CEF",
+				"message": "CEF:0|Vendor|Product",
+			},
+			expect: map[string]any{
+				"appname": "CEF",
+				"message": "CEF:0|Vendor|Product",
+			},
+		},
+		{
+			name: "invalid leef delimiter",
+			parsed: map[string]any{
+				"message": "LEEF:2.0|Vendor|Product|1.0|41|xzz|src=10.0.1.8",
+			},
+			expect: map[string]any{
+				"message": "LEEF:2.0|Vendor|Product|1.0|41|xzz|src=10.0.1.8",
+			},
+		},
+		{
+			name: "plain message",
+			parsed: map[string]any{
+				"message": "not a CEF:0| message",
+			},
+			expect: map[string]any{
+				"message": "not a CEF:0| message",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsePayload(tc.parsed)
+			require.Equal(t, tc.expect, tc.parsed)
+		})
+	}
+}
@@ -28,6 +28,7 @@ const (
 
 	RFC3164 = "rfc3164"
 	RFC5424 = "rfc5424"
+	Auto    = "auto"
 
 	NULTrailer = "NUL"
 	LFTrailer  = "LF"
@@ -61,6 +62,7 @@ type BaseConfig struct {
 	Location                     string  `mapstructure:"location,omitempty"`
 	EnableOctetCounting          bool    `mapstructure:"enable_octet_counting,omitempty"`
 	NonTransparentFramingTrailer *string `mapstructure:"non_transparent_framing_trailer,omitempty"`
+	EnablePayloadParsing         bool    `mapstructure:"enable_payload_parsing,omitempty"`
 }
 
 // Build will build a JSON parser operator.
@@ -91,7 +93,7 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		if *c.NonTransparentFramingTrailer != NULTrailer && *c.NonTransparentFramingTrailer != LFTrailer {
 			return nil, fmt.Errorf("invalid non_transparent_framing_trailer '%s'. Must be either 'LF' or 'NUL'", *c.NonTransparentFramingTrailer)
 		}
-	case proto != RFC5424 && proto != RFC3164:
+	case proto != RFC5424 && proto != RFC3164 && proto != Auto:
 		return nil, fmt.Errorf("unsupported protocol version: %s", proto)
 	}
 
@@ -110,6 +112,7 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		location:                     location,
 		enableOctetCounting:          c.EnableOctetCounting,
 		nonTransparentFramingTrailer: c.NonTransparentFramingTrailer,
+		enablePayloadParsing:         c.EnablePayloadParsing,
 	}, nil
 }
 
@@ -151,6 +154,7 @@ type Parser struct {
 	location                     *time.Location
 	enableOctetCounting          bool
 	nonTransparentFramingTrailer *string
+	enablePayloadParsing         bool
 }
 
 // Process will parse an entry field as syslog.
@@ -165,6 +169,24 @@ func (s *Parser) parse(value any) (any, error) {
 		return nil, err
 	}
 
+	var parsed map[string]any
+	if s.protocol == Auto {
+		parsed, err = s.parseAuto(bytes)
+	} else {
+		parsed, err = s.parseMessage(bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.enablePayloadParsing {
+		parsePayload(parsed)
+	}
+	return parsed, nil
+}
+
+// parseMessage will parse a value using the configured protocol.
+func (s *Parser) parseMessage(bytes []byte) (map[string]any, error) {
 	pFunc, err := s.buildParseFunc()
 	if err != nil {
 		return nil, err
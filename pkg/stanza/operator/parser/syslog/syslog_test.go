@@ -79,14 +79,14 @@ func TestSyslogParseRFC5424_SDNameTooLong(t *testing.T) {
 }
 
 func TestSyslogProtocolConfig(t *testing.T) {
-	for _, proto := range []string{"RFC5424", "rfc5424", "RFC3164", "rfc3164"} {
+	for _, proto := range []string{"RFC5424", "rfc5424", "RFC3164", "rfc3164", "AUTO", "auto"} {
 		cfg := basicConfig()
 		cfg.Protocol = proto
 		_, err := cfg.Build(testutil.Logger(t))
 		require.NoError(t, err)
 	}
 
-	for _, proto := range []string{"RFC5424a", "rfc5424b", "RFC3164c", "rfc3164d"} {
+	for _, proto := range []string{"RFC5424a", "rfc5424b", "RFC3164c", "rfc3164d", "autodetect"} {
 		cfg := basicConfig()
 		cfg.Protocol = proto
 		_, err := cfg.Build(testutil.Logger(t))
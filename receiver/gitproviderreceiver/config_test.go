@@ -17,7 +17,9 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/giteascraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/githubscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/gitlabscraper"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -31,7 +33,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 2)
+	assert.Equal(t, len(cfg.Receivers), 3)
 
 	r0 := cfg.Receivers[component.NewID(metadata.Type)]
 	defaultConfigGitHubScraper := factory.CreateDefaultConfig()
@@ -53,6 +55,27 @@ func TestLoadConfig(t *testing.T) {
 	}
 
 	assert.Equal(t, expectedConfig, r1)
+
+	r2 := cfg.Receivers[component.NewIDWithName(metadata.Type, "selfhosted")].(*Config)
+	gitlabConfig := (&gitlabscraper.Factory{}).CreateDefaultConfig().(*gitlabscraper.Config)
+	gitlabConfig.Endpoint = "https://gitlab.example.com"
+	gitlabConfig.GitLabOrg = "mygroup/mysubgroup"
+	gitlabConfig.SearchQuery = "service"
+	giteaConfig := (&giteascraper.Factory{}).CreateDefaultConfig().(*giteascraper.Config)
+	giteaConfig.Endpoint = "https://gitea.example.com"
+	giteaConfig.GiteaOrg = "myorg"
+	expectedConfig = &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			CollectionInterval: 60 * time.Second,
+			InitialDelay:       1 * time.Second,
+		},
+		Scrapers: map[string]internal.Config{
+			gitlabscraper.TypeStr: gitlabConfig,
+			giteascraper.TypeStr:  giteaConfig,
+		},
+	}
+
+	assert.Equal(t, expectedConfig, r2)
 }
 
 func TestLoadInvalidConfig_NoScrapers(t *testing.T) {
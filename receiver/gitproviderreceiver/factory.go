@@ -15,7 +15,9 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/giteascraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/githubscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/gitlabscraper"
 )
 
 // This file implements a factory for the git provider receiver
@@ -23,6 +25,8 @@ import (
 var (
 	scraperFactories = map[string]internal.ScraperFactory{
 		githubscraper.TypeStr: &githubscraper.Factory{},
+		gitlabscraper.TypeStr: &gitlabscraper.Factory{},
+		giteascraper.TypeStr:  &giteascraper.Factory{},
 	}
 
 	errConfigNotValid = errors.New("configuration is not valid for the git provider receiver")
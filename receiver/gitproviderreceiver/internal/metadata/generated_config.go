@@ -26,8 +26,10 @@ func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
 // MetricsConfig provides config for gitprovider metrics.
 type MetricsConfig struct {
 	GitRepositoryBranchCount      MetricConfig `mapstructure:"git.repository.branch.count"`
+	GitRepositoryCommitCount      MetricConfig `mapstructure:"git.repository.commit.count"`
 	GitRepositoryContributorCount MetricConfig `mapstructure:"git.repository.contributor.count"`
 	GitRepositoryCount            MetricConfig `mapstructure:"git.repository.count"`
+	GitRepositoryPullRequestTime  MetricConfig `mapstructure:"git.repository.pull_request.time"`
 }
 
 func DefaultMetricsConfig() MetricsConfig {
@@ -35,12 +37,18 @@ func DefaultMetricsConfig() MetricsConfig {
 		GitRepositoryBranchCount: MetricConfig{
 			Enabled: true,
 		},
+		GitRepositoryCommitCount: MetricConfig{
+			Enabled: false,
+		},
 		GitRepositoryContributorCount: MetricConfig{
 			Enabled: false,
 		},
 		GitRepositoryCount: MetricConfig{
 			Enabled: true,
 		},
+		GitRepositoryPullRequestTime: MetricConfig{
+			Enabled: true,
+		},
 	}
 }
 
@@ -27,8 +27,10 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
 					GitRepositoryBranchCount:      MetricConfig{Enabled: true},
+					GitRepositoryCommitCount:      MetricConfig{Enabled: true},
 					GitRepositoryContributorCount: MetricConfig{Enabled: true},
 					GitRepositoryCount:            MetricConfig{Enabled: true},
+					GitRepositoryPullRequestTime:  MetricConfig{Enabled: true},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
 					GitVendorName:    ResourceAttributeConfig{Enabled: true},
@@ -41,8 +43,10 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
 					GitRepositoryBranchCount:      MetricConfig{Enabled: false},
+					GitRepositoryCommitCount:      MetricConfig{Enabled: false},
 					GitRepositoryContributorCount: MetricConfig{Enabled: false},
 					GitRepositoryCount:            MetricConfig{Enabled: false},
+					GitRepositoryPullRequestTime:  MetricConfig{Enabled: false},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
 					GitVendorName:    ResourceAttributeConfig{Enabled: false},
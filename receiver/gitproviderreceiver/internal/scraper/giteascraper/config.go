@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package giteascraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/giteascraper"
+
+import (
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/metadata"
+)
+
+// Config relating to Gitea Metric Scraper.
+type Config struct {
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	internal.ScraperConfig
+	// GiteaOrg is the name of the Gitea organization to scrape (gitea scraper only)
+	GiteaOrg string `mapstructure:"gitea_org"`
+}
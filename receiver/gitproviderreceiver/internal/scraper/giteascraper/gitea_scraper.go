@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package giteascraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/giteascraper"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/metadata"
+)
+
+var (
+	errClientNotInitErr = errors.New("http client not initialized")
+	errMissingGiteaOrg  = errors.New("gitea_org must be specified")
+)
+
+type giteaScraper struct {
+	client   *http.Client
+	cfg      *Config
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	mb       *metadata.MetricsBuilder
+	rb       *metadata.ResourceBuilder
+}
+
+func (gts *giteaScraper) start(_ context.Context, host component.Host) (err error) {
+	gts.logger.Sugar().Info("starting the Gitea scraper")
+	gts.client, err = gts.cfg.ToClient(host, gts.settings)
+	return
+}
+
+func newGiteaScraper(
+	_ context.Context,
+	settings receiver.CreateSettings,
+	cfg *Config,
+) *giteaScraper {
+	return &giteaScraper{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+		logger:   settings.Logger,
+		mb:       metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}
+}
+
+// scrape and return gitea metrics. The contributor count is not reported
+// because the Gitea API does not list the contributors of a repository.
+func (gts *giteaScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if gts.client == nil {
+		return pmetric.NewMetrics(), errClientNotInitErr
+	}
+	if gts.cfg.GiteaOrg == "" {
+		return pmetric.NewMetrics(), errMissingGiteaOrg
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	metrics := gts.cfg.MetricsBuilderConfig.Metrics
+
+	// Get the repositories of the organization and record the total count of repositories
+	repos, err := gts.getRepos(ctx)
+	if err != nil {
+		gts.logger.Error("error getting repo data", zap.Error(err))
+		return gts.mb.Emit(), err
+	}
+
+	gts.mb.RecordGitRepositoryCountDataPoint(now, int64(len(repos)))
+
+	var wg sync.WaitGroup
+	// The metrics builder is not safe for concurrent use
+	var mux sync.Mutex
+
+	for _, repo := range repos {
+		repo := repo
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// The data points are only recorded for the requests that succeeded
+			if count, err := gts.getBranchCount(ctx, repo.Name); err != nil {
+				gts.logger.Sugar().Errorf("error getting branch count for repo %s: %v", repo.Name, err)
+			} else {
+				mux.Lock()
+				gts.mb.RecordGitRepositoryBranchCountDataPoint(now, int64(count), repo.Name)
+				mux.Unlock()
+			}
+
+			if metrics.GitRepositoryPullRequestTime.Enabled {
+				if pulls, err := gts.getPullRequests(ctx, repo.Name); err != nil {
+					gts.logger.Sugar().Errorf("error getting pull requests for repo %s: %v", repo.Name, err)
+				} else {
+					mux.Lock()
+					for _, pull := range pulls {
+						age := now.AsTime().Sub(pull.CreatedAt)
+						gts.mb.RecordGitRepositoryPullRequestTimeDataPoint(now, int64(age.Seconds()), repo.Name, pull.Head.Ref)
+					}
+					mux.Unlock()
+				}
+			}
+
+			if metrics.GitRepositoryCommitCount.Enabled {
+				// An empty repository has no default branch to count the commits of
+				var commits int
+				var err error
+				if !repo.Empty {
+					commits, err = gts.getCommitCount(ctx, repo.Name, repo.DefaultBranch)
+				}
+				if err != nil {
+					gts.logger.Sugar().Errorf("error getting commit count for repo %s: %v", repo.Name, err)
+				} else {
+					mux.Lock()
+					gts.mb.RecordGitRepositoryCommitCountDataPoint(now, int64(commits), repo.Name)
+					mux.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Set the resource attributes and emit metrics with those resources
+	gts.rb.SetGitVendorName("gitea")
+	gts.rb.SetOrganizationName(gts.cfg.GiteaOrg)
+	res := gts.rb.Emit()
+	return gts.mb.Emit(metadata.WithResource(res)), nil
+}
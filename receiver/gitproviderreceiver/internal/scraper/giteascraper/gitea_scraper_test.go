@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package giteascraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// newFakeGitea serves the parts of the Gitea API used by the scraper. The
+// repositories are listed over two pages of two items, as if the server used
+// a lower maximum page size than the requested one. Requests to the failing
+// path fail.
+func newFakeGitea(t *testing.T, prCreatedAt time.Time, failing string) *httptest.Server {
+	write := func(w http.ResponseWriter, total int, v any) {
+		w.Header().Set(totalCountHeader, strconv.Itoa(total))
+		assert.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.EscapedPath() == failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		switch r.URL.EscapedPath() {
+		case "/api/v1/orgs/myorg/repos":
+			assert.Equal(t, "50", query.Get("limit"))
+			switch query.Get("page") {
+			case "1":
+				write(w, 3, []map[string]any{
+					{"name": "api", "default_branch": "main"},
+					{"name": "legacy", "default_branch": "main", "archived": true},
+				})
+			case "2":
+				write(w, 3, []map[string]any{{"name": "web", "default_branch": "trunk", "empty": true}})
+			default:
+				assert.Fail(t, "unexpected page", query.Get("page"))
+			}
+		case "/api/v1/repos/myorg/api/branches":
+			// Without the total count, the list ends with an empty page
+			branches := []map[string]any{}
+			if query.Get("page") == "1" {
+				branches = append(branches, map[string]any{"name": "main"}, map[string]any{"name": "feature"})
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(branches))
+		case "/api/v1/repos/myorg/web/branches":
+			write(w, 1, []map[string]any{{"name": "trunk"}})
+		case "/api/v1/repos/myorg/api/pulls":
+			assert.Equal(t, "open", query.Get("state"))
+			write(w, 1, []map[string]any{{"head": map[string]any{"ref": "feature"}, "created_at": prCreatedAt.Format(time.RFC3339)}})
+		case "/api/v1/repos/myorg/web/pulls":
+			write(w, 0, []map[string]any{})
+		case "/api/v1/repos/myorg/api/commits":
+			assert.Equal(t, "main", query.Get("sha"))
+			assert.Equal(t, "1", query.Get("limit"))
+			write(w, 42, []map[string]any{{"sha": "abc"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// dataPoints returns the data points of the gauge of the given name
+func dataPoints(metrics pmetric.Metrics, name string) pmetric.NumberDataPointSlice {
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == name {
+			return ms.At(i).Gauge().DataPoints()
+		}
+	}
+	return pmetric.NewNumberDataPointSlice()
+}
+
+// valuesByRepository returns the values of the data points of the given name by repository name
+func valuesByRepository(metrics pmetric.Metrics, name string) map[string]int64 {
+	values := map[string]int64{}
+	dps := dataPoints(metrics, name)
+	for i := 0; i < dps.Len(); i++ {
+		repo, _ := dps.At(i).Attributes().Get("repository.name")
+		values[repo.Str()] = dps.At(i).IntValue()
+	}
+	return values
+}
+
+func TestScrape(t *testing.T) {
+	prCreatedAt := time.Now().Add(-time.Hour)
+	server := newFakeGitea(t, prCreatedAt, "")
+	defer server.Close()
+
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.GiteaOrg = "myorg"
+	cfg.MetricsBuilderConfig.Metrics.GitRepositoryCommitCount.Enabled = true
+
+	gts := newGiteaScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, gts.start(context.Background(), componenttest.NewNopHost()))
+	metrics, err := gts.scrape(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{
+		"git.vendor.name":   "gitea",
+		"organization.name": "myorg",
+	}, metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	// The archived repositories are ignored
+	assert.EqualValues(t, 2, dataPoints(metrics, "git.repository.count").At(0).IntValue())
+	assert.Equal(t, map[string]int64{"api": 2, "web": 1}, valuesByRepository(metrics, "git.repository.branch.count"))
+	// The commits of empty repositories are not listed
+	assert.Equal(t, map[string]int64{"api": 42, "web": 0}, valuesByRepository(metrics, "git.repository.commit.count"))
+
+	pullRequestTime := dataPoints(metrics, "git.repository.pull_request.time")
+	require.Equal(t, 1, pullRequestTime.Len())
+	assert.InDelta(t, time.Hour.Seconds(), pullRequestTime.At(0).IntValue(), 60)
+	branch, _ := pullRequestTime.At(0).Attributes().Get("branch.name")
+	assert.Equal(t, "feature", branch.Str())
+}
+
+func TestScrapeErrors(t *testing.T) {
+	server := newFakeGitea(t, time.Now(), "")
+	defer server.Close()
+
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	gts := newGiteaScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+
+	_, err := gts.scrape(context.Background())
+	assert.ErrorIs(t, err, errClientNotInitErr)
+
+	require.NoError(t, gts.start(context.Background(), componenttest.NewNopHost()))
+	_, err = gts.scrape(context.Background())
+	assert.ErrorIs(t, err, errMissingGiteaOrg)
+
+	cfg.GiteaOrg = "unknown"
+	_, err = gts.scrape(context.Background())
+	assert.ErrorContains(t, err, "unexpected status code 404")
+}
+
+func TestScrapeFailedRequests(t *testing.T) {
+	server := newFakeGitea(t, time.Now(), "/api/v1/repos/myorg/web/branches")
+	defer server.Close()
+
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.GiteaOrg = "myorg"
+
+	gts := newGiteaScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, gts.start(context.Background(), componenttest.NewNopHost()))
+	metrics, err := gts.scrape(context.Background())
+	require.NoError(t, err)
+
+	// No data point is recorded for the failed request
+	assert.Equal(t, map[string]int64{"api": 2}, valuesByRepository(metrics, "git.repository.branch.count"))
+}
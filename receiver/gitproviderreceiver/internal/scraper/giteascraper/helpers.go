@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package giteascraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/giteascraper"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// The default public Gitea URL
+	defaultBaseURL = "https://gitea.com"
+	// The default maximum page size of the Gitea API
+	pageLimit = 50
+	// The header holding the total count of the items of a list
+	totalCountHeader = "X-Total-Count"
+)
+
+type repository struct {
+	Name          string `json:"name"`
+	DefaultBranch string `json:"default_branch"`
+	Archived      bool   `json:"archived"`
+	Empty         bool   `json:"empty"`
+}
+
+type pullRequest struct {
+	Head struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Returns the URL of a Gitea API path. By default, the public Gitea URL is
+// used. If the user has specified an endpoint in the config via the inherited
+// HTTPClientSettings, then that endpoint is used instead. The endpoint defined
+// needs to be the root of the server.
+// https://docs.gitea.com/development/api-usage
+func (gts *giteaScraper) apiURL(path string, query url.Values) string {
+	base := defaultBaseURL
+	if gts.cfg.HTTPClientSettings.Endpoint != "" {
+		base = gts.cfg.HTTPClientSettings.Endpoint
+	}
+	return strings.TrimSuffix(base, "/") + "/api/v1/" + path + "?" + query.Encode()
+}
+
+// get gets a page of the Gitea API, returning the response whose body
+// must be closed by the caller.
+func (gts *giteaScraper) get(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gts.apiURL(path, query), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gts.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, path)
+	}
+	return resp, nil
+}
+
+// getPages gets every page of a list from the Gitea API, calling handle with
+// the decoder of each page. handle returns the number of items of the page.
+func (gts *giteaScraper) getPages(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	handle func(*json.Decoder) (int, error),
+) error {
+	query.Set("limit", strconv.Itoa(pageLimit))
+
+	for page, count := 1, 0; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		resp, err := gts.get(ctx, path, query)
+		if err != nil {
+			return err
+		}
+		n, err := handle(json.NewDecoder(resp.Body))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		// The servers may use a lower maximum page size than the requested one, so the
+		// end of the list is detected with the total count, or with an empty page
+		count += n
+		total, err := strconv.Atoi(resp.Header.Get(totalCountHeader))
+		if n == 0 || (err == nil && count >= total) {
+			return nil
+		}
+	}
+}
+
+// Get the non archived repositories of the organization
+func (gts *giteaScraper) getRepos(ctx context.Context) ([]repository, error) {
+	var all []repository
+
+	err := gts.getPages(ctx, "orgs/"+url.PathEscape(gts.cfg.GiteaOrg)+"/repos", url.Values{}, func(d *json.Decoder) (int, error) {
+		var repos []repository
+		if err := d.Decode(&repos); err != nil {
+			return 0, err
+		}
+		for _, repo := range repos {
+			if !repo.Archived {
+				all = append(all, repo)
+			}
+		}
+		return len(repos), nil
+	})
+	if err != nil {
+		gts.logger.Error("error getting repos", zap.Error(err))
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// repoPath returns the API path of a repository of the organization
+func (gts *giteaScraper) repoPath(repoName string, elem string) string {
+	return "repos/" + url.PathEscape(gts.cfg.GiteaOrg) + "/" + url.PathEscape(repoName) + "/" + elem
+}
+
+// Get the branch count of a repository
+func (gts *giteaScraper) getBranchCount(ctx context.Context, repoName string) (int, error) {
+	var count int
+
+	err := gts.getPages(ctx, gts.repoPath(repoName, "branches"), url.Values{}, func(d *json.Decoder) (int, error) {
+		var branches []json.RawMessage
+		if err := d.Decode(&branches); err != nil {
+			return 0, err
+		}
+		count += len(branches)
+		return len(branches), nil
+	})
+
+	return count, err
+}
+
+// Get the open pull requests of a repository
+func (gts *giteaScraper) getPullRequests(ctx context.Context, repoName string) ([]pullRequest, error) {
+	var all []pullRequest
+
+	query := url.Values{"state": {"open"}}
+	err := gts.getPages(ctx, gts.repoPath(repoName, "pulls"), query, func(d *json.Decoder) (int, error) {
+		var pulls []pullRequest
+		if err := d.Decode(&pulls); err != nil {
+			return 0, err
+		}
+		all = append(all, pulls...)
+		return len(pulls), nil
+	})
+
+	return all, err
+}
+
+// Get the commit count of a branch from the total count of the commits list,
+// listing a single commit without its details
+func (gts *giteaScraper) getCommitCount(ctx context.Context, repoName string, branch string) (int, error) {
+	query := url.Values{
+		"sha":          {branch},
+		"limit":        {"1"},
+		"stat":         {"false"},
+		"verification": {"false"},
+		"files":        {"false"},
+	}
+	resp, err := gts.get(ctx, gts.repoPath(repoName, "commits"), query)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return strconv.Atoi(resp.Header.Get(totalCountHeader))
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlabscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/gitlabscraper"
+
+import (
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/metadata"
+)
+
+// Config relating to GitLab Metric Scraper.
+type Config struct {
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	internal.ScraperConfig
+	// GitLabOrg is the full path of the GitLab group to scrape, including its subgroups (gitlab scraper only)
+	GitLabOrg string `mapstructure:"gitlab_org"`
+	// SearchQuery filters the projects of the group by name (gitlab scraper only)
+	SearchQuery string `mapstructure:"search_query"`
+}
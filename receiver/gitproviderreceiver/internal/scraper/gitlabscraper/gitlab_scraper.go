@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlabscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/gitlabscraper"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/metadata"
+)
+
+var (
+	errClientNotInitErr = errors.New("http client not initialized")
+	errMissingGitLabOrg = errors.New("gitlab_org must be specified")
+)
+
+type gitlabScraper struct {
+	client   *http.Client
+	cfg      *Config
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	mb       *metadata.MetricsBuilder
+	rb       *metadata.ResourceBuilder
+}
+
+func (gls *gitlabScraper) start(_ context.Context, host component.Host) (err error) {
+	gls.logger.Sugar().Info("starting the GitLab scraper")
+	gls.client, err = gls.cfg.ToClient(host, gls.settings)
+	return
+}
+
+func newGitLabScraper(
+	_ context.Context,
+	settings receiver.CreateSettings,
+	cfg *Config,
+) *gitlabScraper {
+	return &gitlabScraper{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+		logger:   settings.Logger,
+		mb:       metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}
+}
+
+// scrape and return gitlab metrics
+func (gls *gitlabScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if gls.client == nil {
+		return pmetric.NewMetrics(), errClientNotInitErr
+	}
+	if gls.cfg.GitLabOrg == "" {
+		return pmetric.NewMetrics(), errMissingGitLabOrg
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	metrics := gls.cfg.MetricsBuilderConfig.Metrics
+
+	// Get the projects of the group and its subgroups and record the total count of repositories
+	projects, err := gls.getProjects(ctx)
+	if err != nil {
+		gls.logger.Error("error getting project data", zap.Error(err))
+		return gls.mb.Emit(), err
+	}
+
+	gls.mb.RecordGitRepositoryCountDataPoint(now, int64(len(projects)))
+
+	var wg sync.WaitGroup
+	// The metrics builder is not safe for concurrent use
+	var mux sync.Mutex
+
+	for _, p := range projects {
+		p := p
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// The data points are only recorded for the requests that succeeded
+			if count, err := gls.getBranchCount(ctx, p.ID); err != nil {
+				gls.logger.Sugar().Errorf("error getting branch count for project %s: %v", p.PathWithNamespace, err)
+			} else {
+				mux.Lock()
+				gls.mb.RecordGitRepositoryBranchCountDataPoint(now, int64(count), p.PathWithNamespace)
+				mux.Unlock()
+			}
+
+			if metrics.GitRepositoryContributorCount.Enabled {
+				if contribs, err := gls.getContributorCount(ctx, p.ID); err != nil {
+					gls.logger.Sugar().Errorf("error getting contributor count for project %s: %v", p.PathWithNamespace, err)
+				} else {
+					mux.Lock()
+					gls.mb.RecordGitRepositoryContributorCountDataPoint(now, int64(contribs), p.PathWithNamespace)
+					mux.Unlock()
+				}
+			}
+
+			if metrics.GitRepositoryPullRequestTime.Enabled {
+				if mergeRequests, err := gls.getMergeRequests(ctx, p.ID); err != nil {
+					gls.logger.Sugar().Errorf("error getting merge requests for project %s: %v", p.PathWithNamespace, err)
+				} else {
+					mux.Lock()
+					for _, mr := range mergeRequests {
+						age := now.AsTime().Sub(mr.CreatedAt)
+						gls.mb.RecordGitRepositoryPullRequestTimeDataPoint(now, int64(age.Seconds()), p.PathWithNamespace, mr.SourceBranch)
+					}
+					mux.Unlock()
+				}
+			}
+
+			// The statistics are only returned to the members of the project with at least the reporter role
+			if p.Statistics != nil {
+				mux.Lock()
+				gls.mb.RecordGitRepositoryCommitCountDataPoint(now, p.Statistics.CommitCount, p.PathWithNamespace)
+				mux.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Set the resource attributes and emit metrics with those resources
+	gls.rb.SetGitVendorName("gitlab")
+	gls.rb.SetOrganizationName(gls.cfg.GitLabOrg)
+	res := gls.rb.Emit()
+	return gls.mb.Emit(metadata.WithResource(res)), nil
+}
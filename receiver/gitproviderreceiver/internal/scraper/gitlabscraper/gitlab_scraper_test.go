@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlabscraper
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// newFakeGitLab serves the parts of the GitLab REST API used by the scraper,
+// with the projects listed over two pages. Requests to the failing path fail.
+func newFakeGitLab(t *testing.T, mrCreatedAt time.Time, failing string) *httptest.Server {
+	write := func(w http.ResponseWriter, v any) {
+		assert.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "100", query.Get("per_page"))
+		if r.URL.EscapedPath() == failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		switch r.URL.EscapedPath() {
+		case "/api/v4/groups/mygroup%2Fsub/projects":
+			assert.Equal(t, "true", query.Get("include_subgroups"))
+			assert.Equal(t, "false", query.Get("archived"))
+			assert.Equal(t, "true", query.Get("statistics"))
+			if query.Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				write(w, []map[string]any{{"id": 1, "path_with_namespace": "mygroup/sub/api", "statistics": map[string]any{"commit_count": 42}}})
+				return
+			}
+			write(w, []map[string]any{{"id": 2, "path_with_namespace": "mygroup/sub/web"}})
+		case "/api/v4/projects/1/repository/branches":
+			write(w, []map[string]any{{"name": "main"}, {"name": "feature"}})
+		case "/api/v4/projects/2/repository/branches":
+			write(w, []map[string]any{{"name": "main"}})
+		case "/api/v4/projects/1/repository/contributors", "/api/v4/projects/2/repository/contributors":
+			write(w, []map[string]any{{"email": "dev@example.com"}})
+		case "/api/v4/projects/1/merge_requests":
+			assert.Equal(t, "opened", query.Get("state"))
+			write(w, []map[string]any{{"source_branch": "feature", "created_at": mrCreatedAt.Format(time.RFC3339)}})
+		case "/api/v4/projects/2/merge_requests":
+			write(w, []map[string]any{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// dataPoints returns the data points of the gauge of the given name
+func dataPoints(metrics pmetric.Metrics, name string) pmetric.NumberDataPointSlice {
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == name {
+			return ms.At(i).Gauge().DataPoints()
+		}
+	}
+	return pmetric.NewNumberDataPointSlice()
+}
+
+// valuesByRepository returns the values of the data points of the given name by repository name
+func valuesByRepository(metrics pmetric.Metrics, name string) map[string]int64 {
+	values := map[string]int64{}
+	dps := dataPoints(metrics, name)
+	for i := 0; i < dps.Len(); i++ {
+		repo, _ := dps.At(i).Attributes().Get("repository.name")
+		values[repo.Str()] = dps.At(i).IntValue()
+	}
+	return values
+}
+
+func TestScrape(t *testing.T) {
+	mrCreatedAt := time.Now().Add(-time.Hour)
+	server := newFakeGitLab(t, mrCreatedAt, "")
+	defer server.Close()
+
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.GitLabOrg = "mygroup/sub"
+	cfg.MetricsBuilderConfig.Metrics.GitRepositoryContributorCount.Enabled = true
+	cfg.MetricsBuilderConfig.Metrics.GitRepositoryCommitCount.Enabled = true
+
+	gls := newGitLabScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, gls.start(context.Background(), componenttest.NewNopHost()))
+	metrics, err := gls.scrape(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	assert.Equal(t, map[string]any{
+		"git.vendor.name":   "gitlab",
+		"organization.name": "mygroup/sub",
+	}, metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	assert.EqualValues(t, 2, dataPoints(metrics, "git.repository.count").At(0).IntValue())
+	assert.Equal(t, map[string]int64{"mygroup/sub/api": 2, "mygroup/sub/web": 1}, valuesByRepository(metrics, "git.repository.branch.count"))
+	assert.Equal(t, map[string]int64{"mygroup/sub/api": 1, "mygroup/sub/web": 1}, valuesByRepository(metrics, "git.repository.contributor.count"))
+	// The statistics are not returned for the second project
+	assert.Equal(t, map[string]int64{"mygroup/sub/api": 42}, valuesByRepository(metrics, "git.repository.commit.count"))
+
+	pullRequestTime := dataPoints(metrics, "git.repository.pull_request.time")
+	require.Equal(t, 1, pullRequestTime.Len())
+	assert.InDelta(t, time.Hour.Seconds(), pullRequestTime.At(0).IntValue(), 60)
+	branch, _ := pullRequestTime.At(0).Attributes().Get("branch.name")
+	assert.Equal(t, "feature", branch.Str())
+}
+
+func TestScrapeErrors(t *testing.T) {
+	server := newFakeGitLab(t, time.Now(), "")
+	defer server.Close()
+
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	gls := newGitLabScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+
+	_, err := gls.scrape(context.Background())
+	assert.ErrorIs(t, err, errClientNotInitErr)
+
+	require.NoError(t, gls.start(context.Background(), componenttest.NewNopHost()))
+	_, err = gls.scrape(context.Background())
+	assert.ErrorIs(t, err, errMissingGitLabOrg)
+
+	cfg.GitLabOrg = "unknown"
+	_, err = gls.scrape(context.Background())
+	assert.ErrorContains(t, err, "unexpected status code 404")
+}
+
+func TestScrapeFailedRequests(t *testing.T) {
+	server := newFakeGitLab(t, time.Now(), "/api/v4/projects/2/repository/branches")
+	defer server.Close()
+
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.GitLabOrg = "mygroup/sub"
+	cfg.MetricsBuilderConfig.Metrics.GitRepositoryCommitCount.Enabled = true
+
+	gls := newGitLabScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, gls.start(context.Background(), componenttest.NewNopHost()))
+	metrics, err := gls.scrape(context.Background())
+	require.NoError(t, err)
+
+	// No data point is recorded for the failed request
+	assert.Equal(t, map[string]int64{"mygroup/sub/api": 2}, valuesByRepository(metrics, "git.repository.branch.count"))
+}
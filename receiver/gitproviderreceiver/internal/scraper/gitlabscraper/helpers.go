@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlabscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/gitproviderreceiver/internal/scraper/gitlabscraper"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// The default public GitLab URL
+	defaultBaseURL = "https://gitlab.com"
+	// The maximum page size of the GitLab REST API
+	perPage = 100
+)
+
+type project struct {
+	ID                int         `json:"id"`
+	PathWithNamespace string      `json:"path_with_namespace"`
+	Statistics        *statistics `json:"statistics"`
+}
+
+type statistics struct {
+	CommitCount int64 `json:"commit_count"`
+}
+
+type mergeRequest struct {
+	SourceBranch string    `json:"source_branch"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Returns the URL of a GitLab REST API path. By default, the public GitLab
+// URL is used. If the user has specified an endpoint in the config via the
+// inherited HTTPClientSettings, then that endpoint is used instead. The
+// endpoint defined needs to be the root of the server.
+// https://docs.gitlab.com/ee/api/rest/
+func (gls *gitlabScraper) apiURL(path string, query url.Values) string {
+	base := defaultBaseURL
+	if gls.cfg.HTTPClientSettings.Endpoint != "" {
+		base = gls.cfg.HTTPClientSettings.Endpoint
+	}
+	return strings.TrimSuffix(base, "/") + "/api/v4/" + path + "?" + query.Encode()
+}
+
+// getPages gets every page of a list from the GitLab REST API, calling
+// handle with the decoder of each page.
+// https://docs.gitlab.com/ee/api/rest/index.html#pagination
+func (gls *gitlabScraper) getPages(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	handle func(*json.Decoder) error,
+) error {
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, gls.apiURL(path, query), nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := gls.client.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, path)
+		}
+		err = handle(json.NewDecoder(resp.Body))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		page = resp.Header.Get("X-Next-Page")
+	}
+
+	return nil
+}
+
+// Get the non archived projects of the group and of its subgroups, filtered
+// by the search query if any. The statistics, which include the commit
+// count, are only requested if the commit count metric is enabled.
+func (gls *gitlabScraper) getProjects(ctx context.Context) ([]project, error) {
+	var all []project
+
+	query := url.Values{
+		"include_subgroups": {"true"},
+		"archived":          {"false"},
+	}
+	if gls.cfg.SearchQuery != "" {
+		query.Set("search", gls.cfg.SearchQuery)
+	}
+	if gls.cfg.MetricsBuilderConfig.Metrics.GitRepositoryCommitCount.Enabled {
+		query.Set("statistics", "true")
+	}
+
+	err := gls.getPages(ctx, "groups/"+url.PathEscape(gls.cfg.GitLabOrg)+"/projects", query, func(d *json.Decoder) error {
+		var projects []project
+		if err := d.Decode(&projects); err != nil {
+			return err
+		}
+		all = append(all, projects...)
+		return nil
+	})
+	if err != nil {
+		gls.logger.Error("error getting projects", zap.Error(err))
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// countPages counts the items of every page of a list
+func (gls *gitlabScraper) countPages(ctx context.Context, path string, query url.Values) (int, error) {
+	var count int
+
+	err := gls.getPages(ctx, path, query, func(d *json.Decoder) error {
+		var items []json.RawMessage
+		if err := d.Decode(&items); err != nil {
+			return err
+		}
+		count += len(items)
+		return nil
+	})
+
+	return count, err
+}
+
+// Get the branch count of a project
+func (gls *gitlabScraper) getBranchCount(ctx context.Context, projectID int) (int, error) {
+	return gls.countPages(ctx, fmt.Sprintf("projects/%d/repository/branches", projectID), url.Values{})
+}
+
+// Get the contributor count of a project
+func (gls *gitlabScraper) getContributorCount(ctx context.Context, projectID int) (int, error) {
+	return gls.countPages(ctx, fmt.Sprintf("projects/%d/repository/contributors", projectID), url.Values{})
+}
+
+// Get the open merge requests of a project
+func (gls *gitlabScraper) getMergeRequests(ctx context.Context, projectID int) ([]mergeRequest, error) {
+	var all []mergeRequest
+
+	query := url.Values{"state": {"opened"}}
+	err := gls.getPages(ctx, fmt.Sprintf("projects/%d/merge_requests", projectID), query, func(d *json.Decoder) error {
+		var mergeRequests []mergeRequest
+		if err := d.Decode(&mergeRequests); err != nil {
+			return err
+		}
+		all = append(all, mergeRequests...)
+		return nil
+	})
+
+	return all, err
+}
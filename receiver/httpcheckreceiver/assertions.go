@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// statusRange is an inclusive range of status codes
+type statusRange struct {
+	min, max int
+}
+
+type jsonPathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+type jsonPathAssertion struct {
+	path  string
+	steps []jsonPathStep
+	value string
+}
+
+// assertions are the compiled assertions of a target
+type assertions struct {
+	statusExpression string
+	statusRanges     []statusRange
+	bodyRegex        *regexp.Regexp
+	jsonPaths        []jsonPathAssertion
+}
+
+// assertionResult is the outcome of an assertion checked against a response
+type assertionResult struct {
+	assertionType metadata.AttributeAssertionType
+	expression    string
+	passed        bool
+}
+
+func newAssertions(cfg assertionsConfig) (*assertions, error) {
+	var err error
+	a := &assertions{
+		statusExpression: strings.Join(cfg.StatusCodes, ","),
+	}
+
+	for _, status := range cfg.StatusCodes {
+		r, parseErr := parseStatusRange(status)
+		if parseErr != nil {
+			err = multierr.Append(err, parseErr)
+			continue
+		}
+		a.statusRanges = append(a.statusRanges, r)
+	}
+
+	if cfg.BodyRegex != "" {
+		regex, compileErr := regexp.Compile(cfg.BodyRegex)
+		if compileErr != nil {
+			err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidRegex.Error(), compileErr))
+		}
+		a.bodyRegex = regex
+	}
+
+	for _, jsonPath := range cfg.JSONPaths {
+		steps, parseErr := parseJSONPath(jsonPath.Path)
+		if parseErr != nil {
+			err = multierr.Append(err, parseErr)
+			continue
+		}
+		a.jsonPaths = append(a.jsonPaths, jsonPathAssertion{path: jsonPath.Path, steps: steps, value: jsonPath.Value})
+	}
+
+	return a, err
+}
+
+// parseStatusRange parses a status code such as 200, a class such as 2xx or a range such as 200-299
+func parseStatusRange(status string) (statusRange, error) {
+	status = strings.TrimSpace(status)
+	if class, ok := httpResponseClasses[strings.ToLower(status)]; ok {
+		return statusRange{min: class * 100, max: class*100 + 99}, nil
+	}
+
+	low, high, isRange := strings.Cut(status, "-")
+	minCode, err := strconv.Atoi(strings.TrimSpace(low))
+	if err != nil {
+		return statusRange{}, fmt.Errorf("%s: %q", errInvalidStatus.Error(), status)
+	}
+	maxCode := minCode
+	if isRange {
+		if maxCode, err = strconv.Atoi(strings.TrimSpace(high)); err != nil {
+			return statusRange{}, fmt.Errorf("%s: %q", errInvalidStatus.Error(), status)
+		}
+	}
+	if minCode < 100 || maxCode > 599 || minCode > maxCode {
+		return statusRange{}, fmt.Errorf("%s: %q", errInvalidStatus.Error(), status)
+	}
+	return statusRange{min: minCode, max: maxCode}, nil
+}
+
+// parseJSONPath parses a path made of object keys and array indexes, such as $.items[0].name
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%s: empty path", errInvalidJSONPath.Error())
+	}
+
+	rest := strings.TrimPrefix(path, "$")
+	// The first key of the paths which don't start from the root needs no dot
+	bareKey := !strings.HasPrefix(path, "$")
+	var steps []jsonPathStep
+	for rest != "" {
+		if rest[0] == '[' {
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%s: %q", errInvalidJSONPath.Error(), path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("%s: %q", errInvalidJSONPath.Error(), path)
+			}
+			steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			rest = rest[end+1:]
+			bareKey = false
+			continue
+		}
+
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+		case !bareKey:
+			return nil, fmt.Errorf("%s: %q", errInvalidJSONPath.Error(), path)
+		}
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("%s: %q", errInvalidJSONPath.Error(), path)
+		}
+		steps = append(steps, jsonPathStep{key: rest[:end]})
+		rest = rest[end:]
+		bareKey = false
+	}
+	return steps, nil
+}
+
+// needsBody returns whether the assertions are checked against the response body
+func (a *assertions) needsBody() bool {
+	return a.bodyRegex != nil || len(a.jsonPaths) > 0
+}
+
+// check checks the assertions against a response. The assertions of a failed
+// request are checked against a status code of 0 and an empty body.
+func (a *assertions) check(statusCode int, body []byte) []assertionResult {
+	var results []assertionResult
+
+	if len(a.statusRanges) > 0 {
+		passed := false
+		for _, r := range a.statusRanges {
+			if statusCode >= r.min && statusCode <= r.max {
+				passed = true
+				break
+			}
+		}
+		results = append(results, assertionResult{
+			assertionType: metadata.AttributeAssertionTypeStatus,
+			expression:    a.statusExpression,
+			passed:        passed,
+		})
+	}
+
+	if a.bodyRegex != nil {
+		results = append(results, assertionResult{
+			assertionType: metadata.AttributeAssertionTypeBodyRegex,
+			expression:    a.bodyRegex.String(),
+			passed:        a.bodyRegex.Match(body),
+		})
+	}
+
+	if len(a.jsonPaths) > 0 {
+		var doc any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		validJSON := decoder.Decode(&doc) == nil
+		for _, jsonPath := range a.jsonPaths {
+			results = append(results, assertionResult{
+				assertionType: metadata.AttributeAssertionTypeJSONPath,
+				expression:    jsonPath.path,
+				passed:        validJSON && jsonPath.check(doc),
+			})
+		}
+	}
+
+	return results
+}
+
+// check returns whether the path exists in the document, and holds the expected value if any
+func (a jsonPathAssertion) check(doc any) bool {
+	value := doc
+	for _, step := range a.steps {
+		if step.isIndex {
+			array, ok := value.([]any)
+			if !ok || step.index >= len(array) {
+				return false
+			}
+			value = array[step.index]
+			continue
+		}
+		object, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		if value, ok = object[step.key]; !ok {
+			return false
+		}
+	}
+
+	if a.value == "" {
+		return true
+	}
+	if str, ok := value.(string); ok {
+		return str == a.value
+	}
+	// The other values are compared to their JSON representation, such as 42, true or null
+	encoded, err := json.Marshal(value)
+	return err == nil && string(encoded) == a.value
+}
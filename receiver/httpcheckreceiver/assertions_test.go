@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+func TestParseStatusRange(t *testing.T) {
+	testCases := []struct {
+		status      string
+		expected    statusRange
+		expectedErr bool
+	}{
+		{status: "200", expected: statusRange{min: 200, max: 200}},
+		{status: "2xx", expected: statusRange{min: 200, max: 299}},
+		{status: "4XX", expected: statusRange{min: 400, max: 499}},
+		{status: "200-204", expected: statusRange{min: 200, max: 204}},
+		{status: "6xx", expectedErr: true},
+		{status: "99", expectedErr: true},
+		{status: "300-200", expectedErr: true},
+		{status: "200-", expectedErr: true},
+		{status: "ok", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.status, func(t *testing.T) {
+			actual, err := parseStatusRange(tc.status)
+			if tc.expectedErr {
+				require.ErrorContains(t, err, errInvalidStatus.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseJSONPath(t *testing.T) {
+	testCases := []struct {
+		path        string
+		expected    []jsonPathStep
+		expectedErr bool
+	}{
+		{path: "$"},
+		{path: "$.status", expected: []jsonPathStep{{key: "status"}}},
+		{path: "status", expected: []jsonPathStep{{key: "status"}}},
+		{
+			path:     "$.items[1].name",
+			expected: []jsonPathStep{{key: "items"}, {index: 1, isIndex: true}, {key: "name"}},
+		},
+		{path: "[0][2]", expected: []jsonPathStep{{index: 0, isIndex: true}, {index: 2, isIndex: true}}},
+		{path: "", expectedErr: true},
+		{path: "$status", expectedErr: true},
+		{path: "$.items[", expectedErr: true},
+		{path: "$.items[-1]", expectedErr: true},
+		{path: "$..items", expectedErr: true},
+		{path: "$.items[0]name", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			actual, err := parseJSONPath(tc.path)
+			if tc.expectedErr {
+				require.ErrorContains(t, err, errInvalidJSONPath.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestAssertionsCheck(t *testing.T) {
+	a, err := newAssertions(assertionsConfig{
+		StatusCodes: []string{"2xx", "301"},
+		BodyRegex:   `"status":\s*"ok"`,
+		JSONPaths: []jsonPathConfig{
+			{Path: "$.status", Value: "ok"},
+			{Path: "$.items[1].count", Value: "2"},
+			{Path: "$.items[0].enabled", Value: "true"},
+			{Path: "$.items[0].owner", Value: "null"},
+			{Path: "$.items[0]"},
+			{Path: "$.items[2]"},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, a.needsBody())
+
+	body := []byte(`{"status": "ok", "items": [{"enabled": true, "owner": null}, {"count": 2}]}`)
+	assert.Equal(t, []assertionResult{
+		{assertionType: metadata.AttributeAssertionTypeStatus, expression: "2xx,301", passed: true},
+		{assertionType: metadata.AttributeAssertionTypeBodyRegex, expression: `"status":\s*"ok"`, passed: true},
+		{assertionType: metadata.AttributeAssertionTypeJSONPath, expression: "$.status", passed: true},
+		{assertionType: metadata.AttributeAssertionTypeJSONPath, expression: "$.items[1].count", passed: true},
+		{assertionType: metadata.AttributeAssertionTypeJSONPath, expression: "$.items[0].enabled", passed: true},
+		{assertionType: metadata.AttributeAssertionTypeJSONPath, expression: "$.items[0].owner", passed: true},
+		{assertionType: metadata.AttributeAssertionTypeJSONPath, expression: "$.items[0]", passed: true},
+		{assertionType: metadata.AttributeAssertionTypeJSONPath, expression: "$.items[2]", passed: false},
+	}, a.check(200, body))
+
+	// The assertions of a failed request fail
+	for _, result := range a.check(0, nil) {
+		assert.False(t, result.passed, result.expression)
+	}
+
+	results := a.check(301, []byte(`not json "status": "ok"`))
+	assert.True(t, results[0].passed)
+	assert.True(t, results[1].passed)
+	assert.False(t, results[2].passed)
+}
+
+func TestNewAssertionsErrors(t *testing.T) {
+	_, err := newAssertions(assertionsConfig{
+		StatusCodes: []string{"2xx", "700"},
+		BodyRegex:   "(",
+		JSONPaths:   []jsonPathConfig{{Path: ""}},
+	})
+	assert.ErrorContains(t, err, errInvalidStatus.Error())
+	assert.ErrorContains(t, err, errInvalidRegex.Error())
+	assert.ErrorContains(t, err, errInvalidJSONPath.Error())
+}
@@ -19,6 +19,9 @@ import (
 var (
 	errMissingEndpoint = errors.New(`"endpoint" must be specified`)
 	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>[:<port>]`)
+	errInvalidStatus   = errors.New(`"status_codes" must be status codes, such as 200, classes, such as 2xx, or ranges, such as 200-299`)
+	errInvalidRegex    = errors.New(`"body_regex" must be a valid regular expression`)
+	errInvalidJSONPath = errors.New(`"json_paths" must be paths such as $.status or $.items[0].name`)
 )
 
 // Config defines the configuration for the various elements of the receiver agent.
@@ -30,7 +33,25 @@ type Config struct {
 
 type targetConfig struct {
 	confighttp.HTTPClientSettings `mapstructure:",squash"`
-	Method                        string `mapstructure:"method"`
+	Method                        string           `mapstructure:"method"`
+	Body                          string           `mapstructure:"body"`
+	Assertions                    assertionsConfig `mapstructure:"assertions"`
+}
+
+// assertionsConfig defines the assertions checked against the responses of a target
+type assertionsConfig struct {
+	// StatusCodes are the expected status codes, classes or ranges of status codes
+	StatusCodes []string `mapstructure:"status_codes"`
+	// BodyRegex is a regular expression the response body is expected to match
+	BodyRegex string `mapstructure:"body_regex"`
+	// JSONPaths are the values expected in the JSON response body
+	JSONPaths []jsonPathConfig `mapstructure:"json_paths"`
+}
+
+type jsonPathConfig struct {
+	Path string `mapstructure:"path"`
+	// Value is the expected value, the path is only expected to exist if it is empty
+	Value string `mapstructure:"value"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
@@ -46,6 +67,9 @@ func (cfg *targetConfig) Validate() error {
 		}
 	}
 
+	_, assertionsErr := newAssertions(cfg.Assertions)
+	err = multierr.Append(err, assertionsErr)
+
 	return err
 }
 
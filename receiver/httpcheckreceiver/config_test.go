@@ -88,6 +88,29 @@ func TestValidate(t *testing.T) {
 				fmt.Errorf("%w: %s", errInvalidEndpoint, `parse "www.opentelemetry.io/docs": invalid URI for request`),
 			),
 		},
+		{
+			desc: "invalid assertions",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "https://opentelemetry.io",
+						},
+						Assertions: assertionsConfig{
+							StatusCodes: []string{"2xx", "600"},
+							JSONPaths: []jsonPathConfig{
+								{Path: "$status"},
+							},
+						},
+					},
+				},
+				ScraperControllerSettings: scraperhelper.NewDefaultScraperControllerSettings(metadata.Type),
+			},
+			expectedErr: multierr.Combine(
+				fmt.Errorf("%w: %q", errInvalidStatus, "600"),
+				fmt.Errorf("%w: %q", errInvalidJSONPath, "$status"),
+			),
+		},
 		{
 			desc: "valid config",
 			cfg: &Config{
@@ -101,6 +124,13 @@ func TestValidate(t *testing.T) {
 						HTTPClientSettings: confighttp.HTTPClientSettings{
 							Endpoint: "https://opentelemetry.io:80/docs",
 						},
+						Assertions: assertionsConfig{
+							StatusCodes: []string{"2xx", "301"},
+							BodyRegex:   "ok",
+							JSONPaths: []jsonPathConfig{
+								{Path: "$.status", Value: "ok"},
+							},
+						},
 					},
 				},
 				ScraperControllerSettings: scraperhelper.NewDefaultScraperControllerSettings(metadata.Type),
@@ -25,22 +25,34 @@ func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsConfig provides config for httpcheck metrics.
 type MetricsConfig struct {
-	HttpcheckDuration MetricConfig `mapstructure:"httpcheck.duration"`
-	HttpcheckError    MetricConfig `mapstructure:"httpcheck.error"`
-	HttpcheckStatus   MetricConfig `mapstructure:"httpcheck.status"`
+	HttpcheckAssertion        MetricConfig `mapstructure:"httpcheck.assertion"`
+	HttpcheckDuration         MetricConfig `mapstructure:"httpcheck.duration"`
+	HttpcheckError            MetricConfig `mapstructure:"httpcheck.error"`
+	HttpcheckPhaseDuration    MetricConfig `mapstructure:"httpcheck.phase.duration"`
+	HttpcheckStatus           MetricConfig `mapstructure:"httpcheck.status"`
+	HttpcheckTLSCertRemaining MetricConfig `mapstructure:"httpcheck.tls.cert_remaining"`
 }
 
 func DefaultMetricsConfig() MetricsConfig {
 	return MetricsConfig{
+		HttpcheckAssertion: MetricConfig{
+			Enabled: true,
+		},
 		HttpcheckDuration: MetricConfig{
 			Enabled: true,
 		},
 		HttpcheckError: MetricConfig{
 			Enabled: true,
 		},
+		HttpcheckPhaseDuration: MetricConfig{
+			Enabled: false,
+		},
 		HttpcheckStatus: MetricConfig{
 			Enabled: true,
 		},
+		HttpcheckTLSCertRemaining: MetricConfig{
+			Enabled: true,
+		},
 	}
 }
 
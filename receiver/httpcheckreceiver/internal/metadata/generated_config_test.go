@@ -26,9 +26,12 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "all_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					HttpcheckDuration: MetricConfig{Enabled: true},
-					HttpcheckError:    MetricConfig{Enabled: true},
-					HttpcheckStatus:   MetricConfig{Enabled: true},
+					HttpcheckAssertion:        MetricConfig{Enabled: true},
+					HttpcheckDuration:         MetricConfig{Enabled: true},
+					HttpcheckError:            MetricConfig{Enabled: true},
+					HttpcheckPhaseDuration:    MetricConfig{Enabled: true},
+					HttpcheckStatus:           MetricConfig{Enabled: true},
+					HttpcheckTLSCertRemaining: MetricConfig{Enabled: true},
 				},
 			},
 		},
@@ -36,9 +39,12 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "none_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					HttpcheckDuration: MetricConfig{Enabled: false},
-					HttpcheckError:    MetricConfig{Enabled: false},
-					HttpcheckStatus:   MetricConfig{Enabled: false},
+					HttpcheckAssertion:        MetricConfig{Enabled: false},
+					HttpcheckDuration:         MetricConfig{Enabled: false},
+					HttpcheckError:            MetricConfig{Enabled: false},
+					HttpcheckPhaseDuration:    MetricConfig{Enabled: false},
+					HttpcheckStatus:           MetricConfig{Enabled: false},
+					HttpcheckTLSCertRemaining: MetricConfig{Enabled: false},
 				},
 			},
 		},
@@ -11,6 +11,125 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 )
 
+// AttributeAssertionType specifies the a value assertion.type attribute.
+type AttributeAssertionType int
+
+const (
+	_ AttributeAssertionType = iota
+	AttributeAssertionTypeStatus
+	AttributeAssertionTypeBodyRegex
+	AttributeAssertionTypeJSONPath
+)
+
+// String returns the string representation of the AttributeAssertionType.
+func (av AttributeAssertionType) String() string {
+	switch av {
+	case AttributeAssertionTypeStatus:
+		return "status"
+	case AttributeAssertionTypeBodyRegex:
+		return "body_regex"
+	case AttributeAssertionTypeJSONPath:
+		return "json_path"
+	}
+	return ""
+}
+
+// MapAttributeAssertionType is a helper map of string to AttributeAssertionType attribute value.
+var MapAttributeAssertionType = map[string]AttributeAssertionType{
+	"status":     AttributeAssertionTypeStatus,
+	"body_regex": AttributeAssertionTypeBodyRegex,
+	"json_path":  AttributeAssertionTypeJSONPath,
+}
+
+// AttributeHTTPPhase specifies the a value http.phase attribute.
+type AttributeHTTPPhase int
+
+const (
+	_ AttributeHTTPPhase = iota
+	AttributeHTTPPhaseDNS
+	AttributeHTTPPhaseConnect
+	AttributeHTTPPhaseTLS
+	AttributeHTTPPhaseTtfb
+)
+
+// String returns the string representation of the AttributeHTTPPhase.
+func (av AttributeHTTPPhase) String() string {
+	switch av {
+	case AttributeHTTPPhaseDNS:
+		return "dns"
+	case AttributeHTTPPhaseConnect:
+		return "connect"
+	case AttributeHTTPPhaseTLS:
+		return "tls"
+	case AttributeHTTPPhaseTtfb:
+		return "ttfb"
+	}
+	return ""
+}
+
+// MapAttributeHTTPPhase is a helper map of string to AttributeHTTPPhase attribute value.
+var MapAttributeHTTPPhase = map[string]AttributeHTTPPhase{
+	"dns":     AttributeHTTPPhaseDNS,
+	"connect": AttributeHTTPPhaseConnect,
+	"tls":     AttributeHTTPPhaseTLS,
+	"ttfb":    AttributeHTTPPhaseTtfb,
+}
+
+type metricHttpcheckAssertion struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.assertion metric with initial data.
+func (m *metricHttpcheckAssertion) init() {
+	m.data.SetName("httpcheck.assertion")
+	m.data.SetDescription("1 if the response satisfied the assertion, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckAssertion) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, assertionTypeAttributeValue string, assertionExpressionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("assertion.type", assertionTypeAttributeValue)
+	dp.Attributes().PutStr("assertion.expression", assertionExpressionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckAssertion) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckAssertion) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckAssertion(cfg MetricConfig) metricHttpcheckAssertion {
+	m := metricHttpcheckAssertion{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckDuration struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -116,6 +235,58 @@ func newMetricHttpcheckError(cfg MetricConfig) metricHttpcheckError {
 	return m
 }
 
+type metricHttpcheckPhaseDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.phase.duration metric with initial data.
+func (m *metricHttpcheckPhaseDuration) init() {
+	m.data.SetName("httpcheck.phase.duration")
+	m.data.SetDescription("Measures the duration of the phases of the HTTP check. The ttfb phase lasts from the start of the check until the first byte of the response.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckPhaseDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.phase", httpPhaseAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckPhaseDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckPhaseDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckPhaseDuration(cfg MetricConfig) metricHttpcheckPhaseDuration {
+	m := metricHttpcheckPhaseDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckStatus struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -172,17 +343,73 @@ func newMetricHttpcheckStatus(cfg MetricConfig) metricHttpcheckStatus {
 	return m
 }
 
+type metricHttpcheckTLSCertRemaining struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.tls.cert_remaining metric with initial data.
+func (m *metricHttpcheckTLSCertRemaining) init() {
+	m.data.SetName("httpcheck.tls.cert_remaining")
+	m.data.SetDescription("Time until the certificate of the chain served by the endpoint expires, negative once expired.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckTLSCertRemaining) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, tlsCertSubjectAttributeValue string, tlsCertIssuerAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("tls.cert.subject", tlsCertSubjectAttributeValue)
+	dp.Attributes().PutStr("tls.cert.issuer", tlsCertIssuerAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTLSCertRemaining) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTLSCertRemaining) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTLSCertRemaining(cfg MetricConfig) metricHttpcheckTLSCertRemaining {
+	m := metricHttpcheckTLSCertRemaining{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user config.
 type MetricsBuilder struct {
-	config                  MetricsBuilderConfig // config of the metrics builder.
-	startTime               pcommon.Timestamp    // start time that will be applied to all recorded data points.
-	metricsCapacity         int                  // maximum observed number of metrics per resource.
-	metricsBuffer           pmetric.Metrics      // accumulates metrics data before emitting.
-	buildInfo               component.BuildInfo  // contains version information.
-	metricHttpcheckDuration metricHttpcheckDuration
-	metricHttpcheckError    metricHttpcheckError
-	metricHttpcheckStatus   metricHttpcheckStatus
+	config                          MetricsBuilderConfig // config of the metrics builder.
+	startTime                       pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                 int                  // maximum observed number of metrics per resource.
+	metricsBuffer                   pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                       component.BuildInfo  // contains version information.
+	metricHttpcheckAssertion        metricHttpcheckAssertion
+	metricHttpcheckDuration         metricHttpcheckDuration
+	metricHttpcheckError            metricHttpcheckError
+	metricHttpcheckPhaseDuration    metricHttpcheckPhaseDuration
+	metricHttpcheckStatus           metricHttpcheckStatus
+	metricHttpcheckTLSCertRemaining metricHttpcheckTLSCertRemaining
 }
 
 // metricBuilderOption applies changes to default metrics builder.
@@ -197,13 +424,16 @@ func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
 
 func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		config:                  mbc,
-		startTime:               pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:           pmetric.NewMetrics(),
-		buildInfo:               settings.BuildInfo,
-		metricHttpcheckDuration: newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
-		metricHttpcheckError:    newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
-		metricHttpcheckStatus:   newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
+		config:                          mbc,
+		startTime:                       pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                   pmetric.NewMetrics(),
+		buildInfo:                       settings.BuildInfo,
+		metricHttpcheckAssertion:        newMetricHttpcheckAssertion(mbc.Metrics.HttpcheckAssertion),
+		metricHttpcheckDuration:         newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
+		metricHttpcheckError:            newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
+		metricHttpcheckPhaseDuration:    newMetricHttpcheckPhaseDuration(mbc.Metrics.HttpcheckPhaseDuration),
+		metricHttpcheckStatus:           newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
+		metricHttpcheckTLSCertRemaining: newMetricHttpcheckTLSCertRemaining(mbc.Metrics.HttpcheckTLSCertRemaining),
 	}
 	for _, op := range options {
 		op(mb)
@@ -260,9 +490,12 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Scope().SetName("otelcol/httpcheckreceiver")
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricHttpcheckAssertion.emit(ils.Metrics())
 	mb.metricHttpcheckDuration.emit(ils.Metrics())
 	mb.metricHttpcheckError.emit(ils.Metrics())
+	mb.metricHttpcheckPhaseDuration.emit(ils.Metrics())
 	mb.metricHttpcheckStatus.emit(ils.Metrics())
+	mb.metricHttpcheckTLSCertRemaining.emit(ils.Metrics())
 
 	for _, op := range rmo {
 		op(rm)
@@ -283,6 +516,11 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 	return metrics
 }
 
+// RecordHttpcheckAssertionDataPoint adds a data point to httpcheck.assertion metric.
+func (mb *MetricsBuilder) RecordHttpcheckAssertionDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, assertionTypeAttributeValue AttributeAssertionType, assertionExpressionAttributeValue string) {
+	mb.metricHttpcheckAssertion.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, assertionTypeAttributeValue.String(), assertionExpressionAttributeValue)
+}
+
 // RecordHttpcheckDurationDataPoint adds a data point to httpcheck.duration metric.
 func (mb *MetricsBuilder) RecordHttpcheckDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
 	mb.metricHttpcheckDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
@@ -293,11 +531,21 @@ func (mb *MetricsBuilder) RecordHttpcheckErrorDataPoint(ts pcommon.Timestamp, va
 	mb.metricHttpcheckError.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, errorMessageAttributeValue)
 }
 
+// RecordHttpcheckPhaseDurationDataPoint adds a data point to httpcheck.phase.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckPhaseDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue AttributeHTTPPhase) {
+	mb.metricHttpcheckPhaseDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpPhaseAttributeValue.String())
+}
+
 // RecordHttpcheckStatusDataPoint adds a data point to httpcheck.status metric.
 func (mb *MetricsBuilder) RecordHttpcheckStatusDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpStatusCodeAttributeValue int64, httpMethodAttributeValue string, httpStatusClassAttributeValue string) {
 	mb.metricHttpcheckStatus.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpStatusCodeAttributeValue, httpMethodAttributeValue, httpStatusClassAttributeValue)
 }
 
+// RecordHttpcheckTLSCertRemainingDataPoint adds a data point to httpcheck.tls.cert_remaining metric.
+func (mb *MetricsBuilder) RecordHttpcheckTLSCertRemainingDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, tlsCertSubjectAttributeValue string, tlsCertIssuerAttributeValue string) {
+	mb.metricHttpcheckTLSCertRemaining.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, tlsCertSubjectAttributeValue, tlsCertIssuerAttributeValue)
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
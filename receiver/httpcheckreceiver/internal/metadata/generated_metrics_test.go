@@ -55,6 +55,10 @@ func TestMetricsBuilder(t *testing.T) {
 			defaultMetricsCount := 0
 			allMetricsCount := 0
 
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckAssertionDataPoint(ts, 1, "http.url-val", AttributeAssertionTypeStatus, "assertion.expression-val")
+
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordHttpcheckDurationDataPoint(ts, 1, "http.url-val")
@@ -63,10 +67,17 @@ func TestMetricsBuilder(t *testing.T) {
 			allMetricsCount++
 			mb.RecordHttpcheckErrorDataPoint(ts, 1, "http.url-val", "error.message-val")
 
+			allMetricsCount++
+			mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "http.url-val", AttributeHTTPPhaseDNS)
+
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordHttpcheckStatusDataPoint(ts, 1, "http.url-val", 16, "http.method-val", "http.status_class-val")
 
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckTLSCertRemainingDataPoint(ts, 1, "http.url-val", "tls.cert.subject-val", "tls.cert.issuer-val")
+
 			res := pcommon.NewResource()
 			metrics := mb.Emit(WithResource(res))
 
@@ -89,6 +100,29 @@ func TestMetricsBuilder(t *testing.T) {
 			validatedMetrics := make(map[string]bool)
 			for i := 0; i < ms.Len(); i++ {
 				switch ms.At(i).Name() {
+				case "httpcheck.assertion":
+					assert.False(t, validatedMetrics["httpcheck.assertion"], "Found a duplicate in the metrics slice: httpcheck.assertion")
+					validatedMetrics["httpcheck.assertion"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "1 if the response satisfied the assertion, otherwise 0.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.EqualValues(t, "http.url-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("assertion.type")
+					assert.True(t, ok)
+					assert.EqualValues(t, "status", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("assertion.expression")
+					assert.True(t, ok)
+					assert.EqualValues(t, "assertion.expression-val", attrVal.Str())
 				case "httpcheck.duration":
 					assert.False(t, validatedMetrics["httpcheck.duration"], "Found a duplicate in the metrics slice: httpcheck.duration")
 					validatedMetrics["httpcheck.duration"] = true
@@ -124,6 +158,24 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("error.message")
 					assert.True(t, ok)
 					assert.EqualValues(t, "error.message-val", attrVal.Str())
+				case "httpcheck.phase.duration":
+					assert.False(t, validatedMetrics["httpcheck.phase.duration"], "Found a duplicate in the metrics slice: httpcheck.phase.duration")
+					validatedMetrics["httpcheck.phase.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Measures the duration of the phases of the HTTP check. The ttfb phase lasts from the start of the check until the first byte of the response.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.EqualValues(t, "http.url-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("http.phase")
+					assert.True(t, ok)
+					assert.EqualValues(t, "dns", attrVal.Str())
 				case "httpcheck.status":
 					assert.False(t, validatedMetrics["httpcheck.status"], "Found a duplicate in the metrics slice: httpcheck.status")
 					validatedMetrics["httpcheck.status"] = true
@@ -150,6 +202,27 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("http.status_class")
 					assert.True(t, ok)
 					assert.EqualValues(t, "http.status_class-val", attrVal.Str())
+				case "httpcheck.tls.cert_remaining":
+					assert.False(t, validatedMetrics["httpcheck.tls.cert_remaining"], "Found a duplicate in the metrics slice: httpcheck.tls.cert_remaining")
+					validatedMetrics["httpcheck.tls.cert_remaining"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Time until the certificate of the chain served by the endpoint expires, negative once expired.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.EqualValues(t, "http.url-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.subject")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.subject-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.issuer")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.issuer-val", attrVal.Str())
 				}
 			}
 		})
@@ -5,8 +5,12 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptrace"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,16 +24,20 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
 
+// maxBodySize limits the size of the response bodies read to check the assertions
+const maxBodySize = 1 << 20
+
 var (
 	errClientNotInit    = errors.New("client not initialized")
 	httpResponseClasses = map[string]int{"1xx": 1, "2xx": 2, "3xx": 3, "4xx": 4, "5xx": 5}
 )
 
 type httpcheckScraper struct {
-	clients  []*http.Client
-	cfg      *Config
-	settings component.TelemetrySettings
-	mb       *metadata.MetricsBuilder
+	clients    []*http.Client
+	assertions []*assertions
+	cfg        *Config
+	settings   component.TelemetrySettings
+	mb         *metadata.MetricsBuilder
 }
 
 // start starts the scraper by creating a new HTTP Client on the scraper
@@ -40,6 +48,12 @@ func (h *httpcheckScraper) start(_ context.Context, host component.Host) (err er
 			err = multierr.Append(err, clentErr)
 		}
 		h.clients = append(h.clients, client)
+
+		targetAssertions, assertionsErr := newAssertions(target.Assertions)
+		if assertionsErr != nil {
+			err = multierr.Append(err, assertionsErr)
+		}
+		h.assertions = append(h.assertions, targetAssertions)
 	}
 	return
 }
@@ -58,32 +72,70 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 		go func(targetClient *http.Client, targetIndex int) {
 			defer wg.Done()
 
+			target := h.cfg.Targets[targetIndex]
+			targetAssertions := h.assertions[targetIndex]
 			now := pcommon.NewTimestampFromTime(time.Now())
 
-			req, err := http.NewRequestWithContext(ctx, h.cfg.Targets[targetIndex].Method, h.cfg.Targets[targetIndex].Endpoint, http.NoBody)
+			var body io.Reader = http.NoBody
+			if target.Body != "" {
+				body = strings.NewReader(target.Body)
+			}
+			timer := &phaseTimer{durations: map[metadata.AttributeHTTPPhase]time.Duration{}}
+			req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, timer.clientTrace()), target.Method, target.Endpoint, body)
 			if err != nil {
 				h.settings.Logger.Error("failed to create request", zap.Error(err))
 				return
 			}
 
-			start := time.Now()
+			timer.start = time.Now()
 			resp, err := targetClient.Do(req)
+			duration := time.Since(timer.start)
+
+			var respBody []byte
+			var readErr error
+			if err == nil {
+				if targetAssertions.needsBody() {
+					respBody, readErr = io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+				}
+				_ = resp.Body.Close()
+			}
+
 			mux.Lock()
-			h.mb.RecordHttpcheckDurationDataPoint(now, time.Since(start).Milliseconds(), h.cfg.Targets[targetIndex].Endpoint)
+			h.mb.RecordHttpcheckDurationDataPoint(now, duration.Milliseconds(), target.Endpoint)
+			for phase, phaseDuration := range timer.phaseDurations() {
+				h.mb.RecordHttpcheckPhaseDurationDataPoint(now, phaseDuration.Milliseconds(), target.Endpoint, phase)
+			}
 
 			statusCode := 0
 			if err != nil {
-				h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), h.cfg.Targets[targetIndex].Endpoint, err.Error())
+				h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), target.Endpoint, err.Error())
 			} else {
 				statusCode = resp.StatusCode
+				if readErr != nil {
+					h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), target.Endpoint, readErr.Error())
+				}
+				if resp.TLS != nil {
+					for _, cert := range resp.TLS.PeerCertificates {
+						remaining := time.Until(cert.NotAfter)
+						h.mb.RecordHttpcheckTLSCertRemainingDataPoint(now, int64(remaining.Seconds()), target.Endpoint, cert.Subject.String(), cert.Issuer.String())
+					}
+				}
 			}
 
 			for class, intVal := range httpResponseClasses {
 				if statusCode/100 == intVal {
-					h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), h.cfg.Targets[targetIndex].Endpoint, int64(statusCode), req.Method, class)
+					h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), target.Endpoint, int64(statusCode), req.Method, class)
 				} else {
-					h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), h.cfg.Targets[targetIndex].Endpoint, int64(statusCode), req.Method, class)
+					h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), target.Endpoint, int64(statusCode), req.Method, class)
+				}
+			}
+
+			for _, result := range targetAssertions.check(statusCode, respBody) {
+				passed := int64(0)
+				if result.passed {
+					passed = 1
 				}
+				h.mb.RecordHttpcheckAssertionDataPoint(now, passed, target.Endpoint, result.assertionType, result.expression)
 			}
 			mux.Unlock()
 		}(client, idx)
@@ -94,6 +146,74 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 	return h.mb.Emit(), nil
 }
 
+// phaseTimer measures the durations of the phases of a request
+type phaseTimer struct {
+	mu        sync.Mutex
+	start     time.Time
+	dnsStart  time.Time
+	connStart time.Time
+	tlsStart  time.Time
+	durations map[metadata.AttributeHTTPPhase]time.Duration
+}
+
+// clientTrace returns the hooks measuring the phases. The phases of the reused connections aren't measured.
+func (p *phaseTimer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			p.record(metadata.AttributeHTTPPhaseDNS, &p.dnsStart)
+		},
+		ConnectStart: func(string, string) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			// Several addresses may be dialed in parallel, the first dial starts the phase
+			if p.connStart.IsZero() {
+				p.connStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				p.record(metadata.AttributeHTTPPhaseConnect, &p.connStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err == nil {
+				p.record(metadata.AttributeHTTPPhaseTLS, &p.tlsStart)
+			}
+		},
+		GotFirstResponseByte: func() {
+			p.record(metadata.AttributeHTTPPhaseTtfb, &p.start)
+		},
+	}
+}
+
+func (p *phaseTimer) record(phase metadata.AttributeHTTPPhase, start *time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.durations[phase]; !ok {
+		p.durations[phase] = time.Since(*start)
+	}
+}
+
+func (p *phaseTimer) phaseDurations() map[metadata.AttributeHTTPPhase]time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	durations := make(map[metadata.AttributeHTTPPhase]time.Duration, len(p.durations))
+	for phase, duration := range p.durations {
+		durations[phase] = duration
+	}
+	return durations
+}
+
 func newScraper(conf *Config, settings receiver.CreateSettings) *httpcheckScraper {
 	return &httpcheckScraper{
 		cfg:      conf,
@@ -5,14 +5,17 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
@@ -233,3 +236,78 @@ func TestScraperMultipleTargets(t *testing.T) {
 		pmetrictest.IgnoreTimestamp(),
 	))
 }
+
+func TestScraperAssertionsAndTLS(t *testing.T) {
+	ms := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		if req.Method != http.MethodPost || string(body) != `{"check":true}` || req.Header.Get("X-Check") != "1" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, err = rw.Write([]byte(`{"status":"ok","checks":[{"name":"db","healthy":true}]}`))
+		require.NoError(t, err)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.HttpcheckPhaseDuration.Enabled = true
+	cfg.Targets = []*targetConfig{{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ms.URL,
+			Headers:  map[string]configopaque.String{"X-Check": "1"},
+			TLSSetting: configtls.TLSClientSetting{
+				InsecureSkipVerify: true,
+			},
+		},
+		Method: http.MethodPost,
+		Body:   `{"check":true}`,
+		Assertions: assertionsConfig{
+			StatusCodes: []string{"200-204"},
+			BodyRegex:   `"status":"ok"`,
+			JSONPaths: []jsonPathConfig{
+				{Path: "$.checks[0].healthy", Value: "true"},
+				{Path: "$.checks[1]"},
+			},
+		},
+	}}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	metrics := map[string]pmetric.Metric{}
+	ilms := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ilms.Len(); i++ {
+		metrics[ilms.At(i).Name()] = ilms.At(i)
+	}
+
+	assertions := map[string]int64{}
+	dps := metrics["httpcheck.assertion"].Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		expression, _ := dps.At(i).Attributes().Get("assertion.expression")
+		assertions[expression.Str()] = dps.At(i).IntValue()
+	}
+	assert.Equal(t, map[string]int64{
+		"200-204":             1,
+		`"status":"ok"`:       1,
+		"$.checks[0].healthy": 1,
+		"$.checks[1]":         0,
+	}, assertions)
+
+	// The certificate of the test server expires in 2084
+	certs := metrics["httpcheck.tls.cert_remaining"].Gauge().DataPoints()
+	require.Equal(t, 1, certs.Len())
+	assert.Greater(t, certs.At(0).IntValue(), int64(0))
+	subject, _ := certs.At(0).Attributes().Get("tls.cert.subject")
+	assert.Equal(t, "O=Acme Co", subject.Str())
+
+	phases := map[string]bool{}
+	dps = metrics["httpcheck.phase.duration"].Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		phase, _ := dps.At(i).Attributes().Get("http.phase")
+		phases[phase.Str()] = true
+	}
+	assert.Equal(t, map[string]bool{"connect": true, "tls": true, "ttfb": true}, phases)
+}
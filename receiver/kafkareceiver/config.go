@@ -52,6 +52,9 @@ type Config struct {
 	Topic string `mapstructure:"topic"`
 	// Encoding of the messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
+	// EncodingExtension is the component ID of an encoding extension used to unmarshal
+	// messages. It takes precedence over Encoding when set.
+	EncodingExtension *component.ID `mapstructure:"encoding_extension"`
 	// The consumer group that receiver will be consuming messages from (default "otel-collector")
 	GroupID string `mapstructure:"group_id"`
 	// The consumer client ID that receiver will use (default "otel-collector")
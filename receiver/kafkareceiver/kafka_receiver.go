@@ -14,10 +14,14 @@ import (
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/encodingext"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/kafka"
 )
 
@@ -35,6 +39,7 @@ type kafkaTracesConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       TracesUnmarshaler
+	encodingExtension *component.ID
 
 	settings receiver.CreateSettings
 
@@ -51,6 +56,7 @@ type kafkaMetricsConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       MetricsUnmarshaler
+	encodingExtension *component.ID
 
 	settings receiver.CreateSettings
 
@@ -67,6 +73,7 @@ type kafkaLogsConsumer struct {
 	topics            []string
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       LogsUnmarshaler
+	encodingExtension *component.ID
 
 	settings receiver.CreateSettings
 
@@ -81,8 +88,9 @@ var _ receiver.Metrics = (*kafkaMetricsConsumer)(nil)
 var _ receiver.Logs = (*kafkaLogsConsumer)(nil)
 
 func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]TracesUnmarshaler, nextConsumer consumer.Traces) (*kafkaTracesConsumer, error) {
+	// The encoding extension can only be resolved once the host is available in Start.
 	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
+	if unmarshaler == nil && config.EncodingExtension == nil {
 		return nil, errUnrecognizedEncoding
 	}
 
@@ -120,6 +128,7 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		topics:            []string{config.Topic},
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		encodingExtension: config.EncodingExtension,
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -129,6 +138,13 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 }
 
 func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) error {
+	if c.encodingExtension != nil {
+		unmarshaler, err := encodingext.LoadExtension[ptrace.Unmarshaler](host, *c.encodingExtension)
+		if err != nil {
+			return err
+		}
+		c.unmarshaler = newPdataTracesUnmarshaler(unmarshaler, c.encodingExtension.String())
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -181,13 +197,16 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 }
 
 func (c *kafkaTracesConsumer) Shutdown(context.Context) error {
-	c.cancelConsumeLoop()
+	if c.cancelConsumeLoop != nil {
+		c.cancelConsumeLoop()
+	}
 	return c.consumerGroup.Close()
 }
 
 func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers map[string]MetricsUnmarshaler, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
+	// The encoding extension can only be resolved once the host is available in Start.
 	unmarshaler := unmarshalers[config.Encoding]
-	if unmarshaler == nil {
+	if unmarshaler == nil && config.EncodingExtension == nil {
 		return nil, errUnrecognizedEncoding
 	}
 
@@ -222,6 +241,7 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 		topics:            []string{config.Topic},
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		encodingExtension: config.EncodingExtension,
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -231,6 +251,13 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 }
 
 func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) error {
+	if c.encodingExtension != nil {
+		unmarshaler, err := encodingext.LoadExtension[pmetric.Unmarshaler](host, *c.encodingExtension)
+		if err != nil {
+			return err
+		}
+		c.unmarshaler = newPdataMetricsUnmarshaler(unmarshaler, c.encodingExtension.String())
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -283,7 +310,9 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 }
 
 func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
-	c.cancelConsumeLoop()
+	if c.cancelConsumeLoop != nil {
+		c.cancelConsumeLoop()
+	}
 	return c.consumerGroup.Close()
 }
 
@@ -300,19 +329,22 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 	} else {
 		return nil, err
 	}
-	unmarshaler, err := getLogsUnmarshaler(config.Encoding, unmarshalers)
-	if err != nil {
-		return nil, err
+	// The encoding extension can only be resolved once the host is available in Start.
+	var unmarshaler LogsUnmarshaler
+	if config.EncodingExtension == nil {
+		var err error
+		if unmarshaler, err = getLogsUnmarshaler(config.Encoding, unmarshalers); err != nil {
+			return nil, err
+		}
 	}
 	if config.ProtocolVersion != "" {
-		var version sarama.KafkaVersion
-		version, err = sarama.ParseKafkaVersion(config.ProtocolVersion)
+		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
 		if err != nil {
 			return nil, err
 		}
 		c.Version = version
 	}
-	if err = kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
+	if err := kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
 	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
@@ -324,6 +356,7 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 		topics:            []string{config.Topic},
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		encodingExtension: config.EncodingExtension,
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
@@ -360,6 +393,13 @@ func getLogsUnmarshaler(encoding string, unmarshalers map[string]LogsUnmarshaler
 }
 
 func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error {
+	if c.encodingExtension != nil {
+		unmarshaler, err := encodingext.LoadExtension[plog.Unmarshaler](host, *c.encodingExtension)
+		if err != nil {
+			return err
+		}
+		c.unmarshaler = newPdataLogsUnmarshaler(unmarshaler, c.encodingExtension.String())
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancelConsumeLoop = cancel
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -413,7 +453,9 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 }
 
 func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
-	c.cancelConsumeLoop()
+	if c.cancelConsumeLoop != nil {
+		c.cancelConsumeLoop()
+	}
 	return c.consumerGroup.Close()
 }
 
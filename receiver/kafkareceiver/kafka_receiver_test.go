@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -53,6 +54,58 @@ func TestNewTracesReceiver_encoding_err(t *testing.T) {
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
 }
 
+func TestTracesReceiverStart_unknown_encoding_extension(t *testing.T) {
+	id := component.NewID("missing")
+	c := kafkaTracesConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+
+	assert.EqualError(t, c.Start(context.Background(), componenttest.NewNopHost()), `unknown encoding extension "missing"`)
+	// Shutdown is called by the collector even when Start failed.
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestTracesReceiverStart_encoding_extension(t *testing.T) {
+	id := component.NewID("test_encoding")
+	c := kafkaTracesConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("test_encoding"): &testEncodingExtension{},
+		},
+	}
+
+	require.NoError(t, c.Start(context.Background(), host))
+	assert.Equal(t, "test_encoding", c.unmarshaler.Encoding())
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestTracesReceiverStart_encoding_extension_wrong_type(t *testing.T) {
+	id := component.NewID("nop")
+	c := kafkaTracesConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("nop"): &nopExtension{},
+		},
+	}
+
+	assert.Error(t, c.Start(context.Background(), host))
+}
+
 func TestNewTracesReceiver_err_auth_type(t *testing.T) {
 	c := Config{
 		ProtocolVersion: "2.0.0",
@@ -90,6 +143,7 @@ func TestTracesReceiverStart(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      receivertest.NewNopCreateSettings(),
 		consumerGroup: &testConsumerGroup{},
+		unmarshaler:   newPdataTracesUnmarshaler(&ptrace.ProtoUnmarshaler{}, defaultEncoding),
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -122,6 +176,7 @@ func TestTracesReceiver_error(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      settings,
 		consumerGroup: &testConsumerGroup{err: expectedErr},
+		unmarshaler:   newPdataTracesUnmarshaler(&ptrace.ProtoUnmarshaler{}, defaultEncoding),
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -314,6 +369,58 @@ func TestNewMetricsReceiver_encoding_err(t *testing.T) {
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
 }
 
+func TestMetricsReceiverStart_unknown_encoding_extension(t *testing.T) {
+	id := component.NewID("missing")
+	c := kafkaMetricsConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+
+	assert.EqualError(t, c.Start(context.Background(), componenttest.NewNopHost()), `unknown encoding extension "missing"`)
+	// Shutdown is called by the collector even when Start failed.
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestMetricsReceiverStart_encoding_extension(t *testing.T) {
+	id := component.NewID("test_encoding")
+	c := kafkaMetricsConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("test_encoding"): &testEncodingExtension{},
+		},
+	}
+
+	require.NoError(t, c.Start(context.Background(), host))
+	assert.Equal(t, "test_encoding", c.unmarshaler.Encoding())
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestMetricsReceiverStart_encoding_extension_wrong_type(t *testing.T) {
+	id := component.NewID("nop")
+	c := kafkaMetricsConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("nop"): &nopExtension{},
+		},
+	}
+
+	assert.Error(t, c.Start(context.Background(), host))
+}
+
 func TestNewMetricsExporter_err_auth_type(t *testing.T) {
 	c := Config{
 		ProtocolVersion: "2.0.0",
@@ -351,6 +458,7 @@ func TestMetricsReceiverStart(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      receivertest.NewNopCreateSettings(),
 		consumerGroup: &testConsumerGroup{},
+		unmarshaler:   newPdataMetricsUnmarshaler(&pmetric.ProtoUnmarshaler{}, defaultEncoding),
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -383,6 +491,7 @@ func TestMetricsReceiver_error(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      settings,
 		consumerGroup: &testConsumerGroup{err: expectedErr},
+		unmarshaler:   newPdataMetricsUnmarshaler(&pmetric.ProtoUnmarshaler{}, defaultEncoding),
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -573,6 +682,58 @@ func TestNewLogsReceiver_encoding_err(t *testing.T) {
 	assert.EqualError(t, err, errUnrecognizedEncoding.Error())
 }
 
+func TestLogsReceiverStart_unknown_encoding_extension(t *testing.T) {
+	id := component.NewID("missing")
+	c := kafkaLogsConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+
+	assert.EqualError(t, c.Start(context.Background(), componenttest.NewNopHost()), `unknown encoding extension "missing"`)
+	// Shutdown is called by the collector even when Start failed.
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestLogsReceiverStart_encoding_extension(t *testing.T) {
+	id := component.NewID("test_encoding")
+	c := kafkaLogsConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("test_encoding"): &testEncodingExtension{},
+		},
+	}
+
+	require.NoError(t, c.Start(context.Background(), host))
+	assert.Equal(t, "test_encoding", c.unmarshaler.Encoding())
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestLogsReceiverStart_encoding_extension_wrong_type(t *testing.T) {
+	id := component.NewID("nop")
+	c := kafkaLogsConsumer{
+		nextConsumer:      consumertest.NewNop(),
+		settings:          receivertest.NewNopCreateSettings(),
+		consumerGroup:     &testConsumerGroup{},
+		encodingExtension: &id,
+	}
+	host := &testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			component.NewID("nop"): &nopExtension{},
+		},
+	}
+
+	assert.Error(t, c.Start(context.Background(), host))
+}
+
 func TestNewLogsExporter_err_auth_type(t *testing.T) {
 	c := Config{
 		ProtocolVersion: "2.0.0",
@@ -610,6 +771,7 @@ func TestLogsReceiverStart(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      receivertest.NewNopCreateSettings(),
 		consumerGroup: &testConsumerGroup{},
+		unmarshaler:   newPdataLogsUnmarshaler(&plog.ProtoUnmarshaler{}, defaultEncoding),
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -642,6 +804,7 @@ func TestLogsReceiver_error(t *testing.T) {
 		nextConsumer:  consumertest.NewNop(),
 		settings:      settings,
 		consumerGroup: &testConsumerGroup{err: expectedErr},
+		unmarshaler:   newPdataLogsUnmarshaler(&plog.ProtoUnmarshaler{}, defaultEncoding),
 	}
 
 	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
@@ -1085,3 +1248,33 @@ func (t *testConsumerGroup) Resume(_ map[string][]int32) {
 func (t *testConsumerGroup) ResumeAll() {
 	panic("implement me")
 }
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type testEncodingExtension struct {
+	nopExtension
+}
+
+func (testEncodingExtension) UnmarshalTraces([]byte) (ptrace.Traces, error) {
+	return ptrace.NewTraces(), nil
+}
+
+func (testEncodingExtension) UnmarshalMetrics([]byte) (pmetric.Metrics, error) {
+	return pmetric.NewMetrics(), nil
+}
+
+func (testEncodingExtension) UnmarshalLogs([]byte) (plog.Logs, error) {
+	return plog.NewLogs(), nil
+}
@@ -23,6 +23,7 @@ type client interface {
 	getStatementEventsStats() ([]StatementEventStats, error)
 	getTableLockWaitEventStats() ([]tableLockWaitEventStats, error)
 	getReplicaStatusStats() ([]ReplicaStatusStats, error)
+	getRunningQueries(minDuration time.Duration) ([]runningQuery, error)
 	Close() error
 }
 
@@ -60,6 +61,7 @@ type StatementEventStats struct {
 	schema                    string
 	digest                    string
 	digestText                string
+	countStar                 int64
 	sumTimerWait              int64
 	countErrors               int64
 	countWarnings             int64
@@ -161,6 +163,16 @@ type ReplicaStatusStats struct {
 	networkNamespace          string
 }
 
+type runningQuery struct {
+	id    int64
+	user  string
+	host  string
+	db    string
+	state string
+	time  int64
+	info  string
+}
+
 var _ client = (*mySQLClient)(nil)
 
 func newMySQLClient(conf *Config) (client, error) {
@@ -287,12 +299,12 @@ func (c *mySQLClient) getIndexIoWaitsStats() ([]IndexIoWaitsStats, error) {
 
 func (c *mySQLClient) getStatementEventsStats() ([]StatementEventStats, error) {
 	query := fmt.Sprintf("SELECT ifnull(SCHEMA_NAME, 'NONE') as SCHEMA_NAME, DIGEST,"+
-		"LEFT(DIGEST_TEXT, %d) as DIGEST_TEXT, SUM_TIMER_WAIT, SUM_ERRORS,"+
+		"LEFT(DIGEST_TEXT, %d) as DIGEST_TEXT, COUNT_STAR, SUM_TIMER_WAIT, SUM_ERRORS,"+
 		"SUM_WARNINGS, SUM_ROWS_AFFECTED, SUM_ROWS_SENT, SUM_ROWS_EXAMINED,"+
 		"SUM_CREATED_TMP_DISK_TABLES, SUM_CREATED_TMP_TABLES, SUM_SORT_MERGE_PASSES,"+
 		"SUM_SORT_ROWS, SUM_NO_INDEX_USED "+
 		"FROM performance_schema.events_statements_summary_by_digest "+
-		"WHERE SCHEMA_NAME NOT IN ('mysql', 'performance_schema', 'information_schema') "+
+		"WHERE (SCHEMA_NAME NOT IN ('mysql', 'performance_schema', 'information_schema') OR SCHEMA_NAME IS NULL) "+
 		"AND last_seen > DATE_SUB(NOW(), INTERVAL %d SECOND) "+
 		"ORDER BY SUM_TIMER_WAIT DESC "+
 		"LIMIT %d",
@@ -310,7 +322,7 @@ func (c *mySQLClient) getStatementEventsStats() ([]StatementEventStats, error) {
 	for rows.Next() {
 		var s StatementEventStats
 		err := rows.Scan(&s.schema, &s.digest, &s.digestText,
-			&s.sumTimerWait, &s.countErrors, &s.countWarnings,
+			&s.countStar, &s.sumTimerWait, &s.countErrors, &s.countWarnings,
 			&s.countRowsAffected, &s.countRowsSent, &s.countRowsExamined, &s.countCreatedTmpDiskTables,
 			&s.countCreatedTmpTables, &s.countSortMergePasses, &s.countSortRows, &s.countNoIndexUsed)
 		if err != nil {
@@ -322,6 +334,30 @@ func (c *mySQLClient) getStatementEventsStats() ([]StatementEventStats, error) {
 	return stats, nil
 }
 
+// getRunningQueries queries the db for the queries which have been running for at least minDuration.
+func (c *mySQLClient) getRunningQueries(minDuration time.Duration) ([]runningQuery, error) {
+	query := "SELECT ID, ifnull(USER, ''), ifnull(HOST, ''), ifnull(DB, ''), ifnull(STATE, ''), TIME, INFO " +
+		"FROM information_schema.PROCESSLIST " +
+		"WHERE COMMAND = 'Query' AND INFO IS NOT NULL AND ID <> CONNECTION_ID() AND TIME >= ?"
+
+	rows, err := c.client.Query(query, int64(minDuration.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var queries []runningQuery
+	for rows.Next() {
+		var q runningQuery
+		if err := rows.Scan(&q.id, &q.user, &q.host, &q.db, &q.state, &q.time, &q.info); err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+
+	return queries, nil
+}
+
 func (c *mySQLClient) getTableLockWaitEventStats() ([]tableLockWaitEventStats, error) {
 	query := "SELECT OBJECT_SCHEMA, OBJECT_NAME, COUNT_READ_NORMAL, COUNT_READ_WITH_SHARED_LOCKS," +
 		"COUNT_READ_HIGH_PRIORITY, COUNT_READ_NO_INSERT, COUNT_READ_EXTERNAL, COUNT_WRITE_ALLOW_WRITE," +
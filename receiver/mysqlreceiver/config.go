@@ -4,6 +4,7 @@
 package mysqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config/confignet"
@@ -19,6 +20,7 @@ const (
 	defaultStatementEventsDigestTextLimit = 120
 	defaultStatementEventsLimit           = 250
 	defaultStatementEventsTimeLimit       = 24 * time.Hour
+	defaultQueryStatsTopN                 = 100
 )
 
 type Config struct {
@@ -31,6 +33,8 @@ type Config struct {
 	TLS                                     configtls.TLSClientSetting    `mapstructure:"tls,omitempty"`
 	MetricsBuilderConfig                    metadata.MetricsBuilderConfig `mapstructure:",squash"`
 	StatementEvents                         StatementEventsConfig         `mapstructure:"statement_events"`
+	QueryStats                              QueryStatsConfig              `mapstructure:"query_stats"`
+	RunningQueries                          RunningQueriesConfig          `mapstructure:"running_queries"`
 }
 
 type StatementEventsConfig struct {
@@ -39,6 +43,30 @@ type StatementEventsConfig struct {
 	TimeLimit       time.Duration `mapstructure:"time_limit"`
 }
 
+// QueryStatsConfig configures the collection of the mysql.query.digest.* metrics.
+type QueryStatsConfig struct {
+	// TopN is the maximum number of statements reported on each collection, the statements
+	// with the highest execution time during the collection interval are kept.
+	TopN int `mapstructure:"top_n"`
+}
+
+// RunningQueriesConfig configures the log events emitted for the currently running queries.
+type RunningQueriesConfig struct {
+	// MinDuration excludes the queries which have been running for less than this duration.
+	MinDuration time.Duration `mapstructure:"min_duration"`
+}
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.QueryStats.TopN <= 0 {
+		errs = append(errs, errors.New("'query_stats.top_n' must be greater than 0"))
+	}
+	if cfg.RunningQueries.MinDuration < 0 {
+		errs = append(errs, errors.New("'running_queries.min_duration' must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
 		// Nothing to do if there is no config given.
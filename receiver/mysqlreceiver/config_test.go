@@ -61,3 +61,31 @@ func TestLoadConfigDefaultTLS(t *testing.T) {
 
 	require.Equal(t, expected, cfg)
 }
+
+func TestLoadConfigQueryStats(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "query_stats").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Endpoint = "localhost:3306"
+	expected.Username = "otel"
+	expected.Password = "${env:MYSQL_PASSWORD}"
+	expected.TLS.Insecure = true
+	expected.QueryStats.TopN = 20
+	expected.RunningQueries.MinDuration = 5 * time.Second
+
+	require.Equal(t, expected, cfg)
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	expected.QueryStats.TopN = 0
+	expected.RunningQueries.MinDuration = -time.Second
+	require.EqualError(t, component.ValidateConfig(expected),
+		"'query_stats.top_n' must be greater than 0\n'running_queries.min_duration' must not be negative")
+}
@@ -50,6 +50,10 @@ type MetricsConfig struct {
 	MysqlPreparedStatements      MetricConfig `mapstructure:"mysql.prepared_statements"`
 	MysqlQueryClientCount        MetricConfig `mapstructure:"mysql.query.client.count"`
 	MysqlQueryCount              MetricConfig `mapstructure:"mysql.query.count"`
+	MysqlQueryDigestCalls        MetricConfig `mapstructure:"mysql.query.digest.calls"`
+	MysqlQueryDigestMeanTime     MetricConfig `mapstructure:"mysql.query.digest.mean_time"`
+	MysqlQueryDigestRows         MetricConfig `mapstructure:"mysql.query.digest.rows"`
+	MysqlQueryDigestTime         MetricConfig `mapstructure:"mysql.query.digest.time"`
 	MysqlQuerySlowCount          MetricConfig `mapstructure:"mysql.query.slow.count"`
 	MysqlReplicaSQLDelay         MetricConfig `mapstructure:"mysql.replica.sql_delay"`
 	MysqlReplicaTimeBehindSource MetricConfig `mapstructure:"mysql.replica.time_behind_source"`
@@ -147,6 +151,18 @@ func DefaultMetricsConfig() MetricsConfig {
 		MysqlQueryCount: MetricConfig{
 			Enabled: false,
 		},
+		MysqlQueryDigestCalls: MetricConfig{
+			Enabled: false,
+		},
+		MysqlQueryDigestMeanTime: MetricConfig{
+			Enabled: false,
+		},
+		MysqlQueryDigestRows: MetricConfig{
+			Enabled: false,
+		},
+		MysqlQueryDigestTime: MetricConfig{
+			Enabled: false,
+		},
 		MysqlQuerySlowCount: MetricConfig{
 			Enabled: false,
 		},
@@ -51,6 +51,10 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					MysqlPreparedStatements:      MetricConfig{Enabled: true},
 					MysqlQueryClientCount:        MetricConfig{Enabled: true},
 					MysqlQueryCount:              MetricConfig{Enabled: true},
+					MysqlQueryDigestCalls:        MetricConfig{Enabled: true},
+					MysqlQueryDigestMeanTime:     MetricConfig{Enabled: true},
+					MysqlQueryDigestRows:         MetricConfig{Enabled: true},
+					MysqlQueryDigestTime:         MetricConfig{Enabled: true},
 					MysqlQuerySlowCount:          MetricConfig{Enabled: true},
 					MysqlReplicaSQLDelay:         MetricConfig{Enabled: true},
 					MysqlReplicaTimeBehindSource: MetricConfig{Enabled: true},
@@ -104,6 +108,10 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					MysqlPreparedStatements:      MetricConfig{Enabled: false},
 					MysqlQueryClientCount:        MetricConfig{Enabled: false},
 					MysqlQueryCount:              MetricConfig{Enabled: false},
+					MysqlQueryDigestCalls:        MetricConfig{Enabled: false},
+					MysqlQueryDigestMeanTime:     MetricConfig{Enabled: false},
+					MysqlQueryDigestRows:         MetricConfig{Enabled: false},
+					MysqlQueryDigestTime:         MetricConfig{Enabled: false},
 					MysqlQuerySlowCount:          MetricConfig{Enabled: false},
 					MysqlReplicaSQLDelay:         MetricConfig{Enabled: false},
 					MysqlReplicaTimeBehindSource: MetricConfig{Enabled: false},
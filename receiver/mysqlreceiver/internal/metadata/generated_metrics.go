@@ -263,6 +263,36 @@ var MapAttributeConnectionStatus = map[string]AttributeConnectionStatus{
 	"rejected": AttributeConnectionStatusRejected,
 }
 
+// AttributeDigestRowOperation specifies the a value digest_row_operation attribute.
+type AttributeDigestRowOperation int
+
+const (
+	_ AttributeDigestRowOperation = iota
+	AttributeDigestRowOperationSent
+	AttributeDigestRowOperationExamined
+	AttributeDigestRowOperationAffected
+)
+
+// String returns the string representation of the AttributeDigestRowOperation.
+func (av AttributeDigestRowOperation) String() string {
+	switch av {
+	case AttributeDigestRowOperationSent:
+		return "sent"
+	case AttributeDigestRowOperationExamined:
+		return "examined"
+	case AttributeDigestRowOperationAffected:
+		return "affected"
+	}
+	return ""
+}
+
+// MapAttributeDigestRowOperation is a helper map of string to AttributeDigestRowOperation attribute value.
+var MapAttributeDigestRowOperation = map[string]AttributeDigestRowOperation{
+	"sent":     AttributeDigestRowOperationSent,
+	"examined": AttributeDigestRowOperationExamined,
+	"affected": AttributeDigestRowOperationAffected,
+}
+
 // AttributeDirection specifies the a value direction attribute.
 type AttributeDirection int
 
@@ -2304,6 +2334,225 @@ func newMetricMysqlQueryCount(cfg MetricConfig) metricMysqlQueryCount {
 	return m
 }
 
+type metricMysqlQueryDigestCalls struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills mysql.query.digest.calls metric with initial data.
+func (m *metricMysqlQueryDigestCalls) init() {
+	m.data.SetName("mysql.query.digest.calls")
+	m.data.SetDescription("The number of times the normalized statement was executed during the collection interval.")
+	m.data.SetUnit("{call}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMysqlQueryDigestCalls) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("schema", schemaAttributeValue)
+	dp.Attributes().PutStr("digest", digestAttributeValue)
+	dp.Attributes().PutStr("digest_text", digestTextAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMysqlQueryDigestCalls) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMysqlQueryDigestCalls) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMysqlQueryDigestCalls(cfg MetricConfig) metricMysqlQueryDigestCalls {
+	m := metricMysqlQueryDigestCalls{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricMysqlQueryDigestMeanTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills mysql.query.digest.mean_time metric with initial data.
+func (m *metricMysqlQueryDigestMeanTime) init() {
+	m.data.SetName("mysql.query.digest.mean_time")
+	m.data.SetDescription("The mean time spent executing the normalized statement during the collection interval.")
+	m.data.SetUnit("ns")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMysqlQueryDigestMeanTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("schema", schemaAttributeValue)
+	dp.Attributes().PutStr("digest", digestAttributeValue)
+	dp.Attributes().PutStr("digest_text", digestTextAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMysqlQueryDigestMeanTime) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMysqlQueryDigestMeanTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMysqlQueryDigestMeanTime(cfg MetricConfig) metricMysqlQueryDigestMeanTime {
+	m := metricMysqlQueryDigestMeanTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricMysqlQueryDigestRows struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills mysql.query.digest.rows metric with initial data.
+func (m *metricMysqlQueryDigestRows) init() {
+	m.data.SetName("mysql.query.digest.rows")
+	m.data.SetDescription("The number of rows sent, examined or affected by the normalized statement during the collection interval.")
+	m.data.SetUnit("{row}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMysqlQueryDigestRows) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string, digestRowOperationAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("schema", schemaAttributeValue)
+	dp.Attributes().PutStr("digest", digestAttributeValue)
+	dp.Attributes().PutStr("digest_text", digestTextAttributeValue)
+	dp.Attributes().PutStr("operation", digestRowOperationAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMysqlQueryDigestRows) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMysqlQueryDigestRows) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMysqlQueryDigestRows(cfg MetricConfig) metricMysqlQueryDigestRows {
+	m := metricMysqlQueryDigestRows{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricMysqlQueryDigestTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills mysql.query.digest.time metric with initial data.
+func (m *metricMysqlQueryDigestTime) init() {
+	m.data.SetName("mysql.query.digest.time")
+	m.data.SetDescription("The time spent executing the normalized statement during the collection interval.")
+	m.data.SetUnit("ns")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricMysqlQueryDigestTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("schema", schemaAttributeValue)
+	dp.Attributes().PutStr("digest", digestAttributeValue)
+	dp.Attributes().PutStr("digest_text", digestTextAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricMysqlQueryDigestTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricMysqlQueryDigestTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricMysqlQueryDigestTime(cfg MetricConfig) metricMysqlQueryDigestTime {
+	m := metricMysqlQueryDigestTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricMysqlQuerySlowCount struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -3300,6 +3549,10 @@ type MetricsBuilder struct {
 	metricMysqlPreparedStatements      metricMysqlPreparedStatements
 	metricMysqlQueryClientCount        metricMysqlQueryClientCount
 	metricMysqlQueryCount              metricMysqlQueryCount
+	metricMysqlQueryDigestCalls        metricMysqlQueryDigestCalls
+	metricMysqlQueryDigestMeanTime     metricMysqlQueryDigestMeanTime
+	metricMysqlQueryDigestRows         metricMysqlQueryDigestRows
+	metricMysqlQueryDigestTime         metricMysqlQueryDigestTime
 	metricMysqlQuerySlowCount          metricMysqlQuerySlowCount
 	metricMysqlReplicaSQLDelay         metricMysqlReplicaSQLDelay
 	metricMysqlReplicaTimeBehindSource metricMysqlReplicaTimeBehindSource
@@ -3361,6 +3614,10 @@ func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSetting
 		metricMysqlPreparedStatements:      newMetricMysqlPreparedStatements(mbc.Metrics.MysqlPreparedStatements),
 		metricMysqlQueryClientCount:        newMetricMysqlQueryClientCount(mbc.Metrics.MysqlQueryClientCount),
 		metricMysqlQueryCount:              newMetricMysqlQueryCount(mbc.Metrics.MysqlQueryCount),
+		metricMysqlQueryDigestCalls:        newMetricMysqlQueryDigestCalls(mbc.Metrics.MysqlQueryDigestCalls),
+		metricMysqlQueryDigestMeanTime:     newMetricMysqlQueryDigestMeanTime(mbc.Metrics.MysqlQueryDigestMeanTime),
+		metricMysqlQueryDigestRows:         newMetricMysqlQueryDigestRows(mbc.Metrics.MysqlQueryDigestRows),
+		metricMysqlQueryDigestTime:         newMetricMysqlQueryDigestTime(mbc.Metrics.MysqlQueryDigestTime),
 		metricMysqlQuerySlowCount:          newMetricMysqlQuerySlowCount(mbc.Metrics.MysqlQuerySlowCount),
 		metricMysqlReplicaSQLDelay:         newMetricMysqlReplicaSQLDelay(mbc.Metrics.MysqlReplicaSQLDelay),
 		metricMysqlReplicaTimeBehindSource: newMetricMysqlReplicaTimeBehindSource(mbc.Metrics.MysqlReplicaTimeBehindSource),
@@ -3465,6 +3722,10 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	mb.metricMysqlPreparedStatements.emit(ils.Metrics())
 	mb.metricMysqlQueryClientCount.emit(ils.Metrics())
 	mb.metricMysqlQueryCount.emit(ils.Metrics())
+	mb.metricMysqlQueryDigestCalls.emit(ils.Metrics())
+	mb.metricMysqlQueryDigestMeanTime.emit(ils.Metrics())
+	mb.metricMysqlQueryDigestRows.emit(ils.Metrics())
+	mb.metricMysqlQueryDigestTime.emit(ils.Metrics())
 	mb.metricMysqlQuerySlowCount.emit(ils.Metrics())
 	mb.metricMysqlReplicaSQLDelay.emit(ils.Metrics())
 	mb.metricMysqlReplicaTimeBehindSource.emit(ils.Metrics())
@@ -3733,6 +3994,26 @@ func (mb *MetricsBuilder) RecordMysqlQueryCountDataPoint(ts pcommon.Timestamp, i
 	return nil
 }
 
+// RecordMysqlQueryDigestCallsDataPoint adds a data point to mysql.query.digest.calls metric.
+func (mb *MetricsBuilder) RecordMysqlQueryDigestCallsDataPoint(ts pcommon.Timestamp, val int64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string) {
+	mb.metricMysqlQueryDigestCalls.recordDataPoint(mb.startTime, ts, val, schemaAttributeValue, digestAttributeValue, digestTextAttributeValue)
+}
+
+// RecordMysqlQueryDigestMeanTimeDataPoint adds a data point to mysql.query.digest.mean_time metric.
+func (mb *MetricsBuilder) RecordMysqlQueryDigestMeanTimeDataPoint(ts pcommon.Timestamp, val float64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string) {
+	mb.metricMysqlQueryDigestMeanTime.recordDataPoint(mb.startTime, ts, val, schemaAttributeValue, digestAttributeValue, digestTextAttributeValue)
+}
+
+// RecordMysqlQueryDigestRowsDataPoint adds a data point to mysql.query.digest.rows metric.
+func (mb *MetricsBuilder) RecordMysqlQueryDigestRowsDataPoint(ts pcommon.Timestamp, val int64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string, digestRowOperationAttributeValue AttributeDigestRowOperation) {
+	mb.metricMysqlQueryDigestRows.recordDataPoint(mb.startTime, ts, val, schemaAttributeValue, digestAttributeValue, digestTextAttributeValue, digestRowOperationAttributeValue.String())
+}
+
+// RecordMysqlQueryDigestTimeDataPoint adds a data point to mysql.query.digest.time metric.
+func (mb *MetricsBuilder) RecordMysqlQueryDigestTimeDataPoint(ts pcommon.Timestamp, val int64, schemaAttributeValue string, digestAttributeValue string, digestTextAttributeValue string) {
+	mb.metricMysqlQueryDigestTime.recordDataPoint(mb.startTime, ts, val, schemaAttributeValue, digestAttributeValue, digestTextAttributeValue)
+}
+
 // RecordMysqlQuerySlowCountDataPoint adds a data point to mysql.query.slow.count metric.
 func (mb *MetricsBuilder) RecordMysqlQuerySlowCountDataPoint(ts pcommon.Timestamp, inputVal string) error {
 	val, err := strconv.ParseInt(inputVal, 10, 64)
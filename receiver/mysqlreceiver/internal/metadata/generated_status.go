@@ -9,4 +9,5 @@ import (
 const (
 	Type             = "mysql"
 	MetricsStability = component.StabilityLevelBeta
+	LogsStability    = component.StabilityLevelDevelopment
 )
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mysqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type queryStatsKey struct {
+	schema string
+	digest string
+}
+
+// queryStatsTracker turns the cumulative statistics of events_statements_summary_by_digest,
+// as collected for the mysql.statement_event.* metrics, into the statistics of the collection interval.
+type queryStatsTracker struct {
+	lastCollection pcommon.Timestamp
+	previous       map[queryStatsKey]StatementEventStats
+}
+
+// update stores the current cumulative statistics, and returns the start of the collection interval
+// with the statistics of the statements executed during it. Nothing is returned for the statements
+// without a previous value to compute the difference with, as on the first collection, or when the
+// statement was not among the statement_events.limit ones of the previous collection.
+func (t *queryStatsTracker) update(now pcommon.Timestamp, current []StatementEventStats) (pcommon.Timestamp, []StatementEventStats) {
+	start := t.lastCollection
+	next := make(map[queryStatsKey]StatementEventStats, len(current))
+	var deltas []StatementEventStats
+	for _, s := range current {
+		key := queryStatsKey{schema: s.schema, digest: s.digest}
+		next[key] = s
+		prev, ok := t.previous[key]
+		if !ok {
+			continue
+		}
+		// A statement with a lower number of calls because the summary was truncated in between
+		// has all its current values belonging to the collection interval.
+		delta := s
+		if s.countStar >= prev.countStar {
+			delta = s.sub(prev)
+		}
+		if delta.countStar == 0 {
+			continue
+		}
+		deltas = append(deltas, delta)
+	}
+	t.lastCollection = now
+	t.previous = next
+	return start, deltas
+}
+
+func (s StatementEventStats) sub(prev StatementEventStats) StatementEventStats {
+	s.countStar -= prev.countStar
+	s.sumTimerWait -= prev.sumTimerWait
+	s.countErrors -= prev.countErrors
+	s.countWarnings -= prev.countWarnings
+	s.countRowsAffected -= prev.countRowsAffected
+	s.countRowsSent -= prev.countRowsSent
+	s.countRowsExamined -= prev.countRowsExamined
+	s.countCreatedTmpDiskTables -= prev.countCreatedTmpDiskTables
+	s.countCreatedTmpTables -= prev.countCreatedTmpTables
+	s.countSortMergePasses -= prev.countSortMergePasses
+	s.countSortRows -= prev.countSortRows
+	s.countNoIndexUsed -= prev.countNoIndexUsed
+	return s
+}
+
+// topQueryStats keeps the n statements with the highest execution time.
+func topQueryStats(stats []StatementEventStats, n int) []StatementEventStats {
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].sumTimerWait > stats[j].sumTimerWait
+	})
+	if len(stats) > n {
+		stats = stats[:n]
+	}
+	return stats
+}
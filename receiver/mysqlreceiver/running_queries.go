@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mysqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mysqlreceiver/internal/metadata"
+)
+
+// runningQueriesReceiver emits a log event for each query running at every collection interval.
+type runningQueriesReceiver struct {
+	sqlclient    client
+	logger       *zap.Logger
+	config       *Config
+	nextConsumer consumer.Logs
+	obsrecv      *receiverhelper.ObsReport
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newRunningQueriesReceiver(
+	settings receiver.CreateSettings,
+	config *Config,
+	nextConsumer consumer.Logs,
+) (*runningQueriesReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &runningQueriesReceiver{
+		logger:       settings.Logger,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+	}, nil
+}
+
+// Start initializes the db client connection and starts collecting the running queries.
+func (r *runningQueriesReceiver) Start(_ context.Context, _ component.Host) error {
+	sqlclient, err := newMySQLClient(r.config)
+	if err != nil {
+		return err
+	}
+	if err = sqlclient.Connect(); err != nil {
+		return err
+	}
+	r.sqlclient = sqlclient
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.config.CollectionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.collect(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops collecting and closes the db connection.
+func (r *runningQueriesReceiver) Shutdown(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	if r.sqlclient == nil {
+		return nil
+	}
+	return r.sqlclient.Close()
+}
+
+func (r *runningQueriesReceiver) collect(ctx context.Context) {
+	logs, err := r.scrape()
+	if err != nil {
+		r.logger.Error("Failed to fetch running queries", zap.Error(err))
+		return
+	}
+	logRecordCount := logs.LogRecordCount()
+	if logRecordCount == 0 {
+		return
+	}
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	err = r.nextConsumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(obsCtx, metadata.Type, logRecordCount, err)
+	if err != nil {
+		r.logger.Error("Failed to send running queries", zap.Error(err))
+	}
+}
+
+func (r *runningQueriesReceiver) scrape() (plog.Logs, error) {
+	logs := plog.NewLogs()
+	queries, err := r.sqlclient.getRunningQueries(r.config.RunningQueries.MinDuration)
+	if err != nil {
+		return logs, err
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().PutStr("mysql.instance.endpoint", r.config.Endpoint)
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName("otelcol/mysqlreceiver")
+	for _, q := range queries {
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetTimestamp(now)
+		logRecord.SetObservedTimestamp(now)
+		logRecord.Body().SetStr(q.info)
+		attrs := logRecord.Attributes()
+		attrs.PutStr("db.system", "mysql")
+		attrs.PutStr("db.name", q.db)
+		attrs.PutStr("db.user", q.user)
+		attrs.PutInt("mysql.connection_id", q.id)
+		attrs.PutStr("client.address", q.host)
+		if q.state != "" {
+			attrs.PutStr("mysql.state", q.state)
+		}
+		attrs.PutInt("mysql.query.duration", q.time)
+	}
+	return logs, nil
+}
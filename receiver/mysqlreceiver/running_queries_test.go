@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mysqlreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestRunningQueriesScrape(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr = confignet.NetAddr{Endpoint: "localhost:3306"}
+
+	rcvr, err := newRunningQueriesReceiver(receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	rcvr.sqlclient = &mockClient{runningQueriesFile: "running_queries"}
+
+	logs, err := rcvr.scrape()
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	resourceLogs := logs.ResourceLogs().At(0)
+	require.Equal(t, map[string]any{"mysql.instance.endpoint": "localhost:3306"}, resourceLogs.Resource().Attributes().AsRaw())
+	logRecord := resourceLogs.ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, "SELECT SLEEP(10)", logRecord.Body().Str())
+	require.Equal(t, map[string]any{
+		"db.system":            "mysql",
+		"db.name":              "otel",
+		"db.user":              "otel",
+		"mysql.connection_id":  int64(42),
+		"client.address":       "10.0.0.1:51234",
+		"mysql.state":          "executing",
+		"mysql.query.duration": int64(3),
+	}, logRecord.Attributes().AsRaw())
+}
@@ -28,6 +28,9 @@ type mySQLScraper struct {
 	logger    *zap.Logger
 	config    *Config
 	mb        *metadata.MetricsBuilder
+	// queryMb records the delta query metrics, its start time is moved to the start of each collection interval.
+	queryMb    *metadata.MetricsBuilder
+	queryStats queryStatsTracker
 
 	// Feature gates regarding resource attributes
 	renameCommands bool
@@ -38,9 +41,10 @@ func newMySQLScraper(
 	config *Config,
 ) *mySQLScraper {
 	return &mySQLScraper{
-		logger: settings.Logger,
-		config: config,
-		mb:     metadata.NewMetricsBuilder(config.MetricsBuilderConfig, settings),
+		logger:  settings.Logger,
+		config:  config,
+		mb:      metadata.NewMetricsBuilder(config.MetricsBuilderConfig, settings),
+		queryMb: metadata.NewMetricsBuilder(config.MetricsBuilderConfig, settings),
 	}
 }
 
@@ -109,7 +113,9 @@ func (m *mySQLScraper) scrape(context.Context) (pmetric.Metrics, error) {
 	rb.SetMysqlInstanceEndpoint(m.config.Endpoint)
 	m.mb.EmitForResource(metadata.WithResource(rb.Emit()))
 
-	return m.mb.Emit(), errs.Combine()
+	metrics := m.mb.Emit()
+	m.queryMb.Emit().ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+	return metrics, errs.Combine()
 }
 
 func (m *mySQLScraper) scrapeGlobalStats(now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
@@ -498,6 +504,35 @@ func (m *mySQLScraper) scrapeStatementEventsStats(now pcommon.Timestamp, errs *s
 
 		m.mb.RecordMysqlStatementEventWaitTimeDataPoint(now, s.sumTimerWait/picosecondsInNanoseconds, s.schema, s.digest, s.digestText)
 	}
+
+	// collect the delta metrics of the statement digests.
+	if m.queryMetricsEnabled() {
+		m.scrapeQueryStats(now, statementEventsStats)
+	}
+}
+
+func (m *mySQLScraper) queryMetricsEnabled() bool {
+	metrics := m.config.MetricsBuilderConfig.Metrics
+	return metrics.MysqlQueryDigestCalls.Enabled ||
+		metrics.MysqlQueryDigestTime.Enabled ||
+		metrics.MysqlQueryDigestMeanTime.Enabled ||
+		metrics.MysqlQueryDigestRows.Enabled
+}
+
+func (m *mySQLScraper) scrapeQueryStats(now pcommon.Timestamp, stats []StatementEventStats) {
+	start, deltas := m.queryStats.update(now, stats)
+	m.queryMb.Reset(metadata.WithStartTime(start))
+	for _, s := range topQueryStats(deltas, m.config.QueryStats.TopN) {
+		m.queryMb.RecordMysqlQueryDigestCallsDataPoint(now, s.countStar, s.schema, s.digest, s.digestText)
+		m.queryMb.RecordMysqlQueryDigestTimeDataPoint(now, s.sumTimerWait/picosecondsInNanoseconds, s.schema, s.digest, s.digestText)
+		m.queryMb.RecordMysqlQueryDigestMeanTimeDataPoint(now, float64(s.sumTimerWait/picosecondsInNanoseconds)/float64(s.countStar), s.schema, s.digest, s.digestText)
+		m.queryMb.RecordMysqlQueryDigestRowsDataPoint(now, s.countRowsSent, s.schema, s.digest, s.digestText, metadata.AttributeDigestRowOperationSent)
+		m.queryMb.RecordMysqlQueryDigestRowsDataPoint(now, s.countRowsExamined, s.schema, s.digest, s.digestText, metadata.AttributeDigestRowOperationExamined)
+		m.queryMb.RecordMysqlQueryDigestRowsDataPoint(now, s.countRowsAffected, s.schema, s.digest, s.digestText, metadata.AttributeDigestRowOperationAffected)
+	}
+	rb := m.queryMb.NewResourceBuilder()
+	rb.SetMysqlInstanceEndpoint(m.config.Endpoint)
+	m.queryMb.EmitForResource(metadata.WithResource(rb.Emit()))
 }
 
 func (m *mySQLScraper) scrapeTableLockWaitEventStats(now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
@@ -12,10 +12,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 
@@ -120,6 +122,75 @@ func TestScrape(t *testing.T) {
 
 }
 
+func TestScrapeQueryStats(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr = confignet.NetAddr{Endpoint: "localhost:3306"}
+	cfg.QueryStats.TopN = 1
+	cfg.MetricsBuilderConfig.Metrics.MysqlQueryDigestCalls.Enabled = true
+	cfg.MetricsBuilderConfig.Metrics.MysqlQueryDigestTime.Enabled = true
+	cfg.MetricsBuilderConfig.Metrics.MysqlQueryDigestMeanTime.Enabled = true
+	cfg.MetricsBuilderConfig.Metrics.MysqlQueryDigestRows.Enabled = true
+
+	sqlclient := &mockClient{
+		globalStatsFile:             "global_stats",
+		innodbStatsFile:             "innodb_stats",
+		tableIoWaitsFile:            "table_io_waits_stats",
+		indexIoWaitsFile:            "index_io_waits_stats",
+		statementEventsFile:         "query_stats",
+		tableLockWaitEventStatsFile: "table_lock_wait_event_stats",
+		replicaStatusFile:           "replica_stats",
+	}
+	scraper := newMySQLScraper(receivertest.NewNopCreateSettings(), cfg)
+	scraper.sqlclient = sqlclient
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, queryDigestMetrics(metrics), "the first scrape should only record the initial values")
+
+	sqlclient.statementEventsFile = "query_stats_next"
+	metrics, err = scraper.scrape(context.Background())
+	require.NoError(t, err)
+	actual := queryDigestMetrics(metrics)
+	require.Len(t, actual, 4)
+
+	calls := actual["mysql.query.digest.calls"].Sum()
+	require.Equal(t, pmetric.AggregationTemporalityDelta, calls.AggregationTemporality())
+	require.Equal(t, 1, calls.DataPoints().Len(), "only the top statement should be reported")
+	dp := calls.DataPoints().At(0)
+	require.Equal(t, int64(1), dp.IntValue())
+	require.Equal(t, map[string]any{
+		"schema":      "otel",
+		"digest":      "3b4f1d1fe7d5b4a4a0f8e2c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f809",
+		"digest_text": "UPDATE `t` SET `v` = ?",
+	}, dp.Attributes().AsRaw())
+	require.Equal(t, int64(40000), actual["mysql.query.digest.time"].Sum().DataPoints().At(0).IntValue())
+	require.Equal(t, 40000.0, actual["mysql.query.digest.mean_time"].Gauge().DataPoints().At(0).DoubleValue())
+	rows := map[string]int64{}
+	for i := 0; i < actual["mysql.query.digest.rows"].Sum().DataPoints().Len(); i++ {
+		dp := actual["mysql.query.digest.rows"].Sum().DataPoints().At(i)
+		operation, _ := dp.Attributes().Get("operation")
+		rows[operation.Str()] = dp.IntValue()
+	}
+	require.Equal(t, map[string]int64{"sent": 0, "examined": 1, "affected": 1}, rows)
+}
+
+// queryDigestMetrics returns the mysql.query.digest.* metrics by name.
+func queryDigestMetrics(metrics pmetric.Metrics) map[string]pmetric.Metric {
+	out := map[string]pmetric.Metric{}
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			ms := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if strings.HasPrefix(ms.At(k).Name(), "mysql.query.digest.") {
+					out[ms.At(k).Name()] = ms.At(k)
+				}
+			}
+		}
+	}
+	return out
+}
+
 var _ client = (*mockClient)(nil)
 
 type mockClient struct {
@@ -130,6 +201,7 @@ type mockClient struct {
 	statementEventsFile         string
 	tableLockWaitEventStatsFile string
 	replicaStatusFile           string
+	runningQueriesFile          string
 }
 
 func readFile(fname string) (map[string]string, error) {
@@ -250,6 +322,7 @@ func (c *mockClient) getStatementEventsStats() ([]StatementEventStats, error) {
 		s.countSortMergePasses, _ = parseInt(text[11])
 		s.countSortRows, _ = parseInt(text[12])
 		s.countNoIndexUsed, _ = parseInt(text[13])
+		s.countStar, _ = parseInt(text[14])
 
 		stats = append(stats, s)
 	}
@@ -378,6 +451,32 @@ func (c *mockClient) getReplicaStatusStats() ([]ReplicaStatusStats, error) {
 	return stats, nil
 }
 
+func (c *mockClient) getRunningQueries(_ time.Duration) ([]runningQuery, error) {
+	var queries []runningQuery
+	file, err := os.Open(filepath.Join("testdata", "scraper", c.runningQueriesFile+".txt"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var q runningQuery
+		text := strings.Split(scanner.Text(), "\t")
+
+		q.id, _ = parseInt(text[0])
+		q.user = text[1]
+		q.host = text[2]
+		q.db = text[3]
+		q.state = text[4]
+		q.time, _ = parseInt(text[5])
+		q.info = text[6]
+
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
+
 func (c *mockClient) Close() error {
 	return nil
 }
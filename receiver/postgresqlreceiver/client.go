@@ -57,6 +57,8 @@ type client interface {
 	getMaxConnections(ctx context.Context) (int64, error)
 	getIndexStats(ctx context.Context, database string) (map[indexIdentifer]indexStat, error)
 	listDatabases(ctx context.Context) ([]string, error)
+	getQueryStats(ctx context.Context) ([]queryStats, error)
+	getRunningQueries(ctx context.Context, minDuration time.Duration) ([]runningQuery, error)
 }
 
 type postgreSQLClient struct {
@@ -623,6 +625,120 @@ func (c *postgreSQLClient) listDatabases(ctx context.Context) ([]string, error)
 	return databases, nil
 }
 
+type queryStats struct {
+	database          string
+	queryID           string
+	query             string
+	calls             int64
+	totalExecTime     float64
+	rows              int64
+	sharedBlksHit     int64
+	sharedBlksRead    int64
+	sharedBlksDirtied int64
+	sharedBlksWritten int64
+}
+
+// getQueryStats returns the cumulative statistics of the normalized queries tracked by pg_stat_statements,
+// summed over the users running them.
+func (c *postgreSQLClient) getQueryStats(ctx context.Context) ([]queryStats, error) {
+	var versionNum int64
+	if err := c.client.QueryRowContext(ctx, "SELECT current_setting('server_version_num')::integer;").Scan(&versionNum); err != nil {
+		return nil, err
+	}
+	// the execution time columns were renamed in PostgreSQL 13, when the planning time columns were added
+	totalExecTimeColumn := "total_exec_time"
+	if versionNum < 130000 {
+		totalExecTimeColumn = "total_time"
+	}
+
+	query := fmt.Sprintf(`SELECT
+	d.datname,
+	s.queryid::text,
+	max(s.query),
+	sum(s.calls)::bigint,
+	sum(s.%s)::double precision,
+	sum(s.rows)::bigint,
+	sum(s.shared_blks_hit)::bigint,
+	sum(s.shared_blks_read)::bigint,
+	sum(s.shared_blks_dirtied)::bigint,
+	sum(s.shared_blks_written)::bigint
+	FROM pg_stat_statements s
+	JOIN pg_database d ON d.oid = s.dbid
+	WHERE s.queryid IS NOT NULL
+	GROUP BY d.datname, s.queryid;`, totalExecTimeColumn)
+
+	rows, err := c.client.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query pg_stat_statements: %w", err)
+	}
+	defer rows.Close()
+	var stats []queryStats
+	var errors error
+	for rows.Next() {
+		var s queryStats
+		err = rows.Scan(&s.database, &s.queryID, &s.query, &s.calls, &s.totalExecTime, &s.rows,
+			&s.sharedBlksHit, &s.sharedBlksRead, &s.sharedBlksDirtied, &s.sharedBlksWritten)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		stats = append(stats, s)
+	}
+	return stats, errors
+}
+
+type runningQuery struct {
+	database        string
+	pid             int64
+	user            string
+	applicationName string
+	clientAddr      string
+	waitEventType   string
+	waitEvent       string
+	queryStart      time.Time
+	duration        float64
+	query           string
+}
+
+// getRunningQueries returns the queries of the client backends which have been running for at least minDuration.
+func (c *postgreSQLClient) getRunningQueries(ctx context.Context, minDuration time.Duration) ([]runningQuery, error) {
+	query := `SELECT
+	coalesce(datname, ''),
+	pid,
+	coalesce(usename, ''),
+	coalesce(application_name, ''),
+	coalesce(host(client_addr), ''),
+	coalesce(wait_event_type, ''),
+	coalesce(wait_event, ''),
+	query_start,
+	extract('epoch' from clock_timestamp() - query_start)::double precision,
+	query
+	FROM pg_stat_activity
+	WHERE state = 'active'
+	AND backend_type = 'client backend'
+	AND pid <> pg_backend_pid()
+	AND clock_timestamp() - query_start >= $1 * interval '1 second';`
+
+	rows, err := c.client.QueryContext(ctx, query, minDuration.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("unable to query pg_stat_activity: %w", err)
+	}
+	defer rows.Close()
+	var queries []runningQuery
+	var errors error
+	for rows.Next() {
+		var q runningQuery
+		err = rows.Scan(&q.database, &q.pid, &q.user, &q.applicationName, &q.clientAddr,
+			&q.waitEventType, &q.waitEvent, &q.queryStart, &q.duration, &q.query)
+		if err != nil {
+			errors = multierr.Append(errors, err)
+			continue
+		}
+		queries = append(queries, q)
+	}
+	return queries, errors
+}
+
 func filterQueryByDatabases(baseQuery string, databases []string, groupBy bool) string {
 	if len(databases) > 0 {
 		var queryDatabases []string
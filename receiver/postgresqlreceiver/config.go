@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -24,8 +25,12 @@ const (
 	ErrNotSupported        = "invalid config: field '%s' not supported"
 	ErrTransportsSupported = "invalid config: 'transport' must be 'tcp' or 'unix'"
 	ErrHostPort            = "invalid config: 'endpoint' must be in the form <host>:<port> no matter what 'transport' is configured"
+	ErrQueryStatsTopN      = "invalid config: 'query_stats.top_n' must be greater than 0"
+	ErrMinDuration         = "invalid config: 'running_queries.min_duration' must not be negative"
 )
 
+const defaultQueryStatsTopN = 100
+
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	Username                                string                         `mapstructure:"username"`
@@ -34,6 +39,21 @@ type Config struct {
 	confignet.NetAddr                       `mapstructure:",squash"`       // provides Endpoint and Transport
 	configtls.TLSClientSetting              `mapstructure:"tls,omitempty"` // provides SSL details
 	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+	QueryStats                              QueryStatsConfig     `mapstructure:"query_stats"`
+	RunningQueries                          RunningQueriesConfig `mapstructure:"running_queries"`
+}
+
+// QueryStatsConfig configures the collection of the postgresql.query.* metrics from pg_stat_statements.
+type QueryStatsConfig struct {
+	// TopN is the maximum number of queries reported on each collection, the queries
+	// with the highest execution time during the collection interval are kept.
+	TopN int `mapstructure:"top_n"`
+}
+
+// RunningQueriesConfig configures the log events emitted for the currently running queries.
+type RunningQueriesConfig struct {
+	// MinDuration excludes the queries which have been running for less than this duration.
+	MinDuration time.Duration `mapstructure:"min_duration"`
 }
 
 func (cfg *Config) Validate() error {
@@ -66,5 +86,12 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, errors.New(ErrTransportsSupported))
 	}
 
+	if cfg.QueryStats.TopN <= 0 {
+		err = multierr.Append(err, errors.New(ErrQueryStatsTopN))
+	}
+	if cfg.RunningQueries.MinDuration < 0 {
+		err = multierr.Append(err, errors.New(ErrMinDuration))
+	}
+
 	return err
 }
@@ -88,6 +88,19 @@ func TestValidate(t *testing.T) {
 				fmt.Errorf(ErrNotSupported, "MinVersion"),
 			),
 		},
+		{
+			desc: "invalid query stats and running queries settings",
+			defaultConfigModifier: func(cfg *Config) {
+				cfg.Username = "otel"
+				cfg.Password = "otel"
+				cfg.QueryStats.TopN = 0
+				cfg.RunningQueries.MinDuration = -time.Second
+			},
+			expected: multierr.Combine(
+				errors.New(ErrQueryStatsTopN),
+				errors.New(ErrMinDuration),
+			),
+		},
 		{
 			desc: "no error",
 			defaultConfigModifier: func(cfg *Config) {
@@ -149,6 +162,8 @@ func TestLoadConfig(t *testing.T) {
 				KeyFile:  "/home/otel/mypostgreskey.key",
 			},
 		}
+		expected.QueryStats.TopN = 20
+		expected.RunningQueries.MinDuration = 5 * time.Second
 
 		require.Equal(t, expected, cfg)
 	})
@@ -41,6 +41,11 @@ type MetricsConfig struct {
 	PostgresqlIndexScans               MetricConfig `mapstructure:"postgresql.index.scans"`
 	PostgresqlIndexSize                MetricConfig `mapstructure:"postgresql.index.size"`
 	PostgresqlOperations               MetricConfig `mapstructure:"postgresql.operations"`
+	PostgresqlQueryCalls               MetricConfig `mapstructure:"postgresql.query.calls"`
+	PostgresqlQueryExecTime            MetricConfig `mapstructure:"postgresql.query.exec_time"`
+	PostgresqlQueryMeanExecTime        MetricConfig `mapstructure:"postgresql.query.mean_exec_time"`
+	PostgresqlQueryRows                MetricConfig `mapstructure:"postgresql.query.rows"`
+	PostgresqlQuerySharedBlocks        MetricConfig `mapstructure:"postgresql.query.shared_blocks"`
 	PostgresqlReplicationDataDelay     MetricConfig `mapstructure:"postgresql.replication.data_delay"`
 	PostgresqlRollbacks                MetricConfig `mapstructure:"postgresql.rollbacks"`
 	PostgresqlRows                     MetricConfig `mapstructure:"postgresql.rows"`
@@ -104,6 +109,21 @@ func DefaultMetricsConfig() MetricsConfig {
 		PostgresqlOperations: MetricConfig{
 			Enabled: true,
 		},
+		PostgresqlQueryCalls: MetricConfig{
+			Enabled: false,
+		},
+		PostgresqlQueryExecTime: MetricConfig{
+			Enabled: false,
+		},
+		PostgresqlQueryMeanExecTime: MetricConfig{
+			Enabled: false,
+		},
+		PostgresqlQueryRows: MetricConfig{
+			Enabled: false,
+		},
+		PostgresqlQuerySharedBlocks: MetricConfig{
+			Enabled: false,
+		},
 		PostgresqlReplicationDataDelay: MetricConfig{
 			Enabled: true,
 		},
@@ -97,6 +97,40 @@ var MapAttributeBgDurationType = map[string]AttributeBgDurationType{
 	"write": AttributeBgDurationTypeWrite,
 }
 
+// AttributeBlockOperation specifies the a value block_operation attribute.
+type AttributeBlockOperation int
+
+const (
+	_ AttributeBlockOperation = iota
+	AttributeBlockOperationHit
+	AttributeBlockOperationRead
+	AttributeBlockOperationDirtied
+	AttributeBlockOperationWritten
+)
+
+// String returns the string representation of the AttributeBlockOperation.
+func (av AttributeBlockOperation) String() string {
+	switch av {
+	case AttributeBlockOperationHit:
+		return "hit"
+	case AttributeBlockOperationRead:
+		return "read"
+	case AttributeBlockOperationDirtied:
+		return "dirtied"
+	case AttributeBlockOperationWritten:
+		return "written"
+	}
+	return ""
+}
+
+// MapAttributeBlockOperation is a helper map of string to AttributeBlockOperation attribute value.
+var MapAttributeBlockOperation = map[string]AttributeBlockOperation{
+	"hit":     AttributeBlockOperationHit,
+	"read":    AttributeBlockOperationRead,
+	"dirtied": AttributeBlockOperationDirtied,
+	"written": AttributeBlockOperationWritten,
+}
+
 // AttributeOperation specifies the a value operation attribute.
 type AttributeOperation int
 
@@ -1061,6 +1095,275 @@ func newMetricPostgresqlOperations(cfg MetricConfig) metricPostgresqlOperations
 	return m
 }
 
+type metricPostgresqlQueryCalls struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills postgresql.query.calls metric with initial data.
+func (m *metricPostgresqlQueryCalls) init() {
+	m.data.SetName("postgresql.query.calls")
+	m.data.SetDescription("The number of times the query was executed during the collection interval.")
+	m.data.SetUnit("{call}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPostgresqlQueryCalls) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("query_id", queryIDAttributeValue)
+	dp.Attributes().PutStr("query_text", queryTextAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPostgresqlQueryCalls) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPostgresqlQueryCalls) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPostgresqlQueryCalls(cfg MetricConfig) metricPostgresqlQueryCalls {
+	m := metricPostgresqlQueryCalls{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPostgresqlQueryExecTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills postgresql.query.exec_time metric with initial data.
+func (m *metricPostgresqlQueryExecTime) init() {
+	m.data.SetName("postgresql.query.exec_time")
+	m.data.SetDescription("The time spent executing the query during the collection interval.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPostgresqlQueryExecTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("query_id", queryIDAttributeValue)
+	dp.Attributes().PutStr("query_text", queryTextAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPostgresqlQueryExecTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPostgresqlQueryExecTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPostgresqlQueryExecTime(cfg MetricConfig) metricPostgresqlQueryExecTime {
+	m := metricPostgresqlQueryExecTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPostgresqlQueryMeanExecTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills postgresql.query.mean_exec_time metric with initial data.
+func (m *metricPostgresqlQueryMeanExecTime) init() {
+	m.data.SetName("postgresql.query.mean_exec_time")
+	m.data.SetDescription("The mean time spent executing the query during the collection interval.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPostgresqlQueryMeanExecTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("query_id", queryIDAttributeValue)
+	dp.Attributes().PutStr("query_text", queryTextAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPostgresqlQueryMeanExecTime) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPostgresqlQueryMeanExecTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPostgresqlQueryMeanExecTime(cfg MetricConfig) metricPostgresqlQueryMeanExecTime {
+	m := metricPostgresqlQueryMeanExecTime{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPostgresqlQueryRows struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills postgresql.query.rows metric with initial data.
+func (m *metricPostgresqlQueryRows) init() {
+	m.data.SetName("postgresql.query.rows")
+	m.data.SetDescription("The number of rows retrieved or affected by the query during the collection interval.")
+	m.data.SetUnit("{row}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPostgresqlQueryRows) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("query_id", queryIDAttributeValue)
+	dp.Attributes().PutStr("query_text", queryTextAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPostgresqlQueryRows) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPostgresqlQueryRows) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPostgresqlQueryRows(cfg MetricConfig) metricPostgresqlQueryRows {
+	m := metricPostgresqlQueryRows{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPostgresqlQuerySharedBlocks struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills postgresql.query.shared_blocks metric with initial data.
+func (m *metricPostgresqlQuerySharedBlocks) init() {
+	m.data.SetName("postgresql.query.shared_blocks")
+	m.data.SetDescription("The number of shared buffer blocks accessed by the query during the collection interval.")
+	m.data.SetUnit("{block}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPostgresqlQuerySharedBlocks) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, queryIDAttributeValue string, queryTextAttributeValue string, blockOperationAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("query_id", queryIDAttributeValue)
+	dp.Attributes().PutStr("query_text", queryTextAttributeValue)
+	dp.Attributes().PutStr("operation", blockOperationAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPostgresqlQuerySharedBlocks) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPostgresqlQuerySharedBlocks) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPostgresqlQuerySharedBlocks(cfg MetricConfig) metricPostgresqlQuerySharedBlocks {
+	m := metricPostgresqlQuerySharedBlocks{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricPostgresqlReplicationDataDelay struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -1648,6 +1951,11 @@ type MetricsBuilder struct {
 	metricPostgresqlIndexScans               metricPostgresqlIndexScans
 	metricPostgresqlIndexSize                metricPostgresqlIndexSize
 	metricPostgresqlOperations               metricPostgresqlOperations
+	metricPostgresqlQueryCalls               metricPostgresqlQueryCalls
+	metricPostgresqlQueryExecTime            metricPostgresqlQueryExecTime
+	metricPostgresqlQueryMeanExecTime        metricPostgresqlQueryMeanExecTime
+	metricPostgresqlQueryRows                metricPostgresqlQueryRows
+	metricPostgresqlQuerySharedBlocks        metricPostgresqlQuerySharedBlocks
 	metricPostgresqlReplicationDataDelay     metricPostgresqlReplicationDataDelay
 	metricPostgresqlRollbacks                metricPostgresqlRollbacks
 	metricPostgresqlRows                     metricPostgresqlRows
@@ -1693,6 +2001,11 @@ func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSetting
 		metricPostgresqlIndexScans:               newMetricPostgresqlIndexScans(mbc.Metrics.PostgresqlIndexScans),
 		metricPostgresqlIndexSize:                newMetricPostgresqlIndexSize(mbc.Metrics.PostgresqlIndexSize),
 		metricPostgresqlOperations:               newMetricPostgresqlOperations(mbc.Metrics.PostgresqlOperations),
+		metricPostgresqlQueryCalls:               newMetricPostgresqlQueryCalls(mbc.Metrics.PostgresqlQueryCalls),
+		metricPostgresqlQueryExecTime:            newMetricPostgresqlQueryExecTime(mbc.Metrics.PostgresqlQueryExecTime),
+		metricPostgresqlQueryMeanExecTime:        newMetricPostgresqlQueryMeanExecTime(mbc.Metrics.PostgresqlQueryMeanExecTime),
+		metricPostgresqlQueryRows:                newMetricPostgresqlQueryRows(mbc.Metrics.PostgresqlQueryRows),
+		metricPostgresqlQuerySharedBlocks:        newMetricPostgresqlQuerySharedBlocks(mbc.Metrics.PostgresqlQuerySharedBlocks),
 		metricPostgresqlReplicationDataDelay:     newMetricPostgresqlReplicationDataDelay(mbc.Metrics.PostgresqlReplicationDataDelay),
 		metricPostgresqlRollbacks:                newMetricPostgresqlRollbacks(mbc.Metrics.PostgresqlRollbacks),
 		metricPostgresqlRows:                     newMetricPostgresqlRows(mbc.Metrics.PostgresqlRows),
@@ -1781,6 +2094,11 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	mb.metricPostgresqlIndexScans.emit(ils.Metrics())
 	mb.metricPostgresqlIndexSize.emit(ils.Metrics())
 	mb.metricPostgresqlOperations.emit(ils.Metrics())
+	mb.metricPostgresqlQueryCalls.emit(ils.Metrics())
+	mb.metricPostgresqlQueryExecTime.emit(ils.Metrics())
+	mb.metricPostgresqlQueryMeanExecTime.emit(ils.Metrics())
+	mb.metricPostgresqlQueryRows.emit(ils.Metrics())
+	mb.metricPostgresqlQuerySharedBlocks.emit(ils.Metrics())
 	mb.metricPostgresqlReplicationDataDelay.emit(ils.Metrics())
 	mb.metricPostgresqlRollbacks.emit(ils.Metrics())
 	mb.metricPostgresqlRows.emit(ils.Metrics())
@@ -1892,6 +2210,31 @@ func (mb *MetricsBuilder) RecordPostgresqlOperationsDataPoint(ts pcommon.Timesta
 	mb.metricPostgresqlOperations.recordDataPoint(mb.startTime, ts, val, operationAttributeValue.String())
 }
 
+// RecordPostgresqlQueryCallsDataPoint adds a data point to postgresql.query.calls metric.
+func (mb *MetricsBuilder) RecordPostgresqlQueryCallsDataPoint(ts pcommon.Timestamp, val int64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	mb.metricPostgresqlQueryCalls.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, queryTextAttributeValue)
+}
+
+// RecordPostgresqlQueryExecTimeDataPoint adds a data point to postgresql.query.exec_time metric.
+func (mb *MetricsBuilder) RecordPostgresqlQueryExecTimeDataPoint(ts pcommon.Timestamp, val float64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	mb.metricPostgresqlQueryExecTime.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, queryTextAttributeValue)
+}
+
+// RecordPostgresqlQueryMeanExecTimeDataPoint adds a data point to postgresql.query.mean_exec_time metric.
+func (mb *MetricsBuilder) RecordPostgresqlQueryMeanExecTimeDataPoint(ts pcommon.Timestamp, val float64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	mb.metricPostgresqlQueryMeanExecTime.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, queryTextAttributeValue)
+}
+
+// RecordPostgresqlQueryRowsDataPoint adds a data point to postgresql.query.rows metric.
+func (mb *MetricsBuilder) RecordPostgresqlQueryRowsDataPoint(ts pcommon.Timestamp, val int64, queryIDAttributeValue string, queryTextAttributeValue string) {
+	mb.metricPostgresqlQueryRows.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, queryTextAttributeValue)
+}
+
+// RecordPostgresqlQuerySharedBlocksDataPoint adds a data point to postgresql.query.shared_blocks metric.
+func (mb *MetricsBuilder) RecordPostgresqlQuerySharedBlocksDataPoint(ts pcommon.Timestamp, val int64, queryIDAttributeValue string, queryTextAttributeValue string, blockOperationAttributeValue AttributeBlockOperation) {
+	mb.metricPostgresqlQuerySharedBlocks.recordDataPoint(mb.startTime, ts, val, queryIDAttributeValue, queryTextAttributeValue, blockOperationAttributeValue.String())
+}
+
 // RecordPostgresqlReplicationDataDelayDataPoint adds a data point to postgresql.replication.data_delay metric.
 func (mb *MetricsBuilder) RecordPostgresqlReplicationDataDelayDataPoint(ts pcommon.Timestamp, val int64, replicationClientAttributeValue string) {
 	mb.metricPostgresqlReplicationDataDelay.recordDataPoint(mb.startTime, ts, val, replicationClientAttributeValue)
@@ -9,4 +9,5 @@ import (
 const (
 	Type             = "postgresql"
 	MetricsStability = component.StabilityLevelBeta
+	LogsStability    = component.StabilityLevelDevelopment
 )
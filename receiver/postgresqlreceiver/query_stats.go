@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/postgresqlreceiver"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type queryStatsKey struct {
+	database string
+	queryID  string
+}
+
+// queryStatsTracker turns the cumulative statistics of pg_stat_statements into the statistics
+// of the collection interval.
+type queryStatsTracker struct {
+	lastCollection pcommon.Timestamp
+	previous       map[queryStatsKey]queryStats
+}
+
+// update stores the current cumulative statistics, and returns the start of the collection interval
+// with the statistics of the queries executed during it. Nothing is returned on the first collection,
+// as there is no previous value to compute the difference with.
+func (t *queryStatsTracker) update(now pcommon.Timestamp, current []queryStats) (pcommon.Timestamp, []queryStats) {
+	start := t.lastCollection
+	initialized := t.previous != nil
+	next := make(map[queryStatsKey]queryStats, len(current))
+	var deltas []queryStats
+	for _, s := range current {
+		key := queryStatsKey{database: s.database, queryID: s.queryID}
+		next[key] = s
+		if !initialized {
+			continue
+		}
+		// A query seen for the first time, or with a lower number of calls because the statistics were
+		// reset or evicted in between, has all its current values belonging to the collection interval.
+		delta := s
+		if prev, ok := t.previous[key]; ok && s.calls >= prev.calls {
+			delta = s.sub(prev)
+		}
+		if delta.calls == 0 {
+			continue
+		}
+		deltas = append(deltas, delta)
+	}
+	t.lastCollection = now
+	t.previous = next
+	return start, deltas
+}
+
+func (s queryStats) sub(prev queryStats) queryStats {
+	s.calls -= prev.calls
+	s.totalExecTime -= prev.totalExecTime
+	s.rows -= prev.rows
+	s.sharedBlksHit -= prev.sharedBlksHit
+	s.sharedBlksRead -= prev.sharedBlksRead
+	s.sharedBlksDirtied -= prev.sharedBlksDirtied
+	s.sharedBlksWritten -= prev.sharedBlksWritten
+	return s
+}
+
+// topQueryStats keeps the n queries with the highest execution time.
+func topQueryStats(stats []queryStats, n int) []queryStats {
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].totalExecTime > stats[j].totalExecTime
+	})
+	if len(stats) > n {
+		stats = stats[:n]
+	}
+	return stats
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestQueryStatsTracker(t *testing.T) {
+	tracker := &queryStatsTracker{}
+
+	start, deltas := tracker.update(pcommon.Timestamp(10), []queryStats{
+		{database: "otel", queryID: "1", calls: 10, totalExecTime: 100, rows: 10, sharedBlksHit: 5},
+	})
+	require.Equal(t, pcommon.Timestamp(0), start)
+	require.Empty(t, deltas, "the first collection has no previous values")
+
+	start, deltas = tracker.update(pcommon.Timestamp(20), []queryStats{
+		{database: "otel", queryID: "1", calls: 15, totalExecTime: 160, rows: 12, sharedBlksHit: 9},
+		{database: "otel", queryID: "2", calls: 2, totalExecTime: 4, rows: 2},
+		{database: "other", queryID: "1", calls: 3, totalExecTime: 3},
+	})
+	require.Equal(t, pcommon.Timestamp(10), start)
+	require.Equal(t, []queryStats{
+		{database: "otel", queryID: "1", calls: 5, totalExecTime: 60, rows: 2, sharedBlksHit: 4},
+		{database: "otel", queryID: "2", calls: 2, totalExecTime: 4, rows: 2},
+		{database: "other", queryID: "1", calls: 3, totalExecTime: 3},
+	}, deltas)
+
+	start, deltas = tracker.update(pcommon.Timestamp(30), []queryStats{
+		{database: "otel", queryID: "1", calls: 1, totalExecTime: 7, rows: 1},
+		{database: "otel", queryID: "2", calls: 2, totalExecTime: 4, rows: 2},
+	})
+	require.Equal(t, pcommon.Timestamp(20), start)
+	require.Equal(t, []queryStats{
+		{database: "otel", queryID: "1", calls: 1, totalExecTime: 7, rows: 1},
+	}, deltas, "a reset query should report its current values, and the queries without calls should be skipped")
+}
+
+func TestTopQueryStats(t *testing.T) {
+	stats := []queryStats{
+		{queryID: "1", totalExecTime: 1},
+		{queryID: "2", totalExecTime: 30},
+		{queryID: "3", totalExecTime: 20},
+	}
+	require.Equal(t, []queryStats{
+		{queryID: "2", totalExecTime: 30},
+		{queryID: "3", totalExecTime: 20},
+	}, topQueryStats(stats, 2))
+	require.Len(t, topQueryStats(stats, 10), 3)
+}
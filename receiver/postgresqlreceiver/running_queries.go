@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/postgresqlreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/postgresqlreceiver/internal/metadata"
+)
+
+// runningQueriesReceiver emits a log event for each query running at every collection interval.
+type runningQueriesReceiver struct {
+	logger        *zap.Logger
+	config        *Config
+	clientFactory postgreSQLClientFactory
+	nextConsumer  consumer.Logs
+	obsrecv       *receiverhelper.ObsReport
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newRunningQueriesReceiver(
+	settings receiver.CreateSettings,
+	config *Config,
+	clientFactory postgreSQLClientFactory,
+	nextConsumer consumer.Logs,
+) (*runningQueriesReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &runningQueriesReceiver{
+		logger:        settings.Logger,
+		config:        config,
+		clientFactory: clientFactory,
+		nextConsumer:  nextConsumer,
+		obsrecv:       obsrecv,
+	}, nil
+}
+
+func (r *runningQueriesReceiver) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.config.CollectionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.collect(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *runningQueriesReceiver) Shutdown(_ context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *runningQueriesReceiver) collect(ctx context.Context) {
+	logs, err := r.scrape(ctx)
+	if err != nil {
+		r.logger.Error("Failed to collect the running queries", zap.Error(err))
+	}
+	logRecordCount := logs.LogRecordCount()
+	if logRecordCount == 0 {
+		return
+	}
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	err = r.nextConsumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(obsCtx, metadata.Type, logRecordCount, err)
+	if err != nil {
+		r.logger.Error("Failed to send the running queries", zap.Error(err))
+	}
+}
+
+func (r *runningQueriesReceiver) scrape(ctx context.Context) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	client, err := r.clientFactory.getClient(r.config, "")
+	if err != nil {
+		return logs, err
+	}
+	defer client.Close()
+
+	queries, err := client.getRunningQueries(ctx, r.config.RunningQueries.MinDuration)
+	now := pcommon.NewTimestampFromTime(time.Now())
+	scopeLogs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName("otelcol/postgresqlreceiver")
+	for _, q := range queries {
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetTimestamp(now)
+		logRecord.SetObservedTimestamp(now)
+		logRecord.Body().SetStr(q.query)
+		attrs := logRecord.Attributes()
+		attrs.PutStr("db.system", "postgresql")
+		attrs.PutStr("db.name", q.database)
+		attrs.PutStr("db.user", q.user)
+		attrs.PutInt("postgresql.pid", q.pid)
+		attrs.PutStr("postgresql.application_name", q.applicationName)
+		if q.clientAddr != "" {
+			attrs.PutStr("client.address", q.clientAddr)
+		}
+		if q.waitEventType != "" {
+			attrs.PutStr("postgresql.wait_event_type", q.waitEventType)
+			attrs.PutStr("postgresql.wait_event", q.waitEvent)
+		}
+		attrs.PutStr("postgresql.query.start", q.queryStart.UTC().Format(time.RFC3339Nano))
+		attrs.PutDouble("postgresql.query.duration", q.duration)
+	}
+	return logs, err
+}
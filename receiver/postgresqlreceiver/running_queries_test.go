@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package postgresqlreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestRunningQueriesReceiver(t *testing.T) {
+	queryStart := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	listClient := new(mockClient)
+	listClient.On("Close").Return(nil)
+	listClient.On("getRunningQueries", mock.Anything, 2*time.Second).Return([]runningQuery{
+		{
+			database:        "otel",
+			pid:             1234,
+			user:            "otel",
+			applicationName: "psql",
+			clientAddr:      "10.0.0.1",
+			waitEventType:   "Lock",
+			waitEvent:       "relation",
+			queryStart:      queryStart,
+			duration:        3.5,
+			query:           "LOCK TABLE t",
+		},
+	}, nil)
+	factory := new(mockClientFactory)
+	factory.On("getClient", "").Return(listClient, nil)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.RunningQueries.MinDuration = 2 * time.Second
+	sink := new(consumertest.LogsSink)
+	rcvr, err := newRunningQueriesReceiver(receivertest.NewNopCreateSettings(), cfg, factory, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	logRecord := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	require.Equal(t, "LOCK TABLE t", logRecord.Body().Str())
+	require.Equal(t, map[string]any{
+		"db.system":                   "postgresql",
+		"db.name":                     "otel",
+		"db.user":                     "otel",
+		"postgresql.pid":              int64(1234),
+		"postgresql.application_name": "psql",
+		"client.address":              "10.0.0.1",
+		"postgresql.wait_event_type":  "Lock",
+		"postgresql.wait_event":       "relation",
+		"postgresql.query.start":      "2023-12-01T10:00:00Z",
+		"postgresql.query.duration":   3.5,
+	}, logRecord.Attributes().AsRaw())
+}
@@ -24,6 +24,9 @@ type postgreSQLScraper struct {
 	config        *Config
 	clientFactory postgreSQLClientFactory
 	mb            *metadata.MetricsBuilder
+	// queryMb records the delta query metrics, its start time is moved to the start of each collection interval.
+	queryMb    *metadata.MetricsBuilder
+	queryStats queryStatsTracker
 }
 type errsMux struct {
 	sync.RWMutex
@@ -74,6 +77,7 @@ func newPostgreSQLScraper(
 		config:        config,
 		clientFactory: clientFactory,
 		mb:            metadata.NewMetricsBuilder(config.MetricsBuilderConfig, settings),
+		queryMb:       metadata.NewMetricsBuilder(config.MetricsBuilderConfig, settings),
 	}
 }
 
@@ -133,8 +137,13 @@ func (p *postgreSQLScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 	p.collectReplicationStats(ctx, now, listClient, &errs)
 	p.collectMaxConnections(ctx, now, listClient, &errs)
 	p.collectDatabaseLocks(ctx, now, listClient, &errs)
+	if p.queryMetricsEnabled() {
+		p.collectQueryStats(ctx, now, listClient, &errs)
+	}
 
-	return p.mb.Emit(), errs.combine()
+	metrics := p.mb.Emit()
+	p.queryMb.Emit().ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+	return metrics, errs.combine()
 }
 
 func (p *postgreSQLScraper) retrieveDBMetrics(
@@ -337,6 +346,58 @@ func (p *postgreSQLScraper) collectReplicationStats(
 	}
 }
 
+func (p *postgreSQLScraper) queryMetricsEnabled() bool {
+	metrics := p.config.MetricsBuilderConfig.Metrics
+	return metrics.PostgresqlQueryCalls.Enabled ||
+		metrics.PostgresqlQueryExecTime.Enabled ||
+		metrics.PostgresqlQueryMeanExecTime.Enabled ||
+		metrics.PostgresqlQueryRows.Enabled ||
+		metrics.PostgresqlQuerySharedBlocks.Enabled
+}
+
+func (p *postgreSQLScraper) collectQueryStats(
+	ctx context.Context,
+	now pcommon.Timestamp,
+	client client,
+	errs *errsMux,
+) {
+	stats, err := client.getQueryStats(ctx)
+	if err != nil {
+		// the statistics of the queries missing from a partial result would be reported in full
+		// on the next collection, so the previous values are kept until a complete one
+		errs.addPartial(err)
+		return
+	}
+	start, deltas := p.queryStats.update(now, stats)
+	deltas = topQueryStats(deltas, p.config.QueryStats.TopN)
+
+	// the metrics are emitted with the database as resource, like the other per database metrics
+	var databases []string
+	byDatabase := map[string][]queryStats{}
+	for _, s := range deltas {
+		if _, ok := byDatabase[s.database]; !ok {
+			databases = append(databases, s.database)
+		}
+		byDatabase[s.database] = append(byDatabase[s.database], s)
+	}
+	p.queryMb.Reset(metadata.WithStartTime(start))
+	for _, db := range databases {
+		for _, s := range byDatabase[db] {
+			p.queryMb.RecordPostgresqlQueryCallsDataPoint(now, s.calls, s.queryID, s.query)
+			p.queryMb.RecordPostgresqlQueryExecTimeDataPoint(now, s.totalExecTime, s.queryID, s.query)
+			p.queryMb.RecordPostgresqlQueryMeanExecTimeDataPoint(now, s.totalExecTime/float64(s.calls), s.queryID, s.query)
+			p.queryMb.RecordPostgresqlQueryRowsDataPoint(now, s.rows, s.queryID, s.query)
+			p.queryMb.RecordPostgresqlQuerySharedBlocksDataPoint(now, s.sharedBlksHit, s.queryID, s.query, metadata.AttributeBlockOperationHit)
+			p.queryMb.RecordPostgresqlQuerySharedBlocksDataPoint(now, s.sharedBlksRead, s.queryID, s.query, metadata.AttributeBlockOperationRead)
+			p.queryMb.RecordPostgresqlQuerySharedBlocksDataPoint(now, s.sharedBlksDirtied, s.queryID, s.query, metadata.AttributeBlockOperationDirtied)
+			p.queryMb.RecordPostgresqlQuerySharedBlocksDataPoint(now, s.sharedBlksWritten, s.queryID, s.query, metadata.AttributeBlockOperationWritten)
+		}
+		rb := p.queryMb.NewResourceBuilder()
+		rb.SetPostgresqlDatabaseName(db)
+		p.queryMb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+}
+
 func (p *postgreSQLScraper) collectWalAge(
 	ctx context.Context,
 	now pcommon.Timestamp,
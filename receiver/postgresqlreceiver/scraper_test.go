@@ -5,9 +5,12 @@ package postgresqlreceiver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -207,6 +210,84 @@ func TestScraperWithResourceAttributeFeatureGateSingle(t *testing.T) {
 		pmetrictest.IgnoreMetricDataPointsOrder(), pmetrictest.IgnoreStartTimestamp(), pmetrictest.IgnoreTimestamp()))
 }
 
+func TestScraperQueryStats(t *testing.T) {
+	listClient := new(mockClient)
+	listClient.initMocks("", []string{"otel"}, 0)
+	listClient.On("getQueryStats", mock.Anything).Return([]queryStats{
+		{database: "otel", queryID: "-42", query: "SELECT * FROM t WHERE id = $1", calls: 10, totalExecTime: 20, rows: 10, sharedBlksHit: 100},
+		{database: "otel", queryID: "7", query: "UPDATE t SET v = $1", calls: 5, totalExecTime: 50, rows: 5, sharedBlksRead: 10},
+	}, nil).Once()
+	listClient.On("getQueryStats", mock.Anything).Return([]queryStats{
+		{database: "otel", queryID: "-42", query: "SELECT * FROM t WHERE id = $1", calls: 12, totalExecTime: 25, rows: 12, sharedBlksHit: 120},
+	}, errors.New("sql: Scan error")).Once()
+	listClient.On("getQueryStats", mock.Anything).Return([]queryStats{
+		{database: "otel", queryID: "-42", query: "SELECT * FROM t WHERE id = $1", calls: 14, totalExecTime: 30, rows: 14, sharedBlksHit: 140},
+		{database: "otel", queryID: "7", query: "UPDATE t SET v = $1", calls: 6, totalExecTime: 90, rows: 6, sharedBlksRead: 12, sharedBlksDirtied: 1},
+	}, nil).Once()
+	dbClient := new(mockClient)
+	dbClient.initMocks("otel", []string{"otel"}, 0)
+	factory := new(mockClientFactory)
+	factory.On("getClient", "").Return(listClient, nil)
+	factory.On("getClient", "otel").Return(dbClient, nil)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Databases = []string{"otel"}
+	cfg.QueryStats.TopN = 1
+	cfg.Metrics.PostgresqlQueryCalls.Enabled = true
+	cfg.Metrics.PostgresqlQueryExecTime.Enabled = true
+	cfg.Metrics.PostgresqlQueryMeanExecTime.Enabled = true
+	cfg.Metrics.PostgresqlQueryRows.Enabled = true
+	cfg.Metrics.PostgresqlQuerySharedBlocks.Enabled = true
+	scraper := newPostgreSQLScraper(receivertest.NewNopCreateSettings(), cfg, factory)
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, queryMetrics(metrics), "the first scrape should only record the initial values")
+
+	metrics, err = scraper.scrape(context.Background())
+	require.ErrorContains(t, err, "sql: Scan error")
+	require.Empty(t, queryMetrics(metrics), "a partial scrape should not be used to compute the deltas")
+
+	metrics, err = scraper.scrape(context.Background())
+	require.NoError(t, err)
+	actual := queryMetrics(metrics)
+	require.Len(t, actual, 5)
+
+	calls := actual["postgresql.query.calls"].Sum()
+	require.Equal(t, pmetric.AggregationTemporalityDelta, calls.AggregationTemporality())
+	require.Equal(t, 1, calls.DataPoints().Len(), "only the top query should be reported")
+	dp := calls.DataPoints().At(0)
+	require.Equal(t, int64(1), dp.IntValue())
+	require.Equal(t, map[string]any{"query_id": "7", "query_text": "UPDATE t SET v = $1"}, dp.Attributes().AsRaw())
+	require.Equal(t, 40.0, actual["postgresql.query.exec_time"].Sum().DataPoints().At(0).DoubleValue())
+	require.Equal(t, 40.0, actual["postgresql.query.mean_exec_time"].Gauge().DataPoints().At(0).DoubleValue())
+	require.Equal(t, int64(1), actual["postgresql.query.rows"].Sum().DataPoints().At(0).IntValue())
+	blocks := map[string]int64{}
+	for i := 0; i < actual["postgresql.query.shared_blocks"].Sum().DataPoints().Len(); i++ {
+		dp := actual["postgresql.query.shared_blocks"].Sum().DataPoints().At(i)
+		operation, _ := dp.Attributes().Get("operation")
+		blocks[operation.Str()] = dp.IntValue()
+	}
+	require.Equal(t, map[string]int64{"hit": 0, "read": 2, "dirtied": 1, "written": 0}, blocks)
+}
+
+// queryMetrics returns the postgresql.query.* metrics by name.
+func queryMetrics(metrics pmetric.Metrics) map[string]pmetric.Metric {
+	out := map[string]pmetric.Metric{}
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			ms := rm.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if strings.HasPrefix(ms.At(k).Name(), "postgresql.query.") {
+					out[ms.At(k).Name()] = ms.At(k)
+				}
+			}
+		}
+	}
+	return out
+}
+
 type mockClientFactory struct{ mock.Mock }
 type mockClient struct{ mock.Mock }
 
@@ -277,6 +358,16 @@ func (m *mockClient) listDatabases(_ context.Context) ([]string, error) {
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *mockClient) getQueryStats(ctx context.Context) ([]queryStats, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]queryStats), args.Error(1)
+}
+
+func (m *mockClient) getRunningQueries(ctx context.Context, minDuration time.Duration) ([]runningQuery, error) {
+	args := m.Called(ctx, minDuration)
+	return args.Get(0).([]runningQuery), args.Error(1)
+}
+
 func (m *mockClientFactory) getClient(_ *Config, database string) (client, error) {
 	args := m.Called(database)
 	return args.Get(0).(client), args.Error(1)
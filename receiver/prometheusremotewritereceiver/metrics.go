@@ -0,0 +1,557 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	scopeName = "otelcol/prometheusremotewritereceiver"
+
+	targetInfoMetricName = "target_info"
+
+	totalSuffix  = "_total"
+	bucketSuffix = "_bucket"
+	sumSuffix    = "_sum"
+	countSuffix  = "_count"
+
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+)
+
+// writeRequestToMetrics converts a remote write request into metrics. Series
+// are grouped into resources by their job and instance labels, and into metric
+// families by their name and the metadata of the request:
+//   - native histograms are converted into exponential histograms,
+//   - the _bucket, _sum and _count series of histograms and the quantile, _sum
+//     and _count series of summaries are merged into a single metric,
+//   - counters, either described as such by the metadata or whose name ends in
+//     _total, are converted into monotonic cumulative sums,
+//   - other series are converted into gauges.
+//
+// Series which cannot be converted are skipped, and reported in the returned
+// error.
+func writeRequestToMetrics(req *prompb.WriteRequest, buildInfo component.BuildInfo) (pmetric.Metrics, error) {
+	c := newConverter(req)
+	var errs error
+	for i := range req.Timeseries {
+		errs = errors.Join(errs, c.addSeries(&req.Timeseries[i]))
+	}
+
+	md := pmetric.NewMetrics()
+	for _, rb := range c.resources {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rb.resource.CopyTo(rm.Resource())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		sm.Scope().SetVersion(buildInfo.Version)
+		for _, fb := range rb.families {
+			fb.appendMetric(sm.Metrics())
+		}
+	}
+	return md, errs
+}
+
+type converter struct {
+	metadata map[string]prompb.MetricMetadata
+	// histograms and summaries hold the names of the families known to be
+	// classic histograms and summaries.
+	histograms map[string]bool
+	summaries  map[string]bool
+
+	resources   []*resourceBuilder
+	resourceIdx map[string]*resourceBuilder
+}
+
+func newConverter(req *prompb.WriteRequest) *converter {
+	c := &converter{
+		metadata:    make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		histograms:  make(map[string]bool),
+		summaries:   make(map[string]bool),
+		resourceIdx: make(map[string]*resourceBuilder),
+	}
+	for _, m := range req.Metadata {
+		c.metadata[m.MetricFamilyName] = m
+		switch m.Type {
+		case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_GAUGEHISTOGRAM:
+			c.histograms[m.MetricFamilyName] = true
+		case prompb.MetricMetadata_SUMMARY:
+			c.summaries[m.MetricFamilyName] = true
+		}
+	}
+	// Without metadata, histograms and summaries are recognized by the le
+	// and quantile labels of their series.
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		if family, ok := strings.CutSuffix(name, bucketSuffix); ok && hasLabel(ts.Labels, model.BucketLabel) {
+			c.histograms[family] = true
+		} else if hasLabel(ts.Labels, model.QuantileLabel) {
+			c.summaries[name] = true
+		}
+	}
+	return c
+}
+
+func (c *converter) addSeries(ts *prompb.TimeSeries) error {
+	name := labelValue(ts.Labels, model.MetricNameLabel)
+	if name == "" {
+		return errors.New("series without metric name")
+	}
+	rb := c.resourceBuilder(ts.Labels)
+	if name == targetInfoMetricName {
+		rb.addTargetInfo(ts.Labels)
+		return nil
+	}
+
+	if len(ts.Histograms) > 0 {
+		fb := rb.familyBuilder(name, pmetric.MetricTypeExponentialHistogram, c.lookupMetadata(name))
+		if err := fb.addNativeHistograms(ts); err != nil {
+			return fmt.Errorf("series %q: %w", name, err)
+		}
+		return nil
+	}
+
+	if family, ok := strings.CutSuffix(name, bucketSuffix); ok && c.histograms[family] && hasLabel(ts.Labels, model.BucketLabel) {
+		le, err := strconv.ParseFloat(labelValue(ts.Labels, model.BucketLabel), 64)
+		if err != nil {
+			return fmt.Errorf("series %q: invalid %s label: %w", name, model.BucketLabel, err)
+		}
+		rb.familyBuilder(family, pmetric.MetricTypeHistogram, c.lookupMetadata(family)).addBucket(ts, le)
+		return nil
+	}
+	if c.summaries[name] && hasLabel(ts.Labels, model.QuantileLabel) {
+		quantile, err := strconv.ParseFloat(labelValue(ts.Labels, model.QuantileLabel), 64)
+		if err != nil {
+			return fmt.Errorf("series %q: invalid %s label: %w", name, model.QuantileLabel, err)
+		}
+		rb.familyBuilder(name, pmetric.MetricTypeSummary, c.lookupMetadata(name)).addQuantile(ts, quantile)
+		return nil
+	}
+	for _, suffix := range []string{sumSuffix, countSuffix} {
+		family, ok := strings.CutSuffix(name, suffix)
+		if !ok {
+			continue
+		}
+		switch {
+		case c.histograms[family]:
+			rb.familyBuilder(family, pmetric.MetricTypeHistogram, c.lookupMetadata(family)).addSumOrCount(ts, suffix)
+			return nil
+		case c.summaries[family]:
+			rb.familyBuilder(family, pmetric.MetricTypeSummary, c.lookupMetadata(family)).addSumOrCount(ts, suffix)
+			return nil
+		}
+	}
+
+	metadata := c.lookupMetadata(name)
+	metricType := pmetric.MetricTypeGauge
+	if metadata.Type == prompb.MetricMetadata_COUNTER ||
+		(metadata.Type == prompb.MetricMetadata_UNKNOWN && strings.HasSuffix(name, totalSuffix)) {
+		metricType = pmetric.MetricTypeSum
+	}
+	rb.familyBuilder(name, metricType, metadata).addSamples(ts)
+	return nil
+}
+
+// lookupMetadata returns the metadata of a metric family. The name of counter
+// families may or may not include the _total suffix depending on the client.
+func (c *converter) lookupMetadata(name string) prompb.MetricMetadata {
+	if m, ok := c.metadata[name]; ok {
+		return m
+	}
+	if family, ok := strings.CutSuffix(name, totalSuffix); ok {
+		if m, ok := c.metadata[family]; ok {
+			return m
+		}
+	}
+	return prompb.MetricMetadata{}
+}
+
+func (c *converter) resourceBuilder(labels []prompb.Label) *resourceBuilder {
+	job := labelValue(labels, model.JobLabel)
+	instance := labelValue(labels, model.InstanceLabel)
+	key := job + "\xff" + instance
+	if rb, ok := c.resourceIdx[key]; ok {
+		return rb
+	}
+	rb := newResourceBuilder(job, instance)
+	c.resourceIdx[key] = rb
+	c.resources = append(c.resources, rb)
+	return rb
+}
+
+type resourceBuilder struct {
+	resource  pcommon.Resource
+	families  []*familyBuilder
+	familyIdx map[string]*familyBuilder
+}
+
+// newResourceBuilder creates the resource of the series of a target, the same
+// way the Prometheus receiver does for the targets it scrapes.
+func newResourceBuilder(job, instance string) *resourceBuilder {
+	resource := pcommon.NewResource()
+	attrs := resource.Attributes()
+	if job != "" {
+		attrs.PutStr(conventions.AttributeServiceName, job)
+	}
+	if instance != "" {
+		attrs.PutStr(conventions.AttributeServiceInstanceID, instance)
+		host, port, err := net.SplitHostPort(instance)
+		if err != nil {
+			host = instance
+		}
+		attrs.PutStr(conventions.AttributeNetHostName, host)
+		if port != "" {
+			attrs.PutStr(conventions.AttributeNetHostPort, port)
+		}
+	}
+	return &resourceBuilder{
+		resource:  resource,
+		familyIdx: make(map[string]*familyBuilder),
+	}
+}
+
+// addTargetInfo adds the labels of a target_info series to the resource.
+func (rb *resourceBuilder) addTargetInfo(labels []prompb.Label) {
+	attrs := rb.resource.Attributes()
+	for _, l := range labels {
+		switch l.Name {
+		case model.MetricNameLabel, model.JobLabel, model.InstanceLabel:
+			continue
+		}
+		attrs.PutStr(l.Name, l.Value)
+	}
+}
+
+func (rb *resourceBuilder) familyBuilder(name string, metricType pmetric.MetricType, metadata prompb.MetricMetadata) *familyBuilder {
+	// Families of different types sharing a name are not expected, they are
+	// kept as separate metrics if it happens.
+	key := name + "\xff" + metricType.String()
+	if fb, ok := rb.familyIdx[key]; ok {
+		return fb
+	}
+	fb := newFamilyBuilder(name, metricType, metadata)
+	rb.familyIdx[key] = fb
+	rb.families = append(rb.families, fb)
+	return fb
+}
+
+// familyBuilder builds the metric of a metric family.
+type familyBuilder struct {
+	metricType pmetric.MetricType
+	metric     pmetric.Metric
+	// groups hold the points of histograms and summaries being built from
+	// several series, by attributes and timestamp.
+	groups   []*pointGroup
+	groupIdx map[string]*pointGroup
+}
+
+func newFamilyBuilder(name string, metricType pmetric.MetricType, metadata prompb.MetricMetadata) *familyBuilder {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetDescription(metadata.Help)
+	metric.SetUnit(prometheus.UnitWordToUCUM(metadata.Unit))
+	switch metricType {
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeHistogram:
+		metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeSummary:
+		metric.SetEmptySummary()
+	default:
+		metric.SetEmptyGauge()
+	}
+	return &familyBuilder{
+		metricType: metricType,
+		metric:     metric,
+		groupIdx:   make(map[string]*pointGroup),
+	}
+}
+
+func (fb *familyBuilder) appendMetric(metrics pmetric.MetricSlice) {
+	switch fb.metricType {
+	case pmetric.MetricTypeHistogram:
+		dps := fb.metric.Histogram().DataPoints()
+		for _, g := range fb.groups {
+			g.toHistogramDataPoint(dps.AppendEmpty())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := fb.metric.Summary().DataPoints()
+		for _, g := range fb.groups {
+			g.toSummaryDataPoint(dps.AppendEmpty())
+		}
+	}
+	fb.metric.MoveTo(metrics.AppendEmpty())
+}
+
+// addSamples adds the samples of a gauge or counter series.
+func (fb *familyBuilder) addSamples(ts *prompb.TimeSeries) {
+	var dps pmetric.NumberDataPointSlice
+	if fb.metricType == pmetric.MetricTypeSum {
+		dps = fb.metric.Sum().DataPoints()
+	} else {
+		dps = fb.metric.Gauge().DataPoints()
+	}
+	for _, s := range ts.Samples {
+		dp := dps.AppendEmpty()
+		setAttributes(dp.Attributes(), ts.Labels)
+		dp.SetTimestamp(timestampFromMs(s.Timestamp))
+		if value.IsStaleNaN(s.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		} else {
+			dp.SetDoubleValue(s.Value)
+		}
+	}
+	// Exemplars are not tied to a sample of the series, they are attached to
+	// the latest one.
+	if len(ts.Samples) > 0 {
+		addExemplars(dps.At(dps.Len()-1).Exemplars(), ts.Exemplars)
+	}
+}
+
+// addNativeHistograms adds the native histograms of a series.
+func (fb *familyBuilder) addNativeHistograms(ts *prompb.TimeSeries) error {
+	dps := fb.metric.ExponentialHistogram().DataPoints()
+	var errs error
+	for _, h := range ts.Histograms {
+		dp := pmetric.NewExponentialHistogramDataPoint()
+		if err := convertNativeHistogram(h, dp); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		setAttributes(dp.Attributes(), ts.Labels)
+		addExemplars(dp.Exemplars(), ts.Exemplars)
+		dp.MoveTo(dps.AppendEmpty())
+	}
+	return errs
+}
+
+func (fb *familyBuilder) addBucket(ts *prompb.TimeSeries, le float64) {
+	var g *pointGroup
+	for _, s := range ts.Samples {
+		g = fb.pointGroup(ts.Labels, s.Timestamp)
+		g.buckets = append(g.buckets, bucket{le: le, count: s.Value})
+		g.stale = g.stale || value.IsStaleNaN(s.Value)
+	}
+	if g != nil {
+		g.exemplars = append(g.exemplars, ts.Exemplars...)
+	}
+}
+
+func (fb *familyBuilder) addQuantile(ts *prompb.TimeSeries, quantile float64) {
+	for _, s := range ts.Samples {
+		g := fb.pointGroup(ts.Labels, s.Timestamp)
+		g.quantiles = append(g.quantiles, bucket{le: quantile, count: s.Value})
+		g.stale = g.stale || value.IsStaleNaN(s.Value)
+	}
+}
+
+func (fb *familyBuilder) addSumOrCount(ts *prompb.TimeSeries, suffix string) {
+	for _, s := range ts.Samples {
+		g := fb.pointGroup(ts.Labels, s.Timestamp)
+		g.stale = g.stale || value.IsStaleNaN(s.Value)
+		if suffix == sumSuffix {
+			g.sum, g.hasSum = s.Value, true
+		} else {
+			g.count, g.hasCount = s.Value, true
+		}
+	}
+}
+
+func (fb *familyBuilder) pointGroup(labels []prompb.Label, timestamp int64) *pointGroup {
+	key := groupKey(labels, timestamp)
+	if g, ok := fb.groupIdx[key]; ok {
+		return g
+	}
+	g := &pointGroup{labels: labels, timestamp: timestamp}
+	fb.groupIdx[key] = g
+	fb.groups = append(fb.groups, g)
+	return g
+}
+
+// groupKey identifies the series of a histogram or summary point, ignoring
+// the name and the labels distinguishing buckets and quantiles.
+func groupKey(labels []prompb.Label, timestamp int64) string {
+	sorted := make([]prompb.Label, 0, len(labels))
+	for _, l := range labels {
+		if !isSeriesOnlyLabel(l.Name) {
+			sorted = append(sorted, l)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatInt(timestamp, 10))
+	for _, l := range sorted {
+		sb.WriteByte(0xff)
+		sb.WriteString(l.Name)
+		sb.WriteByte(0xff)
+		sb.WriteString(l.Value)
+	}
+	return sb.String()
+}
+
+type bucket struct {
+	le    float64
+	count float64
+}
+
+// pointGroup holds the samples of the series of a histogram or summary point.
+type pointGroup struct {
+	labels    []prompb.Label
+	timestamp int64
+	// buckets hold the cumulative counts of histograms, quantiles the values
+	// of summaries.
+	buckets   []bucket
+	quantiles []bucket
+	sum       float64
+	hasSum    bool
+	count     float64
+	hasCount  bool
+	stale     bool
+	exemplars []prompb.Exemplar
+}
+
+func (g *pointGroup) toHistogramDataPoint(dp pmetric.HistogramDataPoint) {
+	setAttributes(dp.Attributes(), g.labels)
+	dp.SetTimestamp(timestampFromMs(g.timestamp))
+	addExemplars(dp.Exemplars(), g.exemplars)
+	if g.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	sort.Slice(g.buckets, func(i, j int) bool { return g.buckets[i].le < g.buckets[j].le })
+	count := g.count
+	if !g.hasCount && len(g.buckets) > 0 {
+		count = g.buckets[len(g.buckets)-1].count
+	}
+	dp.SetCount(uint64(count))
+	if g.hasSum {
+		dp.SetSum(g.sum)
+	}
+	if len(g.buckets) == 0 {
+		return
+	}
+
+	// Buckets hold cumulative counts, data points the count of each bucket.
+	var previous float64
+	for _, b := range g.buckets {
+		if !math.IsInf(b.le, 1) {
+			dp.ExplicitBounds().Append(b.le)
+		}
+		dp.BucketCounts().Append(uint64(math.Max(b.count-previous, 0)))
+		previous = b.count
+	}
+	if !math.IsInf(g.buckets[len(g.buckets)-1].le, 1) {
+		// The +Inf bucket is missing, its count is the one of the histogram.
+		dp.BucketCounts().Append(uint64(math.Max(count-previous, 0)))
+	}
+}
+
+func (g *pointGroup) toSummaryDataPoint(dp pmetric.SummaryDataPoint) {
+	setAttributes(dp.Attributes(), g.labels)
+	dp.SetTimestamp(timestampFromMs(g.timestamp))
+	if g.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+	dp.SetCount(uint64(g.count))
+	dp.SetSum(g.sum)
+	sort.Slice(g.quantiles, func(i, j int) bool { return g.quantiles[i].le < g.quantiles[j].le })
+	for _, q := range g.quantiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(q.le)
+		qv.SetValue(q.count)
+	}
+}
+
+// isSeriesOnlyLabel reports whether a label identifies the series rather than
+// being an attribute of the data point.
+func isSeriesOnlyLabel(name string) bool {
+	switch name {
+	case model.MetricNameLabel, model.JobLabel, model.InstanceLabel, model.BucketLabel, model.QuantileLabel:
+		return true
+	}
+	return false
+}
+
+func setAttributes(attrs pcommon.Map, labels []prompb.Label) {
+	for _, l := range labels {
+		if !isSeriesOnlyLabel(l.Name) {
+			attrs.PutStr(l.Name, l.Value)
+		}
+	}
+}
+
+func addExemplars(dst pmetric.ExemplarSlice, exemplars []prompb.Exemplar) {
+	for _, e := range exemplars {
+		exemplar := dst.AppendEmpty()
+		exemplar.SetTimestamp(timestampFromMs(e.Timestamp))
+		exemplar.SetDoubleValue(e.Value)
+		for _, l := range e.Labels {
+			switch l.Name {
+			case traceIDLabel:
+				var traceID pcommon.TraceID
+				if b, err := hex.DecodeString(l.Value); err == nil && len(b) == len(traceID) {
+					copy(traceID[:], b)
+					exemplar.SetTraceID(traceID)
+					continue
+				}
+			case spanIDLabel:
+				var spanID pcommon.SpanID
+				if b, err := hex.DecodeString(l.Value); err == nil && len(b) == len(spanID) {
+					copy(spanID[:], b)
+					exemplar.SetSpanID(spanID)
+					continue
+				}
+			}
+			exemplar.FilteredAttributes().PutStr(l.Name, l.Value)
+		}
+	}
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func timestampFromMs(ms int64) pcommon.Timestamp {
+	return pcommon.Timestamp(ms * 1e6)
+}
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func series(name string, labels map[string]string, samples ...prompb.Sample) prompb.TimeSeries {
+	ts := prompb.TimeSeries{
+		Labels:  []prompb.Label{{Name: "__name__", Value: name}},
+		Samples: samples,
+	}
+	for k, v := range labels {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: k, Value: v})
+	}
+	return ts
+}
+
+func metricsByName(t *testing.T, md pmetric.Metrics) map[string]pmetric.Metric {
+	metrics := make(map[string]pmetric.Metric)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				_, ok := metrics[ms.At(k).Name()]
+				require.False(t, ok, "duplicate metric %q", ms.At(k).Name())
+				metrics[ms.At(k).Name()] = ms.At(k)
+			}
+		}
+	}
+	return metrics
+}
+
+func TestWriteRequestToMetricsResources(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("up", map[string]string{"job": "api", "instance": "api-0:8080"}, prompb.Sample{Value: 1, Timestamp: 1000}),
+			series("up", map[string]string{"job": "db", "instance": "db-0:5432"}, prompb.Sample{Value: 0, Timestamp: 1000}),
+			series("target_info", map[string]string{"job": "api", "instance": "api-0:8080", "region": "eu"}, prompb.Sample{Value: 1, Timestamp: 1000}),
+		},
+	}
+	md, err := writeRequestToMetrics(req, component.BuildInfo{Version: "1.2.3"})
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	api := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		"service.name":        "api",
+		"service.instance.id": "api-0:8080",
+		"net.host.name":       "api-0",
+		"net.host.port":       "8080",
+		"region":              "eu",
+	}, api.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, api.ScopeMetrics().Len())
+	assert.Equal(t, "otelcol/prometheusremotewritereceiver", api.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, "1.2.3", api.ScopeMetrics().At(0).Scope().Version())
+	require.Equal(t, 1, api.ScopeMetrics().At(0).Metrics().Len())
+	up := api.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "up", up.Name())
+	require.Equal(t, pmetric.MetricTypeGauge, up.Type())
+	assert.Equal(t, 0, up.Gauge().DataPoints().At(0).Attributes().Len())
+
+	db := md.ResourceMetrics().At(1)
+	assert.Equal(t, "db", db.Resource().Attributes().AsRaw()["service.name"])
+}
+
+func TestWriteRequestToMetricsCounterAndGauge(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("http_requests_total", map[string]string{"method": "GET"},
+				prompb.Sample{Value: 10, Timestamp: 1000},
+				prompb.Sample{Value: 12, Timestamp: 2000}),
+			series("processed", nil, prompb.Sample{Value: 3, Timestamp: 1000}),
+			series("temperature", map[string]string{"room": "a"}, prompb.Sample{Value: 21.5, Timestamp: 1000}),
+			series("queue_size", nil, prompb.Sample{Value: math.Float64frombits(value.StaleNaN), Timestamp: 1000}),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests", Help: "Number of HTTP requests"},
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "processed", Help: "Processed items"},
+			{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "temperature", Help: "Room temperature", Unit: "seconds"},
+		},
+	}
+	req.Timeseries[0].Exemplars = []prompb.Exemplar{{
+		Labels: []prompb.Label{
+			{Name: "trace_id", Value: "0102030405060708090a0b0c0d0e0f10"},
+			{Name: "span_id", Value: "0102030405060708"},
+			{Name: "user", Value: "alice"},
+		},
+		Value:     1,
+		Timestamp: 1500,
+	}}
+
+	md, err := writeRequestToMetrics(req, component.BuildInfo{})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 4)
+
+	requests := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, requests.Type())
+	assert.Equal(t, "Number of HTTP requests", requests.Description())
+	assert.True(t, requests.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+	dps := requests.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, 10.0, dps.At(0).DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(1e9), dps.At(0).Timestamp())
+	assert.Equal(t, 12.0, dps.At(1).DoubleValue())
+	assert.Equal(t, map[string]any{"method": "GET"}, dps.At(1).Attributes().AsRaw())
+	assert.Equal(t, 0, dps.At(0).Exemplars().Len())
+	require.Equal(t, 1, dps.At(1).Exemplars().Len())
+	exemplar := dps.At(1).Exemplars().At(0)
+	assert.Equal(t, pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, exemplar.TraceID())
+	assert.Equal(t, pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8}, exemplar.SpanID())
+	assert.Equal(t, map[string]any{"user": "alice"}, exemplar.FilteredAttributes().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(1.5e9), exemplar.Timestamp())
+
+	processed := metrics["processed"]
+	require.Equal(t, pmetric.MetricTypeSum, processed.Type())
+
+	temperature := metrics["temperature"]
+	require.Equal(t, pmetric.MetricTypeGauge, temperature.Type())
+	assert.Equal(t, "s", temperature.Unit())
+	assert.Equal(t, 21.5, temperature.Gauge().DataPoints().At(0).DoubleValue())
+
+	queueSize := metrics["queue_size"]
+	require.Equal(t, pmetric.MetricTypeGauge, queueSize.Type())
+	assert.True(t, queueSize.Gauge().DataPoints().At(0).Flags().NoRecordedValue())
+}
+
+func TestWriteRequestToMetricsHistogram(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("latency_bucket", map[string]string{"le": "0.5", "path": "/"}, prompb.Sample{Value: 1, Timestamp: 1000}),
+			series("latency_bucket", map[string]string{"le": "+Inf", "path": "/"}, prompb.Sample{Value: 4, Timestamp: 1000}),
+			series("latency_bucket", map[string]string{"le": "1", "path": "/"}, prompb.Sample{Value: 3, Timestamp: 1000}),
+			series("latency_sum", map[string]string{"path": "/"}, prompb.Sample{Value: 2.5, Timestamp: 1000}),
+			series("latency_count", map[string]string{"path": "/"}, prompb.Sample{Value: 4, Timestamp: 1000}),
+			series("latency_count", map[string]string{"path": "/admin"}, prompb.Sample{Value: 0, Timestamp: 1000}),
+			// Not a histogram without buckets or metadata.
+			series("bytes_sum", nil, prompb.Sample{Value: 1024, Timestamp: 1000}),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency", Help: "Request latency", Unit: "seconds"},
+		},
+	}
+
+	md, err := writeRequestToMetrics(req, component.BuildInfo{})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 2)
+
+	latency := metrics["latency"]
+	require.Equal(t, pmetric.MetricTypeHistogram, latency.Type())
+	assert.Equal(t, "Request latency", latency.Description())
+	assert.Equal(t, "s", latency.Unit())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, latency.Histogram().AggregationTemporality())
+	dps := latency.Histogram().DataPoints()
+	require.Equal(t, 2, dps.Len())
+
+	dp := dps.At(0)
+	assert.Equal(t, map[string]any{"path": "/"}, dp.Attributes().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(1e9), dp.Timestamp())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 2.5, dp.Sum())
+	assert.Equal(t, []float64{0.5, 1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 2, 1}, dp.BucketCounts().AsRaw())
+
+	dp = dps.At(1)
+	assert.Equal(t, map[string]any{"path": "/admin"}, dp.Attributes().AsRaw())
+	assert.Equal(t, uint64(0), dp.Count())
+	assert.False(t, dp.HasSum())
+	assert.Equal(t, 0, dp.BucketCounts().Len())
+
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics["bytes_sum"].Type())
+}
+
+func TestWriteRequestToMetricsHistogramWithoutMetadata(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("size_bucket", map[string]string{"le": "10"}, prompb.Sample{Value: 2, Timestamp: 1000}),
+			series("size_count", nil, prompb.Sample{Value: 5, Timestamp: 1000}),
+		},
+	}
+
+	md, err := writeRequestToMetrics(req, component.BuildInfo{})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+	size := metrics["size"]
+	require.Equal(t, pmetric.MetricTypeHistogram, size.Type())
+	dp := size.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, []float64{10}, dp.ExplicitBounds().AsRaw())
+	// The +Inf bucket is missing and inferred from the count.
+	assert.Equal(t, []uint64{2, 3}, dp.BucketCounts().AsRaw())
+}
+
+func TestWriteRequestToMetricsSummary(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("rpc_duration", map[string]string{"quantile": "0.99"}, prompb.Sample{Value: 0.8, Timestamp: 1000}),
+			series("rpc_duration", map[string]string{"quantile": "0.5"}, prompb.Sample{Value: 0.2, Timestamp: 1000}),
+			series("rpc_duration_sum", nil, prompb.Sample{Value: 30, Timestamp: 1000}),
+			series("rpc_duration_count", nil, prompb.Sample{Value: 100, Timestamp: 1000}),
+		},
+	}
+
+	md, err := writeRequestToMetrics(req, component.BuildInfo{})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+	duration := metrics["rpc_duration"]
+	require.Equal(t, pmetric.MetricTypeSummary, duration.Type())
+	require.Equal(t, 1, duration.Summary().DataPoints().Len())
+	dp := duration.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(100), dp.Count())
+	assert.Equal(t, 30.0, dp.Sum())
+	assert.Equal(t, 0, dp.Attributes().Len())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.8, dp.QuantileValues().At(1).Value())
+}
+
+func TestWriteRequestToMetricsErrors(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{Labels: []prompb.Label{{Name: "job", Value: "api"}}, Samples: []prompb.Sample{{Value: 1}}},
+			series("latency_bucket", map[string]string{"le": "foo"}, prompb.Sample{Value: 1, Timestamp: 1000}),
+			series("up", nil, prompb.Sample{Value: 1, Timestamp: 1000}),
+		},
+	}
+
+	md, err := writeRequestToMetrics(req, component.BuildInfo{})
+	assert.ErrorContains(t, err, "series without metric name")
+	assert.ErrorContains(t, err, `series "latency_bucket": invalid le label`)
+	metrics := metricsByName(t, md)
+	assert.Contains(t, metrics, "up")
+}
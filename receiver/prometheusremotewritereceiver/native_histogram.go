@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Native histograms support the same schemas as exponential histogram scales.
+const (
+	minSchema = -4
+	maxSchema = 8
+)
+
+// convertNativeHistogram converts a native histogram into an exponential
+// histogram data point. Both use the same bucket boundaries for a given
+// schema and scale, but the bucket of index i of a native histogram covers
+// (base^(i-1), base^i] while the one of an exponential histogram covers
+// (base^i, base^(i+1)]. Float counts are rounded to the nearest integer.
+func convertNativeHistogram(h prompb.Histogram, dp pmetric.ExponentialHistogramDataPoint) error {
+	if h.Schema < minSchema || h.Schema > maxSchema {
+		return fmt.Errorf("unsupported native histogram schema %d", h.Schema)
+	}
+	dp.SetScale(h.Schema)
+	dp.SetTimestamp(timestampFromMs(h.Timestamp))
+	dp.SetZeroThreshold(h.ZeroThreshold)
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return nil
+	}
+	dp.SetSum(h.Sum)
+
+	if _, ok := h.Count.(*prompb.Histogram_CountFloat); ok {
+		dp.SetCount(roundCount(h.GetCountFloat()))
+		dp.SetZeroCount(roundCount(h.GetZeroCountFloat()))
+	} else {
+		dp.SetCount(h.GetCountInt())
+		dp.SetZeroCount(h.GetZeroCountInt())
+	}
+
+	if err := convertBuckets(h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts, dp.Positive()); err != nil {
+		return fmt.Errorf("positive buckets: %w", err)
+	}
+	if err := convertBuckets(h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts, dp.Negative()); err != nil {
+		return fmt.Errorf("negative buckets: %w", err)
+	}
+	return nil
+}
+
+// convertBuckets converts the sparse buckets of a native histogram, described
+// by spans and either deltas between the counts of integer histograms or the
+// counts of float histograms, into dense exponential histogram buckets.
+func convertBuckets(spans []prompb.BucketSpan, deltas []int64, counts []float64, buckets pmetric.ExponentialHistogramDataPointBuckets) error {
+	var length int
+	for _, span := range spans {
+		length += int(span.Length)
+	}
+	if length != len(deltas) && length != len(counts) {
+		return fmt.Errorf("spans cover %d buckets, got %d deltas and %d counts", length, len(deltas), len(counts))
+	}
+	if length == 0 {
+		return nil
+	}
+
+	var index, current int64
+	var bucket int
+	var started bool
+	for i, span := range spans {
+		// The offset of the first span is the index of its first bucket, the
+		// other ones are the number of empty buckets since the previous span.
+		if i > 0 && span.Offset < 0 {
+			return fmt.Errorf("negative offset %d for span %d", span.Offset, i)
+		}
+		index += int64(span.Offset)
+		if !started {
+			if span.Length == 0 {
+				continue
+			}
+			buckets.SetOffset(int32(index - 1))
+			started = true
+		} else {
+			for j := int32(0); j < span.Offset; j++ {
+				buckets.BucketCounts().Append(0)
+			}
+		}
+		for j := uint32(0); j < span.Length; j++ {
+			var count uint64
+			if len(deltas) == length {
+				current += deltas[bucket]
+				if current < 0 {
+					return fmt.Errorf("negative count for bucket %d", index)
+				}
+				count = uint64(current)
+			} else {
+				if counts[bucket] < 0 {
+					return fmt.Errorf("negative count for bucket %d", index)
+				}
+				count = roundCount(counts[bucket])
+			}
+			buckets.BucketCounts().Append(count)
+			bucket++
+			index++
+		}
+	}
+	return nil
+}
+
+func roundCount(count float64) uint64 {
+	return uint64(math.Round(count))
+}
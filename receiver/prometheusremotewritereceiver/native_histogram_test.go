@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestConvertNativeHistogram(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram prompb.Histogram
+		check     func(t *testing.T, dp pmetric.ExponentialHistogramDataPoint)
+		err       string
+	}{
+		{
+			name: "integer",
+			histogram: prompb.Histogram{
+				Count:         &prompb.Histogram_CountInt{CountInt: 12},
+				Sum:           42,
+				Schema:        2,
+				ZeroThreshold: 0.001,
+				ZeroCount:     &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+				// Buckets 0, 1 and 4.
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 2, Length: 1}},
+				PositiveDeltas: []int64{2, -1, 3},
+				// Buckets -2 and -1.
+				NegativeSpans:  []prompb.BucketSpan{{Offset: -2, Length: 2}},
+				NegativeDeltas: []int64{3, -2},
+				Timestamp:      1000,
+			},
+			check: func(t *testing.T, dp pmetric.ExponentialHistogramDataPoint) {
+				assert.Equal(t, int32(2), dp.Scale())
+				assert.Equal(t, uint64(12), dp.Count())
+				assert.Equal(t, 42.0, dp.Sum())
+				assert.Equal(t, 0.001, dp.ZeroThreshold())
+				assert.Equal(t, uint64(1), dp.ZeroCount())
+				assert.Equal(t, pcommon.Timestamp(1e9), dp.Timestamp())
+				assert.Equal(t, int32(-1), dp.Positive().Offset())
+				assert.Equal(t, []uint64{2, 1, 0, 0, 4}, dp.Positive().BucketCounts().AsRaw())
+				assert.Equal(t, int32(-3), dp.Negative().Offset())
+				assert.Equal(t, []uint64{3, 1}, dp.Negative().BucketCounts().AsRaw())
+			},
+		},
+		{
+			name: "float",
+			histogram: prompb.Histogram{
+				Count:          &prompb.Histogram_CountFloat{CountFloat: 4.6},
+				Sum:            10,
+				Schema:         -1,
+				ZeroCount:      &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: 0.4},
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 0}, {Offset: 3, Length: 2}},
+				PositiveCounts: []float64{1.4, 2.6},
+				Timestamp:      1000,
+			},
+			check: func(t *testing.T, dp pmetric.ExponentialHistogramDataPoint) {
+				assert.Equal(t, int32(-1), dp.Scale())
+				assert.Equal(t, uint64(5), dp.Count())
+				assert.Equal(t, uint64(0), dp.ZeroCount())
+				assert.Equal(t, int32(2), dp.Positive().Offset())
+				assert.Equal(t, []uint64{1, 3}, dp.Positive().BucketCounts().AsRaw())
+				assert.Equal(t, 0, dp.Negative().BucketCounts().Len())
+			},
+		},
+		{
+			name: "stale",
+			histogram: prompb.Histogram{
+				Sum:       math.Float64frombits(value.StaleNaN),
+				Timestamp: 1000,
+			},
+			check: func(t *testing.T, dp pmetric.ExponentialHistogramDataPoint) {
+				assert.True(t, dp.Flags().NoRecordedValue())
+			},
+		},
+		{
+			name:      "unsupported schema",
+			histogram: prompb.Histogram{Schema: 9},
+			err:       "unsupported native histogram schema 9",
+		},
+		{
+			name: "spans not matching deltas",
+			histogram: prompb.Histogram{
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 3}},
+				PositiveDeltas: []int64{1, 2},
+			},
+			err: "positive buckets: spans cover 3 buckets, got 2 deltas and 0 counts",
+		},
+		{
+			name: "negative count",
+			histogram: prompb.Histogram{
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+				PositiveDeltas: []int64{1, -2},
+			},
+			err: "positive buckets: negative count for bucket 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := pmetric.NewExponentialHistogramDataPoint()
+			err := convertNativeHistogram(tt.histogram, dp)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, dp)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	pbContentType = "application/x-protobuf"
+	// writeRequestMessage is the protobuf message of remote write 1.0
+	// requests, the only one supported.
+	writeRequestMessage = "prometheus.WriteRequest"
+	snappyEncoding      = "snappy"
+
+	// maxRequestSize caps both the compressed and the decompressed size of
+	// remote write requests, like Prometheus does.
+	maxRequestSize = 32 << 20
+)
+
+var errRequestTooLarge = fmt.Errorf("request is larger than %d bytes", maxRequestSize)
+
+type prometheusRemoteWriteReceiver struct {
+	conf         *Config
+	nextConsumer consumer.Metrics
+	settings     receiver.CreateSettings
+	obsrecv      *receiverhelper.ObsReport
+	server       *http.Server
+	shutdownWG   sync.WaitGroup
+}
+
+func newReceiver(conf *Config, nextConsumer consumer.Metrics, settings receiver.CreateSettings) (*prometheusRemoteWriteReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusRemoteWriteReceiver{
+		conf:         conf,
+		nextConsumer: nextConsumer,
+		settings:     settings,
+		obsrecv:      obsrecv,
+	}, nil
+}
+
+func (r *prometheusRemoteWriteReceiver) Start(_ context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.conf.Path, r.handleWrite)
+
+	var err error
+	// The body is decompressed by the handler since snappy block encoding,
+	// not the framed one, is used by remote write.
+	r.server, err = r.conf.ToServer(host, r.settings.TelemetrySettings, mux,
+		confighttp.WithDecoder(snappyEncoding, func(body io.ReadCloser) (io.ReadCloser, error) { return body, nil }))
+	if err != nil {
+		return fmt.Errorf("failed to create http server: %w", err)
+	}
+
+	r.settings.Logger.Info("Starting HTTP server", zap.String("endpoint", r.conf.Endpoint))
+	listener, err := r.conf.ToListener()
+	if err != nil {
+		return err
+	}
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(listener); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (r *prometheusRemoteWriteReceiver) Shutdown(ctx context.Context) error {
+	var err error
+	if r.server != nil {
+		err = r.server.Shutdown(ctx)
+	}
+	r.shutdownWG.Wait()
+	return err
+}
+
+func (r *prometheusRemoteWriteReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("%v method not allowed, supported: [POST]", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	if err := checkContentType(req.Header.Get("Content-Type")); err != nil {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	if encoding := req.Header.Get("Content-Encoding"); encoding != "" && encoding != snappyEncoding {
+		http.Error(w, fmt.Sprintf("unsupported content encoding %q, supported: [%s]", encoding, snappyEncoding), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	writeReq, err := decodeWriteRequest(http.MaxBytesReader(w, req.Body, maxRequestSize))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errRequestTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	md, err := writeRequestToMetrics(writeReq, r.settings.BuildInfo)
+	if err != nil {
+		r.settings.Logger.Warn("Failed to convert some of the remote write series", zap.Error(err))
+		if md.DataPointCount() == 0 {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	dataPointCount := md.DataPointCount()
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, "protobuf", dataPointCount, err)
+	if err != nil {
+		status := http.StatusInternalServerError
+		// Remote write clients retry requests failing with 5xx errors only.
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkContentType returns an error if the content type is not the one of
+// remote write 1.0 requests.
+func checkContentType(contentType string) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != pbContentType {
+		return fmt.Errorf("unsupported content type %q, supported: [%s]", contentType, pbContentType)
+	}
+	if message, ok := params["proto"]; ok && message != writeRequestMessage {
+		return fmt.Errorf("unsupported protobuf message %q, supported: [%s]", message, writeRequestMessage)
+	}
+	return nil
+}
+
+func decodeWriteRequest(body io.Reader) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, errRequestTooLarge
+		}
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	// Check the decoded length before allocating the buffer it is decoded to.
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	if decodedLen > maxRequestSize {
+		return nil, fmt.Errorf("decompressed %w", errRequestTooLarge)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	writeReq := &prompb.WriteRequest{}
+	if err = proto.Unmarshal(data, writeReq); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request body: %w", err)
+	}
+	return writeReq, nil
+}
@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return snappy.Encode(nil, data)
+}
+
+func newTestReceiver(t *testing.T, next consumer.Metrics) *prometheusRemoteWriteReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newReceiver(cfg, next, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	return r
+}
+
+func TestHandleWrite(t *testing.T) {
+	writeReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("up", map[string]string{"job": "api"}, prompb.Sample{Value: 1, Timestamp: 1000}),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		contentType     string
+		contentEncoding string
+		body            []byte
+		consumerErr     error
+		expectedStatus  int
+		expectedPoints  int
+	}{
+		{
+			name:           "success",
+			method:         http.MethodPost,
+			contentType:    "application/x-protobuf",
+			body:           encodeWriteRequest(t, writeReq),
+			expectedStatus: http.StatusNoContent,
+			expectedPoints: 1,
+		},
+		{
+			name:            "explicit remote write 1.0 message",
+			method:          http.MethodPost,
+			contentType:     "application/x-protobuf;proto=prometheus.WriteRequest",
+			contentEncoding: "snappy",
+			body:            encodeWriteRequest(t, writeReq),
+			expectedStatus:  http.StatusNoContent,
+			expectedPoints:  1,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "wrong content type",
+			method:         http.MethodPost,
+			contentType:    "application/json",
+			body:           encodeWriteRequest(t, writeReq),
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "remote write 2.0",
+			method:         http.MethodPost,
+			contentType:    "application/x-protobuf;proto=io.prometheus.write.v2.Request",
+			body:           encodeWriteRequest(t, writeReq),
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:            "wrong content encoding",
+			method:          http.MethodPost,
+			contentType:     "application/x-protobuf",
+			contentEncoding: "br",
+			body:            encodeWriteRequest(t, writeReq),
+			expectedStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "not compressed",
+			method:         http.MethodPost,
+			contentType:    "application/x-protobuf",
+			body:           []byte("foo"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "compressed body too large",
+			method:         http.MethodPost,
+			contentType:    "application/x-protobuf",
+			body:           make([]byte, maxRequestSize+1),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "decompressed body too large",
+			method:      http.MethodPost,
+			contentType: "application/x-protobuf",
+			// Snappy blocks start with their decoded length.
+			body:           binary.AppendUvarint(nil, maxRequestSize+1),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "no valid series",
+			method:         http.MethodPost,
+			contentType:    "application/x-protobuf",
+			body:           encodeWriteRequest(t, &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{Samples: []prompb.Sample{{Value: 1}}}}}),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "consumer error",
+			method:         http.MethodPost,
+			contentType:    "application/x-protobuf",
+			body:           encodeWriteRequest(t, writeReq),
+			consumerErr:    errors.New("temporary failure"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "permanent consumer error",
+			method:         http.MethodPost,
+			contentType:    "application/x-protobuf",
+			body:           encodeWriteRequest(t, writeReq),
+			consumerErr:    consumererror.NewPermanent(errors.New("invalid data")),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			var next consumer.Metrics = sink
+			if tt.consumerErr != nil {
+				next = consumertest.NewErr(tt.consumerErr)
+			}
+			r := newTestReceiver(t, next)
+
+			req := httptest.NewRequest(tt.method, defaultPath, bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.contentEncoding != "" {
+				req.Header.Set("Content-Encoding", tt.contentEncoding)
+			}
+			resp := httptest.NewRecorder()
+			r.handleWrite(resp, req)
+
+			assert.Equal(t, tt.expectedStatus, resp.Code, resp.Body.String())
+			assert.Equal(t, tt.expectedPoints, sink.DataPointCount())
+		})
+	}
+}
+
+func TestReceiverStartShutdown(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	r := newTestReceiver(t, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+
+	writeReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("requests_total", map[string]string{"job": "api"}, prompb.Sample{Value: 3, Timestamp: 1000}),
+		},
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s%s", r.conf.Endpoint, defaultPath), bytes.NewReader(encodeWriteRequest(t, writeReq)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.MetricCount())
+	assert.Equal(t, "requests_total", md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	r := newTestReceiver(t, consumertest.NewNop())
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
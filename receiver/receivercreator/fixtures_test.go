@@ -67,6 +67,20 @@ var hostportEndpoint = observer.Endpoint{
 	},
 }
 
+var inventoryEndpoint = observer.Endpoint{
+	ID:     "file_observer/orders-db",
+	Target: "10.0.0.12:5432",
+	Details: &observer.Inventory{
+		Name:      "orders-db",
+		Host:      "10.0.0.12",
+		Port:      5432,
+		Transport: observer.ProtocolTCP,
+		Labels: map[string]string{
+			"service": "postgres",
+		},
+	},
+}
+
 var container = observer.Container{
 	Name:          "otel-agent",
 	Image:         "otelcol",
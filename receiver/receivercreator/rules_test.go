@@ -32,6 +32,7 @@ func Test_ruleEval(t *testing.T) {
 		{"annotations", args{`type == "pod" && annotations["scrape"] == "true"`, podEndpoint}, true, false},
 		{"basic container", args{`type == "container" && labels["region"] == "east-1"`, containerEndpoint}, true, false},
 		{"basic k8s.node", args{`type == "k8s.node" && kubelet_endpoint_port == 10250`, k8sNodeEndpoint}, true, false},
+		{"basic inventory", args{`type == "inventory" && labels["service"] == "postgres" && port == 5432`, inventoryEndpoint}, true, false},
 		{"relocated type builtin", args{`type == "k8s.node" && typeOf("some string") == "string"`, k8sNodeEndpoint}, true, false},
 	}
 	for _, tt := range tests {
@@ -71,6 +72,7 @@ func Test_newRule(t *testing.T) {
 		{"valid pod", args{`type=="pod" && port_name == "http"`}, false},
 		{"valid hostport", args{`type == "hostport" && port_name == "http"`}, false},
 		{"valid container", args{`type == "container" && port == 8080`}, false},
+		{"valid inventory", args{`type == "inventory" && host == "db.example.com"`}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -113,6 +113,13 @@ func newClient(cfg *Config, logger *zap.Logger) (client, error) {
 // setV3ClientConfigs sets SNMP v3 related configurations on gosnmp client based on config
 func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	client.SetSecurityModel(gosnmp.UserSecurityModel)
+	msgFlags, securityParams := getV3SecurityParameters(cfg)
+	client.SetMsgFlags(msgFlags)
+	client.SetSecurityParameters(securityParams)
+}
+
+// getV3SecurityParameters gets the gosnmp security level & user based security parameters based on config
+func getV3SecurityParameters(cfg *Config) (gosnmp.SnmpV3MsgFlags, *gosnmp.UsmSecurityParameters) {
 	// Set goSNMP user based on config
 	securityParams := &gosnmp.UsmSecurityParameters{
 		UserName: cfg.User,
@@ -120,13 +127,11 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 	// Set goSNMP security level & auth/privacy details based on config
 	switch strings.ToUpper(cfg.SecurityLevel) {
 	case "AUTH_NO_PRIV":
-		client.SetMsgFlags(gosnmp.AuthNoPriv)
 		protocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = protocol
 		securityParams.AuthenticationPassphrase = string(cfg.AuthPassword)
+		return gosnmp.AuthNoPriv, securityParams
 	case "AUTH_PRIV":
-		client.SetMsgFlags(gosnmp.AuthPriv)
-
 		authProtocol := getAuthProtocol(cfg.AuthType)
 		securityParams.AuthenticationProtocol = authProtocol
 		securityParams.AuthenticationPassphrase = string(cfg.AuthPassword)
@@ -134,10 +139,10 @@ func setV3ClientConfigs(client goSNMPWrapper, cfg *Config) {
 		privProtocol := getPrivacyProtocol(cfg.PrivacyType)
 		securityParams.PrivacyProtocol = privProtocol
 		securityParams.PrivacyPassphrase = string(cfg.PrivacyPassword)
+		return gosnmp.AuthPriv, securityParams
 	default:
-		client.SetMsgFlags(gosnmp.NoAuthNoPriv)
+		return gosnmp.NoAuthNoPriv, securityParams
 	}
-	client.SetSecurityParameters(securityParams)
 }
 
 // getAuthProtocol gets gosnmp auth protocol based on config auth type
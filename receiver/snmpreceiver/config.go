@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
 )
 
 // Config Defaults
@@ -23,6 +26,7 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultTrapsEndpoint      = "udp://0.0.0.0:162"
 )
 
 var (
@@ -52,6 +56,10 @@ var (
 	errMsgMultipleKeysSetOnResourceAttribute        = `resource attribute '%s' must have only one of oid, scalar_oid, or indexed_value_prefix`
 	errScalarOIDResourceAttributeEndsInNonzeroDigit = `resource attribute '%s' has scalar_oid '%s' that ends in a nonzero digit (scalar oids should not be indexed)`
 	errColumnOIDResourceAttributeEndsInZero         = `resource attribute '%s' has oid '%s' that ends in a zero (column oids should be indexed)`
+	errMsgInvalidTrapsEndpoint                      = `invalid traps endpoint '%s': must be in '[udp|tcp]://[host]:[port]' format`
+	errMsgTrapDefinitionNoOID                       = `trap definition '%s' must contain an oid`
+	errMsgTrapDefinitionBadSeverity                 = `trap definition '%s' severity must be either trace, debug, info, warn, error, or fatal`
+	errMsgTrapVarbindNoOID                          = `trap varbind '%s' must contain an oid`
 
 	// Config errors
 	errEmptyEndpoint        = errors.New("endpoint must be specified")
@@ -68,6 +76,9 @@ var (
 	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
 	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
 	errMetricRequired       = errors.New("must have at least one config under metrics")
+	errTrapsEndpointScheme  = errors.New("traps endpoint scheme must be either tcp or udp")
+	errTrapsRequired        = errors.New("traps must be configured to use the receiver in a logs pipeline")
+	errEmptyMIBDirectories  = errors.New("mibs directories must be specified")
 )
 
 // Config defines the configuration for the various elements of the receiver.
@@ -120,6 +131,10 @@ type Config struct {
 	// Only valid for version “v3” and if "auth_priv" is selected for SecurityLevel
 	PrivacyPassword configopaque.String `mapstructure:"privacy_password"`
 
+	// MIBs is optional and configures the MIB files used to resolve the symbolic OIDs of the config,
+	// such as IF-MIB::ifHCInOctets, to numeric OIDs
+	MIBs *MIBsConfig `mapstructure:"mibs"`
+
 	// ResourceAttributes defines what resource attributes will be used for this receiver and is composed
 	// of resource attribute names along with their resource attribute configurations
 	ResourceAttributes map[string]*ResourceAttributeConfig `mapstructure:"resource_attributes"`
@@ -131,6 +146,51 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// Traps configures the listener of traps and informs, converted to log records when the receiver
+	// is used in a logs pipeline. The version, community and v3 security settings above are used to
+	// authenticate the received traps.
+	// Metrics are optional when Traps is set.
+	Traps *TrapsConfig `mapstructure:"traps"`
+}
+
+// MIBsConfig contains config info about the MIB files this receiver loads.
+type MIBsConfig struct {
+	// Directories is required and lists the directories the MIB files are loaded from
+	Directories []string `mapstructure:"directories"`
+}
+
+// TrapsConfig contains config info about the traps and informs this receiver listens for.
+type TrapsConfig struct {
+	// Endpoint is the address to listen on for traps. Must be formatted as [udp|tcp]://{host}:{port}.
+	// Default: udp://0.0.0.0:162
+	// If no scheme is given, udp is assumed.
+	// If no port is given, 162 is assumed.
+	Endpoint string `mapstructure:"endpoint"`
+	// Definitions is optional and maps trap OIDs to names, keyed by the trap names
+	Definitions map[string]*TrapDefinitionConfig `mapstructure:"definitions"`
+	// Varbinds is optional and maps varbind OIDs to names, keyed by the varbind names
+	Varbinds map[string]*VarbindConfig `mapstructure:"varbinds"`
+}
+
+// TrapDefinitionConfig contains config info about a known trap
+type TrapDefinitionConfig struct {
+	// Description is optional and describes what the trap reports
+	Description string `mapstructure:"description"`
+	// OID is required and is the OID identifying the trap (snmpTrapOID.0)
+	OID string `mapstructure:"oid"`
+	// Severity is optional and is the severity of the log records of the trap.
+	// Valid options: trace, debug, info, warn, error, fatal
+	Severity string `mapstructure:"severity"`
+}
+
+// VarbindConfig contains config info about a known varbind
+type VarbindConfig struct {
+	// Description is optional and describes what the varbind represents
+	Description string `mapstructure:"description"`
+	// OID is required and is the scalar or column OID of the varbind. Varbinds of a column OID
+	// are named after it whatever their index
+	OID string `mapstructure:"oid"`
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -152,6 +212,9 @@ type ResourceAttributeConfig struct {
 	// as an attribute on that resource. The related indexed metric values will then be used to associate metric datapoints to
 	// those resources.
 	IndexedValuePrefix string `mapstructure:"indexed_value_prefix"` // required and valid if no oid or scalar_oid field
+
+	// enums are the names of the values of the OID, as defined by its MIB
+	enums map[int64]string
 }
 
 // AttributeConfig contains config info about all of the metric attributes that will be used by this receiver.
@@ -169,6 +232,9 @@ type AttributeConfig struct {
 	// IndexedValuePrefix is required only if Enum and OID are not defined.
 	// This is used alongside metrics with ColumnOIDs to assign attribute values using this prefix + the OID index of the metric value
 	IndexedValuePrefix string `mapstructure:"indexed_value_prefix"`
+
+	// enums are the names of the values of the OID, as defined by its MIB
+	enums map[int64]string
 }
 
 // MetricConfig contains config info about a given metric
@@ -240,6 +306,27 @@ type Attribute struct {
 	Value string `mapstructure:"value"`
 }
 
+// Unmarshal unmarshals the config, resolving its symbolic OIDs with the configured MIBs
+func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
+	if componentParser == nil {
+		// Nothing to do if there is no config given.
+		return nil
+	}
+
+	if err := componentParser.Unmarshal(cfg); err != nil {
+		return err
+	}
+
+	if cfg.MIBs == nil || len(cfg.MIBs.Directories) == 0 {
+		return nil
+	}
+	mibs, err := mib.Load(cfg.MIBs.Directories)
+	if err != nil {
+		return err
+	}
+	return resolveMIBNames(cfg, mibs)
+}
+
 // Validate validates the given config, returning an error specifying any issues with the config.
 func (cfg *Config) Validate() error {
 	var combinedErr error
@@ -250,6 +337,12 @@ func (cfg *Config) Validate() error {
 		combinedErr = errors.Join(combinedErr, validateSecurity(cfg))
 	}
 	combinedErr = errors.Join(combinedErr, validateMetricConfigs(cfg))
+	if cfg.Traps != nil {
+		combinedErr = errors.Join(combinedErr, validateTraps(cfg.Traps))
+	}
+	if cfg.MIBs != nil && len(cfg.MIBs.Directories) == 0 {
+		combinedErr = errors.Join(combinedErr, errEmptyMIBDirectories)
+	}
 
 	return combinedErr
 }
@@ -378,9 +471,12 @@ func validateMetricConfigs(cfg *Config) error {
 	combinedErr = errors.Join(combinedErr, validateAttributeConfigs(cfg))
 	combinedErr = errors.Join(combinedErr, validateResourceAttributeConfigs(cfg))
 
-	// Ensure there is at least one MetricConfig
+	// Ensure there is at least one MetricConfig, unless the receiver listens for traps
 	metrics := cfg.Metrics
 	if len(metrics) == 0 {
+		if cfg.Traps != nil {
+			return combinedErr
+		}
 		return errors.Join(combinedErr, errMetricRequired)
 	}
 
@@ -635,3 +731,36 @@ func contains(elements []string, value string) bool {
 	}
 	return false
 }
+
+// validateTraps validates the TrapsConfig
+func validateTraps(cfg *TrapsConfig) error {
+	var combinedErr error
+
+	// An empty endpoint is replaced by the default one
+	if cfg.Endpoint != "" {
+		u, err := url.Parse(cfg.Endpoint)
+		switch {
+		case err != nil || u.Host == "" || u.Port() == "":
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidTrapsEndpoint, cfg.Endpoint))
+		case !strings.EqualFold(u.Scheme, "udp") && !strings.EqualFold(u.Scheme, "tcp"):
+			combinedErr = errors.Join(combinedErr, errTrapsEndpointScheme)
+		}
+	}
+
+	for name, definition := range cfg.Definitions {
+		if definition.OID == "" {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgTrapDefinitionNoOID, name))
+		}
+		if _, ok := trapSeverities[strings.ToLower(definition.Severity)]; !ok && definition.Severity != "" {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgTrapDefinitionBadSeverity, name))
+		}
+	}
+
+	for name, varbind := range cfg.Varbinds {
+		if varbind.OID == "" {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgTrapVarbindNoOID, name))
+		}
+	}
+
+	return combinedErr
+}
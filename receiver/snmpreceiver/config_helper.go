@@ -19,6 +19,7 @@ type configHelper struct {
 	metricNamesByOID            map[string]string
 	metricAttributesByOID       map[string][]Attribute
 	resourceAttributesByOID     map[string][]string
+	enumsByOID                  map[string]map[int64]string
 }
 
 // newConfigHelper returns a new configHelper with various pieces of static info saved for easy access
@@ -33,6 +34,7 @@ func newConfigHelper(cfg *Config) *configHelper {
 		metricNamesByOID:            map[string]string{},
 		metricAttributesByOID:       map[string][]Attribute{},
 		resourceAttributesByOID:     map[string][]string{},
+		enumsByOID:                  map[string]map[int64]string{},
 	}
 
 	// Group all metric scalar OIDs and metric column OIDs
@@ -79,6 +81,9 @@ func newConfigHelper(cfg *Config) *configHelper {
 			cfg.Attributes[name] = attributeCfg
 		}
 		ch.attributeColumnOIDs = append(ch.attributeColumnOIDs, attributeCfg.OID)
+		if attributeCfg.enums != nil {
+			ch.enumsByOID[attributeCfg.OID] = attributeCfg.enums
+		}
 	}
 
 	// Find all resource attribute scalar and column OIDs
@@ -91,6 +96,9 @@ func newConfigHelper(cfg *Config) *configHelper {
 				cfg.ResourceAttributes[name] = resourceAttributeCfg
 			}
 			ch.resourceAttributeScalarOIDs = append(ch.resourceAttributeScalarOIDs, resourceAttributeCfg.ScalarOID)
+			if resourceAttributeCfg.enums != nil {
+				ch.enumsByOID[resourceAttributeCfg.ScalarOID] = resourceAttributeCfg.enums
+			}
 			continue
 		}
 		if resourceAttributeCfg.OID != "" {
@@ -101,6 +109,9 @@ func newConfigHelper(cfg *Config) *configHelper {
 				cfg.ResourceAttributes[name] = resourceAttributeCfg
 			}
 			ch.resourceAttributeColumnOIDs = append(ch.resourceAttributeColumnOIDs, resourceAttributeCfg.OID)
+			if resourceAttributeCfg.enums != nil {
+				ch.enumsByOID[resourceAttributeCfg.OID] = resourceAttributeCfg.enums
+			}
 		}
 	}
 
@@ -139,6 +150,12 @@ func (h configHelper) getResourceAttributeColumnOIDs() []string {
 	return h.resourceAttributeColumnOIDs
 }
 
+// getEnumName returns the name of an integer value of a {resource} attribute OID, as defined by its MIB
+func (h configHelper) getEnumName(oid string, value int64) (string, bool) {
+	name, ok := h.enumsByOID[oid][value]
+	return name, ok
+}
+
 // getMetricName a metric names based on a given OID
 func (h configHelper) getMetricName(oid string) string {
 	return h.metricNamesByOID[oid]
@@ -1010,6 +1010,202 @@ func TestLoadConfigMetricConfigs(t *testing.T) {
 }
 
 // Testing Validate directly to test that missing data errors when no defaults are provided
+func TestLoadConfigTrapsConfigs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	type testCase struct {
+		name        string
+		nameVal     string
+		expectedCfg *Config
+		expectedErr string
+	}
+
+	expectedConfigTrapsGood := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsGood.Traps = &TrapsConfig{
+		Endpoint: "udp://0.0.0.0:1162",
+		Definitions: map[string]*TrapDefinitionConfig{
+			"linkDown": {
+				Description: "A communication link is down",
+				OID:         "1.3.6.1.6.3.1.1.5.3",
+				Severity:    "warn",
+			},
+		},
+		Varbinds: map[string]*VarbindConfig{
+			"ifIndex": {
+				OID: "1.3.6.1.2.1.2.2.1.1",
+			},
+		},
+	}
+
+	expectedConfigTrapsNoEndpoint := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsNoEndpoint.Traps = &TrapsConfig{
+		Definitions: map[string]*TrapDefinitionConfig{
+			"linkDown": {
+				OID: "1.3.6.1.6.3.1.1.5.3",
+			},
+		},
+	}
+
+	expectedConfigTrapsBadEndpointScheme := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsBadEndpointScheme.Traps = &TrapsConfig{
+		Endpoint: "http://localhost:162",
+	}
+
+	expectedConfigTrapsDefinitionNoOID := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsDefinitionNoOID.Traps = &TrapsConfig{
+		Definitions: map[string]*TrapDefinitionConfig{
+			"linkDown": {
+				Severity: "warn",
+			},
+		},
+	}
+
+	expectedConfigTrapsDefinitionBadSeverity := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsDefinitionBadSeverity.Traps = &TrapsConfig{
+		Definitions: map[string]*TrapDefinitionConfig{
+			"linkDown": {
+				OID:      "1.3.6.1.6.3.1.1.5.3",
+				Severity: "critical",
+			},
+		},
+	}
+
+	expectedConfigTrapsVarbindNoOID := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsVarbindNoOID.Traps = &TrapsConfig{
+		Varbinds: map[string]*VarbindConfig{
+			"ifIndex": {
+				Description: "The index of the interface",
+			},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name:        "GoodTrapsNoErrors",
+			nameVal:     "traps_good",
+			expectedCfg: expectedConfigTrapsGood,
+			expectedErr: "",
+		},
+		{
+			name:        "NoTrapsEndpointNoErrors",
+			nameVal:     "traps_no_endpoint",
+			expectedCfg: expectedConfigTrapsNoEndpoint,
+			expectedErr: "",
+		},
+		{
+			name:        "BadTrapsEndpointSchemeErrors",
+			nameVal:     "traps_bad_endpoint_scheme",
+			expectedCfg: expectedConfigTrapsBadEndpointScheme,
+			expectedErr: errTrapsEndpointScheme.Error(),
+		},
+		{
+			name:        "NoTrapDefinitionOIDErrors",
+			nameVal:     "traps_definition_no_oid",
+			expectedCfg: expectedConfigTrapsDefinitionNoOID,
+			expectedErr: fmt.Sprintf(errMsgTrapDefinitionNoOID, "linkDown"),
+		},
+		{
+			name:        "BadTrapDefinitionSeverityErrors",
+			nameVal:     "traps_definition_bad_severity",
+			expectedCfg: expectedConfigTrapsDefinitionBadSeverity,
+			expectedErr: fmt.Sprintf(errMsgTrapDefinitionBadSeverity, "linkDown"),
+		},
+		{
+			name:        "NoTrapVarbindOIDErrors",
+			nameVal:     "traps_varbind_no_oid",
+			expectedCfg: expectedConfigTrapsVarbindNoOID,
+			expectedErr: fmt.Sprintf(errMsgTrapVarbindNoOID, "ifIndex"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := cm.Sub(component.NewIDWithName(metadata.Type, test.nameVal).String())
+			require.NoError(t, err)
+
+			cfg := factory.CreateDefaultConfig()
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if test.expectedErr == "" {
+				require.NoError(t, component.ValidateConfig(cfg))
+			} else {
+				require.ErrorContains(t, component.ValidateConfig(cfg), test.expectedErr)
+			}
+
+			require.Equal(t, test.expectedCfg, cfg)
+		})
+	}
+}
+
+func TestLoadConfigMIBs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	expectedCfg := factory.CreateDefaultConfig().(*Config)
+	expectedCfg.MIBs = &MIBsConfig{
+		Directories: []string{"internal/mib/testdata/mibs"},
+	}
+	expectedCfg.ResourceAttributes = map[string]*ResourceAttributeConfig{
+		"ra1": {
+			OID: "1.3.6.1.2.1.2.2.1.2",
+		},
+	}
+	expectedCfg.Attributes = map[string]*AttributeConfig{
+		"a1": {
+			OID:   "1.3.6.1.2.1.2.2.1.8",
+			enums: map[int64]string{1: "up", 2: "down", 3: "testing"},
+		},
+	}
+	expectedCfg.Metrics = map[string]*MetricConfig{
+		"m1": {
+			Unit: "1",
+			Gauge: &GaugeMetric{
+				ValueType: "int",
+			},
+			ScalarOIDs: []ScalarOID{
+				{
+					OID: "1.3.6.1.2.1.2.1.0",
+				},
+			},
+		},
+		"m2": {
+			Unit: "By",
+			Sum: &SumMetric{
+				Aggregation: "cumulative",
+				Monotonic:   true,
+				ValueType:   "int",
+			},
+			ColumnOIDs: []ColumnOID{
+				{
+					OID:                "1.3.6.1.2.1.2.2.1.10",
+					ResourceAttributes: []string{"ra1"},
+					Attributes: []Attribute{
+						{
+							Name: "a1",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "mibs").String())
+	require.NoError(t, err)
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+	require.Equal(t, expectedCfg, cfg)
+
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "mibs_unknown_object").String())
+	require.NoError(t, err)
+	cfg = factory.CreateDefaultConfig()
+	err = component.UnmarshalConfig(sub, cfg)
+	require.ErrorContains(t, err, "metric 'm1' oid 'TEST-IF-MIB::ifOutOctets' could not be resolved")
+}
+
 func TestValidate(t *testing.T) {
 	type testCase struct {
 		name        string
@@ -25,7 +25,8 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -59,6 +60,9 @@ func createMetricsReceiver(
 	if err := addMissingConfigDefaults(snmpConfig); err != nil {
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
+	if len(snmpConfig.Metrics) == 0 {
+		return nil, errMetricRequired
+	}
 
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
 	scraper, err := scraperhelper.NewScraper(metadata.Type, snmpScraper.scrape, scraperhelper.WithStart(snmpScraper.start))
@@ -69,21 +73,36 @@ func createMetricsReceiver(
 	return scraperhelper.NewScraperControllerReceiver(&snmpConfig.ScraperControllerSettings, params, consumer, scraperhelper.AddScraper(scraper))
 }
 
-// addMissingConfigDefaults adds any missing config parameters that have defaults
-func addMissingConfigDefaults(cfg *Config) error {
-	// Add the schema prefix to the endpoint if it doesn't contain one
-	if !strings.Contains(cfg.Endpoint, "://") {
-		cfg.Endpoint = "udp://" + cfg.Endpoint
+// createLogsReceiver creates the receiver converting the SNMP traps to logs
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	config component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+	if snmpConfig.Traps == nil {
+		return nil, errTrapsRequired
 	}
 
-	// Add default port to endpoint if it doesn't contain one
-	u, err := url.Parse(cfg.Endpoint)
-	if err == nil && u.Port() == "" {
-		portSuffix := "161"
-		if cfg.Endpoint[len(cfg.Endpoint)-1:] != ":" {
-			portSuffix = ":" + portSuffix
+	if err := addMissingConfigDefaults(snmpConfig); err != nil {
+		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
+	}
+
+	return newTrapReceiver(params, snmpConfig, consumer)
+}
+
+// addMissingConfigDefaults adds any missing config parameters that have defaults
+func addMissingConfigDefaults(cfg *Config) error {
+	cfg.Endpoint = addMissingEndpointDefaults(cfg.Endpoint, "161")
+	if cfg.Traps != nil {
+		if cfg.Traps.Endpoint == "" {
+			cfg.Traps.Endpoint = defaultTrapsEndpoint
 		}
-		cfg.Endpoint += portSuffix
+		cfg.Traps.Endpoint = addMissingEndpointDefaults(cfg.Traps.Endpoint, "162")
 	}
 
 	// Set defaults for metric configs
@@ -106,3 +125,22 @@ func addMissingConfigDefaults(cfg *Config) error {
 
 	return component.ValidateConfig(cfg)
 }
+
+// addMissingEndpointDefaults adds the udp scheme and the given port to the endpoint if it doesn't contain them
+func addMissingEndpointDefaults(endpoint string, port string) string {
+	// Add the schema prefix to the endpoint if it doesn't contain one
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "udp://" + endpoint
+	}
+
+	// Add default port to endpoint if it doesn't contain one
+	u, err := url.Parse(endpoint)
+	if err == nil && u.Port() == "" {
+		portSuffix := port
+		if endpoint[len(endpoint)-1:] != ":" {
+			portSuffix = ":" + portSuffix
+		}
+		endpoint += portSuffix
+	}
+	return endpoint
+}
@@ -260,6 +260,62 @@ func TestNewFactory(t *testing.T) {
 				require.Equal(t, "1", snmpCfg.Metrics["m1"].Unit)
 			},
 		},
+		{
+			desc: "creates a new factory and CreateLogsReceiver returns no error",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{
+					Definitions: map[string]*TrapDefinitionConfig{
+						"linkDown": {
+							OID: "1.3.6.1.6.3.1.1.5.3",
+						},
+					},
+				}
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, defaultTrapsEndpoint, snmpCfg.Traps.Endpoint)
+			},
+		},
+		{
+			desc: "CreateLogsReceiver adds the default port to the traps endpoint",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{
+					Endpoint: "localhost",
+				}
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, "udp://localhost:162", snmpCfg.Traps.Endpoint)
+			},
+		},
+		{
+			desc: "CreateLogsReceiver returns an error without traps config",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errTrapsRequired)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
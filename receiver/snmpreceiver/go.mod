@@ -15,6 +15,7 @@ require (
 	go.opentelemetry.io/collector/otelcol v0.90.2-0.20231201205146-6e2fdc755b34
 	go.opentelemetry.io/collector/pdata v1.0.1-0.20231201205146-6e2fdc755b34
 	go.opentelemetry.io/collector/receiver v0.90.2-0.20231201205146-6e2fdc755b34
+	go.opentelemetry.io/collector/semconv v0.90.2-0.20231201205146-6e2fdc755b34
 	go.uber.org/zap v1.26.0
 )
 
@@ -93,7 +94,6 @@ require (
 	go.opentelemetry.io/collector/extension v0.90.2-0.20231201205146-6e2fdc755b34 // indirect
 	go.opentelemetry.io/collector/featuregate v1.0.1-0.20231201205146-6e2fdc755b34 // indirect
 	go.opentelemetry.io/collector/processor v0.90.2-0.20231201205146-6e2fdc755b34 // indirect
-	go.opentelemetry.io/collector/semconv v0.90.2-0.20231201205146-6e2fdc755b34 // indirect
 	go.opentelemetry.io/collector/service v0.90.2-0.20231201205146-6e2fdc755b34 // indirect
 	go.opentelemetry.io/contrib/config v0.1.1 // indirect
 	go.opentelemetry.io/contrib/propagators/b3 v1.21.1 // indirect
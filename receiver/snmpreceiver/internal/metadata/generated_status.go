@@ -8,5 +8,6 @@ import (
 
 const (
 	Type             = "snmp"
+	LogsStability    = component.StabilityLevelDevelopment
 	MetricsStability = component.StabilityLevelAlpha
 )
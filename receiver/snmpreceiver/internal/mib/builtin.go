@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+// builtinModules define the parts of the base SMI modules the other modules
+// rely on, so that they don't have to be provided along with them.
+var builtinModules = []string{
+	`SNMPv2-SMI DEFINITIONS ::= BEGIN
+org          OBJECT IDENTIFIER ::= { iso 3 }
+dod          OBJECT IDENTIFIER ::= { org 6 }
+internet     OBJECT IDENTIFIER ::= { dod 1 }
+directory    OBJECT IDENTIFIER ::= { internet 1 }
+mgmt         OBJECT IDENTIFIER ::= { internet 2 }
+mib-2        OBJECT IDENTIFIER ::= { mgmt 1 }
+transmission OBJECT IDENTIFIER ::= { mib-2 10 }
+experimental OBJECT IDENTIFIER ::= { internet 3 }
+private      OBJECT IDENTIFIER ::= { internet 4 }
+enterprises  OBJECT IDENTIFIER ::= { private 1 }
+security     OBJECT IDENTIFIER ::= { internet 5 }
+snmpV2       OBJECT IDENTIFIER ::= { internet 6 }
+snmpDomains  OBJECT IDENTIFIER ::= { snmpV2 1 }
+snmpProxys   OBJECT IDENTIFIER ::= { snmpV2 2 }
+snmpModules  OBJECT IDENTIFIER ::= { snmpV2 3 }
+zeroDotZero  OBJECT IDENTIFIER ::= { 0 0 }
+END`,
+	`RFC1155-SMI DEFINITIONS ::= BEGIN
+internet     OBJECT IDENTIFIER ::= { iso org(3) dod(6) 1 }
+directory    OBJECT IDENTIFIER ::= { internet 1 }
+mgmt         OBJECT IDENTIFIER ::= { internet 2 }
+experimental OBJECT IDENTIFIER ::= { internet 3 }
+private      OBJECT IDENTIFIER ::= { internet 4 }
+enterprises  OBJECT IDENTIFIER ::= { private 1 }
+END`,
+	`RFC1213-MIB DEFINITIONS ::= BEGIN
+IMPORTS mgmt FROM RFC1155-SMI;
+mib-2 OBJECT IDENTIFIER ::= { mgmt 1 }
+DisplayString ::= OCTET STRING
+PhysAddress ::= OCTET STRING
+END`,
+	`SNMPv2-TC DEFINITIONS ::= BEGIN
+DisplayString ::= OCTET STRING (SIZE (0..255))
+PhysAddress ::= OCTET STRING
+MacAddress ::= OCTET STRING (SIZE (6))
+TruthValue ::= INTEGER { true(1), false(2) }
+TestAndIncr ::= INTEGER (0..2147483647)
+AutonomousType ::= OBJECT IDENTIFIER
+InstancePointer ::= OBJECT IDENTIFIER
+VariablePointer ::= OBJECT IDENTIFIER
+RowPointer ::= OBJECT IDENTIFIER
+RowStatus ::= INTEGER { active(1), notInService(2), notReady(3), createAndGo(4), createAndWait(5), destroy(6) }
+TimeStamp ::= TimeTicks
+TimeInterval ::= INTEGER (0..2147483647)
+DateAndTime ::= OCTET STRING (SIZE (8 | 11))
+StorageType ::= INTEGER { other(1), volatile(2), nonVolatile(3), permanent(4), readOnly(5) }
+TDomain ::= OBJECT IDENTIFIER
+TAddress ::= OCTET STRING (SIZE (1..255))
+END`,
+}
@@ -0,0 +1,357 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mib loads MIB modules to resolve the symbolic names of the objects
+// they define, such as IF-MIB::ifHCInOctets, to numeric OIDs.
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxDepth limits the length of the chains of OIDs and types, to detect the cycles
+const maxDepth = 64
+
+var (
+	numericOIDRegex = regexp.MustCompile(`^\.?[0-9]+(\.[0-9]+)*$`)
+	suffixRegex     = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*$`)
+
+	// rootOIDs are the OIDs defined by ASN.1 itself
+	rootOIDs = map[string]string{
+		"ccitt":           "0",
+		"iso":             "1",
+		"joint-iso-ccitt": "2",
+	}
+
+	// primitiveTypes maps the SMI base types to their SMIv2 name
+	primitiveTypes = map[string]string{
+		"INTEGER":           "INTEGER",
+		"Integer32":         "Integer32",
+		"Unsigned32":        "Unsigned32",
+		"UInteger32":        "Unsigned32",
+		"Counter32":         "Counter32",
+		"Counter":           "Counter32",
+		"Counter64":         "Counter64",
+		"Gauge32":           "Gauge32",
+		"Gauge":             "Gauge32",
+		"TimeTicks":         "TimeTicks",
+		"IpAddress":         "IpAddress",
+		"NetworkAddress":    "IpAddress",
+		"Opaque":            "Opaque",
+		"OCTET STRING":      "OCTET STRING",
+		"OBJECT IDENTIFIER": "OBJECT IDENTIFIER",
+		"BITS":              "BITS",
+		"SEQUENCE":          "SEQUENCE",
+		"SEQUENCE OF":       "SEQUENCE OF",
+		"CHOICE":            "CHOICE",
+	}
+)
+
+// Node is an object defined by a MIB module
+type Node struct {
+	Module string
+	Name   string
+	// OID is the numeric OID of the object, without leading dot
+	OID string
+	// Type is the SMI base type of the object, such as Counter64 or OCTET STRING,
+	// following its textual conventions. It is empty for the objects without syntax.
+	Type string
+	// Units are the units of the object, if any
+	Units string
+	// Enums are the named numbers of an enumerated object, following its textual conventions
+	Enums map[int64]string
+	// Scalar is set for the object types which aren't tables, rows or columns
+	Scalar bool
+}
+
+// MIBs are the objects defined by a set of MIB modules
+type MIBs struct {
+	// nodes are the objects by qualified name
+	nodes map[string]*Node
+	// nodesByName are the objects by name, for the names which aren't qualified with their module
+	nodesByName map[string][]*Node
+	nodesByOID  map[string]*Node
+	// unresolved are the errors resolving the OIDs of the objects, by qualified name
+	unresolved map[string]error
+	// loadErrs are the errors parsing the MIB files
+	loadErrs []error
+}
+
+// Load loads the MIB modules of the files of the given directories. The base
+// SMI modules are built in, unless the directories contain them.
+// The files which can't be parsed are skipped, the errors they cause being
+// reported when resolving the names they may define.
+func Load(directories []string) (*MIBs, error) {
+	modules := map[string]*module{}
+	var loadErrs []error
+	for _, dir := range directories {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MIB directory: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			src, err := os.ReadFile(filepath.Clean(path))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read MIB file: %w", err)
+			}
+			parsed, err := parseModules(string(src))
+			if err != nil {
+				loadErrs = append(loadErrs, fmt.Errorf("failed to parse MIB file %s: %w", path, err))
+				continue
+			}
+			addModules(modules, parsed)
+		}
+	}
+	for _, src := range builtinModules {
+		parsed, err := parseModules(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse built in MIB module: %w", err)
+		}
+		addModules(modules, parsed)
+	}
+
+	return build(modules, loadErrs), nil
+}
+
+// addModules adds the modules which aren't loaded yet
+func addModules(modules map[string]*module, parsed []*module) {
+	for _, m := range parsed {
+		if _, ok := modules[m.name]; !ok {
+			modules[m.name] = m
+		}
+	}
+}
+
+// build resolves the OIDs and types of the objects of the modules
+func build(modules map[string]*module, loadErrs []error) *MIBs {
+	r := &resolver{modules: modules, oids: map[string]string{}}
+	m := &MIBs{
+		nodes:       map[string]*Node{},
+		nodesByName: map[string][]*Node{},
+		nodesByOID:  map[string]*Node{},
+		unresolved:  map[string]error{},
+		loadErrs:    loadErrs,
+	}
+
+	var objectTypes []*Node
+	for _, mod := range modules {
+		for name, d := range mod.definitions {
+			key := qualifiedName(mod.name, name)
+			oid, err := r.oid(mod, name, 0)
+			if err != nil {
+				m.unresolved[key] = err
+				continue
+			}
+			node := &Node{
+				Module: mod.name,
+				Name:   name,
+				OID:    oid,
+				Units:  d.units,
+			}
+			if d.syntax != nil {
+				node.Type, node.Enums = r.resolveSyntax(mod, d.syntax)
+			}
+			m.nodes[key] = node
+			m.nodesByName[name] = append(m.nodesByName[name], node)
+			if _, ok := m.nodesByOID[oid]; !ok {
+				m.nodesByOID[oid] = node
+			}
+			if d.objectType {
+				objectTypes = append(objectTypes, node)
+			}
+		}
+	}
+
+	// The columns are the children of the rows, which are SEQUENCEs
+	for _, node := range objectTypes {
+		if node.Type == "SEQUENCE" || node.Type == "SEQUENCE OF" {
+			continue
+		}
+		parentOID, _, _ := cutLast(node.OID)
+		parent := m.nodesByOID[parentOID]
+		node.Scalar = parent == nil || parent.Type != "SEQUENCE"
+	}
+	return m
+}
+
+// IsNumeric returns whether a reference to an object is a numeric OID
+func IsNumeric(ref string) bool {
+	return numericOIDRegex.MatchString(ref)
+}
+
+// Resolve resolves a reference to an object to its numeric OID, along with the
+// object it refers to. The reference is either a numeric OID, or the name of
+// an object optionally qualified with its module, such as IF-MIB::ifDescr, and
+// optionally followed by the index of an instance, such as sysUpTime.0.
+// The object referred to by a numeric OID is the closest object it belongs to,
+// if any.
+func (m *MIBs) Resolve(ref string) (string, *Node, error) {
+	if IsNumeric(ref) {
+		return ref, m.lookup(strings.TrimPrefix(ref, ".")), nil
+	}
+
+	moduleName, name, qualified := strings.Cut(ref, "::")
+	if !qualified {
+		name, moduleName = ref, ""
+	}
+	name, suffix, indexed := strings.Cut(name, ".")
+	if indexed && !suffixRegex.MatchString(suffix) {
+		return "", nil, fmt.Errorf("invalid index %q of %s", suffix, ref)
+	}
+
+	node, err := m.find(moduleName, name)
+	if err != nil {
+		return "", nil, err
+	}
+	if indexed {
+		return node.OID + "." + suffix, node, nil
+	}
+	return node.OID, node, nil
+}
+
+// find finds an object by name, and optionally module
+func (m *MIBs) find(moduleName, name string) (*Node, error) {
+	if moduleName != "" {
+		key := qualifiedName(moduleName, name)
+		if node, ok := m.nodes[key]; ok {
+			return node, nil
+		}
+		if err, ok := m.unresolved[key]; ok {
+			return nil, fmt.Errorf("failed to resolve the OID of %s: %w", key, err)
+		}
+		return nil, m.notFound(key)
+	}
+
+	candidates := m.nodesByName[name]
+	switch {
+	case len(candidates) == 0:
+		for key, err := range m.unresolved {
+			if _, n, _ := strings.Cut(key, "::"); n == name {
+				return nil, fmt.Errorf("failed to resolve the OID of %s: %w", key, err)
+			}
+		}
+		return nil, m.notFound(name)
+	case len(candidates) > 1:
+		for _, candidate := range candidates[1:] {
+			if candidate.OID != candidates[0].OID {
+				return nil, fmt.Errorf("%s is defined by several MIB modules, it must be qualified with its module, such as %s",
+					name, qualifiedName(candidates[0].Module, name))
+			}
+		}
+	}
+	return candidates[0], nil
+}
+
+func (m *MIBs) notFound(name string) error {
+	return errors.Join(append([]error{fmt.Errorf("unknown MIB object %s", name)}, m.loadErrs...)...)
+}
+
+// lookup returns the closest object the numeric OID belongs to
+func (m *MIBs) lookup(oid string) *Node {
+	for prefix := oid; prefix != ""; prefix, _, _ = cutLast(prefix) {
+		if node, ok := m.nodesByOID[prefix]; ok {
+			return node
+		}
+	}
+	return nil
+}
+
+// cutLast cuts the last sub-identifier of an OID
+func cutLast(oid string) (string, string, bool) {
+	i := strings.LastIndexByte(oid, '.')
+	if i < 0 {
+		return "", oid, false
+	}
+	return oid[:i], oid[i+1:], true
+}
+
+func qualifiedName(moduleName, name string) string {
+	return moduleName + "::" + name
+}
+
+// resolver resolves the symbols of the modules, following their imports
+type resolver struct {
+	modules map[string]*module
+	// oids are the resolved OIDs by qualified name
+	oids map[string]string
+}
+
+// oid resolves the OID of an object defined or imported by the module
+func (r *resolver) oid(mod *module, name string, depth int) (string, error) {
+	key := qualifiedName(mod.name, name)
+	if oid, ok := r.oids[key]; ok {
+		return oid, nil
+	}
+	if depth > maxDepth {
+		return "", fmt.Errorf("the OID of %s is defined recursively", key)
+	}
+
+	d, ok := mod.definitions[name]
+	if !ok {
+		if from, imported := mod.imports[name]; imported {
+			fromMod, loaded := r.modules[from]
+			if !loaded {
+				return "", fmt.Errorf("module %s imported by %s is not loaded", from, mod.name)
+			}
+			return r.oid(fromMod, name, depth+1)
+		}
+		if oid, root := rootOIDs[name]; root {
+			return oid, nil
+		}
+		return "", fmt.Errorf("%s is neither defined nor imported by %s", name, mod.name)
+	}
+
+	parts := make([]string, 0, len(d.subIDs)+1)
+	if d.parent != "" {
+		parentOID, err := r.oid(mod, d.parent, depth+1)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, parentOID)
+	}
+	oid := strings.Join(append(parts, d.subIDs...), ".")
+	r.oids[key] = oid
+	return oid, nil
+}
+
+// resolveSyntax resolves the base type of a syntax, following the textual conventions
+func (r *resolver) resolveSyntax(mod *module, s *syntax) (string, map[int64]string) {
+	name, enums := s.name, s.enums
+	for depth := 0; depth < maxDepth; depth++ {
+		if base, ok := primitiveTypes[name]; ok {
+			return base, enums
+		}
+		t, tMod := r.findType(mod, name)
+		if t == nil {
+			return name, enums
+		}
+		// The named numbers of a refined type take precedence
+		if enums == nil {
+			enums = t.enums
+		}
+		name, mod = t.name, tMod
+	}
+	return name, enums
+}
+
+// findType finds a type defined or imported by the module, along with the module defining it
+func (r *resolver) findType(mod *module, name string) (*syntax, *module) {
+	if t, ok := mod.types[name]; ok {
+		return t, mod
+	}
+	if fromMod, ok := r.modules[mod.imports[name]]; ok {
+		if t, ok := fromMod.types[name]; ok {
+			return t, fromMod
+		}
+	}
+	return nil, nil
+}
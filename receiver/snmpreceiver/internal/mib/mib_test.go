@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	mibs, err := Load([]string{filepath.Join("testdata", "mibs")})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		ref         string
+		expectedOID string
+		expected    *Node
+		expectedErr string
+	}{
+		{
+			desc:        "qualified column",
+			ref:         "TEST-IF-MIB::ifInOctets",
+			expectedOID: "1.3.6.1.2.1.2.2.1.10",
+			expected: &Node{
+				Module: "TEST-IF-MIB",
+				Name:   "ifInOctets",
+				OID:    "1.3.6.1.2.1.2.2.1.10",
+				Type:   "Counter32",
+				Units:  "By",
+			},
+		},
+		{
+			desc:        "unqualified scalar instance",
+			ref:         "ifNumber.0",
+			expectedOID: "1.3.6.1.2.1.2.1.0",
+			expected: &Node{
+				Module: "TEST-IF-MIB",
+				Name:   "ifNumber",
+				OID:    "1.3.6.1.2.1.2.1",
+				Type:   "Integer32",
+				Scalar: true,
+			},
+		},
+		{
+			desc:        "enumeration of a textual convention",
+			ref:         "TEST-IF-MIB::ifOperStatus",
+			expectedOID: "1.3.6.1.2.1.2.2.1.8",
+			expected: &Node{
+				Module: "TEST-IF-MIB",
+				Name:   "ifOperStatus",
+				OID:    "1.3.6.1.2.1.2.2.1.8",
+				Type:   "INTEGER",
+				Enums:  map[int64]string{1: "up", 2: "down", 3: "testing"},
+			},
+		},
+		{
+			desc:        "built in textual convention",
+			ref:         "ifPromiscuousMode",
+			expectedOID: "1.3.6.1.2.1.31.1",
+			expected: &Node{
+				Module: "TEST-IF-MIB",
+				Name:   "ifPromiscuousMode",
+				OID:    "1.3.6.1.2.1.31.1",
+				Type:   "INTEGER",
+				Enums:  map[int64]string{1: "true", 2: "false"},
+				Scalar: true,
+			},
+		},
+		{
+			desc:        "SMIv1 object",
+			ref:         "TEST-ENTERPRISE-MIB::testTemperature.0",
+			expectedOID: "1.3.6.1.4.1.9999.1.0",
+			expected: &Node{
+				Module: "TEST-ENTERPRISE-MIB",
+				Name:   "testTemperature",
+				OID:    "1.3.6.1.4.1.9999.1",
+				Type:   "Gauge32",
+				Scalar: true,
+			},
+		},
+		{
+			desc:        "SMIv1 trap",
+			ref:         "testOverheat",
+			expectedOID: "1.3.6.1.4.1.9999.0.1",
+			expected: &Node{
+				Module: "TEST-ENTERPRISE-MIB",
+				Name:   "testOverheat",
+				OID:    "1.3.6.1.4.1.9999.0.1",
+			},
+		},
+		{
+			desc:        "numeric OID of an instance",
+			ref:         ".1.3.6.1.2.1.2.2.1.5.3",
+			expectedOID: ".1.3.6.1.2.1.2.2.1.5.3",
+			expected: &Node{
+				Module: "TEST-IF-MIB",
+				Name:   "ifSpeed",
+				OID:    "1.3.6.1.2.1.2.2.1.5",
+				Type:   "Gauge32",
+				Units:  "bits per second",
+			},
+		},
+		{
+			desc:        "numeric OID of an unknown object",
+			ref:         "0.1.2",
+			expectedOID: "0.1.2",
+		},
+		{
+			desc:        "ambiguous name",
+			ref:         "ifSpeed",
+			expectedErr: "ifSpeed is defined by several MIB modules",
+		},
+		{
+			desc:        "unknown name",
+			ref:         "TEST-IF-MIB::ifOutOctets",
+			expectedErr: "unknown MIB object TEST-IF-MIB::ifOutOctets",
+		},
+		{
+			desc:        "invalid index",
+			ref:         "ifNumber.a",
+			expectedErr: `invalid index "a" of ifNumber.a`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			oid, node, err := mibs.Resolve(tc.ref)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOID, oid)
+			assert.Equal(t, tc.expected, node)
+		})
+	}
+}
+
+func TestLoadInvalidMIBs(t *testing.T) {
+	mibs, err := Load([]string{filepath.Join("testdata", "mibs"), filepath.Join("testdata", "invalid")})
+	require.NoError(t, err)
+
+	// The valid files are loaded anyway
+	oid, _, err := mibs.Resolve("TEST-IF-MIB::ifDescr")
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.6.1.2.1.2.2.1.2", oid)
+
+	_, _, err = mibs.Resolve("brokenObject")
+	assert.ErrorContains(t, err, "unknown MIB object brokenObject")
+	assert.ErrorContains(t, err, "BROKEN-MIB.txt: line 7")
+
+	_, _, err = mibs.Resolve("MISSING-IMPORT-MIB::acmeObject")
+	assert.ErrorContains(t, err, "module ACME-SMI imported by MISSING-IMPORT-MIB is not loaded")
+
+	_, err = Load([]string{filepath.Join("testdata", "missing")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,505 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mib // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// tokenize splits a MIB file into tokens, dropping the comments
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case c == '-' && i+1 < len(src) && src[i+1] == '-':
+			// A comment ends at the end of the line or at the next "--"
+			i += 2
+			for i < len(src) && src[i] != '\n' {
+				if src[i] == '-' && i+1 < len(src) && src[i+1] == '-' {
+					i += 2
+					break
+				}
+				i++
+			}
+		case c == '"':
+			end := strings.IndexByte(src[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			text := src[i+1 : i+1+end]
+			tokens = append(tokens, token{kind: tokenString, text: text, line: line})
+			line += strings.Count(text, "\n")
+			i += end + 2
+		case c == '\'':
+			// Binary or hexadecimal string, such as '00'H
+			end := strings.IndexByte(src[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", line)
+			}
+			j := i + end + 2
+			if j < len(src) && isLetter(src[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenString, text: src[i:j], line: line})
+			i = j
+		case isDigit(c) || (c == '-' && i+1 < len(src) && isDigit(src[i+1])):
+			j := i + 1
+			for j < len(src) && isDigit(src[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[i:j], line: line})
+			i = j
+		case isLetter(c):
+			j := i + 1
+			for j < len(src) && (isLetter(src[j]) || isDigit(src[j]) || src[j] == '_' ||
+				(src[j] == '-' && j+1 < len(src) && src[j+1] != '-')) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[i:j], line: line})
+			i = j
+		case strings.HasPrefix(src[i:], "::="):
+			tokens = append(tokens, token{kind: tokenSymbol, text: "::=", line: line})
+			i += 3
+		case strings.HasPrefix(src[i:], ".."):
+			tokens = append(tokens, token{kind: tokenSymbol, text: "..", line: line})
+			i += 2
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(c), line: line})
+			i++
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, line: line}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// syntax is the SYNTAX of an object, or the definition of a type
+type syntax struct {
+	// name is either a primitive type or the name of another type
+	name string
+	// enums are the named numbers of an enumerated INTEGER
+	enums map[int64]string
+}
+
+// definition is an object defined by a MIB module
+type definition struct {
+	name string
+	// parent is the name of the object the OID of this object is relative to, if any
+	parent string
+	// subIDs are appended to the OID of the parent, or form the OID if there is no parent
+	subIDs []string
+	syntax *syntax
+	units  string
+	// objectType is set for the OBJECT-TYPE definitions
+	objectType bool
+}
+
+// module is a parsed MIB module
+type module struct {
+	name string
+	// imports maps the imported symbols to the module they are imported from
+	imports     map[string]string
+	definitions map[string]*definition
+	types       map[string]*syntax
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseModules parses the modules of a MIB file
+func parseModules(src string) ([]*module, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	var modules []*module
+	for p.peek().kind != tokenEOF {
+		m, err := p.parseModule()
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, m)
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no module definition")
+	}
+	return modules, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.next(); t.text != text {
+		return p.errorf(t, "expected %q, got %q", text, t.text)
+	}
+	return nil
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", t.line, fmt.Sprintf(format, args...))
+}
+
+// skipUntil skips the tokens until the given one, which is consumed
+func (p *parser) skipUntil(text string) error {
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "expected %q before the end of the file", text)
+		case t.text == text && t.kind != tokenString:
+			return nil
+		}
+	}
+}
+
+// skipBlock skips a block of tokens starting with an opening bracket, and ending with the matching closing one
+func (p *parser) skipBlock() error {
+	open := p.next()
+	closing := map[string]string{"{": "}", "(": ")", "[": "]"}[open.text]
+	depth := 1
+	for depth > 0 {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(open, "unterminated %q", open.text)
+		case t.kind != tokenSymbol:
+		case t.text == open.text:
+			depth++
+		case t.text == closing:
+			depth--
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseModule() (*module, error) {
+	name := p.next()
+	if name.kind != tokenIdent {
+		return nil, p.errorf(name, "expected a module name, got %q", name.text)
+	}
+	if err := p.expect("DEFINITIONS"); err != nil {
+		return nil, err
+	}
+	if err := p.skipUntil("::="); err != nil {
+		return nil, err
+	}
+	if err := p.expect("BEGIN"); err != nil {
+		return nil, err
+	}
+
+	m := &module{
+		name:        name.text,
+		imports:     map[string]string{},
+		definitions: map[string]*definition{},
+		types:       map[string]*syntax{},
+	}
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return nil, p.errorf(t, "module %s is not terminated by END", m.name)
+		case t.text == "END":
+			return m, nil
+		case t.text == "IMPORTS":
+			if err := p.parseImports(m); err != nil {
+				return nil, err
+			}
+		case t.text == "EXPORTS":
+			if err := p.skipUntil(";"); err != nil {
+				return nil, err
+			}
+		case t.kind == tokenIdent:
+			if err := p.parseAssignment(m, t.text); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.errorf(t, "unexpected %q", t.text)
+		}
+	}
+}
+
+func (p *parser) parseImports(m *module) error {
+	var symbols []string
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "IMPORTS is not terminated by ';'")
+		case t.text == ";":
+			return nil
+		case t.text == "FROM":
+			from := p.next()
+			for _, symbol := range symbols {
+				m.imports[symbol] = from.text
+			}
+			symbols = nil
+		case t.kind == tokenIdent:
+			symbols = append(symbols, t.text)
+		}
+	}
+}
+
+func (p *parser) parseAssignment(m *module, name string) error {
+	switch p.peek().text {
+	case "MACRO":
+		return p.skipUntil("END")
+	case "::=":
+		p.next()
+		s, err := p.parseTypeAssignment()
+		if err != nil {
+			return err
+		}
+		m.types[name] = s
+		return nil
+	}
+
+	d, err := p.parseValueAssignment(name)
+	if err != nil {
+		return err
+	}
+	if d != nil {
+		m.definitions[name] = d
+	}
+	return nil
+}
+
+// parseTypeAssignment parses a type, or a TEXTUAL-CONVENTION
+func (p *parser) parseTypeAssignment() (*syntax, error) {
+	if p.peek().text == "TEXTUAL-CONVENTION" {
+		// The SYNTAX clause is the last clause of a TEXTUAL-CONVENTION
+		if err := p.skipUntil("SYNTAX"); err != nil {
+			return nil, err
+		}
+	}
+	return p.parseSyntax()
+}
+
+// parseValueAssignment parses the definition of an object, such as an OBJECT-TYPE or an OBJECT IDENTIFIER.
+// It returns nil for the values which aren't objects.
+func (p *parser) parseValueAssignment(name string) (*definition, error) {
+	d := &definition{name: name}
+	var enterprise string
+	switch kind := p.next(); kind.text {
+	case "OBJECT":
+		if err := p.expect("IDENTIFIER"); err != nil {
+			return nil, err
+		}
+	case "OBJECT-TYPE":
+		d.objectType = true
+	}
+
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF || t.text == "END":
+			return nil, p.errorf(t, "definition of %s is not terminated", name)
+		case t.text == "SYNTAX":
+			p.next()
+			s, err := p.parseSyntax()
+			if err != nil {
+				return nil, err
+			}
+			d.syntax = s
+		case t.text == "UNITS":
+			p.next()
+			d.units = p.next().text
+		case t.text == "ENTERPRISE":
+			p.next()
+			enterprise = p.next().text
+		case t.text == "{" || t.text == "(":
+			if err := p.skipBlock(); err != nil {
+				return nil, err
+			}
+		case t.text == "::=":
+			p.next()
+			if p.peek().text != "{" {
+				// The value of a SMIv1 TRAP-TYPE is its specific trap number
+				value := p.next()
+				if enterprise == "" || value.kind != tokenNumber {
+					return nil, nil
+				}
+				d.parent, d.subIDs = enterprise, []string{"0", value.text}
+				return d, nil
+			}
+			if err := p.parseOIDValue(d); err != nil {
+				return nil, err
+			}
+			return d, nil
+		default:
+			p.next()
+		}
+	}
+}
+
+// parseOIDValue parses an OID value, such as { ifEntry 1 } or { iso org(3) dod(6) 1 }
+func (p *parser) parseOIDValue(d *definition) error {
+	open := p.next()
+	for first := true; ; first = false {
+		t := p.next()
+		switch {
+		case t.text == "}":
+			if d.parent == "" && len(d.subIDs) == 0 {
+				return p.errorf(open, "empty OID value of %s", d.name)
+			}
+			return nil
+		case t.kind == tokenNumber:
+			d.subIDs = append(d.subIDs, t.text)
+		case t.kind == tokenIdent:
+			// A name is followed by its number, unless it is the parent the OID is relative to
+			if p.peek().text != "(" {
+				if !first {
+					return p.errorf(t, "unexpected name %q in the OID value of %s", t.text, d.name)
+				}
+				d.parent = t.text
+				continue
+			}
+			p.next()
+			number := p.next()
+			if number.kind != tokenNumber {
+				return p.errorf(number, "expected a number, got %q", number.text)
+			}
+			if err := p.expect(")"); err != nil {
+				return err
+			}
+			d.subIDs = append(d.subIDs, number.text)
+		default:
+			return p.errorf(t, "unexpected %q in the OID value of %s", t.text, d.name)
+		}
+	}
+}
+
+// parseSyntax parses a type, such as Counter32, INTEGER { up(1), down(2) } or OCTET STRING (SIZE (0..255))
+func (p *parser) parseSyntax() (*syntax, error) {
+	s := &syntax{}
+	if p.peek().text == "[" {
+		if err := p.skipBlock(); err != nil {
+			return nil, err
+		}
+	}
+	if t := p.peek().text; t == "IMPLICIT" || t == "EXPLICIT" {
+		p.next()
+	}
+
+	t := p.next()
+	switch t.text {
+	case "OCTET":
+		if err := p.expect("STRING"); err != nil {
+			return nil, err
+		}
+		s.name = "OCTET STRING"
+	case "OBJECT":
+		if err := p.expect("IDENTIFIER"); err != nil {
+			return nil, err
+		}
+		s.name = "OBJECT IDENTIFIER"
+	case "SEQUENCE":
+		if p.peek().text == "OF" {
+			p.next()
+			p.next()
+			return &syntax{name: "SEQUENCE OF"}, nil
+		}
+		s.name = "SEQUENCE"
+	case "CHOICE":
+		s.name = "CHOICE"
+	default:
+		if t.kind != tokenIdent {
+			return nil, p.errorf(t, "expected a type, got %q", t.text)
+		}
+		s.name = t.text
+	}
+
+	if p.peek().text == "{" {
+		if s.name == "SEQUENCE" || s.name == "CHOICE" {
+			if err := p.skipBlock(); err != nil {
+				return nil, err
+			}
+		} else {
+			enums, err := p.parseNamedNumbers()
+			if err != nil {
+				return nil, err
+			}
+			s.enums = enums
+		}
+	}
+	if p.peek().text == "(" {
+		if err := p.skipBlock(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// parseNamedNumbers parses the named numbers of an enumerated INTEGER or BITS
+func (p *parser) parseNamedNumbers() (map[int64]string, error) {
+	p.next()
+	enums := map[int64]string{}
+	for {
+		t := p.next()
+		switch {
+		case t.text == "}":
+			return enums, nil
+		case t.text == ",":
+		case t.kind == tokenIdent:
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			number := p.next()
+			value, err := strconv.ParseInt(number.text, 10, 64)
+			if err != nil {
+				return nil, p.errorf(number, "invalid number %q of %s", number.text, t.text)
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			enums[value] = t.text
+		default:
+			return nil, p.errorf(t, "unexpected %q in named numbers", t.text)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+)
+
+var (
+	// MIB error messages
+	errMsgMIBMetricOID            = `metric '%s' oid '%s' could not be resolved: %w`
+	errMsgMIBAttributeOID         = `attribute '%s' oid '%s' could not be resolved: %w`
+	errMsgMIBResourceAttributeOID = `resource_attribute '%s' oid '%s' could not be resolved: %w`
+	errMsgMIBTrapDefinitionOID    = `trap definition '%s' oid '%s' could not be resolved: %w`
+	errMsgMIBTrapVarbindOID       = `trap varbind '%s' oid '%s' could not be resolved: %w`
+)
+
+// integerTypes are the SMI types of the objects the client returns integer values for
+var integerTypes = map[string]bool{
+	"INTEGER":    true,
+	"Integer32":  true,
+	"Unsigned32": true,
+	"Gauge32":    true,
+	"TimeTicks":  true,
+	"Counter32":  true,
+	"Counter64":  true,
+}
+
+// resolveMIBNames replaces the symbolic OIDs of the config, such as IF-MIB::ifHCInOctets,
+// with their numeric OIDs. The units, data types and enums the config doesn't specify are
+// inferred from the MIB definitions of the objects.
+func resolveMIBNames(cfg *Config, mibs *mib.MIBs) error {
+	var combinedErr error
+
+	for name, metricCfg := range cfg.Metrics {
+		var nodes []*mib.Node
+		for i, scalarOID := range metricCfg.ScalarOIDs {
+			oid, node, err := resolveOID(mibs, scalarOID.OID, true)
+			if err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMIBMetricOID, name, scalarOID.OID, err))
+				continue
+			}
+			metricCfg.ScalarOIDs[i].OID = oid
+			nodes = append(nodes, node)
+		}
+		for i, columnOID := range metricCfg.ColumnOIDs {
+			oid, node, err := resolveOID(mibs, columnOID.OID, false)
+			if err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMIBMetricOID, name, columnOID.OID, err))
+				continue
+			}
+			metricCfg.ColumnOIDs[i].OID = oid
+			nodes = append(nodes, node)
+		}
+		inferMetricConfig(metricCfg, nodes)
+	}
+
+	for name, attrCfg := range cfg.Attributes {
+		oid, node, err := resolveOID(mibs, attrCfg.OID, false)
+		if err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMIBAttributeOID, name, attrCfg.OID, err))
+			continue
+		}
+		attrCfg.OID = oid
+		if node != nil {
+			attrCfg.enums = node.Enums
+		}
+	}
+
+	for name, resourceAttrCfg := range cfg.ResourceAttributes {
+		oid, node, err := resolveOID(mibs, resourceAttrCfg.OID, false)
+		if err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMIBResourceAttributeOID, name, resourceAttrCfg.OID, err))
+			continue
+		}
+		scalarOID, scalarNode, err := resolveOID(mibs, resourceAttrCfg.ScalarOID, true)
+		if err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMIBResourceAttributeOID, name, resourceAttrCfg.ScalarOID, err))
+			continue
+		}
+		resourceAttrCfg.OID, resourceAttrCfg.ScalarOID = oid, scalarOID
+		switch {
+		case node != nil:
+			resourceAttrCfg.enums = node.Enums
+		case scalarNode != nil:
+			resourceAttrCfg.enums = scalarNode.Enums
+		}
+	}
+
+	if cfg.Traps != nil {
+		for name, definition := range cfg.Traps.Definitions {
+			oid, _, err := resolveOID(mibs, definition.OID, false)
+			if err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMIBTrapDefinitionOID, name, definition.OID, err))
+				continue
+			}
+			definition.OID = oid
+		}
+		for name, varbind := range cfg.Traps.Varbinds {
+			oid, _, err := resolveOID(mibs, varbind.OID, false)
+			if err != nil {
+				combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgMIBTrapVarbindOID, name, varbind.OID, err))
+				continue
+			}
+			varbind.OID = oid
+		}
+	}
+
+	return combinedErr
+}
+
+// resolveOID resolves a symbolic or numeric OID. The instance of a scalar object
+// is requested if its name isn't followed by an index.
+func resolveOID(mibs *mib.MIBs, ref string, instance bool) (string, *mib.Node, error) {
+	if ref == "" {
+		return "", nil, nil
+	}
+	oid, node, err := mibs.Resolve(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	if instance && !mib.IsNumeric(ref) && node.Scalar && oid == node.OID {
+		oid += ".0"
+	}
+	return oid, node, nil
+}
+
+// inferMetricConfig sets the unit and data type of a metric config from the
+// MIB definitions of its objects, unless they are configured
+func inferMetricConfig(metricCfg *MetricConfig, nodes []*mib.Node) {
+	var node *mib.Node
+	for _, n := range nodes {
+		if n != nil && n.Type != "" {
+			node = n
+			break
+		}
+	}
+	if node == nil {
+		return
+	}
+
+	if metricCfg.Unit == "" {
+		metricCfg.Unit = node.Units
+		if metricCfg.Unit == "" {
+			metricCfg.Unit = "1"
+		}
+	}
+	if !integerTypes[node.Type] {
+		return
+	}
+
+	switch {
+	case metricCfg.Gauge == nil && metricCfg.Sum == nil:
+		if node.Type == "Counter32" || node.Type == "Counter64" {
+			metricCfg.Sum = &SumMetric{
+				Aggregation: "cumulative",
+				Monotonic:   true,
+				ValueType:   "int",
+			}
+		} else {
+			metricCfg.Gauge = &GaugeMetric{
+				ValueType: "int",
+			}
+		}
+	case metricCfg.Gauge != nil && metricCfg.Gauge.ValueType == "":
+		metricCfg.Gauge.ValueType = "int"
+	case metricCfg.Sum != nil && metricCfg.Sum.ValueType == "":
+		metricCfg.Sum.ValueType = "int"
+	}
+}
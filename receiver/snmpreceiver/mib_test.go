@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/mib"
+)
+
+func loadTestMIBs(t *testing.T) *mib.MIBs {
+	mibs, err := mib.Load([]string{filepath.Join("internal", "mib", "testdata", "mibs")})
+	require.NoError(t, err)
+	return mibs
+}
+
+func TestResolveMIBNames(t *testing.T) {
+	cfg := &Config{
+		ResourceAttributes: map[string]*ResourceAttributeConfig{
+			"ra1": {
+				ScalarOID: "TEST-IF-MIB::ifPromiscuousMode",
+			},
+		},
+		Metrics: map[string]*MetricConfig{
+			"m1": {
+				Unit: "{degrees}",
+				Gauge: &GaugeMetric{
+					ValueType: "double",
+				},
+				ScalarOIDs: []ScalarOID{
+					{
+						OID:                "TEST-ENTERPRISE-MIB::testTemperature",
+						ResourceAttributes: []string{"ra1"},
+					},
+				},
+			},
+			"m2": {
+				Sum: &SumMetric{
+					Aggregation: "delta",
+				},
+				ColumnOIDs: []ColumnOID{
+					{
+						OID: "1.3.6.1.2.1.2.2.1.10",
+					},
+				},
+			},
+			"m3": {
+				Unit: "By",
+				Gauge: &GaugeMetric{
+					ValueType: "int",
+				},
+				ScalarOIDs: []ScalarOID{
+					{
+						OID: "1.3.6.1.4.1.1.0",
+					},
+				},
+			},
+		},
+		Traps: &TrapsConfig{
+			Definitions: map[string]*TrapDefinitionConfig{
+				"overheat": {
+					OID: "testOverheat",
+				},
+				"linkDown": {
+					OID: "TEST-IF-MIB::linkDown",
+				},
+			},
+			Varbinds: map[string]*VarbindConfig{
+				"temperature": {
+					OID: "TEST-ENTERPRISE-MIB::testTemperature",
+				},
+			},
+		},
+	}
+
+	require.NoError(t, resolveMIBNames(cfg, loadTestMIBs(t)))
+
+	assert.Equal(t, "1.3.6.1.2.1.31.1.0", cfg.ResourceAttributes["ra1"].ScalarOID)
+	assert.Equal(t, map[int64]string{1: "true", 2: "false"}, cfg.ResourceAttributes["ra1"].enums)
+
+	// The configured unit and data type are kept
+	assert.Equal(t, "1.3.6.1.4.1.9999.1.0", cfg.Metrics["m1"].ScalarOIDs[0].OID)
+	assert.Equal(t, "{degrees}", cfg.Metrics["m1"].Unit)
+	assert.Equal(t, &GaugeMetric{ValueType: "double"}, cfg.Metrics["m1"].Gauge)
+
+	// The numeric OIDs are looked up too
+	assert.Equal(t, "1.3.6.1.2.1.2.2.1.10", cfg.Metrics["m2"].ColumnOIDs[0].OID)
+	assert.Equal(t, "By", cfg.Metrics["m2"].Unit)
+	assert.Equal(t, &SumMetric{Aggregation: "delta", ValueType: "int"}, cfg.Metrics["m2"].Sum)
+
+	// The OIDs unknown to the MIBs are kept as is
+	assert.Equal(t, "1.3.6.1.4.1.1.0", cfg.Metrics["m3"].ScalarOIDs[0].OID)
+	assert.Equal(t, "By", cfg.Metrics["m3"].Unit)
+
+	assert.Equal(t, "1.3.6.1.4.1.9999.0.1", cfg.Traps.Definitions["overheat"].OID)
+	assert.Equal(t, "1.3.6.1.2.1.31.2", cfg.Traps.Definitions["linkDown"].OID)
+	assert.Equal(t, "1.3.6.1.4.1.9999.1", cfg.Traps.Varbinds["temperature"].OID)
+}
+
+func TestResolveMIBNamesErrors(t *testing.T) {
+	cfg := &Config{
+		Attributes: map[string]*AttributeConfig{
+			"a1": {
+				OID: "ifSpeed",
+			},
+		},
+		Metrics: map[string]*MetricConfig{
+			"m1": {
+				ColumnOIDs: []ColumnOID{
+					{
+						OID: "TEST-IF-MIB::ifOutOctets",
+					},
+				},
+			},
+		},
+	}
+
+	err := resolveMIBNames(cfg, loadTestMIBs(t))
+	assert.ErrorContains(t, err, "attribute 'a1' oid 'ifSpeed' could not be resolved: ifSpeed is defined by several MIB modules")
+	assert.ErrorContains(t, err, "metric 'm1' oid 'TEST-IF-MIB::ifOutOctets' could not be resolved: unknown MIB object TEST-IF-MIB::ifOutOctets")
+}
+
+func TestIndexedDataToAttributeEnums(t *testing.T) {
+	cfg := &Config{
+		Attributes: map[string]*AttributeConfig{
+			"a1": {
+				OID:   "1.3.6.1.2.1.2.2.1.8",
+				enums: map[int64]string{1: "up", 2: "down"},
+			},
+		},
+	}
+	configHelper := newConfigHelper(cfg)
+
+	values := map[string]indexedAttributeValues{}
+	for index, value := range map[string]int64{"1": 1, "2": 2, "3": 3} {
+		data := SNMPData{
+			columnOID: ".1.3.6.1.2.1.2.2.1.8",
+			oid:       ".1.3.6.1.2.1.2.2.1.8." + index,
+			value:     value,
+			valueType: integerVal,
+		}
+		require.NoError(t, indexedDataToAttribute(data, configHelper, values))
+	}
+
+	// The values the MIB doesn't name are kept as is
+	assert.Equal(t, indexedAttributeValues{".1": "up", ".2": "down", ".3": "3"}, values[".1.3.6.1.2.1.2.2.1.8"])
+}
@@ -105,7 +105,7 @@ func (s *snmpScraper) scrapeScalarMetrics(
 		return
 	}
 	// Retrieve scalar OID SNMP data for resource attributes
-	scalarResourceAttributes := s.scrapeScalarResourceAttributes(configHelper.getResourceAttributeScalarOIDs(), scraperErrors)
+	scalarResourceAttributes := s.scrapeScalarResourceAttributes(configHelper.getResourceAttributeScalarOIDs(), configHelper, scraperErrors)
 
 	// For each piece of SNMP data, attempt to create the necessary OTEL structures (resources/metrics/datapoints)
 	for _, data := range scalarData {
@@ -130,13 +130,13 @@ func (s *snmpScraper) scrapeIndexedMetrics(
 	}
 
 	// Retrieve column OID SNMP indexed data for attributes
-	columnOIDIndexedAttributeValues := s.scrapeIndexedAttributes(configHelper.getAttributeColumnOIDs(), scraperErrors)
+	columnOIDIndexedAttributeValues := s.scrapeIndexedAttributes(configHelper.getAttributeColumnOIDs(), configHelper, scraperErrors)
 
 	// Retrieve column OID SNMP indexed data for resource attributes
-	columnOIDIndexedResourceAttributeValues := s.scrapeIndexedAttributes(configHelper.getResourceAttributeColumnOIDs(), scraperErrors)
+	columnOIDIndexedResourceAttributeValues := s.scrapeIndexedAttributes(configHelper.getResourceAttributeColumnOIDs(), configHelper, scraperErrors)
 
 	// Retrieve scalar OID SNMP data for resource attributes
-	columnOIDScalarOIDResourceAttributeValues := s.scrapeScalarResourceAttributes(configHelper.getResourceAttributeScalarOIDs(), scraperErrors)
+	columnOIDScalarOIDResourceAttributeValues := s.scrapeScalarResourceAttributes(configHelper.getResourceAttributeScalarOIDs(), configHelper, scraperErrors)
 
 	// Retrieve all SNMP indexed data from column metric OIDs
 	indexedData := s.client.GetIndexedData(metricColumnOIDs, scraperErrors)
@@ -380,6 +380,7 @@ func getResourceAttributes(
 // config scalar OIDs and stores the returned data for later use by metrics
 func (s *snmpScraper) scrapeScalarResourceAttributes(
 	scalarOIDs []string,
+	configHelper *configHelper,
 	scraperErrors *scrapererror.ScrapeErrors,
 ) map[string]string {
 	scalarOIDAttributeValues := make(map[string]string, len(scalarOIDs))
@@ -394,7 +395,7 @@ func (s *snmpScraper) scrapeScalarResourceAttributes(
 
 	// For each piece of SNMP data, store the necessary info to help create resources later if needed
 	for _, data := range scalarData {
-		if err := scalarDataToResourceAttribute(data, scalarOIDAttributeValues); err != nil {
+		if err := scalarDataToResourceAttribute(data, configHelper, scalarOIDAttributeValues); err != nil {
 			scraperErrors.AddPartial(1, fmt.Errorf(errMsgScalarAttributeOIDProcessing, data.oid, err))
 		}
 	}
@@ -405,6 +406,7 @@ func (s *snmpScraper) scrapeScalarResourceAttributes(
 // (for a resource attribute) and store it in a map for later use
 func scalarDataToResourceAttribute(
 	data SNMPData,
+	configHelper *configHelper,
 	scalarOIDAttributeValues map[string]string,
 ) error {
 	// Get the string value of the SNMP data for the {resource} attribute value
@@ -416,7 +418,7 @@ func scalarDataToResourceAttribute(
 	case stringVal:
 		stringValue = data.value.(string)
 	case integerVal:
-		stringValue = strconv.FormatInt(data.value.(int64), 10)
+		stringValue = integerAttributeValue(data.oid, data.value.(int64), configHelper)
 	case floatVal:
 		stringValue = strconv.FormatFloat(data.value.(float64), 'f', 2, 64)
 	}
@@ -432,6 +434,7 @@ func scalarDataToResourceAttribute(
 // config column OIDs and stores the returned indexed data for later use by metrics
 func (s *snmpScraper) scrapeIndexedAttributes(
 	columnOIDs []string,
+	configHelper *configHelper,
 	scraperErrors *scrapererror.ScrapeErrors,
 ) map[string]indexedAttributeValues {
 	columnOIDIndexedAttributeValues := map[string]indexedAttributeValues{}
@@ -446,7 +449,7 @@ func (s *snmpScraper) scrapeIndexedAttributes(
 
 	// For each piece of SNMP data, store the necessary info to help create resources later if needed
 	for _, data := range indexedData {
-		if err := indexedDataToAttribute(data, columnOIDIndexedAttributeValues); err != nil {
+		if err := indexedDataToAttribute(data, configHelper, columnOIDIndexedAttributeValues); err != nil {
 			scraperErrors.AddPartial(1, fmt.Errorf(errMsgIndexedAttributeOIDProcessing, data.oid, data.columnOID, err))
 		}
 	}
@@ -459,6 +462,7 @@ func (s *snmpScraper) scrapeIndexedAttributes(
 // {resource} attribute config column OID and OID index)
 func indexedDataToAttribute(
 	data SNMPData,
+	configHelper *configHelper,
 	columnOIDIndexedAttributeValues map[string]indexedAttributeValues,
 ) error {
 	// Get the string value of the SNMP data for the {resource} attribute value
@@ -470,7 +474,7 @@ func indexedDataToAttribute(
 	case stringVal:
 		stringValue = data.value.(string)
 	case integerVal:
-		stringValue = strconv.FormatInt(data.value.(int64), 10)
+		stringValue = integerAttributeValue(data.columnOID, data.value.(int64), configHelper)
 	case floatVal:
 		stringValue = strconv.FormatFloat(data.value.(float64), 'f', 2, 64)
 	}
@@ -485,3 +489,12 @@ func indexedDataToAttribute(
 
 	return nil
 }
+
+// integerAttributeValue returns the {resource} attribute value of an integer, which is the name
+// of the integer if the MIB of the {resource} attribute OID enumerates its values
+func integerAttributeValue(oid string, value int64, configHelper *configHelper) string {
+	if name, ok := configHelper.getEnumName(oid, value); ok {
+		return name
+	}
+	return strconv.FormatInt(value, 10)
+}
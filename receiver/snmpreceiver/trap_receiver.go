@@ -0,0 +1,318 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+const (
+	// sysUpTimeOID and snmpTrapOIDOID are the OIDs of the first two varbinds of SNMPv2c and v3 traps,
+	// holding the uptime of the agent and the OID identifying the trap
+	sysUpTimeOID   = "1.3.6.1.2.1.1.3.0"
+	snmpTrapOIDOID = "1.3.6.1.6.3.1.1.4.1.0"
+	// snmpTrapsOID prefixes the OIDs of the generic SNMPv1 traps, as per RFC 3584
+	snmpTrapsOID = "1.3.6.1.6.3.1.1.5"
+	// enterpriseSpecificTrap is the generic trap value of the enterprise specific SNMPv1 traps
+	enterpriseSpecificTrap = 6
+
+	attributeTrapOID      = "snmp.trap.oid"
+	attributeTrapName     = "snmp.trap.name"
+	attributePDUType      = "snmp.pdu.type"
+	attributeVersion      = "snmp.version"
+	attributeAgentAddress = "snmp.agent.address"
+	attributeVarbinds     = "snmp.varbinds"
+)
+
+// trapSeverities maps the severities of the trap definitions to log severities
+var trapSeverities = map[string]plog.SeverityNumber{
+	"trace": plog.SeverityNumberTrace,
+	"debug": plog.SeverityNumberDebug,
+	"info":  plog.SeverityNumberInfo,
+	"warn":  plog.SeverityNumberWarn,
+	"error": plog.SeverityNumberError,
+	"fatal": plog.SeverityNumberFatal,
+}
+
+// trapDefinition is a known trap
+type trapDefinition struct {
+	name     string
+	severity string
+}
+
+// trapReceiver listens for SNMP traps and informs, and converts them to log records
+type trapReceiver struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	consumer  consumer.Logs
+	obsrecv   *receiverhelper.ObsReport
+	transport string
+	address   string
+
+	// definitions are the known traps by OID
+	definitions map[string]trapDefinition
+	// varbindNames are the names of the known varbinds by OID
+	varbindNames map[string]string
+	// converter converts the varbind values the same way as the polled values
+	converter *snmpClient
+
+	listener *gosnmp.TrapListener
+	wg       sync.WaitGroup
+}
+
+// newTrapReceiver creates a trap receiver
+// Relies on config being validated thoroughly
+func newTrapReceiver(params receiver.CreateSettings, cfg *Config, consumer consumer.Logs) (*trapReceiver, error) {
+	// Checked in config
+	trapsURL, _ := url.Parse(cfg.Traps.Endpoint)
+	transport := strings.ToLower(trapsURL.Scheme)
+
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             params.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &trapReceiver{
+		settings:     params,
+		config:       cfg,
+		consumer:     consumer,
+		obsrecv:      obsrecv,
+		transport:    transport,
+		address:      trapsURL.Host,
+		definitions:  make(map[string]trapDefinition, len(cfg.Traps.Definitions)),
+		varbindNames: make(map[string]string, len(cfg.Traps.Varbinds)),
+		converter:    &snmpClient{logger: params.Logger},
+	}
+	for name, definition := range cfg.Traps.Definitions {
+		r.definitions[normalizeOID(definition.OID)] = trapDefinition{
+			name:     name,
+			severity: strings.ToLower(definition.Severity),
+		}
+	}
+	for name, varbind := range cfg.Traps.Varbinds {
+		r.varbindNames[normalizeOID(varbind.OID)] = name
+	}
+	return r, nil
+}
+
+// Start starts listening for traps
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	listener := gosnmp.NewTrapListener()
+	listener.Params = r.listenerParams()
+	listener.OnNewTrap = r.handleTrap
+
+	errs := make(chan error, 1)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if err := listener.Listen(r.transport + "://" + r.address); err != nil {
+			r.settings.Logger.Error("failed to listen for traps", zap.String("endpoint", r.config.Traps.Endpoint), zap.Error(err))
+			errs <- err
+		}
+	}()
+
+	select {
+	case <-listener.Listening():
+		r.listener = listener
+		return nil
+	case err := <-errs:
+		return fmt.Errorf("failed to listen for traps on %s: %w", r.config.Traps.Endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps
+func (r *trapReceiver) Shutdown(_ context.Context) error {
+	if r.listener != nil {
+		r.listener.Close()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// listenerParams returns the gosnmp parameters used to decode and authenticate the traps
+func (r *trapReceiver) listenerParams() *gosnmp.GoSNMP {
+	params := &gosnmp.GoSNMP{
+		Version:   gosnmp.Version2c,
+		Community: r.config.Community,
+		Timeout:   r.config.Timeout,
+	}
+	if r.isV3() {
+		params.Version = gosnmp.Version3
+		params.SecurityModel = gosnmp.UserSecurityModel
+		params.MsgFlags, params.SecurityParameters = getV3SecurityParameters(r.config)
+	}
+	return params
+}
+
+func (r *trapReceiver) isV3() bool {
+	return strings.EqualFold(r.config.Version, "v3")
+}
+
+// handleTrap converts a received trap to a log record, and passes it to the next consumer
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if !r.accepts(packet) {
+		r.settings.Logger.Debug("dropping trap not matching the configured version, community or user",
+			zap.Stringer("source", addr))
+		return
+	}
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	logs := r.convertTrap(packet, addr, time.Now())
+	err := r.consumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(ctx, metadata.Type, logs.LogRecordCount(), err)
+	if err != nil {
+		r.settings.Logger.Error("failed to consume trap", zap.Error(err))
+	}
+}
+
+// accepts returns whether the trap is a trap or inform matching the configured version and community or user.
+// The authentication and privacy of SNMPv3 traps is checked by gosnmp.
+func (r *trapReceiver) accepts(packet *gosnmp.SnmpPacket) bool {
+	switch packet.PDUType { // nolint:exhaustive
+	case gosnmp.Trap, gosnmp.SNMPv2Trap, gosnmp.InformRequest:
+	default:
+		return false
+	}
+
+	if packet.Version != gosnmp.Version3 {
+		return !r.isV3() && packet.Community == r.config.Community
+	}
+	if !r.isV3() {
+		return false
+	}
+	securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	return ok && securityParams.UserName == r.config.User
+}
+
+// convertTrap converts a trap to a log record
+func (r *trapReceiver) convertTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr, now time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	timestamp := pcommon.NewTimestampFromTime(now)
+	record.SetObservedTimestamp(timestamp)
+	record.SetTimestamp(timestamp)
+
+	attrs := record.Attributes()
+	attrs.PutStr(attributeVersion, versionName(packet.Version))
+	if packet.PDUType == gosnmp.InformRequest {
+		attrs.PutStr(attributePDUType, "inform")
+	} else {
+		attrs.PutStr(attributePDUType, "trap")
+	}
+	if addr != nil {
+		attrs.PutStr(conventions.AttributeNetPeerIP, addr.IP.String())
+		attrs.PutInt(conventions.AttributeNetPeerPort, int64(addr.Port))
+	}
+
+	var trapOID string
+	if packet.PDUType == gosnmp.Trap {
+		trapOID = v1TrapOID(packet.SnmpTrap)
+		if packet.AgentAddress != "" {
+			attrs.PutStr(attributeAgentAddress, packet.AgentAddress)
+		}
+	}
+
+	varbinds := attrs.PutEmptyMap(attributeVarbinds)
+	for _, pdu := range packet.Variables {
+		oid := normalizeOID(pdu.Name)
+		switch oid {
+		case sysUpTimeOID:
+			continue
+		case snmpTrapOIDOID:
+			trapOID = normalizeOID(toString(pdu.Value))
+			continue
+		}
+		putVarbind(varbinds.PutEmpty(r.varbindName(oid)), r.converter.convertSnmpPDUToSnmpData(pdu))
+	}
+
+	attrs.PutStr(attributeTrapOID, trapOID)
+	definition, ok := r.definitions[trapOID]
+	if !ok {
+		record.Body().SetStr(trapOID)
+		return logs
+	}
+	attrs.PutStr(attributeTrapName, definition.name)
+	record.Body().SetStr(definition.name)
+	if definition.severity != "" {
+		record.SetSeverityNumber(trapSeverities[definition.severity])
+		record.SetSeverityText(strings.ToUpper(definition.severity))
+	}
+	return logs
+}
+
+// varbindName returns the name of the varbind with the given OID, or the OID itself
+// if it is not known. Varbinds of a known column OID are named after it.
+func (r *trapReceiver) varbindName(oid string) string {
+	for prefix := oid; prefix != ""; {
+		if name, ok := r.varbindNames[prefix]; ok {
+			return name
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return oid
+}
+
+// putVarbind sets the value of a varbind according to its type
+func putVarbind(dest pcommon.Value, data SNMPData) {
+	switch data.valueType {
+	case integerVal:
+		dest.SetInt(data.value.(int64))
+	case floatVal:
+		dest.SetDouble(data.value.(float64))
+	case stringVal:
+		dest.SetStr(data.value.(string))
+	default:
+		dest.SetStr(toString(data.value))
+	}
+}
+
+// v1TrapOID returns the OID identifying a SNMPv1 trap, as per RFC 3584
+func v1TrapOID(trap gosnmp.SnmpTrap) string {
+	if trap.GenericTrap != enterpriseSpecificTrap {
+		return fmt.Sprintf("%s.%d", snmpTrapsOID, trap.GenericTrap+1)
+	}
+	return fmt.Sprintf("%s.0.%d", normalizeOID(trap.Enterprise), trap.SpecificTrap)
+}
+
+func versionName(version gosnmp.SnmpVersion) string {
+	switch version {
+	case gosnmp.Version1:
+		return "v1"
+	case gosnmp.Version3:
+		return "v3"
+	default:
+		return "v2c"
+	}
+}
+
+// normalizeOID removes the leading dot of the OIDs returned by gosnmp
+func normalizeOID(oid string) string {
+	return strings.TrimPrefix(oid, ".")
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newTestTrapReceiver(t *testing.T, endpoint string, sink *consumertest.LogsSink) *trapReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traps = &TrapsConfig{
+		Endpoint: endpoint,
+		Definitions: map[string]*TrapDefinitionConfig{
+			"linkDown": {
+				OID:      "1.3.6.1.6.3.1.1.5.3",
+				Severity: "warn",
+			},
+			"myTrap": {
+				OID: "1.3.6.1.4.1.8072.2.3.0.1",
+			},
+		},
+		Varbinds: map[string]*VarbindConfig{
+			"ifIndex": {
+				OID: "1.3.6.1.2.1.2.2.1.1",
+			},
+		},
+	}
+	require.NoError(t, addMissingConfigDefaults(cfg))
+
+	r, err := newTrapReceiver(receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	return r
+}
+
+func TestConvertTrap(t *testing.T) {
+	r := newTestTrapReceiver(t, "", new(consumertest.LogsSink))
+	addr := &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1024}
+	now := time.Now()
+
+	testCases := []struct {
+		desc             string
+		packet           *gosnmp.SnmpPacket
+		expectedBody     string
+		expectedOID      string
+		expectedName     string
+		expectedType     string
+		expectedSev      plog.SeverityNumber
+		expectedVarbinds map[string]any
+	}{
+		{
+			desc: "v2c trap of a known OID",
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version2c,
+				Community: "public",
+				PDUType:   gosnmp.SNMPv2Trap,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: "." + sysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(100)},
+					{Name: "." + snmpTrapOIDOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+					{Name: ".1.3.6.1.2.1.2.2.1.1.2", Type: gosnmp.Integer, Value: 2},
+					{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: gosnmp.OctetString, Value: []byte("eth0")},
+				},
+			},
+			expectedBody: "linkDown",
+			expectedOID:  "1.3.6.1.6.3.1.1.5.3",
+			expectedName: "linkDown",
+			expectedType: "trap",
+			expectedSev:  plog.SeverityNumberWarn,
+			expectedVarbinds: map[string]any{
+				"ifIndex":               "2",
+				"1.3.6.1.2.1.2.2.1.2.2": "eth0",
+			},
+		},
+		{
+			desc: "v2c inform of an unknown OID",
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version2c,
+				Community: "public",
+				PDUType:   gosnmp.InformRequest,
+				Variables: []gosnmp.SnmpPDU{
+					{Name: "." + sysUpTimeOID, Type: gosnmp.TimeTicks, Value: uint32(100)},
+					{Name: "." + snmpTrapOIDOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.1.2"},
+				},
+			},
+			expectedBody:     "1.3.6.1.4.1.1.2",
+			expectedOID:      "1.3.6.1.4.1.1.2",
+			expectedType:     "inform",
+			expectedVarbinds: map[string]any{},
+		},
+		{
+			desc: "v1 generic trap",
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version1,
+				Community: "public",
+				PDUType:   gosnmp.Trap,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:   ".1.3.6.1.4.1.8072.3.2.10",
+					AgentAddress: "10.0.0.2",
+					GenericTrap:  2,
+				},
+			},
+			expectedBody:     "linkDown",
+			expectedOID:      "1.3.6.1.6.3.1.1.5.3",
+			expectedName:     "linkDown",
+			expectedType:     "trap",
+			expectedSev:      plog.SeverityNumberWarn,
+			expectedVarbinds: map[string]any{},
+		},
+		{
+			desc: "v1 enterprise specific trap",
+			packet: &gosnmp.SnmpPacket{
+				Version:   gosnmp.Version1,
+				Community: "public",
+				PDUType:   gosnmp.Trap,
+				SnmpTrap: gosnmp.SnmpTrap{
+					Enterprise:   ".1.3.6.1.4.1.8072.2.3",
+					AgentAddress: "10.0.0.2",
+					GenericTrap:  6,
+					SpecificTrap: 1,
+				},
+			},
+			expectedBody:     "myTrap",
+			expectedOID:      "1.3.6.1.4.1.8072.2.3.0.1",
+			expectedName:     "myTrap",
+			expectedType:     "trap",
+			expectedVarbinds: map[string]any{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.True(t, r.accepts(tc.packet))
+			logs := r.convertTrap(tc.packet, addr, now)
+			require.Equal(t, 1, logs.LogRecordCount())
+			record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+
+			assert.Equal(t, tc.expectedBody, record.Body().Str())
+			assert.Equal(t, tc.expectedSev, record.SeverityNumber())
+			assert.Equal(t, now.UnixNano(), record.Timestamp().AsTime().UnixNano())
+
+			attrs := record.Attributes().AsRaw()
+			assert.Equal(t, tc.expectedOID, attrs[attributeTrapOID])
+			assert.Equal(t, tc.expectedType, attrs[attributePDUType])
+			assert.Equal(t, "10.0.0.1", attrs["net.peer.ip"])
+			if tc.expectedName != "" {
+				assert.Equal(t, tc.expectedName, attrs[attributeTrapName])
+			} else {
+				assert.NotContains(t, attrs, attributeTrapName)
+			}
+			if tc.packet.Version == gosnmp.Version1 {
+				assert.Equal(t, "10.0.0.2", attrs[attributeAgentAddress])
+			}
+
+			varbinds, ok := record.Attributes().Get(attributeVarbinds)
+			require.True(t, ok)
+			for name, value := range tc.expectedVarbinds {
+				v, ok := varbinds.Map().Get(name)
+				require.True(t, ok, "missing varbind %s", name)
+				assert.Equal(t, value, v.AsString())
+			}
+			assert.Equal(t, len(tc.expectedVarbinds), varbinds.Map().Len())
+		})
+	}
+}
+
+func TestTrapReceiverAccepts(t *testing.T) {
+	r := newTestTrapReceiver(t, "", new(consumertest.LogsSink))
+
+	assert.False(t, r.accepts(&gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: "private",
+		PDUType:   gosnmp.SNMPv2Trap,
+	}), "community mismatch")
+	assert.False(t, r.accepts(&gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: "public",
+		PDUType:   gosnmp.GetRequest,
+	}), "not a trap")
+	assert.False(t, r.accepts(&gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		PDUType:            gosnmp.SNMPv2Trap,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "user"},
+	}), "version mismatch")
+
+	r.config.Version = "v3"
+	r.config.User = "user"
+	assert.True(t, r.accepts(&gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		PDUType:            gosnmp.SNMPv2Trap,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "user"},
+	}))
+	assert.False(t, r.accepts(&gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		PDUType:            gosnmp.SNMPv2Trap,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{UserName: "other"},
+	}), "user mismatch")
+}
+
+func TestTrapReceiverReceivesTraps(t *testing.T) {
+	// Find a free port to listen on
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, conn.Close())
+
+	sink := new(consumertest.LogsSink)
+	r := newTestTrapReceiver(t, "udp://127.0.0.1:"+strconv.Itoa(port), sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	sender := &gosnmp.GoSNMP{
+		Target:    "127.0.0.1",
+		Port:      uint16(port),
+		Community: "public",
+		Version:   gosnmp.Version2c,
+		Timeout:   time.Second,
+	}
+	require.NoError(t, sender.Connect())
+	defer sender.Conn.Close()
+
+	_, err = sender.SendTrap(gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: "." + snmpTrapOIDOID, Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+			{Name: ".1.3.6.1.2.1.2.2.1.1.2", Type: gosnmp.Integer, Value: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	record := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "linkDown", record.Body().Str())
+	ifIndex, ok := record.Attributes().AsRaw()[attributeVarbinds].(map[string]any)["ifIndex"]
+	require.True(t, ok)
+	assert.EqualValues(t, 2, ifIndex)
+}
@@ -45,6 +45,7 @@ type Query struct {
 	SQL                string      `mapstructure:"sql"`
 	Metrics            []MetricCfg `mapstructure:"metrics"`
 	Logs               []LogsCfg   `mapstructure:"logs"`
+	Traces             []TracesCfg `mapstructure:"traces"`
 	TrackingColumn     string      `mapstructure:"tracking_column"`
 	TrackingStartValue string      `mapstructure:"tracking_start_value"`
 }
@@ -54,14 +55,19 @@ func (q Query) Validate() error {
 	if q.SQL == "" {
 		errs = multierr.Append(errs, errors.New("'query.sql' cannot be empty"))
 	}
-	if len(q.Logs) == 0 && len(q.Metrics) == 0 {
-		errs = multierr.Append(errs, errors.New("at least one of 'query.logs' and 'query.metrics' must not be empty"))
+	if len(q.Logs) == 0 && len(q.Metrics) == 0 && len(q.Traces) == 0 {
+		errs = multierr.Append(errs, errors.New("at least one of 'query.logs', 'query.metrics' and 'query.traces' must not be empty"))
 	}
 	for _, logs := range q.Logs {
 		if err := logs.Validate(); err != nil {
 			errs = multierr.Append(errs, err)
 		}
 	}
+	for _, traces := range q.Traces {
+		if err := traces.Validate(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
 	for _, metric := range q.Metrics {
 		if err := metric.Validate(); err != nil {
 			errs = multierr.Append(errs, err)
@@ -71,7 +77,10 @@ func (q Query) Validate() error {
 }
 
 type LogsCfg struct {
-	BodyColumn string `mapstructure:"body_column"`
+	BodyColumn       string   `mapstructure:"body_column"`
+	AttributeColumns []string `mapstructure:"attribute_columns"`
+	TsColumn         string   `mapstructure:"ts_column"`
+	SeverityColumn   string   `mapstructure:"severity_column"`
 }
 
 func (config LogsCfg) Validate() error {
@@ -82,6 +91,32 @@ func (config LogsCfg) Validate() error {
 	return errs
 }
 
+type TracesCfg struct {
+	NameColumn          string   `mapstructure:"name_column"`
+	TraceIDColumn       string   `mapstructure:"trace_id_column"`
+	SpanIDColumn        string   `mapstructure:"span_id_column"`
+	ParentSpanIDColumn  string   `mapstructure:"parent_span_id_column"`
+	StartTsColumn       string   `mapstructure:"start_ts_column"`
+	EndTsColumn         string   `mapstructure:"end_ts_column"`
+	StatusColumn        string   `mapstructure:"status_column"`
+	StatusMessageColumn string   `mapstructure:"status_message_column"`
+	AttributeColumns    []string `mapstructure:"attribute_columns"`
+}
+
+func (config TracesCfg) Validate() error {
+	var errs error
+	if config.NameColumn == "" {
+		errs = multierr.Append(errs, errors.New("'name_column' must not be empty"))
+	}
+	if config.StartTsColumn == "" {
+		errs = multierr.Append(errs, errors.New("'start_ts_column' must not be empty"))
+	}
+	if config.EndTsColumn == "" {
+		errs = multierr.Append(errs, errors.New("'end_ts_column' must not be empty"))
+	}
+	return errs
+}
+
 type MetricCfg struct {
 	MetricName       string            `mapstructure:"metric_name"`
 	ValueColumn      string            `mapstructure:"value_column"`
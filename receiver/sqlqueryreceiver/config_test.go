@@ -98,7 +98,7 @@ func TestLoadConfig(t *testing.T) {
 		{
 			fname:        "config-invalid-missing-logs-metrics.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
-			errorMessage: "at least one of 'query.logs' and 'query.metrics' must not be empty",
+			errorMessage: "at least one of 'query.logs', 'query.metrics' and 'query.traces' must not be empty",
 		},
 		{
 			fname:        "config-invalid-missing-datasource.yaml",
@@ -122,7 +122,10 @@ func TestLoadConfig(t *testing.T) {
 						TrackingStartValue: "10",
 						Logs: []LogsCfg{
 							{
-								BodyColumn: "log_body",
+								BodyColumn:       "log_body",
+								AttributeColumns: []string{"log_source"},
+								TsColumn:         "log_time",
+								SeverityColumn:   "log_level",
 							},
 						},
 					},
@@ -134,6 +137,42 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "'body_column' must not be empty",
 		},
+		{
+			fname: "config-traces.yaml",
+			id:    component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: 10 * time.Second,
+					InitialDelay:       time.Second,
+				},
+				Driver:     "mydriver",
+				DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+				Queries: []Query{
+					{
+						SQL:                "select * from job_history where job_id > ?",
+						TrackingColumn:     "job_id",
+						TrackingStartValue: "10",
+						Traces: []TracesCfg{
+							{
+								NameColumn:          "job_name",
+								TraceIDColumn:       "trace_id",
+								SpanIDColumn:        "span_id",
+								StartTsColumn:       "started_at",
+								EndTsColumn:         "finished_at",
+								StatusColumn:        "outcome",
+								StatusMessageColumn: "error_message",
+								AttributeColumns:    []string{"job_id", "user_name"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			fname:        "config-traces-missing-columns.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'name_column' must not be empty; 'start_ts_column' must not be empty; 'end_ts_column' must not be empty",
+		},
 		{
 			fname:        "config-unnecessary-aggregation.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
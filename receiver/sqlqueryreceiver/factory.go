@@ -17,5 +17,6 @@ func NewFactory() receiver.Factory {
 		createDefaultConfig,
 		receiver.WithLogs(createLogsReceiverFunc(sql.Open, newDbClient), metadata.LogsStability),
 		receiver.WithMetrics(createMetricsReceiverFunc(sql.Open, newDbClient), metadata.MetricsStability),
+		receiver.WithTraces(createTracesReceiverFunc(sql.Open, newDbClient), metadata.TracesStability),
 	)
 }
@@ -10,4 +10,5 @@ const (
 	Type             = "sqlquery"
 	MetricsStability = component.StabilityLevelAlpha
 	LogsStability    = component.StabilityLevelDevelopment
+	TracesStability  = component.StabilityLevelDevelopment
 )
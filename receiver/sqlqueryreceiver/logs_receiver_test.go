@@ -9,7 +9,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
 )
 
 func TestLogsQueryReceiver_Collect(t *testing.T) {
@@ -20,7 +23,7 @@ func TestLogsQueryReceiver_Collect(t *testing.T) {
 			{{"col1": "42"}, {"col1": "63"}},
 		},
 	}
-	queryReceiver := logsQueryReceiver{
+	queryReceiver := pollingQueryReceiver{
 		client: fakeClient,
 		query: Query{
 			Logs: []LogsCfg{
@@ -30,7 +33,7 @@ func TestLogsQueryReceiver_Collect(t *testing.T) {
 			},
 		},
 	}
-	logs, err := queryReceiver.collect(context.Background())
+	logs, err := queryReceiver.collectLogs(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, logs)
 	assert.Equal(t, 2, logs.LogRecordCount())
@@ -49,3 +52,44 @@ func TestLogsQueryReceiver_Collect(t *testing.T) {
 		"Observed timestamps of all log records collected in a single scrape should be equal",
 	)
 }
+
+func TestLogsQueryReceiver_CollectWithAttributesTimestampAndSeverity(t *testing.T) {
+	fakeClient := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{
+				{"body": "connection refused", "source": "db-1", "ts": "2023-09-28T15:04:05Z", "level": "error"},
+				{"body": "started", "ts": "1695913445000000000", "level": "Info"},
+			},
+		},
+	}
+	queryReceiver := pollingQueryReceiver{
+		client: fakeClient,
+		logger: zap.NewNop(),
+		query: Query{
+			Logs: []LogsCfg{
+				{
+					BodyColumn:       "body",
+					AttributeColumns: []string{"source"},
+					TsColumn:         "ts",
+					SeverityColumn:   "level",
+				},
+			},
+		},
+	}
+	logs, err := queryReceiver.collectLogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	logRecord := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "connection refused", logRecord.Body().Str())
+	assert.Equal(t, map[string]any{"source": "db-1"}, logRecord.Attributes().AsRaw())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Date(2023, 9, 28, 15, 4, 5, 0, time.UTC)), logRecord.Timestamp())
+	assert.Equal(t, "error", logRecord.SeverityText())
+	assert.Equal(t, plog.SeverityNumberError, logRecord.SeverityNumber())
+
+	logRecord = logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1)
+	assert.Equal(t, 0, logRecord.Attributes().Len())
+	assert.Equal(t, pcommon.Timestamp(1695913445000000000), logRecord.Timestamp())
+	assert.Equal(t, "Info", logRecord.SeverityText())
+	assert.Equal(t, plog.SeverityNumberInfo, logRecord.SeverityNumber())
+}
@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+)
+
+// pollingReceiver runs the queries of a signal at each collection interval. It is shared by the
+// logs and traces receivers, which only convert the rows and send them to their next consumer.
+type pollingReceiver struct {
+	config           *Config
+	settings         receiver.CreateSettings
+	createConnection dbProviderFunc
+	createClient     clientProviderFunc
+	queryReceivers   []*pollingQueryReceiver
+
+	isStarted                bool
+	collectionIntervalTicker *time.Ticker
+	shutdownRequested        chan struct{}
+
+	id            component.ID
+	storageClient storage.Client
+	obsrecv       *receiverhelper.ObsReport
+
+	// hasSignal tells whether the query produces the signal of the receiver.
+	hasSignal func(query Query) bool
+	// storageClientName is the name of the storage client of the signal. The logs and traces receivers
+	// cannot share a client, a file storage client locking its file for instance.
+	storageClientName string
+	// trackingValueStorageKey returns the storage key of the tracking value of a query.
+	trackingValueStorageKey func(queryID string) string
+	// collectFunc runs the queries and sends their results to the next consumer.
+	collectFunc func()
+}
+
+func newPollingReceiver(
+	config *Config,
+	settings receiver.CreateSettings,
+	sqlOpenerFunc sqlOpenerFunc,
+	createClient clientProviderFunc,
+	hasSignal func(query Query) bool,
+	storageClientName string,
+	trackingValueStorageKey func(queryID string) string,
+) (*pollingReceiver, error) {
+
+	obsr, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	receiver := &pollingReceiver{
+		config:   config,
+		settings: settings,
+		createConnection: func() (*sql.DB, error) {
+			return sqlOpenerFunc(config.Driver, config.DataSource)
+		},
+		createClient:            createClient,
+		shutdownRequested:       make(chan struct{}),
+		id:                      settings.ID,
+		obsrecv:                 obsr,
+		hasSignal:               hasSignal,
+		storageClientName:       storageClientName,
+		trackingValueStorageKey: trackingValueStorageKey,
+	}
+
+	return receiver, nil
+}
+
+func (receiver *pollingReceiver) Start(ctx context.Context, host component.Host) error {
+	if receiver.isStarted {
+		receiver.settings.Logger.Debug("requested start, but already started, ignoring.")
+		return nil
+	}
+	receiver.settings.Logger.Debug("starting...")
+	receiver.isStarted = true
+
+	var err error
+	receiver.storageClient, err = adapter.GetNamedStorageClient(ctx, host, receiver.config.StorageID, receiver.settings.ID, receiver.storageClientName)
+	if err != nil {
+		return fmt.Errorf("error connecting to storage: %w", err)
+	}
+
+	receiver.createQueryReceivers()
+
+	for _, queryReceiver := range receiver.queryReceivers {
+		err := queryReceiver.start(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	receiver.startCollecting()
+	receiver.settings.Logger.Debug("started.")
+	return nil
+}
+
+func (receiver *pollingReceiver) createQueryReceivers() {
+	receiver.queryReceivers = nil
+	for i, query := range receiver.config.Queries {
+		if !receiver.hasSignal(query) {
+			continue
+		}
+		id := fmt.Sprintf("query-%d: %s", i, query.SQL)
+		queryReceiver := newPollingQueryReceiver(
+			id,
+			query,
+			receiver.createConnection,
+			receiver.createClient,
+			receiver.settings.Logger,
+			receiver.storageClient,
+			receiver.trackingValueStorageKey(id),
+		)
+		receiver.queryReceivers = append(receiver.queryReceivers, queryReceiver)
+	}
+}
+
+func (receiver *pollingReceiver) startCollecting() {
+	receiver.collectionIntervalTicker = time.NewTicker(receiver.config.CollectionInterval)
+
+	go func() {
+		for {
+			select {
+			case <-receiver.collectionIntervalTicker.C:
+				receiver.collectFunc()
+			case <-receiver.shutdownRequested:
+				return
+			}
+		}
+	}()
+}
+
+func (receiver *pollingReceiver) Shutdown(ctx context.Context) error {
+	if !receiver.isStarted {
+		receiver.settings.Logger.Debug("Requested shutdown, but not started, ignoring.")
+		return nil
+	}
+
+	receiver.settings.Logger.Debug("stopping...")
+	receiver.stopCollecting()
+	for _, queryReceiver := range receiver.queryReceivers {
+		queryReceiver.shutdown(ctx)
+	}
+
+	var errors error
+	if receiver.storageClient != nil {
+		errors = multierr.Append(errors, receiver.storageClient.Close(ctx))
+	}
+
+	receiver.isStarted = false
+	receiver.settings.Logger.Debug("stopped.")
+
+	return errors
+}
+
+func (receiver *pollingReceiver) stopCollecting() {
+	if receiver.collectionIntervalTicker != nil {
+		receiver.collectionIntervalTicker.Stop()
+	}
+	close(receiver.shutdownRequested)
+}
+
+type pollingQueryReceiver struct {
+	id           string
+	query        Query
+	createDb     dbProviderFunc
+	createClient clientProviderFunc
+	logger       *zap.Logger
+
+	db            *sql.DB
+	client        dbClient
+	trackingValue string
+	// TODO: Extract persistence into its own component
+	storageClient           storage.Client
+	trackingValueStorageKey string
+}
+
+func newPollingQueryReceiver(
+	id string,
+	query Query,
+	dbProviderFunc dbProviderFunc,
+	clientProviderFunc clientProviderFunc,
+	logger *zap.Logger,
+	storageClient storage.Client,
+	trackingValueStorageKey string,
+) *pollingQueryReceiver {
+	queryReceiver := &pollingQueryReceiver{
+		id:                      id,
+		query:                   query,
+		createDb:                dbProviderFunc,
+		createClient:            clientProviderFunc,
+		logger:                  logger,
+		storageClient:           storageClient,
+		trackingValueStorageKey: trackingValueStorageKey,
+	}
+	queryReceiver.trackingValue = queryReceiver.query.TrackingStartValue
+	return queryReceiver
+}
+
+func (queryReceiver *pollingQueryReceiver) ID() string {
+	return queryReceiver.id
+}
+
+func (queryReceiver *pollingQueryReceiver) start(ctx context.Context) error {
+	var err error
+	queryReceiver.db, err = queryReceiver.createDb()
+	if err != nil {
+		return fmt.Errorf("failed to open db connection: %w", err)
+	}
+	queryReceiver.client = queryReceiver.createClient(dbWrapper{queryReceiver.db}, queryReceiver.query.SQL, queryReceiver.logger)
+
+	queryReceiver.trackingValue = queryReceiver.retrieveTrackingValue(ctx)
+
+	return nil
+}
+
+// retrieveTrackingValue retrieves the tracking value from storage, if storage is configured.
+// Otherwise, it returns the tracking value configured in `tracking_start_value`.
+func (queryReceiver *pollingQueryReceiver) retrieveTrackingValue(ctx context.Context) string {
+	trackingValueFromConfig := queryReceiver.query.TrackingStartValue
+	if queryReceiver.storageClient == nil {
+		return trackingValueFromConfig
+	}
+
+	storedTrackingValueBytes, err := queryReceiver.storageClient.Get(ctx, queryReceiver.trackingValueStorageKey)
+	if err != nil || storedTrackingValueBytes == nil {
+		return trackingValueFromConfig
+	}
+
+	return string(storedTrackingValueBytes)
+
+}
+
+// queryRows runs the query, passing it the tracking value if a tracking column is configured.
+func (queryReceiver *pollingQueryReceiver) queryRows(ctx context.Context) ([]stringMap, error) {
+	if queryReceiver.query.TrackingColumn != "" {
+		return queryReceiver.client.queryRows(ctx, queryReceiver.trackingValue)
+	}
+	return queryReceiver.client.queryRows(ctx)
+}
+
+func (queryReceiver *pollingQueryReceiver) storeTrackingValue(ctx context.Context, row stringMap) error {
+	if queryReceiver.query.TrackingColumn == "" {
+		return nil
+	}
+	queryReceiver.trackingValue = row[queryReceiver.query.TrackingColumn]
+	if queryReceiver.storageClient != nil {
+		err := queryReceiver.storageClient.Set(ctx, queryReceiver.trackingValueStorageKey, []byte(queryReceiver.trackingValue))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (queryReceiver *pollingQueryReceiver) shutdown(_ context.Context) {
+}
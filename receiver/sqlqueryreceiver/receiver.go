@@ -33,6 +33,18 @@ func createLogsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clie
 	}
 }
 
+func createTracesReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) receiver.CreateTracesFunc {
+	return func(
+		ctx context.Context,
+		settings receiver.CreateSettings,
+		config component.Config,
+		consumer consumer.Traces,
+	) (receiver.Traces, error) {
+		sqlQueryConfig := config.(*Config)
+		return newTracesReceiver(sqlQueryConfig, settings, sqlOpenerFunc, clientProviderFunc, consumer)
+	}
+}
+
 func createMetricsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) receiver.CreateMetricsFunc {
 	return func(
 		ctx context.Context,
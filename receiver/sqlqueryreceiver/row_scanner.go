@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/multierr"
 )
 
@@ -32,7 +34,7 @@ func newRowScanner(colTypes []colType) *rowScanner {
 			}
 			format := "%v"
 			if t, isTime := v.(time.Time); isTime {
-				return t.Format(time.RFC3339), nil
+				return t.Format(time.RFC3339Nano), nil
 			}
 			if reflect.TypeOf(v).Kind() == reflect.Slice {
 				// The Postgres driver returns a []uint8 (ascii string) for decimal and numeric types,
@@ -65,3 +67,16 @@ func (rs *rowScanner) toStringMap() (stringMap, error) {
 	}
 	return out, errs
 }
+
+// parseTimestamp parses the value of a timestamp column, either an integer number of nanoseconds since the epoch,
+// or a time formatted by the row scanner.
+func parseTimestamp(val string) (pcommon.Timestamp, error) {
+	if nanos, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return pcommon.Timestamp(nanos), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse timestamp, value was %q: %w", val, err)
+	}
+	return pcommon.NewTimestampFromTime(t), nil
+}
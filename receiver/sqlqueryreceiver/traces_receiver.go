@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
+)
+
+type tracesReceiver struct {
+	*pollingReceiver
+	nextConsumer consumer.Traces
+}
+
+func newTracesReceiver(
+	config *Config,
+	settings receiver.CreateSettings,
+	sqlOpenerFunc sqlOpenerFunc,
+	createClient clientProviderFunc,
+	nextConsumer consumer.Traces,
+) (*tracesReceiver, error) {
+	pollingReceiver, err := newPollingReceiver(config, settings, sqlOpenerFunc, createClient, hasTraces, string(component.DataTypeTraces), tracesTrackingValueStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver := &tracesReceiver{
+		pollingReceiver: pollingReceiver,
+		nextConsumer:    nextConsumer,
+	}
+	pollingReceiver.collectFunc = receiver.collect
+
+	return receiver, nil
+}
+
+func hasTraces(query Query) bool {
+	return len(query.Traces) > 0
+}
+
+// tracesTrackingValueStorageKey differs from the key of the logs queries, so that a query producing
+// both logs and traces keeps a tracking value for each signal even with a storage extension returning
+// the same client whatever its name.
+func tracesTrackingValueStorageKey(queryID string) string {
+	return fmt.Sprintf("%s.%s", queryID, "traces.trackingValue")
+}
+
+func (receiver *tracesReceiver) collect() {
+	tracesChannel := make(chan ptrace.Traces)
+	for _, queryReceiver := range receiver.queryReceivers {
+		go func(queryReceiver *pollingQueryReceiver) {
+			traces, err := queryReceiver.collectTraces(context.Background())
+			if err != nil {
+				receiver.settings.Logger.Error("error collecting traces", zap.Error(err), zap.String("query", queryReceiver.ID()))
+			}
+			tracesChannel <- traces
+		}(queryReceiver)
+	}
+
+	allTraces := ptrace.NewTraces()
+	for range receiver.queryReceivers {
+		traces := <-tracesChannel
+		traces.ResourceSpans().MoveAndAppendTo(allTraces.ResourceSpans())
+	}
+
+	spanCount := allTraces.SpanCount()
+	if spanCount > 0 {
+		ctx := receiver.obsrecv.StartTracesOp(context.Background())
+		err := receiver.nextConsumer.ConsumeTraces(context.Background(), allTraces)
+		receiver.obsrecv.EndTracesOp(ctx, metadata.Type, spanCount, err)
+		if err != nil {
+			receiver.settings.Logger.Error("failed to send traces", zap.Error(err))
+		}
+	}
+}
+
+func (queryReceiver *pollingQueryReceiver) collectTraces(ctx context.Context) (ptrace.Traces, error) {
+	traces := ptrace.NewTraces()
+
+	rows, err := queryReceiver.queryRows(ctx)
+	if err != nil {
+		return traces, fmt.Errorf("error getting rows: %w", err)
+	}
+
+	var errs error
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for tracesConfigIndex, tracesConfig := range queryReceiver.query.Traces {
+		for _, row := range rows {
+			span := ptrace.NewSpan()
+			if err := rowToSpan(row, tracesConfig, span); err != nil {
+				queryReceiver.logger.Warn("failed to convert row to span, skipping row", zap.Error(err), zap.String("query", queryReceiver.id))
+			} else {
+				span.MoveTo(spans.AppendEmpty())
+			}
+			if tracesConfigIndex == 0 {
+				errs = multierr.Append(errs, queryReceiver.storeTrackingValue(ctx, row))
+			}
+		}
+	}
+	return traces, errs
+}
+
+func rowToSpan(row stringMap, config TracesCfg, span ptrace.Span) error {
+	span.SetName(row[config.NameColumn])
+	span.SetKind(ptrace.SpanKindInternal)
+
+	traceID, err := parseTraceID(row[config.TraceIDColumn])
+	if err != nil {
+		return fmt.Errorf("trace_id_column %q: %w", config.TraceIDColumn, err)
+	}
+	span.SetTraceID(traceID)
+
+	spanID, err := parseSpanID(row[config.SpanIDColumn])
+	if err != nil {
+		return fmt.Errorf("span_id_column %q: %w", config.SpanIDColumn, err)
+	}
+	span.SetSpanID(spanID)
+
+	if val, found := row[config.ParentSpanIDColumn]; found && config.ParentSpanIDColumn != "" && val != "" {
+		var parentSpanID pcommon.SpanID
+		if err := decodeID(val, parentSpanID[:]); err != nil {
+			return fmt.Errorf("parent_span_id_column %q: %w", config.ParentSpanIDColumn, err)
+		}
+		span.SetParentSpanID(parentSpanID)
+	}
+
+	startTimestamp, err := parseTimestamp(row[config.StartTsColumn])
+	if err != nil {
+		return fmt.Errorf("start_ts_column %q: %w", config.StartTsColumn, err)
+	}
+	span.SetStartTimestamp(startTimestamp)
+	endTimestamp, err := parseTimestamp(row[config.EndTsColumn])
+	if err != nil {
+		return fmt.Errorf("end_ts_column %q: %w", config.EndTsColumn, err)
+	}
+	span.SetEndTimestamp(endTimestamp)
+
+	if status, found := row[config.StatusColumn]; found && config.StatusColumn != "" {
+		span.Status().SetCode(statusCode(status))
+		// the description must only be set for an error status
+		if span.Status().Code() == ptrace.StatusCodeError {
+			span.Status().SetMessage(row[config.StatusMessageColumn])
+		}
+	}
+
+	// the columns with a NULL value are missing from the row
+	for _, columnName := range config.AttributeColumns {
+		if attrVal, found := row[columnName]; found {
+			span.Attributes().PutStr(columnName, attrVal)
+		}
+	}
+	return nil
+}
+
+// parseTraceID decodes a hex encoded trace ID, or generates a random one when the value is empty,
+// for example when no trace_id_column is configured.
+func parseTraceID(val string) (pcommon.TraceID, error) {
+	var traceID pcommon.TraceID
+	if val == "" {
+		_, err := rand.Read(traceID[:])
+		return traceID, err
+	}
+	return traceID, decodeID(val, traceID[:])
+}
+
+// parseSpanID decodes a hex encoded span ID, or generates a random one when the value is empty,
+// for example when no span_id_column is configured.
+func parseSpanID(val string) (pcommon.SpanID, error) {
+	var spanID pcommon.SpanID
+	if val == "" {
+		_, err := rand.Read(spanID[:])
+		return spanID, err
+	}
+	return spanID, decodeID(val, spanID[:])
+}
+
+func decodeID(val string, dst []byte) error {
+	// UUID columns are a common way to store the IDs, so the dashes are ignored
+	val = strings.ReplaceAll(strings.TrimSpace(val), "-", "")
+	if hex.DecodedLen(len(val)) != len(dst) {
+		return fmt.Errorf("expected %d hex characters, value was %q", hex.EncodedLen(len(dst)), val)
+	}
+	if _, err := hex.Decode(dst, []byte(val)); err != nil {
+		return fmt.Errorf("failed to decode hex value %q: %w", val, err)
+	}
+	return nil
+}
+
+// statusCode maps the value of the status column to a span status code. The values `ok` and `error`
+// (and their usual synonyms) are recognized, any other value leaves the status unset.
+func statusCode(status string) ptrace.StatusCode {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "ok", "success", "succeeded", "successful":
+		return ptrace.StatusCodeOk
+	case "error", "failure", "failed":
+		return ptrace.StatusCodeError
+	}
+	return ptrace.StatusCodeUnset
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestTracesQueryReceiver_Collect(t *testing.T) {
+	fakeClient := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{
+				{
+					"id":         "1",
+					"job":        "backup",
+					"trace_id":   "5b8efff7-98b2-4d3b-a7c6-d5f3e3c5a1b2",
+					"span_id":    "eee19b7ec3c1b174",
+					"started_at": "2023-09-28T15:04:05Z",
+					"ended_at":   "2023-09-28T15:05:05Z",
+					"outcome":    "FAILED",
+					"error":      "disk full",
+					"user":       "admin",
+				},
+				{
+					"id":         "2",
+					"job":        "cleanup",
+					"started_at": "1695913445000000000",
+					"ended_at":   "1695913446000000000",
+					"outcome":    "success",
+					"error":      "ignored",
+				},
+				{
+					"id":         "3",
+					"job":        "invalid",
+					"trace_id":   "not-hex",
+					"started_at": "1695913445000000000",
+					"ended_at":   "1695913446000000000",
+				},
+			},
+		},
+	}
+	queryReceiver := pollingQueryReceiver{
+		client: fakeClient,
+		logger: zap.NewNop(),
+		query: Query{
+			TrackingColumn: "id",
+			Traces: []TracesCfg{
+				{
+					NameColumn:          "job",
+					TraceIDColumn:       "trace_id",
+					SpanIDColumn:        "span_id",
+					StartTsColumn:       "started_at",
+					EndTsColumn:         "ended_at",
+					StatusColumn:        "outcome",
+					StatusMessageColumn: "error",
+					AttributeColumns:    []string{"user"},
+				},
+			},
+		},
+	}
+	traces, err := queryReceiver.collectTraces(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, traces.SpanCount(), "the row with an invalid trace ID should be skipped")
+	assert.Equal(t, "3", queryReceiver.trackingValue)
+
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "backup", span.Name())
+	assert.Equal(t, pcommon.TraceID{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0xb2, 0x4d, 0x3b, 0xa7, 0xc6, 0xd5, 0xf3, 0xe3, 0xc5, 0xa1, 0xb2}, span.TraceID())
+	assert.Equal(t, pcommon.SpanID{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74}, span.SpanID())
+	assert.True(t, span.ParentSpanID().IsEmpty())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Date(2023, 9, 28, 15, 4, 5, 0, time.UTC)), span.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Date(2023, 9, 28, 15, 5, 5, 0, time.UTC)), span.EndTimestamp())
+	assert.Equal(t, ptrace.StatusCodeError, span.Status().Code())
+	assert.Equal(t, "disk full", span.Status().Message())
+	assert.Equal(t, map[string]any{"user": "admin"}, span.Attributes().AsRaw())
+
+	span = traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1)
+	assert.Equal(t, "cleanup", span.Name())
+	assert.False(t, span.TraceID().IsEmpty(), "a random trace ID should be generated")
+	assert.False(t, span.SpanID().IsEmpty(), "a random span ID should be generated")
+	assert.Equal(t, pcommon.Timestamp(1695913445000000000), span.StartTimestamp())
+	assert.Equal(t, pcommon.Timestamp(1695913446000000000), span.EndTimestamp())
+	assert.Equal(t, ptrace.StatusCodeOk, span.Status().Code())
+	assert.Empty(t, span.Status().Message())
+	assert.Equal(t, 0, span.Attributes().Len())
+}
+
+func TestTracesReceiver_TrackingValueStorageKey(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Queries = []Query{
+		{
+			SQL:            "select * from jobs where id > ?",
+			TrackingColumn: "id",
+			Logs:           []LogsCfg{{BodyColumn: "job"}},
+			Traces:         []TracesCfg{{NameColumn: "job", StartTsColumn: "started_at", EndTsColumn: "ended_at"}},
+		},
+	}
+	settings := receivertest.NewNopCreateSettings()
+
+	logsReceiver, err := newLogsReceiver(config, settings, sql.Open, newDbClient, consumertest.NewNop())
+	require.NoError(t, err)
+	logsReceiver.createQueryReceivers()
+	tracesReceiver, err := newTracesReceiver(config, settings, sql.Open, newDbClient, consumertest.NewNop())
+	require.NoError(t, err)
+	tracesReceiver.createQueryReceivers()
+
+	require.Len(t, logsReceiver.queryReceivers, 1)
+	require.Len(t, tracesReceiver.queryReceivers, 1)
+	assert.Equal(t, "query-0: select * from jobs where id > ?.trackingValue", logsReceiver.queryReceivers[0].trackingValueStorageKey)
+	assert.Equal(t, "query-0: select * from jobs where id > ?.traces.trackingValue", tracesReceiver.queryReceivers[0].trackingValueStorageKey)
+}
+
+func TestLogsAndTracesReceiversWithFileStorage(t *testing.T) {
+	ctx := context.Background()
+	storageFactory := filestorage.NewFactory()
+	storageConfig := storageFactory.CreateDefaultConfig().(*filestorage.Config)
+	storageConfig.Directory = t.TempDir()
+	storageExtension, err := storageFactory.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), storageConfig)
+	require.NoError(t, err)
+	storageID := component.NewID(storageFactory.Type())
+	host := storagetest.NewStorageHost().WithExtension(storageID, storageExtension)
+	require.NoError(t, storageExtension.Start(ctx, host))
+	defer func() {
+		require.NoError(t, storageExtension.Shutdown(ctx))
+	}()
+
+	config := createDefaultConfig().(*Config)
+	config.StorageID = &storageID
+	config.Queries = []Query{
+		{
+			SQL:            "select * from jobs where id > ?",
+			TrackingColumn: "id",
+			Logs:           []LogsCfg{{BodyColumn: "job"}},
+			Traces:         []TracesCfg{{NameColumn: "job", StartTsColumn: "started_at", EndTsColumn: "ended_at"}},
+		},
+	}
+	settings := receivertest.NewNopCreateSettings()
+	openDb := func(string, string) (*sql.DB, error) { return nil, nil }
+
+	logsReceiver, err := newLogsReceiver(config, settings, openDb, newDbClient, consumertest.NewNop())
+	require.NoError(t, err)
+	tracesReceiver, err := newTracesReceiver(config, settings, openDb, newDbClient, consumertest.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, logsReceiver.Start(ctx, host))
+	require.NoError(t, tracesReceiver.Start(ctx, host))
+
+	require.NoError(t, logsReceiver.queryReceivers[0].storeTrackingValue(ctx, stringMap{"id": "1"}))
+	require.NoError(t, tracesReceiver.queryReceivers[0].storeTrackingValue(ctx, stringMap{"id": "2"}))
+	assert.Equal(t, "1", logsReceiver.queryReceivers[0].retrieveTrackingValue(ctx))
+	assert.Equal(t, "2", tracesReceiver.queryReceivers[0].retrieveTrackingValue(ctx))
+
+	require.NoError(t, logsReceiver.Shutdown(ctx))
+	require.NoError(t, tracesReceiver.Shutdown(ctx))
+}
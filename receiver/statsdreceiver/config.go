@@ -41,7 +41,7 @@ func (c *Config) Validate() error {
 		switch eachMap.StatsdType {
 		case protocol.TimingTypeName, protocol.TimingAltTypeName, protocol.HistogramTypeName, protocol.DistributionTypeName:
 			// do nothing
-		case protocol.CounterTypeName, protocol.GaugeTypeName:
+		case protocol.CounterTypeName, protocol.GaugeTypeName, protocol.SetTypeName:
 			fallthrough
 		default:
 			errs = multierr.Append(errs, fmt.Errorf("statsd_type is not a supported mapping for histogram and timing metrics: %s", eachMap.StatsdType))
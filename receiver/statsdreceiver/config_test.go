@@ -136,6 +136,16 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(statsdTypeNotSupportErr, "abc"),
 		},
+		{
+			name: "SetStatsdTypeNotSupport",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "set", ObserverType: "gauge"},
+				},
+			},
+			expectedErr: fmt.Sprintf(statsdTypeNotSupportErr, "set"),
+		},
 		{
 			name: "ObserverTypeNotSupport",
 			cfg: &Config{
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 )
@@ -25,7 +26,7 @@ const (
 )
 
 var (
-	defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: "gauge"}}
+	defaultTimerHistogramMapping = []protocol.TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "distribution", ObserverType: "histogram"}}
 )
 
 // NewFactory creates a factory for the StatsD receiver.
@@ -34,6 +35,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
 
@@ -56,6 +58,42 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
+	var err error
 	c := cfg.(*Config)
-	return newReceiver(params, *c, consumer)
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv receiver.Metrics
+		rcv, err = newReceiver(params, *c, consumer)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).metricsConsumer = consumer
+	return r, nil
 }
+
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	var err error
+	c := cfg.(*Config)
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv receiver.Logs
+		rcv, err = newLogsReceiver(params, *c, consumer)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+// This is the map of already created statsd receivers for particular configurations.
+// We maintain this map because the Factory is asked metrics and logs receivers separately
+// when it gets CreateMetricsReceiver() and CreateLogsReceiver() but they must not
+// create separate objects, they must use one statsdReceiver object per configuration.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -8,10 +8,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 )
 
 type testHost struct {
@@ -54,3 +57,48 @@ func TestCreateMetricsReceiverWithNilConsumer(t *testing.T) {
 	assert.Error(t, err, "nil consumer")
 	assert.Nil(t, receiver)
 }
+
+func TestCreateLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+
+	params := receivertest.NewNopCreateSettings()
+	tReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tReceiver, "receiver creation failed")
+}
+
+func TestCreateLogsReceiverWithNilConsumer(t *testing.T) {
+	receiver, err := createLogsReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		createDefaultConfig(),
+		nil,
+	)
+
+	assert.Error(t, err, "nil consumer")
+	assert.Nil(t, receiver)
+}
+
+func TestMetricsAndLogsReceiversShareServer(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0"
+
+	params := receivertest.NewNopCreateSettings()
+	metricsConsumer := new(consumertest.MetricsSink)
+	logsConsumer := new(consumertest.LogsSink)
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, metricsConsumer)
+	require.NoError(t, err)
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, logsConsumer)
+	require.NoError(t, err)
+	assert.Same(t, mReceiver, lReceiver)
+
+	r := mReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*statsdReceiver)
+	assert.Same(t, metricsConsumer, r.metricsConsumer)
+	assert.Same(t, logsConsumer, r.logsConsumer)
+
+	assert.NoError(t, mReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, lReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, mReceiver.Shutdown(context.Background()))
+	assert.NoError(t, lReceiver.Shutdown(context.Background()))
+}
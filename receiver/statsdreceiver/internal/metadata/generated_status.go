@@ -9,4 +9,5 @@ import (
 const (
 	Type             = "statsd"
 	MetricsStability = component.StabilityLevelBeta
+	LogsStability    = component.StabilityLevelDevelopment
 )
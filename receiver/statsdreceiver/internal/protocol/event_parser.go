@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	serviceCheckMessagePrefix = "|m:"
+	defaultEventAlertType     = "info"
+
+	attributeHostName            = "host.name"
+	attributeEventTitle          = "statsd.event.title"
+	attributeEventAggregationKey = "statsd.event.aggregation_key"
+	attributeEventPriority       = "statsd.event.priority"
+	attributeEventSourceType     = "statsd.event.source_type"
+	attributeEventAlertType      = "statsd.event.alert_type"
+	attributeServiceCheckName    = "statsd.service_check.name"
+	attributeServiceCheckStatus  = "statsd.service_check.status"
+)
+
+var (
+	errEmptyEventTitle       = errors.New("empty event title")
+	errEmptyServiceCheckName = errors.New("empty service check name")
+
+	// Indexed by the service check status sent by the client.
+	serviceCheckStatusNames    = []string{"ok", "warning", "critical", "unknown"}
+	serviceCheckStatusSeverity = []plog.SeverityNumber{
+		plog.SeverityNumberInfo,
+		plog.SeverityNumberWarn,
+		plog.SeverityNumberError,
+		plog.SeverityNumberUnspecified,
+	}
+)
+
+// events holds the DogStatsD events and service checks received from one address.
+type events struct {
+	addr    net.Addr
+	records plog.LogRecordSlice
+}
+
+func (p *StatsDParser) aggregateEvent(line string, addr net.Addr, parse func(string, plog.LogRecord) error) error {
+	record := plog.NewLogRecord()
+	if err := parse(line, record); err != nil {
+		return err
+	}
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+
+	addrKey := newNetAddr(addr)
+	e, ok := p.eventsByAddress[addrKey]
+	if !ok {
+		e = &events{
+			addr:    addr,
+			records: plog.NewLogRecordSlice(),
+		}
+		p.eventsByAddress[addrKey] = e
+	}
+	record.MoveTo(e.records.AppendEmpty())
+	return nil
+}
+
+// GetLogs gets the events and service checks received since the last call and resets them.
+func (p *StatsDParser) GetLogs() []BatchLogs {
+	batchLogs := make([]BatchLogs, 0, len(p.eventsByAddress))
+	for _, e := range p.eventsByAddress {
+		batch := BatchLogs{
+			Info: client.Info{
+				Addr: e.addr,
+			},
+			Logs: plog.NewLogs(),
+		}
+		sl := batch.Logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(receiverName)
+		sl.Scope().SetVersion(p.BuildInfo.Version)
+		e.records.MoveAndAppendTo(sl.LogRecords())
+		batchLogs = append(batchLogs, batch)
+	}
+	p.eventsByAddress = make(map[netAddr]*events)
+	return batchLogs
+}
+
+// parseEventMessage parses a DogStatsD event of the form
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|k:<aggregation key>|p:<priority>|s:<source type>|t:<alert type>|#<tags>
+func parseEventMessage(line string, record plog.LogRecord) error {
+	headerEnd := strings.IndexByte(line, '}')
+	if headerEnd < 0 || headerEnd+1 >= len(line) || line[headerEnd+1] != ':' {
+		return fmt.Errorf("invalid event format: %s", line)
+	}
+	lengths := strings.SplitN(line[len(eventPrefix):headerEnd], ",", 2)
+	if len(lengths) != 2 {
+		return fmt.Errorf("invalid event lengths: %s", line[:headerEnd+1])
+	}
+	titleLen, err := strconv.Atoi(lengths[0])
+	if err != nil || titleLen < 0 {
+		return fmt.Errorf("parse event title length: %s", lengths[0])
+	}
+	textLen, err := strconv.Atoi(lengths[1])
+	if err != nil || textLen < 0 {
+		return fmt.Errorf("parse event text length: %s", lengths[1])
+	}
+
+	rest := line[headerEnd+2:]
+	// the lengths are checked one by one first, so that their sum cannot overflow
+	if titleLen > len(rest) || textLen > len(rest) || titleLen+1+textLen > len(rest) || rest[titleLen] != '|' {
+		return fmt.Errorf("event title and text do not match the declared lengths: %s", line)
+	}
+	title := rest[:titleLen]
+	if title == "" {
+		return errEmptyEventTitle
+	}
+	text := rest[titleLen+1 : titleLen+1+textLen]
+	rest = rest[titleLen+1+textLen:]
+
+	record.Body().SetStr(strings.ReplaceAll(text, `\n`, "\n"))
+	attrs := record.Attributes()
+	attrs.PutStr(attributeEventTitle, title)
+	alertType := defaultEventAlertType
+
+	for _, part := range splitEventParts(rest) {
+		switch {
+		case strings.HasPrefix(part, "k:"):
+			attrs.PutStr(attributeEventAggregationKey, part[2:])
+		case strings.HasPrefix(part, "p:"):
+			attrs.PutStr(attributeEventPriority, part[2:])
+		case strings.HasPrefix(part, "s:"):
+			attrs.PutStr(attributeEventSourceType, part[2:])
+		case strings.HasPrefix(part, "t:"):
+			alertType = part[2:]
+		default:
+			if err = parseCommonEventPart(part, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	attrs.PutStr(attributeEventAlertType, alertType)
+	record.SetSeverityText(alertType)
+	switch alertType {
+	case "error":
+		record.SetSeverityNumber(plog.SeverityNumberError)
+	case "warning":
+		record.SetSeverityNumber(plog.SeverityNumberWarn)
+	case "info", "success":
+		record.SetSeverityNumber(plog.SeverityNumberInfo)
+	default:
+		return fmt.Errorf("unsupported event alert type: %s", alertType)
+	}
+	return nil
+}
+
+// parseServiceCheckMessage parses a DogStatsD service check of the form
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>
+func parseServiceCheckMessage(line string, record plog.LogRecord) error {
+	rest := line[len(serviceCheckPrefix):]
+	// The message is always the last field and may contain any character.
+	if idx := strings.Index(rest, serviceCheckMessagePrefix); idx >= 0 {
+		record.Body().SetStr(strings.ReplaceAll(rest[idx+len(serviceCheckMessagePrefix):], `\n`, "\n"))
+		rest = rest[:idx]
+	}
+
+	parts := strings.Split(rest, "|")
+	if len(parts) < 2 {
+		return fmt.Errorf("invalid service check format: %s", line)
+	}
+	if parts[0] == "" {
+		return errEmptyServiceCheckName
+	}
+	status, err := strconv.Atoi(parts[1])
+	if err != nil || status < 0 || status >= len(serviceCheckStatusNames) {
+		return fmt.Errorf("invalid service check status: %s", parts[1])
+	}
+
+	attrs := record.Attributes()
+	attrs.PutStr(attributeServiceCheckName, parts[0])
+	attrs.PutStr(attributeServiceCheckStatus, serviceCheckStatusNames[status])
+	record.SetSeverityText(serviceCheckStatusNames[status])
+	record.SetSeverityNumber(serviceCheckStatusSeverity[status])
+
+	for _, part := range parts[2:] {
+		if err = parseCommonEventPart(part, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitEventParts(rest string) []string {
+	rest = strings.TrimPrefix(rest, "|")
+	if rest == "" {
+		return nil
+	}
+	return strings.Split(rest, "|")
+}
+
+// parseCommonEventPart handles the fields shared by events and service checks.
+func parseCommonEventPart(part string, record plog.LogRecord) error {
+	switch {
+	case strings.HasPrefix(part, "d:"):
+		ts, err := strconv.ParseInt(part[2:], 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse timestamp: %s", part[2:])
+		}
+		record.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(ts, 0)))
+	case strings.HasPrefix(part, "h:"):
+		record.Attributes().PutStr(attributeHostName, part[2:])
+	case strings.HasPrefix(part, "#"):
+		tags, err := parseTags(strings.TrimPrefix(part, "#"))
+		if err != nil {
+			return err
+		}
+		for _, kv := range tags {
+			record.Attributes().PutStr(string(kv.Key), kv.Value.AsString())
+		}
+	default:
+		return fmt.Errorf("unrecognized message part: %s", part)
+	}
+	return nil
+}
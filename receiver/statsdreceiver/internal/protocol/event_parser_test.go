@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func Test_ParseEventMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantRecord func() plog.LogRecord
+		err        error
+	}{
+		{
+			name:  "missing lengths",
+			input: "_e:title|text",
+			err:   errors.New("invalid event format: _e:title|text"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   errors.New("parse event title length: a"),
+		},
+		{
+			name:  "lengths do not match",
+			input: "_e{3,4}:title|text",
+			err:   errors.New("event title and text do not match the declared lengths: _e{3,4}:title|text"),
+		},
+		{
+			name:  "title length larger than the message",
+			input: "_e{9223372036854775807,0}:a|b",
+			err:   errors.New("event title and text do not match the declared lengths: _e{9223372036854775807,0}:a|b"),
+		},
+		{
+			name:  "text length larger than the message",
+			input: "_e{1,9223372036854775807}:a|b",
+			err:   errors.New("event title and text do not match the declared lengths: _e{1,9223372036854775807}:a|b"),
+		},
+		{
+			name:  "overflowing lengths",
+			input: "_e{4611686018427387904,4611686018427387903}:a|b",
+			err:   errors.New("event title and text do not match the declared lengths: _e{4611686018427387904,4611686018427387903}:a|b"),
+		},
+		{
+			name:  "title length of the whole message",
+			input: "_e{3,0}:a|b",
+			err:   errors.New("event title and text do not match the declared lengths: _e{3,0}:a|b"),
+		},
+		{
+			name:  "empty title",
+			input: "_e{0,4}:|text",
+			err:   errors.New("empty event title"),
+		},
+		{
+			name:  "unsupported alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   errors.New("unsupported event alert type: fatal"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:extra",
+			err:   errors.New("unrecognized message part: x:extra"),
+		},
+		{
+			name:  "minimal event",
+			input: "_e{5,4}:title|text",
+			wantRecord: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.Body().SetStr("text")
+				lr.Attributes().PutStr("statsd.event.title", "title")
+				lr.Attributes().PutStr("statsd.event.alert_type", "info")
+				lr.SetSeverityText("info")
+				lr.SetSeverityNumber(plog.SeverityNumberInfo)
+				return lr
+			},
+		},
+		{
+			name:  "event with all fields",
+			input: `_e{10,12}:Deploy|ing|line1\nline2|d:1700000000|h:web-1|k:deploy|p:low|s:jenkins|t:error|#env:prod,team:web`,
+			wantRecord: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.Body().SetStr("line1\nline2")
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)))
+				lr.Attributes().PutStr("statsd.event.title", "Deploy|ing")
+				lr.Attributes().PutStr("host.name", "web-1")
+				lr.Attributes().PutStr("statsd.event.aggregation_key", "deploy")
+				lr.Attributes().PutStr("statsd.event.priority", "low")
+				lr.Attributes().PutStr("statsd.event.source_type", "jenkins")
+				lr.Attributes().PutStr("env", "prod")
+				lr.Attributes().PutStr("team", "web")
+				lr.Attributes().PutStr("statsd.event.alert_type", "error")
+				lr.SetSeverityText("error")
+				lr.SetSeverityNumber(plog.SeverityNumberError)
+				return lr
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := plog.NewLogRecord()
+			err := parseEventMessage(tt.input, got)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRecord(), got)
+			}
+		})
+	}
+}
+
+func Test_ParseServiceCheckMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantRecord func() plog.LogRecord
+		err        error
+	}{
+		{
+			name:  "missing status",
+			input: "_sc|db.health",
+			err:   errors.New("invalid service check format: _sc|db.health"),
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty service check name"),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|db.health|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_sc|db.health|0|d:now",
+			err:   errors.New("parse timestamp: now"),
+		},
+		{
+			name:  "minimal service check",
+			input: "_sc|db.health|0",
+			wantRecord: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.Attributes().PutStr("statsd.service_check.name", "db.health")
+				lr.Attributes().PutStr("statsd.service_check.status", "ok")
+				lr.SetSeverityText("ok")
+				lr.SetSeverityNumber(plog.SeverityNumberInfo)
+				return lr
+			},
+		},
+		{
+			name:  "service check with all fields",
+			input: "_sc|db.health|2|d:1700000000|h:db-1|#env:prod|m:connection refused|retrying",
+			wantRecord: func() plog.LogRecord {
+				lr := plog.NewLogRecord()
+				lr.Body().SetStr("connection refused|retrying")
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1700000000, 0)))
+				lr.Attributes().PutStr("statsd.service_check.name", "db.health")
+				lr.Attributes().PutStr("statsd.service_check.status", "critical")
+				lr.Attributes().PutStr("host.name", "db-1")
+				lr.Attributes().PutStr("env", "prod")
+				lr.SetSeverityText("critical")
+				lr.SetSeverityNumber(plog.SeverityNumberError)
+				return lr
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := plog.NewLogRecord()
+			err := parseServiceCheckMessage(tt.input, got)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRecord(), got)
+			}
+		})
+	}
+}
+
+func TestStatsDParser_GetLogs(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	const devVersion = "dev-0.0.1"
+	p := &StatsDParser{
+		BuildInfo: component.BuildInfo{
+			Version: devVersion,
+		},
+	}
+	require.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	require.NoError(t, p.Aggregate("_e{5,4}:title|text", addr))
+	require.NoError(t, p.Aggregate("_sc|db.health|1", addr))
+	require.NoError(t, p.Aggregate("test.metric:1|c", addr))
+	assert.Error(t, p.Aggregate("_sc|db.health", addr))
+
+	batches := p.GetLogs()
+	require.Len(t, batches, 1)
+	assert.Equal(t, addr, batches[0].Info.Addr)
+	logs := batches[0].Logs
+	require.Equal(t, 2, logs.LogRecordCount())
+	sl := logs.ResourceLogs().At(0).ScopeLogs().At(0)
+	assert.Equal(t, receiverName, sl.Scope().Name())
+	assert.Equal(t, devVersion, sl.Scope().Version())
+	assert.Equal(t, "text", sl.LogRecords().At(0).Body().Str())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), sl.LogRecords().At(0).ObservedTimestamp())
+	assert.Equal(t, plog.SeverityNumberWarn, sl.LogRecords().At(1).SeverityNumber())
+
+	// Events are reset after each call and do not end up in the metrics.
+	assert.Empty(t, p.GetLogs())
+	assert.Equal(t, 1, p.GetMetrics()[0].Metrics.MetricCount())
+}
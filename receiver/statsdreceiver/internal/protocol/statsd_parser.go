@@ -38,6 +38,7 @@ const (
 	HistogramType    MetricType = "h"
 	TimingType       MetricType = "ms"
 	DistributionType MetricType = "d"
+	SetType          MetricType = "s"
 
 	CounterTypeName      TypeName = "counter"
 	GaugeTypeName        TypeName = "gauge"
@@ -45,6 +46,7 @@ const (
 	TimingTypeName       TypeName = "timing"
 	TimingAltTypeName    TypeName = "timer"
 	DistributionTypeName TypeName = "distribution"
+	SetTypeName          TypeName = "set"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -82,6 +84,8 @@ type StatsDParser struct {
 	isMonotonicCounter   bool
 	timerEvents          ObserverCategory
 	histogramEvents      ObserverCategory
+	distributionEvents   ObserverCategory
+	eventsByAddress      map[netAddr]*events
 	lastIntervalTime     time.Time
 	BuildInfo            component.BuildInfo
 }
@@ -92,6 +96,7 @@ type instruments struct {
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
 	histograms             map[statsDMetricDescription]histogramMetric
+	sets                   map[statsDMetricDescription]setMetric
 	timersAndDistributions []pmetric.ScopeMetrics
 }
 
@@ -102,6 +107,7 @@ func newInstruments(addr net.Addr) *instruments {
 		counters:   make(map[statsDMetricDescription]pmetric.ScopeMetrics),
 		summaries:  make(map[statsDMetricDescription]summaryMetric),
 		histograms: make(map[statsDMetricDescription]histogramMetric),
+		sets:       make(map[statsDMetricDescription]setMetric),
 	}
 }
 
@@ -115,6 +121,10 @@ type summaryMetric struct {
 	weights []float64
 }
 
+type setMetric struct {
+	values map[string]struct{}
+}
+
 type histogramStructure = structure.Histogram[float64]
 
 type histogramMetric struct {
@@ -124,6 +134,7 @@ type histogramMetric struct {
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
+	asString    string
 	addition    bool
 	unit        string
 	sampleRate  float64
@@ -147,6 +158,8 @@ func (t MetricType) FullName() TypeName {
 		return HistogramTypeName
 	case DistributionType:
 		return DistributionTypeName
+	case SetType:
+		return SetTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -159,22 +172,32 @@ func (p *StatsDParser) resetState(when time.Time) {
 func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
 
+	p.eventsByAddress = make(map[netAddr]*events)
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
+	distributionMapped := false
 	// Note: validation occurs in ("../".Config).validate()
 	for _, eachMap := range sendTimerHistogram {
 		switch eachMap.StatsdType {
-		case HistogramTypeName, DistributionTypeName:
+		case HistogramTypeName:
 			p.histogramEvents.method = eachMap.ObserverType
 			p.histogramEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+		case DistributionTypeName:
+			p.distributionEvents.method = eachMap.ObserverType
+			p.distributionEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+			distributionMapped = true
 		case TimingTypeName, TimingAltTypeName:
 			p.timerEvents.method = eachMap.ObserverType
 			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
-		case CounterTypeName, GaugeTypeName:
+		case CounterTypeName, GaugeTypeName, SetTypeName:
 		}
 	}
+	// Distributions are aggregated like histograms unless they have their own mapping.
+	if !distributionMapped {
+		p.distributionEvents = p.histogramEvents
+	}
 	return nil
 }
 
@@ -238,6 +261,13 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 			)
 		}
 
+		for desc, setMetric := range instrument.sets {
+			ilm := rm.ScopeMetrics().AppendEmpty()
+			p.setVersionAndNameScope(ilm.Scope())
+
+			buildSetMetric(desc, setMetric, now, ilm)
+		}
+
 		batchMetrics = append(batchMetrics, batch)
 	}
 	p.resetState(now)
@@ -259,17 +289,26 @@ var timeNowFunc = time.Now
 
 func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 	switch t {
-	case HistogramType, DistributionType:
+	case HistogramType:
 		return p.histogramEvents
+	case DistributionType:
+		return p.distributionEvents
 	case TimingType:
 		return p.timerEvents
-	case CounterType, GaugeType:
+	case CounterType, GaugeType, SetType:
 	}
 	return defaultObserverCategory
 }
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return p.aggregateEvent(line, addr, parseEventMessage)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return p.aggregateEvent(line, addr, parseServiceCheckMessage)
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
 	if err != nil {
 		return err
@@ -305,6 +344,14 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 			point.SetIntValue(point.IntValue() + parsedMetric.counterValue())
 		}
 
+	case SetType:
+		existing, ok := instrument.sets[parsedMetric.description]
+		if !ok {
+			existing = setMetric{values: make(map[string]struct{})}
+			instrument.sets[parsedMetric.description] = existing
+		}
+		existing.values[parsedMetric.asString] = struct{}{}
+
 	case TimingType, HistogramType, DistributionType:
 		category := p.observerCategoryFor(parsedMetric.description.metricType)
 		switch category.method {
@@ -370,18 +417,20 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 	if valueStr == "" {
 		return result, errEmptyMetricValue
 	}
-	if strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+") {
-		result.addition = true
-	}
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType, DistributionType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType, SetType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
 	}
 
+	// Set members are arbitrary strings, signs are part of the value.
+	if inType != SetType && (strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+")) {
+		result.addition = true
+	}
+
 	additionalParts := parts[2:]
 
 	var kvs []attribute.KeyValue
@@ -398,33 +447,23 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			// handle an empty tag set
-			// where the tags part was still sent (some clients do this)
-			if len(tagsStr) == 0 {
-				continue
-			}
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				k := tagParts[0]
-				v := tagParts[1]
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+	if inType == SetType {
+		result.asString = valueStr
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
 	// add metric_type dimension for all metrics
@@ -441,6 +480,24 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 	return result, nil
 }
 
+func parseTags(tagsStr string) ([]attribute.KeyValue, error) {
+	// handle an empty tag set
+	// where the tags part was still sent (some clients do this)
+	if len(tagsStr) == 0 {
+		return nil, nil
+	}
+
+	var kvs []attribute.KeyValue
+	for _, tagSet := range strings.Split(tagsStr, ",") {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		if len(tagParts) != 2 {
+			return nil, fmt.Errorf("invalid tag format: %s", tagParts)
+		}
+		kvs = append(kvs, attribute.String(tagParts[0], tagParts[1]))
+	}
+	return kvs, nil
+}
+
 type netAddr struct {
 	Network string
 	String  string
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 
@@ -231,6 +232,25 @@ func Test_ParseMessageToMetric(t *testing.T) {
 				false,
 				"h", 0, nil, nil),
 		},
+		{
+			name:  "string set",
+			input: "test.metric:user-1|s|#key:value",
+			wantMetric: statsDMetric{
+				description: testDescription("test.metric", "s", []string{"key"}, []string{"value"}),
+				asString:    "user-1",
+			},
+		},
+		{
+			name:  "signed set",
+			input: "test.metric:-42|s",
+			wantMetric: statsDMetric{
+				description: statsDMetricDescription{
+					name:       "test.metric",
+					metricType: "s",
+				},
+				asString: "-42",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1410,3 +1430,86 @@ func TestStatsDParser_AggregateTimerWithHistogram(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsDParser_AggregateSets(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	require.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	for _, line := range []string{
+		"users:alice|s|#mykey:myvalue",
+		"users:bob|s|#mykey:myvalue",
+		"users:alice|s|#mykey:myvalue",
+		"users:carol|s|#mykey:othervalue",
+	} {
+		require.NoError(t, p.Aggregate(line, addr))
+	}
+
+	metrics := p.GetMetrics()[0].Metrics
+	require.Equal(t, 2, metrics.MetricCount())
+	counts := map[string]int64{}
+	sms := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		m := sms.At(i).Metrics().At(0)
+		assert.Equal(t, "users", m.Name())
+		require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+		dp := m.Gauge().DataPoints().At(0)
+		assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), dp.Timestamp())
+		tag, _ := dp.Attributes().Get("mykey")
+		counts[tag.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"myvalue": 2, "othervalue": 1}, counts)
+}
+
+func TestStatsDParser_DistributionMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping []TimerHistogramMapping
+		expect  map[string]pmetric.MetricType
+	}{
+		{
+			name: "distribution follows histogram",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "histogram", ObserverType: "summary"},
+			},
+			expect: map[string]pmetric.MetricType{
+				"H": pmetric.MetricTypeSummary,
+				"D": pmetric.MetricTypeSummary,
+			},
+		},
+		{
+			name: "distribution mapped separately",
+			mapping: []TimerHistogramMapping{
+				{StatsdType: "histogram", ObserverType: "gauge"},
+				{StatsdType: "distribution", ObserverType: "histogram"},
+			},
+			expect: map[string]pmetric.MetricType{
+				"H": pmetric.MetricTypeGauge,
+				"D": pmetric.MetricTypeExponentialHistogram,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &StatsDParser{}
+			require.NoError(t, p.Initialize(false, false, tt.mapping))
+
+			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+			require.NoError(t, p.Aggregate("H:10|h", addr))
+			require.NoError(t, p.Aggregate("D:10|d", addr))
+
+			types := map[string]pmetric.MetricType{}
+			sms := p.GetMetrics()[0].Metrics.ResourceMetrics().At(0).ScopeMetrics()
+			for i := 0; i < sms.Len(); i++ {
+				ms := sms.At(i).Metrics()
+				for j := 0; j < ms.Len(); j++ {
+					types[ms.At(j).Name()] = ms.At(j).Type()
+				}
+			}
+			assert.Equal(t, tt.expect, types)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 )
 
 // StatsD defines the properties of a StatsD connection.
@@ -50,7 +51,7 @@ func (s *StatsD) connect(transport Transport) error {
 		}
 	}
 
-	address := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	address := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
 
 	var err error
 	switch transport {
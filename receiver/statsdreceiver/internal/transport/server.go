@@ -8,8 +8,6 @@ import (
 	"errors"
 	"net"
 
-	"go.opentelemetry.io/collector/consumer"
-
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 )
 
@@ -23,7 +21,6 @@ type Server interface {
 	// the Parser and passed to the next consumer.
 	ListenAndServe(
 		p protocol.Parser,
-		mc consumer.Metrics,
 		r Reporter,
 		transferChan chan<- Metric,
 	) error
@@ -5,6 +5,7 @@ package transport
 
 import (
 	"net"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -13,7 +14,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
@@ -73,7 +73,6 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			port, err := strconv.Atoi(portStr)
 			require.NoError(t, err)
 
-			mc := new(consumertest.MetricsSink)
 			p := &protocol.StatsDParser{}
 			require.NoError(t, err)
 			mr := NewMockReporter(1)
@@ -83,7 +82,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			wgListenAndServe.Add(1)
 			go func() {
 				defer wgListenAndServe.Done()
-				assert.Error(t, srv.ListenAndServe(p, mc, mr, transferChan))
+				assert.Error(t, srv.ListenAndServe(p, mr, transferChan))
 			}()
 
 			runtime.Gosched()
@@ -115,3 +114,43 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_UnixgramServer_ListenAndServe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	// A socket left behind by a previous run must not prevent binding.
+	stale, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+
+	srv, err := NewUnixgramServer(path)
+	require.NoError(t, err)
+
+	mr := NewMockReporter(1)
+	transferChan := make(chan Metric, 10)
+
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, mr, transferChan))
+	}()
+
+	conn, err := net.Dial("unixgram", path)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test.metric:42|c\n_sc|db.health|0\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	assert.Eventually(t, func() bool {
+		return len(transferChan) == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, srv.Close())
+	wgListenAndServe.Wait()
+	assert.NoFileExists(t, path)
+
+	metric := <-transferChan
+	assert.Equal(t, "test.metric:42|c", metric.Raw)
+	assert.Equal(t, "unixgram", metric.Addr.Network())
+	assert.Equal(t, path, metric.Addr.String())
+	assert.NoError(t, stale.Close())
+}
@@ -11,8 +11,6 @@ import (
 	"strings"
 	"sync"
 
-	"go.opentelemetry.io/collector/consumer"
-
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 )
 
@@ -41,8 +39,8 @@ func NewTCPServer(addr string) (Server, error) {
 	return &t, nil
 }
 
-func (t *tcpServer) ListenAndServe(parser protocol.Parser, nextConsumer consumer.Metrics, reporter Reporter, transferChan chan<- Metric) error {
-	if parser == nil || nextConsumer == nil || reporter == nil {
+func (t *tcpServer) ListenAndServe(parser protocol.Parser, reporter Reporter, transferChan chan<- Metric) error {
+	if parser == nil || reporter == nil {
 		return errNilListenAndServeParameters
 	}
 
@@ -10,17 +10,17 @@ import (
 	"net"
 	"strings"
 
-	"go.opentelemetry.io/collector/consumer"
-
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/protocol"
 )
 
-type udpServer struct {
+// packetServer serves StatsD messages received over a datagram oriented transport.
+type packetServer struct {
 	packetConn net.PacketConn
 	reporter   Reporter
+	transport  string
 }
 
-var _ (Server) = (*udpServer)(nil)
+var _ (Server) = (*packetServer)(nil)
 
 // NewUDPServer creates a transport.Server using UDP as its transport.
 func NewUDPServer(addr string) (Server, error) {
@@ -29,19 +29,19 @@ func NewUDPServer(addr string) (Server, error) {
 		return nil, err
 	}
 
-	u := udpServer{
+	u := packetServer{
 		packetConn: packetConn,
+		transport:  "UDP",
 	}
 	return &u, nil
 }
 
-func (u *udpServer) ListenAndServe(
+func (u *packetServer) ListenAndServe(
 	parser protocol.Parser,
-	nextConsumer consumer.Metrics,
 	reporter Reporter,
 	transferChan chan<- Metric,
 ) error {
-	if parser == nil || nextConsumer == nil || reporter == nil {
+	if parser == nil || reporter == nil {
 		return errNilListenAndServeParameters
 	}
 
@@ -53,10 +53,11 @@ func (u *udpServer) ListenAndServe(
 		if n > 0 {
 			bufCopy := make([]byte, n)
 			copy(bufCopy, buf)
-			u.handlePacket(bufCopy, addr, transferChan)
+			u.handlePacket(bufCopy, u.remoteAddr(addr), transferChan)
 		}
 		if err != nil {
-			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				u.transport,
 				u.packetConn.LocalAddr(),
 				err)
 			var netErr net.Error
@@ -70,11 +71,21 @@ func (u *udpServer) ListenAndServe(
 	}
 }
 
-func (u *udpServer) Close() error {
+func (u *packetServer) Close() error {
 	return u.packetConn.Close()
 }
 
-func (u *udpServer) handlePacket(
+// remoteAddr returns the address used to group the metrics of a packet.
+// Unix datagram clients are usually unbound and have no address, the socket
+// address is used for them instead.
+func (u *packetServer) remoteAddr(addr net.Addr) net.Addr {
+	if addr == nil || addr.String() == "" {
+		return u.packetConn.LocalAddr()
+	}
+	return addr
+}
+
+func (u *packetServer) handlePacket(
 	data []byte,
 	addr net.Addr,
 	transferChan chan<- Metric,
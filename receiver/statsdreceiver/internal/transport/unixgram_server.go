@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"os"
+
+	"go.uber.org/multierr"
+)
+
+type unixgramServer struct {
+	packetServer
+	path string
+}
+
+var _ Server = (*unixgramServer)(nil)
+
+// NewUnixgramServer creates a transport.Server using a Unix domain datagram socket
+// bound to the given path as its transport.
+func NewUnixgramServer(path string) (Server, error) {
+	// Remove a socket left behind by a previous run, any other file is left untouched.
+	if info, err := os.Lstat(path); err == nil && info.Mode()&fs.ModeSocket != 0 {
+		if err = os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	packetConn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := unixgramServer{
+		packetServer: packetServer{
+			packetConn: packetConn,
+			transport:  "Unixgram",
+		},
+		path: path,
+	}
+	return &u, nil
+}
+
+func (u *unixgramServer) Close() error {
+	err := u.packetServer.Close()
+	if rmErr := os.Remove(u.path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+		err = multierr.Append(err, rmErr)
+	}
+	return err
+}
@@ -22,18 +22,23 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics for StatsD protocol and the
+// receiver.Logs for DogStatsD events and service checks.
 type statsdReceiver struct {
 	settings receiver.CreateSettings
 	config   *Config
 
-	server       transport.Server
-	reporter     transport.Reporter
-	parser       protocol.Parser
-	nextConsumer consumer.Metrics
-	cancel       context.CancelFunc
+	server          transport.Server
+	reporter        transport.Reporter
+	parser          protocol.Parser
+	metricsConsumer consumer.Metrics
+	logsConsumer    consumer.Logs
+	cancel          context.CancelFunc
 }
 
 // newReceiver creates the StatsD receiver with the given parameters.
@@ -46,6 +51,34 @@ func newReceiver(
 		return nil, component.ErrNilNextConsumer
 	}
 
+	r, err := newStatsdReceiver(set, config)
+	if err != nil {
+		return nil, err
+	}
+	r.metricsConsumer = nextConsumer
+	return r, nil
+}
+
+// newLogsReceiver creates the StatsD receiver emitting the DogStatsD events and
+// service checks as logs.
+func newLogsReceiver(
+	set receiver.CreateSettings,
+	config Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	r, err := newStatsdReceiver(set, config)
+	if err != nil {
+		return nil, err
+	}
+	r.logsConsumer = nextConsumer
+	return r, nil
+}
+
+func newStatsdReceiver(set receiver.CreateSettings, config Config) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
@@ -55,31 +88,30 @@ func newReceiver(
 		return nil, err
 	}
 
-	r := &statsdReceiver{
-		settings:     set,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		reporter:     rep,
+	return &statsdReceiver{
+		settings: set,
+		config:   &config,
+		reporter: rep,
 		parser: &protocol.StatsDParser{
 			BuildInfo: set.BuildInfo,
 		},
-	}
-	return r, nil
+	}, nil
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
 	switch strings.ToLower(config.NetAddr.Transport) {
 	case "", "udp":
 		return transport.NewUDPServer(config.NetAddr.Endpoint)
 	case "tcp":
 		return transport.NewTCPServer(config.NetAddr.Endpoint)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q", config.NetAddr.Transport)
 }
 
-// Start starts a UDP server that can process StatsD messages.
+// Start starts a server that can process StatsD messages over the configured transport.
 func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	ctx, r.cancel = context.WithCancel(ctx)
 	server, err := buildTransportServer(*r.config)
@@ -98,7 +130,7 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	go func() {
-		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.parser, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				host.ReportFatalError(err)
 			}
@@ -109,11 +141,23 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 			select {
 			case <-ticker.C:
 				batchMetrics := r.parser.GetMetrics()
-				for _, batch := range batchMetrics {
-					batchCtx := client.NewContext(ctx, batch.Info)
+				if r.metricsConsumer != nil {
+					for _, batch := range batchMetrics {
+						batchCtx := client.NewContext(ctx, batch.Info)
 
-					if err := r.Flush(batchCtx, batch.Metrics, r.nextConsumer); err != nil {
-						r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+						if err := r.Flush(batchCtx, batch.Metrics, r.metricsConsumer); err != nil {
+							r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+						}
+					}
+				}
+				batchLogs := r.parser.GetLogs()
+				if r.logsConsumer != nil {
+					for _, batch := range batchLogs {
+						batchCtx := client.NewContext(ctx, batch.Info)
+
+						if err := r.logsConsumer.ConsumeLogs(batchCtx, batch.Logs); err != nil {
+							r.reporter.OnDebugf("Error flushing logs", zap.Error(err))
+						}
 					}
 				}
 			case metric := <-transferChan:
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
@@ -197,3 +198,35 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func Test_statsdreceiver_LogsEndToEnd(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr = confignet.NetAddr{
+		Endpoint:  filepath.Join(t.TempDir(), "dsd.sock"),
+		Transport: "unixgram",
+	}
+	cfg.AggregationInterval = 100 * time.Millisecond
+
+	sink := new(consumertest.LogsSink)
+	rcv, err := newLogsReceiver(receivertest.NewNopCreateSettings(), *cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, rcv.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("unixgram", cfg.NetAddr.Endpoint)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("_e{6,11}:Deploy|web-1 ready|t:success\ntest.metric:42|c\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 10*time.Second, 50*time.Millisecond)
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "web-1 ready", lr.Body().Str())
+	title, ok := lr.Attributes().Get("statsd.event.title")
+	require.True(t, ok)
+	assert.Equal(t, "Deploy", title.Str())
+}
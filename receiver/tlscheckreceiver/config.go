@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
+)
+
+// Predefined error responses for configuration validation failures
+var (
+	errMissingTargets    = errors.New(`at least one of "targets" or "files" must be specified`)
+	errMissingEndpoint   = errors.New(`"endpoint" must be specified`)
+	errInvalidEndpoint   = errors.New(`"endpoint" must be in the form of <hostname>:<port>`)
+	errInvalidStartTLS   = errors.New(`"starttls" must be one of smtp, imap, pop3, ldap or postgres`)
+	errInvalidFile       = errors.New(`"files" must be valid glob patterns`)
+	errInvalidTimeout    = errors.New(`"timeout" must be positive`)
+	errConfigNotTLSCheck = errors.New("config was not a TLS check receiver config")
+)
+
+// Config defines the configuration for the various elements of the receiver agent.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+	// Targets are the TLS servers whose certificates are checked
+	Targets []*targetConfig `mapstructure:"targets"`
+	// Files are the glob patterns of the PEM files whose certificates are checked
+	Files []string `mapstructure:"files"`
+}
+
+type targetConfig struct {
+	// Endpoint is the address of the TLS server, in the form of host:port
+	Endpoint string `mapstructure:"endpoint"`
+	// StartTLS is the protocol used to upgrade the connection to TLS, the connection is
+	// expected to start with a TLS handshake if it is empty
+	StartTLS string `mapstructure:"starttls"`
+	// TLSSetting is used to validate the certificate chain served by the endpoint
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *targetConfig) Validate() error {
+	var err error
+
+	if cfg.Endpoint == "" {
+		err = multierr.Append(err, errMissingEndpoint)
+	} else if _, _, splitErr := net.SplitHostPort(cfg.Endpoint); splitErr != nil {
+		err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), splitErr))
+	}
+
+	if _, ok := startTLSUpgrades[cfg.StartTLS]; cfg.StartTLS != "" && !ok {
+		err = multierr.Append(err, fmt.Errorf("%s: %q", errInvalidStartTLS.Error(), cfg.StartTLS))
+	}
+
+	return err
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *Config) Validate() error {
+	var err error
+
+	if len(cfg.Targets) == 0 && len(cfg.Files) == 0 {
+		err = multierr.Append(err, errMissingTargets)
+	}
+
+	for _, file := range cfg.Files {
+		if _, matchErr := filepath.Match(file, ""); matchErr != nil {
+			err = multierr.Append(err, fmt.Errorf("%s: %q", errInvalidFile.Error(), file))
+		}
+	}
+
+	if cfg.Timeout <= 0 {
+		err = multierr.Append(err, errInvalidTimeout)
+	}
+
+	for _, target := range cfg.Targets {
+		err = multierr.Append(err, target.Validate())
+	}
+
+	return err
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
+)
+
+func TestCheckConfig(t *testing.T) {
+	t.Parallel()
+	if err := componenttest.CheckConfigStruct(&Config{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		cfg         *Config
+		expectedErr []error
+	}{
+		{
+			desc: "missing targets and files",
+			cfg: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: time.Minute,
+					Timeout:            time.Second,
+				},
+			},
+			expectedErr: []error{errMissingTargets},
+		},
+		{
+			desc: "invalid targets",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{},
+					{Endpoint: "example.com"},
+					{Endpoint: "example.com:25", StartTLS: "ftp"},
+				},
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: time.Minute,
+					Timeout:            time.Second,
+				},
+			},
+			expectedErr: []error{errMissingEndpoint, errInvalidEndpoint, errInvalidStartTLS},
+		},
+		{
+			desc: "invalid files and timeout",
+			cfg: &Config{
+				Files:                     []string{"/etc/ssl/[.pem"},
+				ScraperControllerSettings: scraperhelper.NewDefaultScraperControllerSettings(metadata.Type),
+			},
+			expectedErr: []error{errInvalidFile, errInvalidTimeout},
+		},
+		{
+			desc: "valid config",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{Endpoint: "example.com:443"},
+					{Endpoint: "[::1]:389", StartTLS: "ldap"},
+				},
+				Files: []string{"/etc/ssl/*.pem"},
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: time.Minute,
+					Timeout:            time.Second,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actualErr := tc.cfg.Validate()
+			if len(tc.expectedErr) == 0 {
+				require.NoError(t, actualErr)
+				return
+			}
+			for _, expectedErr := range tc.expectedErr {
+				require.ErrorContains(t, actualErr, expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	rcvrs, err := cm.Sub("receivers")
+	require.NoError(t, err)
+	tlsconf, err := rcvrs.Sub("tlscheck")
+	require.NoError(t, err)
+
+	actualConfig, ok := NewFactory().CreateDefaultConfig().(*Config)
+	require.True(t, ok)
+	require.NoError(t, tlsconf.Unmarshal(actualConfig))
+	require.NoError(t, actualConfig.Validate())
+
+	expectedConfig, ok := NewFactory().CreateDefaultConfig().(*Config)
+	require.True(t, ok)
+	expectedConfig.CollectionInterval = 10 * time.Minute
+	expectedConfig.Timeout = 5 * time.Second
+	expectedConfig.Targets = []*targetConfig{
+		{Endpoint: "example.com:443"},
+		{Endpoint: "mail.example.com:587", StartTLS: "smtp"},
+		{
+			Endpoint: "ldap.example.com:389",
+			StartTLS: "ldap",
+			TLSSetting: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile: "/etc/ssl/internal-ca.pem",
+				},
+				ServerName: "ldap.internal",
+			},
+		},
+	}
+	expectedConfig.Files = []string{"/etc/ssl/certs/*.pem"}
+	require.Equal(t, expectedConfig, actualConfig)
+}
@@ -0,0 +1,98 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import "go.opentelemetry.io/collector/confmap"
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for tlscheck metrics.
+type MetricsConfig struct {
+	TlscheckChainValid MetricConfig `mapstructure:"tlscheck.chain.valid"`
+	TlscheckError      MetricConfig `mapstructure:"tlscheck.error"`
+	TlscheckNotAfter   MetricConfig `mapstructure:"tlscheck.not_after"`
+	TlscheckTimeLeft   MetricConfig `mapstructure:"tlscheck.time_left"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		TlscheckChainValid: MetricConfig{
+			Enabled: true,
+		},
+		TlscheckError: MetricConfig{
+			Enabled: true,
+		},
+		TlscheckNotAfter: MetricConfig{
+			Enabled: true,
+		},
+		TlscheckTimeLeft: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for tlscheck resource attributes.
+type ResourceAttributesConfig struct {
+	TlscheckEndpoint ResourceAttributeConfig `mapstructure:"tlscheck.endpoint"`
+	TlscheckFilePath ResourceAttributeConfig `mapstructure:"tlscheck.file.path"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		TlscheckEndpoint: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		TlscheckFilePath: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for tlscheck metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,372 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+type metricTlscheckChainValid struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.chain.valid metric with initial data.
+func (m *metricTlscheckChainValid) init() {
+	m.data.SetName("tlscheck.chain.valid")
+	m.data.SetDescription("1 if the certificate chain served by the endpoint is valid for its name, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricTlscheckChainValid) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckChainValid) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckChainValid) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckChainValid(cfg MetricConfig) metricTlscheckChainValid {
+	m := metricTlscheckChainValid{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckError struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.error metric with initial data.
+func (m *metricTlscheckError) init() {
+	m.data.SetName("tlscheck.error")
+	m.data.SetDescription("Records errors occurring during the check, including the chain validation errors.")
+	m.data.SetUnit("{error}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricTlscheckError) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, errorMessageAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("error.message", errorMessageAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckError) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckError) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckError(cfg MetricConfig) metricTlscheckError {
+	m := metricTlscheckError{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckNotAfter struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.not_after metric with initial data.
+func (m *metricTlscheckNotAfter) init() {
+	m.data.SetName("tlscheck.not_after")
+	m.data.SetDescription("Time at which the certificate expires, in seconds since the Unix epoch.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricTlscheckNotAfter) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlsCertSubjectAttributeValue string, tlsCertIssuerAttributeValue string, tlsCertSerialNumberAttributeValue string, tlsCertSansAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("tls.cert.subject", tlsCertSubjectAttributeValue)
+	dp.Attributes().PutStr("tls.cert.issuer", tlsCertIssuerAttributeValue)
+	dp.Attributes().PutStr("tls.cert.serial_number", tlsCertSerialNumberAttributeValue)
+	dp.Attributes().PutStr("tls.cert.sans", tlsCertSansAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckNotAfter) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckNotAfter) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckNotAfter(cfg MetricConfig) metricTlscheckNotAfter {
+	m := metricTlscheckNotAfter{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricTlscheckTimeLeft struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills tlscheck.time_left metric with initial data.
+func (m *metricTlscheckTimeLeft) init() {
+	m.data.SetName("tlscheck.time_left")
+	m.data.SetDescription("Time until the certificate expires, negative once expired.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricTlscheckTimeLeft) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, tlsCertSubjectAttributeValue string, tlsCertIssuerAttributeValue string, tlsCertSerialNumberAttributeValue string, tlsCertSansAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("tls.cert.subject", tlsCertSubjectAttributeValue)
+	dp.Attributes().PutStr("tls.cert.issuer", tlsCertIssuerAttributeValue)
+	dp.Attributes().PutStr("tls.cert.serial_number", tlsCertSerialNumberAttributeValue)
+	dp.Attributes().PutStr("tls.cert.sans", tlsCertSansAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricTlscheckTimeLeft) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricTlscheckTimeLeft) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricTlscheckTimeLeft(cfg MetricConfig) metricTlscheckTimeLeft {
+	m := metricTlscheckTimeLeft{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	config                   MetricsBuilderConfig // config of the metrics builder.
+	startTime                pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity          int                  // maximum observed number of metrics per resource.
+	metricsBuffer            pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                component.BuildInfo  // contains version information.
+	metricTlscheckChainValid metricTlscheckChainValid
+	metricTlscheckError      metricTlscheckError
+	metricTlscheckNotAfter   metricTlscheckNotAfter
+	metricTlscheckTimeLeft   metricTlscheckTimeLeft
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		config:                   mbc,
+		startTime:                pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:            pmetric.NewMetrics(),
+		buildInfo:                settings.BuildInfo,
+		metricTlscheckChainValid: newMetricTlscheckChainValid(mbc.Metrics.TlscheckChainValid),
+		metricTlscheckError:      newMetricTlscheckError(mbc.Metrics.TlscheckError),
+		metricTlscheckNotAfter:   newMetricTlscheckNotAfter(mbc.Metrics.TlscheckNotAfter),
+		metricTlscheckTimeLeft:   newMetricTlscheckTimeLeft(mbc.Metrics.TlscheckTimeLeft),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted metrics.
+func (mb *MetricsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(mb.config.ResourceAttributes)
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(pmetric.ResourceMetrics)
+
+// WithResource sets the provided resource on the emitted ResourceMetrics.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithResource(res pcommon.Resource) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		res.CopyTo(rm.Resource())
+	}
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/tlscheckreceiver")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricTlscheckChainValid.emit(ils.Metrics())
+	mb.metricTlscheckError.emit(ils.Metrics())
+	mb.metricTlscheckNotAfter.emit(ils.Metrics())
+	mb.metricTlscheckTimeLeft.emit(ils.Metrics())
+
+	for _, op := range rmo {
+		op(rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordTlscheckChainValidDataPoint adds a data point to tlscheck.chain.valid metric.
+func (mb *MetricsBuilder) RecordTlscheckChainValidDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricTlscheckChainValid.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordTlscheckErrorDataPoint adds a data point to tlscheck.error metric.
+func (mb *MetricsBuilder) RecordTlscheckErrorDataPoint(ts pcommon.Timestamp, val int64, errorMessageAttributeValue string) {
+	mb.metricTlscheckError.recordDataPoint(mb.startTime, ts, val, errorMessageAttributeValue)
+}
+
+// RecordTlscheckNotAfterDataPoint adds a data point to tlscheck.not_after metric.
+func (mb *MetricsBuilder) RecordTlscheckNotAfterDataPoint(ts pcommon.Timestamp, val int64, tlsCertSubjectAttributeValue string, tlsCertIssuerAttributeValue string, tlsCertSerialNumberAttributeValue string, tlsCertSansAttributeValue string) {
+	mb.metricTlscheckNotAfter.recordDataPoint(mb.startTime, ts, val, tlsCertSubjectAttributeValue, tlsCertIssuerAttributeValue, tlsCertSerialNumberAttributeValue, tlsCertSansAttributeValue)
+}
+
+// RecordTlscheckTimeLeftDataPoint adds a data point to tlscheck.time_left metric.
+func (mb *MetricsBuilder) RecordTlscheckTimeLeftDataPoint(ts pcommon.Timestamp, val int64, tlsCertSubjectAttributeValue string, tlsCertIssuerAttributeValue string, tlsCertSerialNumberAttributeValue string, tlsCertSansAttributeValue string) {
+	mb.metricTlscheckTimeLeft.recordDataPoint(mb.startTime, ts, val, tlsCertSubjectAttributeValue, tlsCertIssuerAttributeValue, tlsCertSerialNumberAttributeValue, tlsCertSansAttributeValue)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
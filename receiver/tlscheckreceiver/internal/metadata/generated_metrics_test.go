@@ -0,0 +1,182 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testConfigCollection int
+
+const (
+	testSetDefault testConfigCollection = iota
+	testSetAll
+	testSetNone
+)
+
+func TestMetricsBuilder(t *testing.T) {
+	tests := []struct {
+		name      string
+		configSet testConfigCollection
+	}{
+		{
+			name:      "default",
+			configSet: testSetDefault,
+		},
+		{
+			name:      "all_set",
+			configSet: testSetAll,
+		},
+		{
+			name:      "none_set",
+			configSet: testSetNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := pcommon.Timestamp(1_000_000_000)
+			ts := pcommon.Timestamp(1_000_001_000)
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			settings := receivertest.NewNopCreateSettings()
+			settings.Logger = zap.New(observedZapCore)
+			mb := NewMetricsBuilder(loadMetricsBuilderConfig(t, test.name), settings, WithStartTime(start))
+
+			expectedWarnings := 0
+
+			assert.Equal(t, expectedWarnings, observedLogs.Len())
+
+			defaultMetricsCount := 0
+			allMetricsCount := 0
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordTlscheckChainValidDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordTlscheckErrorDataPoint(ts, 1, "error.message-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordTlscheckNotAfterDataPoint(ts, 1, "tls.cert.subject-val", "tls.cert.issuer-val", "tls.cert.serial_number-val", "tls.cert.sans-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordTlscheckTimeLeftDataPoint(ts, 1, "tls.cert.subject-val", "tls.cert.issuer-val", "tls.cert.serial_number-val", "tls.cert.sans-val")
+
+			rb := mb.NewResourceBuilder()
+			rb.SetTlscheckEndpoint("tlscheck.endpoint-val")
+			rb.SetTlscheckFilePath("tlscheck.file.path-val")
+			res := rb.Emit()
+			metrics := mb.Emit(WithResource(res))
+
+			if test.configSet == testSetNone {
+				assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+				return
+			}
+
+			assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+			rm := metrics.ResourceMetrics().At(0)
+			assert.Equal(t, res, rm.Resource())
+			assert.Equal(t, 1, rm.ScopeMetrics().Len())
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			if test.configSet == testSetDefault {
+				assert.Equal(t, defaultMetricsCount, ms.Len())
+			}
+			if test.configSet == testSetAll {
+				assert.Equal(t, allMetricsCount, ms.Len())
+			}
+			validatedMetrics := make(map[string]bool)
+			for i := 0; i < ms.Len(); i++ {
+				switch ms.At(i).Name() {
+				case "tlscheck.chain.valid":
+					assert.False(t, validatedMetrics["tlscheck.chain.valid"], "Found a duplicate in the metrics slice: tlscheck.chain.valid")
+					validatedMetrics["tlscheck.chain.valid"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "1 if the certificate chain served by the endpoint is valid for its name, otherwise 0.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "tlscheck.error":
+					assert.False(t, validatedMetrics["tlscheck.error"], "Found a duplicate in the metrics slice: tlscheck.error")
+					validatedMetrics["tlscheck.error"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Records errors occurring during the check, including the chain validation errors.", ms.At(i).Description())
+					assert.Equal(t, "{error}", ms.At(i).Unit())
+					assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("error.message")
+					assert.True(t, ok)
+					assert.EqualValues(t, "error.message-val", attrVal.Str())
+				case "tlscheck.not_after":
+					assert.False(t, validatedMetrics["tlscheck.not_after"], "Found a duplicate in the metrics slice: tlscheck.not_after")
+					validatedMetrics["tlscheck.not_after"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Time at which the certificate expires, in seconds since the Unix epoch.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("tls.cert.subject")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.subject-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.issuer")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.issuer-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.serial_number")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.serial_number-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.sans")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.sans-val", attrVal.Str())
+				case "tlscheck.time_left":
+					assert.False(t, validatedMetrics["tlscheck.time_left"], "Found a duplicate in the metrics slice: tlscheck.time_left")
+					validatedMetrics["tlscheck.time_left"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Time until the certificate expires, negative once expired.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("tls.cert.subject")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.subject-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.issuer")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.issuer-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.serial_number")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.serial_number-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("tls.cert.sans")
+					assert.True(t, ok)
+					assert.EqualValues(t, "tls.cert.sans-val", attrVal.Str())
+				}
+			}
+		})
+	}
+}
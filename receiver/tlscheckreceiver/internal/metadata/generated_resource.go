@@ -0,0 +1,43 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourceBuilder is a helper struct to build resources predefined in metadata.yaml.
+// The ResourceBuilder is not thread-safe and must not to be used in multiple goroutines.
+type ResourceBuilder struct {
+	config ResourceAttributesConfig
+	res    pcommon.Resource
+}
+
+// NewResourceBuilder creates a new ResourceBuilder. This method should be called on the start of the application.
+func NewResourceBuilder(rac ResourceAttributesConfig) *ResourceBuilder {
+	return &ResourceBuilder{
+		config: rac,
+		res:    pcommon.NewResource(),
+	}
+}
+
+// SetTlscheckEndpoint sets provided value as "tlscheck.endpoint" attribute.
+func (rb *ResourceBuilder) SetTlscheckEndpoint(val string) {
+	if rb.config.TlscheckEndpoint.Enabled {
+		rb.res.Attributes().PutStr("tlscheck.endpoint", val)
+	}
+}
+
+// SetTlscheckFilePath sets provided value as "tlscheck.file.path" attribute.
+func (rb *ResourceBuilder) SetTlscheckFilePath(val string) {
+	if rb.config.TlscheckFilePath.Enabled {
+		rb.res.Attributes().PutStr("tlscheck.file.path", val)
+	}
+}
+
+// Emit returns the built resource and resets the internal builder state.
+func (rb *ResourceBuilder) Emit() pcommon.Resource {
+	r := rb.res
+	rb.res = pcommon.NewResource()
+	return r
+}
@@ -0,0 +1,46 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBuilder(t *testing.T) {
+	for _, test := range []string{"default", "all_set", "none_set"} {
+		t.Run(test, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, test)
+			rb := NewResourceBuilder(cfg)
+			rb.SetTlscheckEndpoint("tlscheck.endpoint-val")
+			rb.SetTlscheckFilePath("tlscheck.file.path-val")
+
+			res := rb.Emit()
+			assert.Equal(t, 0, rb.Emit().Attributes().Len()) // Second call should return empty Resource
+
+			switch test {
+			case "default":
+				assert.Equal(t, 2, res.Attributes().Len())
+			case "all_set":
+				assert.Equal(t, 2, res.Attributes().Len())
+			case "none_set":
+				assert.Equal(t, 0, res.Attributes().Len())
+				return
+			default:
+				assert.Failf(t, "unexpected test case: %s", test)
+			}
+
+			val, ok := res.Attributes().Get("tlscheck.endpoint")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "tlscheck.endpoint-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("tlscheck.file.path")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "tlscheck.file.path-val", val.Str())
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
+)
+
+var errNoCertificate = errors.New("no certificate found")
+
+type tlscheckScraper struct {
+	tlsConfigs []*tls.Config
+	cfg        *Config
+	settings   component.TelemetrySettings
+	mb         *metadata.MetricsBuilder
+}
+
+// targetResult is the outcome of the check of a target
+type targetResult struct {
+	certs []*x509.Certificate
+	// chainErr is the chain validation error, only meaningful if the chain was validated
+	chainErr  error
+	validated bool
+	err       error
+}
+
+// start starts the scraper by loading the TLS configuration of each target
+func (s *tlscheckScraper) start(_ context.Context, _ component.Host) error {
+	var err error
+	for _, target := range s.cfg.Targets {
+		tlsConfig, loadErr := target.TLSSetting.LoadTLSConfig()
+		if loadErr != nil {
+			err = multierr.Append(err, fmt.Errorf("failed to load the TLS configuration of %s: %w", target.Endpoint, loadErr))
+		}
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		s.tlsConfigs = append(s.tlsConfigs, tlsConfig)
+	}
+	return err
+}
+
+// checkTarget connects to the target and retrieves the certificate chain it serves
+func (s *tlscheckScraper) checkTarget(ctx context.Context, target *targetConfig, tlsConfig *tls.Config) (result targetResult) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", target.Endpoint)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if result.err = conn.SetDeadline(deadline); result.err != nil {
+			return result
+		}
+	}
+
+	if upgrade, ok := startTLSUpgrades[target.StartTLS]; ok {
+		if result.err = upgrade(conn); result.err != nil {
+			return result
+		}
+	}
+
+	config := tlsConfig.Clone()
+	if config.ServerName == "" {
+		config.ServerName, _, _ = net.SplitHostPort(target.Endpoint)
+	}
+	// The chain is validated after the handshake so that the certificates of invalid chains are reported too
+	result.validated = !config.InsecureSkipVerify
+	config.InsecureSkipVerify = true
+	if target.StartTLS == "" && len(config.NextProtos) == 0 {
+		// gRPC servers require the negotiation of HTTP/2
+		config.NextProtos = []string{"h2", "http/1.1"}
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if result.err = tlsConn.HandshakeContext(ctx); result.err != nil {
+		result.validated = false
+		return result
+	}
+	result.certs = tlsConn.ConnectionState().PeerCertificates
+	if len(result.certs) == 0 {
+		result.err = errNoCertificate
+		result.validated = false
+		return result
+	}
+
+	if result.validated {
+		intermediates := x509.NewCertPool()
+		for _, cert := range result.certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, result.chainErr = result.certs[0].Verify(x509.VerifyOptions{
+			DNSName:       config.ServerName,
+			Roots:         config.RootCAs,
+			Intermediates: intermediates,
+		})
+	}
+	return result
+}
+
+// loadCertificates loads the certificates of a PEM file
+func loadCertificates(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return certs, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errNoCertificate
+	}
+	return certs, nil
+}
+
+// subjectAltNames returns the comma separated subject alternative names of a certificate
+func subjectAltNames(cert *x509.Certificate) string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	names = append(names, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	return strings.Join(names, ",")
+}
+
+func (s *tlscheckScraper) recordCertificates(now pcommon.Timestamp, certs []*x509.Certificate) {
+	for _, cert := range certs {
+		subject := cert.Subject.String()
+		issuer := cert.Issuer.String()
+		serialNumber := cert.SerialNumber.Text(16)
+		sans := subjectAltNames(cert)
+		s.mb.RecordTlscheckNotAfterDataPoint(now, cert.NotAfter.Unix(), subject, issuer, serialNumber, sans)
+		s.mb.RecordTlscheckTimeLeftDataPoint(now, int64(cert.NotAfter.Sub(now.AsTime()).Seconds()), subject, issuer, serialNumber, sans)
+	}
+}
+
+// scrape checks the certificates of the targets and files
+func (s *tlscheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if s.cfg == nil || len(s.tlsConfigs) != len(s.cfg.Targets) {
+		return pmetric.NewMetrics(), errors.New("scraper not started")
+	}
+
+	results := make([]targetResult, len(s.cfg.Targets))
+	var wg sync.WaitGroup
+	wg.Add(len(s.cfg.Targets))
+	for i, target := range s.cfg.Targets {
+		go func(i int, target *targetConfig) {
+			defer wg.Done()
+			results[i] = s.checkTarget(ctx, target, s.tlsConfigs[i])
+		}(i, target)
+	}
+	wg.Wait()
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i, target := range s.cfg.Targets {
+		result := results[i]
+		s.recordCertificates(now, result.certs)
+		if result.validated {
+			var valid int64
+			if result.chainErr == nil {
+				valid = 1
+			} else {
+				s.mb.RecordTlscheckErrorDataPoint(now, int64(1), result.chainErr.Error())
+			}
+			s.mb.RecordTlscheckChainValidDataPoint(now, valid)
+		}
+		if result.err != nil {
+			s.settings.Logger.Debug("failed to check target", zap.String("endpoint", target.Endpoint), zap.Error(result.err))
+			s.mb.RecordTlscheckErrorDataPoint(now, int64(1), result.err.Error())
+		}
+
+		rb := s.mb.NewResourceBuilder()
+		rb.SetTlscheckEndpoint(target.Endpoint)
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	seen := map[string]bool{}
+	for _, pattern := range s.cfg.Files {
+		// The patterns are validated by the configuration
+		paths, _ := filepath.Glob(pattern)
+		if len(paths) == 0 {
+			s.settings.Logger.Debug("no file matches the pattern", zap.String("pattern", pattern))
+		}
+		for _, path := range paths {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			certs, err := loadCertificates(path)
+			s.recordCertificates(now, certs)
+			if err != nil {
+				s.mb.RecordTlscheckErrorDataPoint(now, int64(1), err.Error())
+			}
+
+			rb := s.mb.NewResourceBuilder()
+			rb.SetTlscheckFilePath(path)
+			s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+		}
+	}
+
+	return s.mb.Emit(), nil
+}
+
+func newScraper(conf *Config, settings receiver.CreateSettings) *tlscheckScraper {
+	return &tlscheckScraper{
+		cfg:      conf,
+		settings: settings.TelemetrySettings,
+		mb:       metadata.NewMetricsBuilder(conf.MetricsBuilderConfig, settings),
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCertificate creates a certificate signed by the parent, or a self-signed one if the parent is nil
+func newTestCertificate(t *testing.T, serialNumber int64, commonName string, notAfter time.Time, parent *testCertificate) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serialNumber),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-48 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	signer := testCertificate{cert: template, key: key}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{"localhost"}
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		signer = *parent
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer.cert, &key.PublicKey, signer.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCertificate{cert: cert, key: key}
+}
+
+func (c testCertificate) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key}
+}
+
+func writePEM(t *testing.T, path string, certs ...testCertificate) {
+	var data []byte
+	for _, c := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})...)
+	}
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+// newTLSServer serves TLS connections, after negotiating STARTTLS with SMTP if smtp is set
+func newTLSServer(t *testing.T, cert testCertificate, smtp bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	config := &tls.Config{Certificates: []tls.Certificate{cert.tlsCertificate()}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if smtp {
+					text := textproto.NewConn(conn)
+					_ = text.PrintfLine("220 localhost ESMTP")
+					if _, err := text.ReadLine(); err != nil {
+						return
+					}
+					_ = text.PrintfLine("250 STARTTLS")
+					if _, err := text.ReadLine(); err != nil {
+						return
+					}
+					_ = text.PrintfLine("220 Ready to start TLS")
+				}
+				_ = tls.Server(conn, config).Handshake()
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func resourceMetrics(t *testing.T, metrics pmetric.Metrics, key, value string) pmetric.MetricSlice {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		rm := metrics.ResourceMetrics().At(i)
+		if v, ok := rm.Resource().Attributes().Get(key); ok && v.Str() == value {
+			return rm.ScopeMetrics().At(0).Metrics()
+		}
+	}
+	require.Failf(t, "resource not found", "%s=%s", key, value)
+	return pmetric.NewMetricSlice()
+}
+
+func dataPoints(metrics pmetric.MetricSlice, name string) pmetric.NumberDataPointSlice {
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.Name() != name {
+			continue
+		}
+		if metric.Type() == pmetric.MetricTypeSum {
+			return metric.Sum().DataPoints()
+		}
+		return metric.Gauge().DataPoints()
+	}
+	return pmetric.NewNumberDataPointSlice()
+}
+
+func TestScraper(t *testing.T) {
+	dir := t.TempDir()
+	notAfter := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	ca := newTestCertificate(t, 1, "Test CA", notAfter.Add(time.Hour), nil)
+	leaf := newTestCertificate(t, 0x2a, "localhost", notAfter, &ca)
+	expired := newTestCertificate(t, 3, "expired", time.Now().Add(-24*time.Hour), &ca)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	writePEM(t, caFile, ca)
+	writePEM(t, filepath.Join(dir, "bundle.pem"), leaf, ca)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.pem"), []byte("not a certificate"), 0600))
+
+	validEndpoint := newTLSServer(t, leaf, false)
+	smtpEndpoint := newTLSServer(t, leaf, true)
+	expiredEndpoint := newTLSServer(t, expired, false)
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedEndpoint := closedListener.Addr().String()
+	require.NoError(t, closedListener.Close())
+
+	trustCA := configtls.TLSClientSetting{TLSSetting: configtls.TLSSetting{CAFile: caFile}}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = 5 * time.Second
+	cfg.Targets = []*targetConfig{
+		{Endpoint: validEndpoint, TLSSetting: trustCA},
+		{Endpoint: smtpEndpoint, StartTLS: "smtp", TLSSetting: trustCA},
+		{Endpoint: expiredEndpoint, TLSSetting: trustCA},
+		{Endpoint: closedEndpoint},
+	}
+	cfg.Files = []string{filepath.Join(dir, "*.pem"), filepath.Join(dir, "bundle.pem")}
+	require.NoError(t, cfg.Validate())
+
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	// Each target and each file, without duplicates
+	require.Equal(t, 7, metrics.ResourceMetrics().Len())
+
+	for _, endpoint := range []string{validEndpoint, smtpEndpoint} {
+		rm := resourceMetrics(t, metrics, "tlscheck.endpoint", endpoint)
+		notAfterPoints := dataPoints(rm, "tlscheck.not_after")
+		require.Equal(t, 1, notAfterPoints.Len())
+		assert.Equal(t, notAfter.Unix(), notAfterPoints.At(0).IntValue())
+		attributes := notAfterPoints.At(0).Attributes().AsRaw()
+		assert.Equal(t, map[string]any{
+			"tls.cert.subject":       "CN=localhost",
+			"tls.cert.issuer":        "CN=Test CA",
+			"tls.cert.serial_number": "2a",
+			"tls.cert.sans":          "localhost,127.0.0.1",
+		}, attributes)
+		timeLeft := dataPoints(rm, "tlscheck.time_left").At(0).IntValue()
+		assert.InDelta(t, time.Until(notAfter).Seconds(), timeLeft, 60)
+		assert.EqualValues(t, 1, dataPoints(rm, "tlscheck.chain.valid").At(0).IntValue())
+		assert.Equal(t, 0, dataPoints(rm, "tlscheck.error").Len())
+	}
+
+	rm := resourceMetrics(t, metrics, "tlscheck.endpoint", expiredEndpoint)
+	assert.Less(t, dataPoints(rm, "tlscheck.time_left").At(0).IntValue(), int64(0))
+	assert.EqualValues(t, 0, dataPoints(rm, "tlscheck.chain.valid").At(0).IntValue())
+	errorPoint := dataPoints(rm, "tlscheck.error").At(0)
+	errorMessage, _ := errorPoint.Attributes().Get("error.message")
+	assert.Contains(t, errorMessage.Str(), "expired")
+
+	rm = resourceMetrics(t, metrics, "tlscheck.endpoint", closedEndpoint)
+	assert.Equal(t, 0, dataPoints(rm, "tlscheck.not_after").Len())
+	assert.Equal(t, 0, dataPoints(rm, "tlscheck.chain.valid").Len())
+	assert.Equal(t, 1, dataPoints(rm, "tlscheck.error").Len())
+
+	rm = resourceMetrics(t, metrics, "tlscheck.file.path", filepath.Join(dir, "bundle.pem"))
+	assert.Equal(t, 2, dataPoints(rm, "tlscheck.not_after").Len())
+	assert.Equal(t, 0, dataPoints(rm, "tlscheck.chain.valid").Len())
+
+	rm = resourceMetrics(t, metrics, "tlscheck.file.path", filepath.Join(dir, "key.pem"))
+	assert.Equal(t, 0, dataPoints(rm, "tlscheck.not_after").Len())
+	errorMessage, _ = dataPoints(rm, "tlscheck.error").At(0).Attributes().Get("error.message")
+	assert.Equal(t, errNoCertificate.Error(), errorMessage.Str())
+}
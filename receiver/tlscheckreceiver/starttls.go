@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"strings"
+)
+
+// startTLSUpgrades are the negotiations upgrading plain text connections to TLS, by protocol
+var startTLSUpgrades = map[string]func(conn net.Conn) error{
+	"smtp":     startTLSSMTP,
+	"imap":     startTLSIMAP,
+	"pop3":     startTLSPOP3,
+	"ldap":     startTLSLDAP,
+	"postgres": startTLSPostgres,
+}
+
+// startTLSSMTP negotiates TLS as described by RFC 3207
+func startTLSSMTP(conn net.Conn) error {
+	text := textproto.NewConn(conn)
+	if _, _, err := text.ReadResponse(220); err != nil {
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	if _, err = text.Cmd("EHLO %s", hostname); err != nil {
+		return err
+	}
+	if _, _, err = text.ReadResponse(250); err != nil {
+		return fmt.Errorf("smtp EHLO: %w", err)
+	}
+
+	if _, err = text.Cmd("STARTTLS"); err != nil {
+		return err
+	}
+	if _, _, err = text.ReadResponse(220); err != nil {
+		return fmt.Errorf("smtp STARTTLS: %w", err)
+	}
+	return nil
+}
+
+// startTLSIMAP negotiates TLS as described by RFC 2595
+func startTLSIMAP(conn net.Conn) error {
+	text := textproto.NewConn(conn)
+	greeting, err := text.ReadLine()
+	if err != nil {
+		return fmt.Errorf("imap greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting, "* OK") {
+		return fmt.Errorf("imap greeting: unexpected response %q", greeting)
+	}
+
+	if err = text.PrintfLine("a001 STARTTLS"); err != nil {
+		return err
+	}
+	// Skip the untagged responses sent before the completion of the command
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return fmt.Errorf("imap STARTTLS: %w", err)
+		}
+		if !strings.HasPrefix(line, "a001 ") {
+			continue
+		}
+		if !strings.HasPrefix(line, "a001 OK") {
+			return fmt.Errorf("imap STARTTLS: unexpected response %q", line)
+		}
+		return nil
+	}
+}
+
+// startTLSPOP3 negotiates TLS as described by RFC 2595
+func startTLSPOP3(conn net.Conn) error {
+	text := textproto.NewConn(conn)
+	greeting, err := text.ReadLine()
+	if err != nil {
+		return fmt.Errorf("pop3 greeting: %w", err)
+	}
+	if !strings.HasPrefix(greeting, "+OK") {
+		return fmt.Errorf("pop3 greeting: unexpected response %q", greeting)
+	}
+
+	if err = text.PrintfLine("STLS"); err != nil {
+		return err
+	}
+	line, err := text.ReadLine()
+	if err != nil {
+		return fmt.Errorf("pop3 STLS: %w", err)
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return fmt.Errorf("pop3 STLS: unexpected response %q", line)
+	}
+	return nil
+}
+
+// ldapStartTLSRequest is the BER encoding of the StartTLS extended request of RFC 4511, with the message ID 1
+var ldapStartTLSRequest = append([]byte{
+	0x30, 0x1d, // LDAPMessage
+	0x02, 0x01, 0x01, // messageID
+	0x77, 0x18, // ExtendedRequest
+	0x80, 0x16, // requestName
+}, "1.3.6.1.4.1.1466.20037"...)
+
+// startTLSLDAP negotiates TLS as described by RFC 4511
+func startTLSLDAP(conn net.Conn) error {
+	if _, err := conn.Write(ldapStartTLSRequest); err != nil {
+		return err
+	}
+
+	message, err := readBERElement(conn)
+	if err != nil {
+		return fmt.Errorf("ldap StartTLS: %w", err)
+	}
+	tag, content, _, err := parseBERElement(message)
+	if err != nil || tag != 0x30 {
+		return errors.New("ldap StartTLS: malformed response")
+	}
+	// Skip the message ID to the ExtendedResponse
+	if _, _, content, err = parseBERElement(content); err != nil {
+		return errors.New("ldap StartTLS: malformed response")
+	}
+	if tag, content, _, err = parseBERElement(content); err != nil || tag != 0x78 {
+		return errors.New("ldap StartTLS: malformed response")
+	}
+	tag, resultCode, _, err := parseBERElement(content)
+	if err != nil || tag != 0x0a || len(resultCode) != 1 {
+		return errors.New("ldap StartTLS: malformed response")
+	}
+	if resultCode[0] != 0 {
+		return fmt.Errorf("ldap StartTLS: result code %d", resultCode[0])
+	}
+	return nil
+}
+
+// readBERElement reads a single BER element, without reading past its end
+func readBERElement(r io.Reader) ([]byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	length := int(header[1])
+	if length&0x80 != 0 {
+		lengthBytes := make([]byte, length&0x7f)
+		if len(lengthBytes) == 0 || len(lengthBytes) > 3 {
+			return nil, errors.New("unsupported BER length")
+		}
+		if _, err := io.ReadFull(r, lengthBytes); err != nil {
+			return nil, err
+		}
+		header = append(header, lengthBytes...)
+		length = 0
+		for _, b := range lengthBytes {
+			length = length<<8 | int(b)
+		}
+	}
+
+	element := make([]byte, len(header)+length)
+	copy(element, header)
+	if _, err := io.ReadFull(r, element[len(header):]); err != nil {
+		return nil, err
+	}
+	return element, nil
+}
+
+// parseBERElement splits the first BER element of b into its tag and content
+func parseBERElement(b []byte) (tag byte, content []byte, rest []byte, err error) {
+	if len(b) < 2 {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+	tag, length, b := b[0], int(b[1]), b[2:]
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 3 || len(b) < n {
+			return 0, nil, nil, errors.New("unsupported BER length")
+		}
+		length = 0
+		for _, c := range b[:n] {
+			length = length<<8 | int(c)
+		}
+		b = b[n:]
+	}
+	if len(b) < length {
+		return 0, nil, nil, io.ErrUnexpectedEOF
+	}
+	return tag, b[:length], b[length:], nil
+}
+
+// postgresSSLRequestCode is the code of the SSLRequest message of the PostgreSQL protocol
+const postgresSSLRequestCode = 80877103
+
+// startTLSPostgres negotiates TLS with the SSLRequest message of the PostgreSQL protocol
+func startTLSPostgres(conn net.Conn) error {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequestCode)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return fmt.Errorf("postgres SSLRequest: %w", err)
+	}
+	if response[0] != 'S' {
+		return errors.New("postgres SSLRequest: the server does not support SSL")
+	}
+	return nil
+}
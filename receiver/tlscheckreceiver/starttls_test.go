@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// textServer replies to each command of a line based protocol, after sending the greeting
+func textServer(greeting string, replies map[string]string) func(t *testing.T, conn net.Conn) {
+	return func(t *testing.T, conn net.Conn) {
+		text := textproto.NewConn(conn)
+		assert.NoError(t, text.PrintfLine("%s", greeting))
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command, _, _ := strings.Cut(line, " ")
+			reply, ok := replies[command]
+			if !ok {
+				reply = replies[line]
+			}
+			if _, err = io.WriteString(conn, reply); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ldapServer replies to the StartTLS extended request with the given result code
+func ldapServer(resultCode byte) func(t *testing.T, conn net.Conn) {
+	return func(t *testing.T, conn net.Conn) {
+		request, err := readBERElement(conn)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, ldapStartTLSRequest, request)
+		_, _ = conn.Write([]byte{
+			0x30, 0x0c,
+			0x02, 0x01, 0x01,
+			0x78, 0x07,
+			0x0a, 0x01, resultCode,
+			0x04, 0x00,
+			0x04, 0x00,
+		})
+	}
+}
+
+// postgresServer replies to the SSLRequest message with the given response
+func postgresServer(response byte) func(t *testing.T, conn net.Conn) {
+	return func(t *testing.T, conn net.Conn) {
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(conn, request); !assert.NoError(t, err) {
+			return
+		}
+		assert.EqualValues(t, 8, binary.BigEndian.Uint32(request[0:4]))
+		assert.EqualValues(t, postgresSSLRequestCode, binary.BigEndian.Uint32(request[4:8]))
+		_, _ = conn.Write([]byte{response})
+	}
+}
+
+func TestStartTLS(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		protocol    string
+		server      func(t *testing.T, conn net.Conn)
+		expectedErr string
+	}{
+		{
+			desc:     "smtp",
+			protocol: "smtp",
+			server: textServer("220 mail.example.com ESMTP", map[string]string{
+				"EHLO":     "250-mail.example.com\r\n250-PIPELINING\r\n250 STARTTLS\r\n",
+				"STARTTLS": "220 Ready to start TLS\r\n",
+			}),
+		},
+		{
+			desc:     "smtp without STARTTLS",
+			protocol: "smtp",
+			server: textServer("220 mail.example.com ESMTP", map[string]string{
+				"EHLO":     "250 mail.example.com\r\n",
+				"STARTTLS": "502 Command not implemented\r\n",
+			}),
+			expectedErr: "smtp STARTTLS",
+		},
+		{
+			desc:     "imap",
+			protocol: "imap",
+			server: textServer("* OK IMAP4rev1 ready", map[string]string{
+				"a001": "* BYE not really\r\na001 OK Begin TLS negotiation now\r\n",
+			}),
+		},
+		{
+			desc:     "imap without STARTTLS",
+			protocol: "imap",
+			server: textServer("* OK IMAP4rev1 ready", map[string]string{
+				"a001": "a001 BAD unknown command\r\n",
+			}),
+			expectedErr: "imap STARTTLS",
+		},
+		{
+			desc:     "pop3",
+			protocol: "pop3",
+			server: textServer("+OK POP3 ready", map[string]string{
+				"STLS": "+OK Begin TLS negotiation\r\n",
+			}),
+		},
+		{
+			desc:        "pop3 unexpected greeting",
+			protocol:    "pop3",
+			server:      textServer("-ERR go away", nil),
+			expectedErr: "pop3 greeting",
+		},
+		{
+			desc:     "ldap",
+			protocol: "ldap",
+			server:   ldapServer(0),
+		},
+		{
+			desc:        "ldap unavailable",
+			protocol:    "ldap",
+			server:      ldapServer(52),
+			expectedErr: "ldap StartTLS: result code 52",
+		},
+		{
+			desc:     "postgres",
+			protocol: "postgres",
+			server:   postgresServer('S'),
+		},
+		{
+			desc:        "postgres without SSL",
+			protocol:    "postgres",
+			server:      postgresServer('N'),
+			expectedErr: "the server does not support SSL",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				defer server.Close()
+				tc.server(t, server)
+			}()
+
+			err := startTLSUpgrades[tc.protocol](client)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			client.Close()
+			<-done
+		})
+	}
+}
+
+func TestParseBERElement(t *testing.T) {
+	long := append([]byte{0x04, 0x81, 0x80}, make([]byte, 0x80)...)
+	tag, content, rest, err := parseBERElement(append(long, 0x05, 0x00))
+	require.NoError(t, err)
+	assert.EqualValues(t, 0x04, tag)
+	assert.Len(t, content, 0x80)
+	assert.Equal(t, []byte{0x05, 0x00}, rest)
+
+	_, _, _, err = parseBERElement([]byte{0x04, 0x05, 0x00})
+	assert.Error(t, err)
+	_, _, _, err = parseBERElement([]byte{0x04, 0x80})
+	assert.Error(t, err)
+}
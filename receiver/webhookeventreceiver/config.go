@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.uber.org/multierr"
 )
 
@@ -26,6 +27,7 @@ type Config struct {
 	Path                          string                   `mapstructure:"path"`            // path for data collection. Default is <host>:<port>/services/collector
 	HealthPath                    string                   `mapstructure:"health_path"`     // path for health check api. Default is /services/collector/health
 	RequiredHeader                RequiredHeader           `mapstructure:"required_header"` // optional setting to set a required header for all requests to have
+	Secret                        configopaque.String      `mapstructure:"secret"`          // optional webhook secret the GitHub signatures and GitLab tokens of all requests are verified with
 }
 
 type RequiredHeader struct {
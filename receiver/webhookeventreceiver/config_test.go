@@ -134,6 +134,7 @@ func TestLoadConfig(t *testing.T) {
 			Key:   "key-present",
 			Value: "value-present",
 		},
+		Secret: "webhook-secret",
 	}
 
 	// create expected config
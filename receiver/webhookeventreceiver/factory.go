@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
 )
 
@@ -19,6 +20,7 @@ const (
 	// Default endpoints to bind to.
 	// defaultEndpoint = "localhost:8080"
 	scopeLogName        = "otlp/" + metadata.Type
+	scopeTraceName      = "otlp/" + metadata.Type
 	defaultReadTimeout  = "500ms"
 	defaultWriteTimeout = "500ms"
 	defaultPath         = "/events"
@@ -31,6 +33,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
 	)
 }
 
@@ -51,6 +54,39 @@ func createLogsReceiver(
 	cfg component.Config,
 	consumer consumer.Logs,
 ) (receiver.Logs, error) {
+	var err error
+	var recv receiver.Logs
 	conf := cfg.(*Config)
-	return newLogsReceiver(params, *conf, consumer)
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		recv, err = newLogsReceiver(params, *conf, consumer)
+		return recv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*eventReceiver).logConsumer = consumer
+	return r, nil
 }
+
+// createTracesReceiver creates a traces receiver based on provided config.
+func createTracesReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Traces,
+) (receiver.Traces, error) {
+	var err error
+	var recv receiver.Traces
+	conf := cfg.(*Config)
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		recv, err = newTracesReceiver(params, *conf, consumer)
+		return recv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*eventReceiver).traceConsumer = consumer
+	return r, nil
+}
+
+var receivers = sharedcomponent.NewSharedComponents()
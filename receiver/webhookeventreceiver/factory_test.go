@@ -69,3 +69,34 @@ func TestCreateLogsReceiver(t *testing.T) {
 		t.Run(test.desc, test.run)
 	}
 }
+
+func TestCreateTracesReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:8080"
+	require.NoError(t, cfg.Validate(), "error validating default config")
+
+	tracesReceiver, err := createTracesReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err, "failed to create traces receiver")
+
+	logsReceiver, err := createLogsReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err, "failed to create logs receiver")
+	require.Same(t, tracesReceiver, logsReceiver, "the logs and traces receivers of a config should share their server")
+
+	_, err = createTracesReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		createDefaultConfig(),
+		nil,
+	)
+	require.Error(t, err, "Succeeded in creating a receiver without a consumer")
+}
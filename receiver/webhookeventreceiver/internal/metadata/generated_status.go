@@ -7,6 +7,7 @@ import (
 )
 
 const (
-	Type          = "webhookevent"
-	LogsStability = component.StabilityLevelAlpha
+	Type            = "webhookevent"
+	LogsStability   = component.StabilityLevelAlpha
+	TracesStability = component.StabilityLevelDevelopment
 )
@@ -5,6 +5,7 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
@@ -26,23 +28,33 @@ import (
 
 var (
 	errNilLogsConsumer       = errors.New("missing a logs consumer")
+	errNilTracesConsumer     = errors.New("missing a traces consumer")
 	errMissingEndpoint       = errors.New("missing a receiver endpoint")
 	errInvalidRequestMethod  = errors.New("invalid method. Valid method is POST")
 	errInvalidEncodingType   = errors.New("invalid encoding type")
 	errEmptyResponseBody     = errors.New("request body content length is zero")
 	errMissingRequiredHeader = errors.New("request was missing required header or incorrect header value")
+	errInvalidSecret         = errors.New("request signature or token does not match the webhook secret")
+	errRequestTooLarge       = errors.New("request body exceeds the maximum request body size")
 )
 
-const healthyResponse = `{"text": "Webhookevent receiver is healthy"}`
+const (
+	healthyResponse = `{"text": "Webhookevent receiver is healthy"}`
+
+	// defaultMaxRequestBodySize is the maximum size of the request bodies, before and after
+	// decompression, when max_request_body_size is not set
+	defaultMaxRequestBodySize = 10 << 20
+)
 
 type eventReceiver struct {
-	settings    receiver.CreateSettings
-	cfg         *Config
-	logConsumer consumer.Logs
-	server      *http.Server
-	shutdownWG  sync.WaitGroup
-	obsrecv     *receiverhelper.ObsReport
-	gzipPool    *sync.Pool
+	settings      receiver.CreateSettings
+	cfg           *Config
+	logConsumer   consumer.Logs
+	traceConsumer consumer.Traces
+	server        *http.Server
+	shutdownWG    sync.WaitGroup
+	obsrecv       *receiverhelper.ObsReport
+	gzipPool      *sync.Pool
 }
 
 func newLogsReceiver(params receiver.CreateSettings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
@@ -50,6 +62,30 @@ func newLogsReceiver(params receiver.CreateSettings, cfg Config, consumer consum
 		return nil, errNilLogsConsumer
 	}
 
+	er, err := newEventReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	er.logConsumer = consumer
+
+	return er, nil
+}
+
+func newTracesReceiver(params receiver.CreateSettings, cfg Config, consumer consumer.Traces) (receiver.Traces, error) {
+	if consumer == nil {
+		return nil, errNilTracesConsumer
+	}
+
+	er, err := newEventReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	er.traceConsumer = consumer
+
+	return er, nil
+}
+
+func newEventReceiver(params receiver.CreateSettings, cfg Config) (*eventReceiver, error) {
 	if cfg.Endpoint == "" {
 		return nil, errMissingEndpoint
 	}
@@ -71,11 +107,10 @@ func newLogsReceiver(params receiver.CreateSettings, cfg Config, consumer consum
 
 	// create eventReceiver instance
 	er := &eventReceiver{
-		settings:    params,
-		cfg:         &cfg,
-		logConsumer: consumer,
-		obsrecv:     obsrecv,
-		gzipPool:    &sync.Pool{New: func() any { return new(gzip.Reader) }},
+		settings: params,
+		cfg:      &cfg,
+		obsrecv:  obsrecv,
+		gzipPool: &sync.Pool{New: func() any { return new(gzip.Reader) }},
 	}
 
 	return er, nil
@@ -147,7 +182,6 @@ func (er *eventReceiver) Shutdown(_ context.Context) error {
 // handleReq handles incoming request from webhook. On success returns a 200 response code to the webhook
 func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	ctx := r.Context()
-	ctx = er.obsrecv.StartLogsOp(ctx)
 
 	if r.Method != http.MethodPost {
 		er.failBadReq(ctx, w, http.StatusBadRequest, errInvalidRequestMethod)
@@ -170,10 +204,16 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 	}
 
 	if r.ContentLength == 0 {
-		er.obsrecv.EndLogsOp(ctx, metadata.Type, 0, nil)
 		er.failBadReq(ctx, w, http.StatusBadRequest, errEmptyResponseBody)
+		return
 	}
 
+	maxBodySize := er.cfg.MaxRequestBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = defaultMaxRequestBodySize
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
 	bodyReader := r.Body
 	// gzip encoded case
 	if encoding == "gzip" || encoding == "x-gzip" {
@@ -190,19 +230,54 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 		defer er.gzipPool.Put(reader)
 	}
 
-	// finish reading the body into a log
-	sc := bufio.NewScanner(bodyReader)
-	ld, numLogs := reqToLog(sc, r.URL.Query(), er.cfg, er.settings)
-	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
-
+	// the body is read at once, as the signatures are computed over all of it and both signals are built from it.
+	// The decompressed body is limited as well, reading one more byte to detect larger bodies
+	body, err := io.ReadAll(io.LimitReader(bodyReader, maxBodySize+1))
 	_ = bodyReader.Close()
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || int64(len(body)) > maxBodySize {
+		er.failBadReq(ctx, w, http.StatusRequestEntityTooLarge, errRequestTooLarge)
+		return
+	}
+	if err != nil {
+		er.failBadReq(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	if er.cfg.Secret != "" && !verifySecret(r.Header, body, string(er.cfg.Secret)) {
+		er.failBadReq(ctx, w, http.StatusUnauthorized, errInvalidSecret)
+		return
+	}
+
+	// the traces are parsed before either signal is consumed, so that an invalid event is rejected
+	// without its logs being consumed
+	var td ptrace.Traces
+	if er.traceConsumer != nil {
+		if td, err = reqToTraces(r.Header, body, r.URL.Query(), er.settings); err != nil {
+			er.failBadReq(ctx, w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	var consumerErr error
+	if er.logConsumer != nil {
+		logsCtx := er.obsrecv.StartLogsOp(ctx)
+		sc := bufio.NewScanner(bytes.NewReader(body))
+		ld, numLogs := reqToLog(sc, r.URL.Query(), er.cfg, er.settings)
+		consumerErr = er.logConsumer.ConsumeLogs(logsCtx, ld)
+		er.obsrecv.EndLogsOp(logsCtx, metadata.Type, numLogs, consumerErr)
+	}
+
+	if er.traceConsumer != nil && consumerErr == nil && td.SpanCount() > 0 {
+		tracesCtx := er.obsrecv.StartTracesOp(ctx)
+		consumerErr = er.traceConsumer.ConsumeTraces(tracesCtx, td)
+		er.obsrecv.EndTracesOp(tracesCtx, metadata.Type, td.SpanCount(), consumerErr)
+	}
 
 	if consumerErr != nil {
 		er.failBadReq(ctx, w, http.StatusInternalServerError, consumerErr)
-		er.obsrecv.EndLogsOp(ctx, metadata.Type, numLogs, nil)
 	} else {
 		w.WriteHeader(http.StatusOK)
-		er.obsrecv.EndLogsOp(ctx, metadata.Type, numLogs, nil)
 	}
 }
 
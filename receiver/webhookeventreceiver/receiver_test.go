@@ -11,6 +11,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -155,6 +157,9 @@ func TestFailedReq(t *testing.T) {
 	headerCfg.Endpoint = "localhost:0"
 	headerCfg.RequiredHeader.Key = "key-present"
 	headerCfg.RequiredHeader.Value = "value-present"
+	limitCfg := createDefaultConfig().(*Config)
+	limitCfg.Endpoint = "localhost:0"
+	limitCfg.MaxRequestBodySize = 64
 
 	tests := []struct {
 		desc   string
@@ -204,6 +209,29 @@ func TestFailedReq(t *testing.T) {
 			}(),
 			status: http.StatusUnauthorized,
 		},
+		{
+			desc:   "Body too large",
+			cfg:    *limitCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(strings.Repeat("a", 65))),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc: "Decompressed body too large",
+			cfg:  *limitCfg,
+			req: func() *http.Request {
+				var msg bytes.Buffer
+				gzipWriter := gzip.NewWriter(&msg)
+				_, err := gzipWriter.Write([]byte(strings.Repeat("a", 1024)))
+				require.NoError(t, err, "Gzip writer failed")
+				require.NoError(t, gzipWriter.Close())
+				require.LessOrEqual(t, msg.Len(), 64)
+
+				req := httptest.NewRequest("POST", "http://localhost/events", &msg)
+				req.Header.Set("Content-Encoding", "gzip")
+				return req
+			}(),
+			status: http.StatusRequestEntityTooLarge,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -226,6 +254,106 @@ func TestFailedReq(t *testing.T) {
 	}
 }
 
+func TestSecretReq(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Secret = "It's a Secret to Everybody"
+
+	tests := []struct {
+		desc   string
+		header http.Header
+		status int
+	}{
+		{
+			desc:   "Valid signature",
+			header: http.Header{githubSignatureHeader: []string{"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "Invalid token",
+			header: http.Header{gitlabTokenHeader: []string{"not the secret"}},
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "Missing signature",
+			header: http.Header{},
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			receiver, err := newLogsReceiver(receivertest.NewNopCreateSettings(), *cfg, sink)
+			require.NoError(t, err, "Failed to create receiver")
+
+			r := receiver.(*eventReceiver)
+			require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()), "Failed to start receiver")
+			defer func() {
+				require.NoError(t, r.Shutdown(context.Background()), "Failed to shutdown receiver")
+			}()
+
+			req := httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("Hello, World!"))
+			req.Header = test.header
+			w := httptest.NewRecorder()
+			r.handleReq(w, req, httprouter.ParamsFromContext(context.Background()))
+
+			response := w.Result()
+			require.Equal(t, test.status, response.StatusCode)
+			if test.status != http.StatusOK {
+				require.Equal(t, 0, sink.LogRecordCount())
+			}
+		})
+	}
+}
+
+func TestTracesReq(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+
+	payload, err := os.ReadFile(filepath.Join("testdata", "github_workflow_job.json"))
+	require.NoError(t, err)
+	// webhooks deliver their events on a single line
+	var body bytes.Buffer
+	require.NoError(t, json.Compact(&body, payload))
+
+	tracesSink := new(consumertest.TracesSink)
+	logsSink := new(consumertest.LogsSink)
+	receiver, err := newTracesReceiver(receivertest.NewNopCreateSettings(), *cfg, tracesSink)
+	require.NoError(t, err, "Failed to create receiver")
+
+	r := receiver.(*eventReceiver)
+	r.logConsumer = logsSink
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()), "Failed to start receiver")
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()), "Failed to shutdown receiver")
+	}()
+
+	req := httptest.NewRequest("POST", "http://localhost/events", &body)
+	req.Header.Set(githubEventHeader, githubWorkflowJobEvent)
+	w := httptest.NewRecorder()
+	r.handleReq(w, req, httprouter.ParamsFromContext(context.Background()))
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	require.Equal(t, 3, tracesSink.SpanCount())
+	require.Equal(t, 1, logsSink.LogRecordCount())
+
+	// events which are not CI pipelines are only converted to logs
+	req = httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("test"))
+	w = httptest.NewRecorder()
+	r.handleReq(w, req, httprouter.ParamsFromContext(context.Background()))
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	require.Equal(t, 3, tracesSink.SpanCount())
+	require.Equal(t, 2, logsSink.LogRecordCount())
+
+	// invalid events are rejected before their logs are consumed
+	req = httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("not json"))
+	req.Header.Set(githubEventHeader, githubWorkflowJobEvent)
+	w = httptest.NewRecorder()
+	r.handleReq(w, req, httprouter.ParamsFromContext(context.Background()))
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	require.Equal(t, 2, logsSink.LogRecordCount())
+}
+
 func TestHealthCheck(t *testing.T) {
 	defaultConfig := createDefaultConfig().(*Config)
 	defaultConfig.Endpoint = "localhost:0"
@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
+)
+
+const (
+	githubEventHeader = "X-GitHub-Event"
+	gitlabEventHeader = "X-Gitlab-Event"
+
+	githubWorkflowRunEvent = "workflow_run"
+	githubWorkflowJobEvent = "workflow_job"
+	gitlabPipelineEvent    = "Pipeline Hook"
+
+	attributeCIProvider      = "ci.provider"
+	attributeRepository      = "vcs.repository.name"
+	attributeRef             = "vcs.ref"
+	attributeRevision        = "vcs.revision"
+	attributeURL             = "url.full"
+	attributePipelineID      = "ci.pipeline.id"
+	attributePipelineName    = "ci.pipeline.name"
+	attributePipelineAttempt = "ci.pipeline.run.attempt"
+	attributeJobID           = "ci.job.id"
+	attributeJobName         = "ci.job.name"
+	attributeJobStage        = "ci.job.stage"
+	attributeRunnerName      = "ci.runner.name"
+	attributeStepNumber      = "ci.step.number"
+	attributeResult          = "ci.result"
+)
+
+type githubRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type githubWorkflowRun struct {
+	Action      string `json:"action"`
+	WorkflowRun struct {
+		ID           int64     `json:"id"`
+		Name         string    `json:"name"`
+		RunAttempt   int64     `json:"run_attempt"`
+		HeadBranch   string    `json:"head_branch"`
+		HeadSHA      string    `json:"head_sha"`
+		Conclusion   string    `json:"conclusion"`
+		HTMLURL      string    `json:"html_url"`
+		RunStartedAt time.Time `json:"run_started_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	} `json:"workflow_run"`
+	Repository githubRepository `json:"repository"`
+}
+
+type githubWorkflowJob struct {
+	Action      string `json:"action"`
+	WorkflowJob struct {
+		ID          int64     `json:"id"`
+		RunID       int64     `json:"run_id"`
+		RunAttempt  int64     `json:"run_attempt"`
+		Name        string    `json:"name"`
+		HeadBranch  string    `json:"head_branch"`
+		HeadSHA     string    `json:"head_sha"`
+		Conclusion  string    `json:"conclusion"`
+		HTMLURL     string    `json:"html_url"`
+		RunnerName  string    `json:"runner_name"`
+		StartedAt   time.Time `json:"started_at"`
+		CompletedAt time.Time `json:"completed_at"`
+		Steps       []struct {
+			Name        string    `json:"name"`
+			Number      int64     `json:"number"`
+			Conclusion  string    `json:"conclusion"`
+			StartedAt   time.Time `json:"started_at"`
+			CompletedAt time.Time `json:"completed_at"`
+		} `json:"steps"`
+	} `json:"workflow_job"`
+	Repository githubRepository `json:"repository"`
+}
+
+// gitlabTime is a timestamp of the GitLab webhooks, which are not formatted as RFC 3339 in every event
+type gitlabTime struct {
+	time.Time
+}
+
+func (t *gitlabTime) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil || value == nil {
+		return err
+	}
+	parsed, err := time.Parse("2006-01-02 15:04:05 MST", *value)
+	if err != nil {
+		parsed, err = time.Parse(time.RFC3339, *value)
+	}
+	t.Time = parsed
+	return err
+}
+
+type gitlabPipeline struct {
+	ObjectAttributes struct {
+		ID         int64      `json:"id"`
+		Name       string     `json:"name"`
+		Ref        string     `json:"ref"`
+		SHA        string     `json:"sha"`
+		Status     string     `json:"status"`
+		URL        string     `json:"url"`
+		CreatedAt  gitlabTime `json:"created_at"`
+		FinishedAt gitlabTime `json:"finished_at"`
+	} `json:"object_attributes"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	Builds []struct {
+		ID         int64      `json:"id"`
+		Stage      string     `json:"stage"`
+		Name       string     `json:"name"`
+		Status     string     `json:"status"`
+		StartedAt  gitlabTime `json:"started_at"`
+		FinishedAt gitlabTime `json:"finished_at"`
+		Runner     *struct {
+			Description string `json:"description"`
+		} `json:"runner"`
+	} `json:"builds"`
+}
+
+// reqToTraces converts the completed GitHub Actions workflow runs and jobs, and the finished GitLab CI
+// pipelines to traces. The workflows and pipelines are the root spans, with their jobs, and the steps
+// of the jobs, as children. The trace and span IDs are derived from the IDs of the runs and jobs, so
+// that the spans of the jobs delivered by distinct webhooks share the trace of their workflow.
+// The other requests are converted to empty traces.
+func reqToTraces(header http.Header, body []byte, query url.Values, settings receiver.CreateSettings) (ptrace.Traces, error) {
+	traces := ptrace.NewTraces()
+
+	var err error
+	switch {
+	case header.Get(githubEventHeader) == githubWorkflowRunEvent:
+		var event githubWorkflowRun
+		if err = json.Unmarshal(body, &event); err == nil && event.Action == "completed" {
+			githubWorkflowRunToSpans(newScopeSpans(traces, "github", event.Repository.FullName, query, settings), event)
+		}
+	case header.Get(githubEventHeader) == githubWorkflowJobEvent:
+		var event githubWorkflowJob
+		if err = json.Unmarshal(body, &event); err == nil && event.Action == "completed" {
+			githubWorkflowJobToSpans(newScopeSpans(traces, "github", event.Repository.FullName, query, settings), event)
+		}
+	case header.Get(gitlabEventHeader) == gitlabPipelineEvent:
+		var event gitlabPipeline
+		if err = json.Unmarshal(body, &event); err == nil && gitlabFinished(event.ObjectAttributes.Status) {
+			gitlabPipelineToSpans(newScopeSpans(traces, "gitlab", event.Project.PathWithNamespace, query, settings), event)
+		}
+	}
+
+	return traces, err
+}
+
+// newScopeSpans appends the spans of an event, with the CI provider, the repository and the query parameters as
+// resource attributes
+func newScopeSpans(traces ptrace.Traces, provider string, repository string, query url.Values, settings receiver.CreateSettings) ptrace.SpanSlice {
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	for k := range query {
+		if query.Get(k) != "" {
+			resourceSpans.Resource().Attributes().PutStr(k, query.Get(k))
+		}
+	}
+	resourceSpans.Resource().Attributes().PutStr(attributeCIProvider, provider)
+	resourceSpans.Resource().Attributes().PutStr(attributeRepository, repository)
+
+	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+	scopeSpans.Scope().SetName(scopeTraceName)
+	scopeSpans.Scope().SetVersion(settings.BuildInfo.Version)
+	scopeSpans.Scope().Attributes().PutStr("source", settings.ID.String())
+	scopeSpans.Scope().Attributes().PutStr("receiver", metadata.Type)
+	return scopeSpans.Spans()
+}
+
+func githubWorkflowRunToSpans(spans ptrace.SpanSlice, event githubWorkflowRun) {
+	run := event.WorkflowRun
+	runID := strconv.FormatInt(run.ID, 10)
+	attempt := strconv.FormatInt(run.RunAttempt, 10)
+
+	span := spans.AppendEmpty()
+	span.SetTraceID(newTraceID("github", event.Repository.FullName, runID, attempt))
+	span.SetSpanID(newSpanID("github", event.Repository.FullName, runID, attempt))
+	span.SetName(run.Name)
+	span.SetKind(ptrace.SpanKindInternal)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(run.RunStartedAt))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(run.UpdatedAt))
+	setResult(span, run.Conclusion)
+	span.Attributes().PutInt(attributePipelineID, run.ID)
+	span.Attributes().PutStr(attributePipelineName, run.Name)
+	span.Attributes().PutInt(attributePipelineAttempt, run.RunAttempt)
+	span.Attributes().PutStr(attributeRef, run.HeadBranch)
+	span.Attributes().PutStr(attributeRevision, run.HeadSHA)
+	span.Attributes().PutStr(attributeURL, run.HTMLURL)
+}
+
+func githubWorkflowJobToSpans(spans ptrace.SpanSlice, event githubWorkflowJob) {
+	job := event.WorkflowJob
+	runID := strconv.FormatInt(job.RunID, 10)
+	attempt := strconv.FormatInt(job.RunAttempt, 10)
+	jobID := strconv.FormatInt(job.ID, 10)
+	traceID := newTraceID("github", event.Repository.FullName, runID, attempt)
+
+	span := spans.AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(newSpanID("github", event.Repository.FullName, "job", jobID))
+	span.SetParentSpanID(newSpanID("github", event.Repository.FullName, runID, attempt))
+	span.SetName(job.Name)
+	span.SetKind(ptrace.SpanKindInternal)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(job.StartedAt))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(job.CompletedAt))
+	setResult(span, job.Conclusion)
+	span.Attributes().PutInt(attributePipelineID, job.RunID)
+	span.Attributes().PutInt(attributePipelineAttempt, job.RunAttempt)
+	span.Attributes().PutInt(attributeJobID, job.ID)
+	span.Attributes().PutStr(attributeJobName, job.Name)
+	span.Attributes().PutStr(attributeRef, job.HeadBranch)
+	span.Attributes().PutStr(attributeRevision, job.HeadSHA)
+	span.Attributes().PutStr(attributeURL, job.HTMLURL)
+	span.Attributes().PutStr(attributeRunnerName, job.RunnerName)
+
+	for _, step := range job.Steps {
+		// The steps which did not run have no timing
+		if step.StartedAt.IsZero() || step.CompletedAt.IsZero() {
+			continue
+		}
+		stepSpan := spans.AppendEmpty()
+		stepSpan.SetTraceID(traceID)
+		stepSpan.SetSpanID(newSpanID("github", event.Repository.FullName, "job", jobID, "step", strconv.FormatInt(step.Number, 10)))
+		stepSpan.SetParentSpanID(span.SpanID())
+		stepSpan.SetName(step.Name)
+		stepSpan.SetKind(ptrace.SpanKindInternal)
+		stepSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(step.StartedAt))
+		stepSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(step.CompletedAt))
+		setResult(stepSpan, step.Conclusion)
+		stepSpan.Attributes().PutInt(attributeJobID, job.ID)
+		stepSpan.Attributes().PutInt(attributeStepNumber, step.Number)
+	}
+}
+
+// gitlabFinished returns whether a GitLab pipeline or job status is final
+func gitlabFinished(status string) bool {
+	switch status {
+	case "success", "failed", "canceled", "skipped":
+		return true
+	}
+	return false
+}
+
+func gitlabPipelineToSpans(spans ptrace.SpanSlice, event gitlabPipeline) {
+	pipeline := event.ObjectAttributes
+	project := event.Project.PathWithNamespace
+	pipelineID := strconv.FormatInt(pipeline.ID, 10)
+	traceID := newTraceID("gitlab", project, pipelineID)
+
+	span := spans.AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(newSpanID("gitlab", project, pipelineID))
+	name := pipeline.Name
+	if name == "" {
+		name = project + " " + pipeline.Ref
+	}
+	span.SetName(name)
+	span.SetKind(ptrace.SpanKindInternal)
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(pipeline.CreatedAt.Time))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(pipeline.FinishedAt.Time))
+	setResult(span, pipeline.Status)
+	span.Attributes().PutInt(attributePipelineID, pipeline.ID)
+	span.Attributes().PutStr(attributePipelineName, name)
+	span.Attributes().PutStr(attributeRef, pipeline.Ref)
+	span.Attributes().PutStr(attributeRevision, pipeline.SHA)
+	if pipeline.URL != "" {
+		span.Attributes().PutStr(attributeURL, pipeline.URL)
+	}
+
+	for _, build := range event.Builds {
+		// The jobs which did not run have no timing
+		if !gitlabFinished(build.Status) || build.StartedAt.IsZero() || build.FinishedAt.IsZero() {
+			continue
+		}
+		jobSpan := spans.AppendEmpty()
+		jobSpan.SetTraceID(traceID)
+		jobSpan.SetSpanID(newSpanID("gitlab", project, "job", strconv.FormatInt(build.ID, 10)))
+		jobSpan.SetParentSpanID(span.SpanID())
+		jobSpan.SetName(build.Name)
+		jobSpan.SetKind(ptrace.SpanKindInternal)
+		jobSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(build.StartedAt.Time))
+		jobSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(build.FinishedAt.Time))
+		setResult(jobSpan, build.Status)
+		jobSpan.Attributes().PutInt(attributePipelineID, pipeline.ID)
+		jobSpan.Attributes().PutInt(attributeJobID, build.ID)
+		jobSpan.Attributes().PutStr(attributeJobName, build.Name)
+		jobSpan.Attributes().PutStr(attributeJobStage, build.Stage)
+		if build.Runner != nil {
+			jobSpan.Attributes().PutStr(attributeRunnerName, build.Runner.Description)
+		}
+	}
+}
+
+// setResult sets the status of a span from the conclusion of a GitHub run, job or step, or from the status of
+// a GitLab pipeline or job. The cancelled and skipped runs keep an unset status.
+func setResult(span ptrace.Span, result string) {
+	span.Attributes().PutStr(attributeResult, result)
+	switch result {
+	case "success":
+		span.Status().SetCode(ptrace.StatusCodeOk)
+	case "failure", "failed", "timed_out", "startup_failure":
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage(result)
+	}
+}
+
+// newTraceID derives a trace ID from the identifiers of a workflow run or pipeline
+func newTraceID(ids ...string) pcommon.TraceID {
+	var traceID pcommon.TraceID
+	sum := sha256.Sum256([]byte(strings.Join(ids, "/")))
+	copy(traceID[:], sum[:])
+	return traceID
+}
+
+// newSpanID derives a span ID from the identifiers of a workflow run or pipeline, job or step
+func newSpanID(ids ...string) pcommon.SpanID {
+	var spanID pcommon.SpanID
+	sum := sha256.Sum256([]byte(strings.Join(ids, "/")))
+	copy(spanID[:], sum[:])
+	return spanID
+}
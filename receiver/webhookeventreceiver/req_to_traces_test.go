@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestReqToTracesGitHub(t *testing.T) {
+	settings := receivertest.NewNopCreateSettings()
+
+	runBody, err := os.ReadFile(filepath.Join("testdata", "github_workflow_run.json"))
+	require.NoError(t, err)
+	runTraces, err := reqToTraces(newHeader(githubEventHeader, githubWorkflowRunEvent), runBody, url.Values{"team": []string{"platform"}}, settings)
+	require.NoError(t, err)
+	require.Equal(t, 1, runTraces.SpanCount())
+
+	resource := runTraces.ResourceSpans().At(0).Resource().Attributes()
+	require.Equal(t, map[string]any{
+		attributeCIProvider: "github",
+		attributeRepository: "octo-org/octo-repo",
+		"team":              "platform",
+	}, resource.AsRaw())
+	require.Equal(t, scopeTraceName, runTraces.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Name())
+
+	run := runTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	require.Equal(t, "Build", run.Name())
+	require.True(t, run.ParentSpanID().IsEmpty())
+	require.Equal(t, time.Date(2023, 12, 1, 10, 0, 5, 0, time.UTC), run.StartTimestamp().AsTime())
+	require.Equal(t, time.Date(2023, 12, 1, 10, 3, 5, 0, time.UTC), run.EndTimestamp().AsTime())
+	require.Equal(t, ptrace.StatusCodeError, run.Status().Code())
+	require.Equal(t, "failure", run.Status().Message())
+	pipelineID, _ := run.Attributes().Get(attributePipelineID)
+	require.EqualValues(t, 30433642, pipelineID.Int())
+
+	jobBody, err := os.ReadFile(filepath.Join("testdata", "github_workflow_job.json"))
+	require.NoError(t, err)
+	jobTraces, err := reqToTraces(newHeader(githubEventHeader, githubWorkflowJobEvent), jobBody, url.Values{}, settings)
+	require.NoError(t, err)
+	// the skipped step did not run
+	require.Equal(t, 3, jobTraces.SpanCount())
+
+	spans := jobTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	job := spans.At(0)
+	require.Equal(t, "test", job.Name())
+	require.Equal(t, run.TraceID(), job.TraceID())
+	require.Equal(t, run.SpanID(), job.ParentSpanID())
+	require.Equal(t, ptrace.StatusCodeOk, job.Status().Code())
+	runner, _ := job.Attributes().Get(attributeRunnerName)
+	require.Equal(t, "ubuntu-runner-1", runner.Str())
+
+	for i, name := range []string{"Set up job", "Run tests"} {
+		step := spans.At(i + 1)
+		require.Equal(t, name, step.Name())
+		require.Equal(t, run.TraceID(), step.TraceID())
+		require.Equal(t, job.SpanID(), step.ParentSpanID())
+		number, _ := step.Attributes().Get(attributeStepNumber)
+		require.EqualValues(t, i+1, number.Int())
+	}
+}
+
+func TestReqToTracesGitLab(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "gitlab_pipeline.json"))
+	require.NoError(t, err)
+
+	traces, err := reqToTraces(newHeader(gitlabEventHeader, gitlabPipelineEvent), body, url.Values{}, receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	// the skipped job did not run
+	require.Equal(t, 3, traces.SpanCount())
+
+	repository, _ := traces.ResourceSpans().At(0).Resource().Attributes().Get(attributeRepository)
+	require.Equal(t, "gitlab-org/gitlab-test", repository.Str())
+
+	spans := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	pipeline := spans.At(0)
+	require.Equal(t, "Nightly build", pipeline.Name())
+	require.True(t, pipeline.ParentSpanID().IsEmpty())
+	require.Equal(t, time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC), pipeline.StartTimestamp().AsTime())
+	require.Equal(t, time.Date(2023, 12, 1, 10, 5, 0, 0, time.UTC), pipeline.EndTimestamp().AsTime())
+	require.Equal(t, ptrace.StatusCodeError, pipeline.Status().Code())
+
+	unit := spans.At(1)
+	require.Equal(t, "unit", unit.Name())
+	require.Equal(t, pipeline.TraceID(), unit.TraceID())
+	require.Equal(t, pipeline.SpanID(), unit.ParentSpanID())
+	require.Equal(t, ptrace.StatusCodeOk, unit.Status().Code())
+	stage, _ := unit.Attributes().Get(attributeJobStage)
+	require.Equal(t, "test", stage.Str())
+	runner, _ := unit.Attributes().Get(attributeRunnerName)
+	require.Equal(t, "shared-runner-1", runner.Str())
+
+	production := spans.At(2)
+	require.Equal(t, "production", production.Name())
+	require.Equal(t, ptrace.StatusCodeError, production.Status().Code())
+	_, ok := production.Attributes().Get(attributeRunnerName)
+	require.False(t, ok)
+}
+
+func TestReqToTracesIgnoredEvents(t *testing.T) {
+	tests := []struct {
+		desc   string
+		header http.Header
+		body   string
+	}{
+		{
+			desc:   "Workflow run in progress",
+			header: newHeader(githubEventHeader, githubWorkflowRunEvent),
+			body:   `{"action": "in_progress", "workflow_run": {"id": 1}}`,
+		},
+		{
+			desc:   "Workflow job queued",
+			header: newHeader(githubEventHeader, githubWorkflowJobEvent),
+			body:   `{"action": "queued", "workflow_job": {"id": 1}}`,
+		},
+		{
+			desc:   "Pipeline running",
+			header: newHeader(gitlabEventHeader, gitlabPipelineEvent),
+			body:   `{"object_attributes": {"id": 1, "status": "running", "finished_at": null}}`,
+		},
+		{
+			desc:   "Other GitHub event",
+			header: newHeader(githubEventHeader, "push"),
+			body:   `{"ref": "refs/heads/main"}`,
+		},
+		{
+			desc:   "Generic event",
+			header: http.Header{},
+			body:   "this is a: log",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			traces, err := reqToTraces(test.header, []byte(test.body), url.Values{}, receivertest.NewNopCreateSettings())
+			require.NoError(t, err)
+			require.Equal(t, 0, traces.SpanCount())
+		})
+	}
+}
+
+func TestReqToTracesInvalidEvent(t *testing.T) {
+	_, err := reqToTraces(newHeader(githubEventHeader, githubWorkflowJobEvent), []byte("not json"), url.Values{}, receivertest.NewNopCreateSettings())
+	require.Error(t, err)
+}
+
+func newHeader(key string, value string) http.Header {
+	header := http.Header{}
+	header.Set(key, value)
+	return header
+}
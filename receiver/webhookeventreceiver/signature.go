@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	githubSignatureHeader = "X-Hub-Signature-256"
+	gitlabTokenHeader     = "X-Gitlab-Token"
+)
+
+// verifySecret verifies that a request was sent by a webhook configured with the secret,
+// either with the HMAC-SHA256 signature of its body sent by GitHub, or with the token sent by GitLab.
+// https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+// https://docs.gitlab.com/ee/user/project/integrations/webhooks.html#validate-payloads-by-using-a-secret-token
+func verifySecret(header http.Header, body []byte, secret string) bool {
+	if signature := header.Get(githubSignatureHeader); signature != "" {
+		digest, found := strings.CutPrefix(signature, "sha256=")
+		if !found {
+			return false
+		}
+		expected, err := hex.DecodeString(digest)
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return hmac.Equal(mac.Sum(nil), expected)
+	}
+
+	if token := header.Get(gitlabTokenHeader); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySecret(t *testing.T) {
+	// signature of the body with the secret "It's a Secret to Everybody", from the GitHub documentation
+	body := []byte("Hello, World!")
+	signature := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+
+	tests := []struct {
+		desc   string
+		header http.Header
+		secret string
+		expect bool
+	}{
+		{
+			desc:   "Valid GitHub signature",
+			header: http.Header{githubSignatureHeader: []string{signature}},
+			secret: "It's a Secret to Everybody",
+			expect: true,
+		},
+		{
+			desc:   "GitHub signature of another secret",
+			header: http.Header{githubSignatureHeader: []string{signature}},
+			secret: "another secret",
+		},
+		{
+			desc:   "GitHub signature without algorithm",
+			header: http.Header{githubSignatureHeader: []string{"757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"}},
+			secret: "It's a Secret to Everybody",
+		},
+		{
+			desc:   "GitHub signature not hex encoded",
+			header: http.Header{githubSignatureHeader: []string{"sha256=notahexdigest"}},
+			secret: "It's a Secret to Everybody",
+		},
+		{
+			desc:   "Valid GitLab token",
+			header: http.Header{gitlabTokenHeader: []string{"token"}},
+			secret: "token",
+			expect: true,
+		},
+		{
+			desc:   "Invalid GitLab token",
+			header: http.Header{gitlabTokenHeader: []string{"other-token"}},
+			secret: "token",
+		},
+		{
+			desc:   "Missing signature and token",
+			header: http.Header{},
+			secret: "token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			require.Equal(t, test.expect, verifySecret(test.header, body, test.secret))
+		})
+	}
+}